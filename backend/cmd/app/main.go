@@ -27,14 +27,24 @@ import (
 	s3 "github.com/kust1q/Zapp/backend/internal/core/providers/db/minio"
 	db "github.com/kust1q/Zapp/backend/internal/core/providers/db/postgres"
 	"github.com/kust1q/Zapp/backend/internal/core/providers/db/redis/cache"
+	rateLimitStorage "github.com/kust1q/Zapp/backend/internal/core/providers/db/redis/ratelimit"
+	timelineStorage "github.com/kust1q/Zapp/backend/internal/core/providers/db/redis/timeline"
 	"github.com/kust1q/Zapp/backend/internal/core/providers/db/redis/tokens"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/mailer"
 	searchClient "github.com/kust1q/Zapp/backend/internal/core/providers/search"
 	wsProvider "github.com/kust1q/Zapp/backend/internal/core/providers/websocket" // Infrastructure
+	"github.com/kust1q/Zapp/backend/internal/core/service/admin"
 	"github.com/kust1q/Zapp/backend/internal/core/service/auth"
+	"github.com/kust1q/Zapp/backend/internal/core/service/drafts"
 	"github.com/kust1q/Zapp/backend/internal/core/service/feed"
 	"github.com/kust1q/Zapp/backend/internal/core/service/media"
+	"github.com/kust1q/Zapp/backend/internal/core/service/messages"
 	"github.com/kust1q/Zapp/backend/internal/core/service/notification"
+	"github.com/kust1q/Zapp/backend/internal/core/service/outbox"
+	"github.com/kust1q/Zapp/backend/internal/core/service/ratelimit"
+	"github.com/kust1q/Zapp/backend/internal/core/service/reports"
 	searchService "github.com/kust1q/Zapp/backend/internal/core/service/search"
+	"github.com/kust1q/Zapp/backend/internal/core/service/timeline"
 	"github.com/kust1q/Zapp/backend/internal/core/service/tweets"
 	"github.com/kust1q/Zapp/backend/internal/core/service/user" // Connection Logic
 	"github.com/kust1q/Zapp/backend/internal/core/service/websocket"
@@ -128,19 +138,46 @@ func main() {
 	wsHub := wsProvider.NewHub()
 	go wsHub.Run()
 
-	mediaService := media.NewMediaService(pgDB, minioDB)
+	tokenStorage := tokens.NewTokenStorage(redisClient, cfg.Tokens.AccessTTL)
+	mediaService := media.NewMediaService(&cfg.Uploads, pgDB, minioDB)
 	authService := auth.NewAuthService(
-		&config.AuthServiceConfig{PrivateKey: cfg.JWT.PrivateKey, PublicKey: cfg.JWT.PublicKey, AccessTTL: cfg.Tokens.AccessTTL, RefreshTTL: cfg.Tokens.RefreshTTL},
+		&config.AuthServiceConfig{
+			KeyID:              cfg.JWT.KeyID,
+			PrivateKey:         cfg.JWT.PrivateKey,
+			PublicKey:          cfg.JWT.PublicKey,
+			PreviousPublicKeys: cfg.JWT.PreviousPublicKeys,
+			AccessTTL:          cfg.Tokens.AccessTTL,
+			RefreshTTL:         cfg.Tokens.RefreshTTL,
+			RecoveryTTL:        cfg.Tokens.RecoveryTTL,
+			VerificationTTL:    cfg.Tokens.VerificationTTL,
+			ClientURL:          cfg.App.ClientURL,
+		},
 		pgDB,
 		mediaService,
-		tokens.NewTokenStorage(redisClient),
-		kafkaProducer)
-	tweetService := tweets.NewTweetService(pgDB, mediaService, kafkaProducer)
-	userService := user.NewUserService(pgDB, mediaService, kafkaProducer)
-	feedService := feed.NewFeedService(pgDB, tweetService)
+		tokenStorage,
+		mailer.NewMailer(&cfg.Mailer))
+	timelineService := timeline.NewTimelineService(
+		&cfg.Timeline,
+		pgDB,
+		timelineStorage.NewTimelineStorage(redisClient, cfg.Timeline.MaxLength, cfg.Timeline.TTL))
+	tweetService := tweets.NewTweetService(&cfg.Tweets, pgDB, mediaService, timelineService)
+	userService := user.NewUserService(pgDB, mediaService, timelineService, tweetService, tokenStorage)
+	feedService := feed.NewFeedService(pgDB, tweetService, timelineService)
 	searchService := searchService.NewSearchService(pgDB, mediaService, tweetService, searchClient)
 	wsService := websocket.NewWebSocketService(wsHub)
-	notifService := notification.NewNotificationService(wsHub, pgDB)
+	notifService := notification.NewNotificationService(wsHub, pgDB, mediaService)
+	messageService := messages.NewMessageService(pgDB, mediaService, wsHub)
+	outboxService := outbox.NewOutboxService(&cfg.Outbox, pgDB, kafkaProducer)
+	adminService := admin.NewAdminService(pgDB, tokenStorage, tweetService, mediaService)
+	reportService := reports.NewReportService(pgDB, adminService)
+	draftService := drafts.NewDraftService(&cfg.Drafts, pgDB, mediaService, tweetService, notifService)
+	rateLimitService := ratelimit.NewRateLimitService(&cfg.RateLimit, rateLimitStorage.NewRateLimitStorage(redisClient))
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go outboxService.Run(workersCtx)
+	go draftService.Run(workersCtx)
+	go mediaService.Run(workersCtx)
 
 	handler := httpHandler.NewHandler(
 		authService,
@@ -151,6 +188,11 @@ func main() {
 		mediaService,
 		wsService,
 		notifService,
+		messageService,
+		adminService,
+		reportService,
+		draftService,
+		rateLimitService,
 	)
 
 	srv := &http.Server{
@@ -191,6 +233,8 @@ func main() {
 
 	logrus.Info("Shutting down server...")
 
+	stopWorkers()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/search/controllers/kafka"
+	"github.com/kust1q/Zapp/backend/internal/search/providers/db/postgres"
+	"github.com/kust1q/Zapp/backend/internal/search/providers/search/elastic"
+	"github.com/kust1q/Zapp/backend/internal/search/service/reindex"
+	"github.com/kust1q/Zapp/backend/internal/search/service/search"
+	el "github.com/kust1q/Zapp/backend/pkg/elastic"
+	pg "github.com/kust1q/Zapp/backend/pkg/postgres"
+	_ "github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// Rebuilds search indices from Postgres:
+//
+//	go run cmd/reindex/main.go -target=all -batch=1000
+func main() {
+	target := flag.String("target", "all", "what to reindex: all, tweets or users")
+	batchSize := flag.Int("batch", 1000, "rows per bulk request")
+	flag.Parse()
+
+	logrus.SetFormatter(&logrus.JSONFormatter{})
+
+	if *target != "all" && *target != "tweets" && *target != "users" {
+		logrus.Fatalf("unknown target %q", *target)
+	}
+	if *batchSize < 1 {
+		logrus.Fatal("batch must be > 0")
+	}
+
+	if err := config.InitConfig(); err != nil {
+		logrus.WithError(err).Fatal("error initializing config")
+	}
+	cfg := config.Get()
+	if err := cfg.Validate(); err != nil {
+		logrus.WithError(err).Fatal("invalid configuration")
+	}
+
+	postgresConnect, err := pg.NewPostgresConnect(&cfg.Postgres)
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to initialize pg connect")
+	}
+	defer postgresConnect.Close()
+
+	elasticClient, err := el.NewElasticClient([]string{fmt.Sprintf("http://%s:%s", cfg.Elastic.Host, cfg.Elastic.Port)})
+	if err != nil {
+		logrus.Fatalf("failed to connect to elasticsearch: %v", err)
+	}
+
+	elasticRepo := elastic.NewElasticRepository(elasticClient)
+	searchService := search.NewSearchService(elasticRepo, &cfg.Trends)
+
+	reindexService := reindex.NewReindexService(
+		postgres.NewSourceDB(postgresConnect),
+		elasticRepo,
+		kafka.NewSearchHandler(searchService),
+		*batchSize,
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *target == "all" || *target == "users" {
+		if err := reindexService.ReindexUsers(ctx); err != nil {
+			logrus.WithError(err).Fatal("failed to reindex users")
+		}
+	}
+	if *target == "all" || *target == "tweets" {
+		if err := reindexService.ReindexTweets(ctx); err != nil {
+			logrus.WithError(err).Fatal("failed to reindex tweets")
+		}
+	}
+	logrus.Info("Reindex completed")
+}
@@ -43,12 +43,12 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// if err := elasticRepo.InitIndices(ctx); err != nil {
-	// 	logrus.Fatalf("failed to init elastic indices: %v", err)
-	// }
-	// logrus.Info("Elastic indices initialized successfully")
+	if err := elasticRepo.InitIndices(ctx); err != nil {
+		logrus.Fatalf("failed to init elastic indices: %v", err)
+	}
+	logrus.Info("Elastic indices initialized successfully")
 
-	searchService := search.NewSearchService(elasticRepo)
+	searchService := search.NewSearchService(elasticRepo, &cfg.Trends)
 
 	kafkaHadler := kafka.NewSearchHandler(searchService)
 	consumer := kafkaProvider.NewEventConsumer(&cfg.Kafka)
@@ -7,7 +7,8 @@ import (
 
 type (
 	ApplicationConfig struct {
-		Port string `mapstructure:"port"`
+		Port      string `mapstructure:"port"`
+		ClientURL string `mapstructure:"client_url"`
 	}
 
 	CacheConfig struct {
@@ -16,14 +17,19 @@ type (
 	}
 
 	TokensConfig struct {
-		AccessTTL   time.Duration `mapstructure:"access_ttl"`
-		RefreshTTL  time.Duration `mapstructure:"refresh_ttl"`
-		RecoveryTTL time.Duration `mapstructure:"recovery_ttl"`
+		AccessTTL       time.Duration `mapstructure:"access_ttl"`
+		RefreshTTL      time.Duration `mapstructure:"refresh_ttl"`
+		RecoveryTTL     time.Duration `mapstructure:"recovery_ttl"`
+		VerificationTTL time.Duration `mapstructure:"verification_ttl"`
 	}
 
+	// JWTConfig signs with PrivateKey under KeyID. PreviousPublicKeys, by key ID,
+	// keep tokens signed with retired keys valid until they expire.
 	JWTConfig struct {
-		PrivateKey *rsa.PrivateKey
-		PublicKey  *rsa.PublicKey
+		KeyID              string
+		PrivateKey         *rsa.PrivateKey
+		PublicKey          *rsa.PublicKey
+		PreviousPublicKeys map[string]*rsa.PublicKey
 	}
 
 	MinioConfig struct {
@@ -52,6 +58,18 @@ type (
 		DB       int    `mapstructure:"db"`
 	}
 
+	// MailerConfig selects the mail transport: "smtp" delivers through Host:Port,
+	// "file" appends messages to FilePath (or logs them when it is empty) for local runs.
+	MailerConfig struct {
+		Driver   string `mapstructure:"driver"`
+		Host     string `mapstructure:"host"`
+		Port     string `mapstructure:"port"`
+		Username string `mapstructure:"username"`
+		Password string `mapstructure:"password"`
+		From     string `mapstructure:"from"`
+		FilePath string `mapstructure:"file_path"`
+	}
+
 	ElasticConfig struct {
 		Host string `mapstructure:"host"`
 		Port string `mapstructure:"port"`
@@ -73,4 +91,72 @@ type (
 			GroupID string `mapstructure:"group_id"`
 		} `mapstructure:"consumer"`
 	}
+
+	OutboxConfig struct {
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		BatchSize    int           `mapstructure:"batch_size"`
+		Retention    time.Duration `mapstructure:"retention"`
+	}
+
+	TimelineConfig struct {
+		MaxLength          int           `mapstructure:"max_length"`
+		CelebrityThreshold int           `mapstructure:"celebrity_threshold"`
+		BackfillSize       int           `mapstructure:"backfill_size"`
+		TTL                time.Duration `mapstructure:"ttl"`
+	}
+
+	TrendsConfig struct {
+		Window   time.Duration `mapstructure:"window"`
+		Baseline time.Duration `mapstructure:"baseline"`
+		MinCount int           `mapstructure:"min_count"`
+		MaxSize  int           `mapstructure:"max_size"`
+		CacheTTL time.Duration `mapstructure:"cache_ttl"`
+	}
+
+	// TweetsConfig limits tweet edits. A zero EditWindow or MaxEdits disables that limit.
+	TweetsConfig struct {
+		EditWindow time.Duration `mapstructure:"edit_window"`
+		MaxEdits   int           `mapstructure:"max_edits"`
+	}
+
+	// DraftsConfig drives the scheduler that publishes scheduled drafts. A claimed draft
+	// is leased to one replica for Lease and retried after it until MaxAttempts.
+	DraftsConfig struct {
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		BatchSize    int           `mapstructure:"batch_size"`
+		Lease        time.Duration `mapstructure:"lease"`
+		MaxAttempts  int           `mapstructure:"max_attempts"`
+	}
+
+	// UploadsConfig drives resumable uploads. Every chunk but the last one is exactly
+	// ChunkSize; an upload that is not attached within TTL is removed by the cleanup
+	// that runs every CleanupInterval.
+	UploadsConfig struct {
+		ChunkSize       int64         `mapstructure:"chunk_size"`
+		TTL             time.Duration `mapstructure:"ttl"`
+		CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	}
+
+	// RateLimitPolicy allows Limit requests per sliding Window.
+	RateLimitPolicy struct {
+		Limit  int           `mapstructure:"limit"`
+		Window time.Duration `mapstructure:"window"`
+	}
+
+	// SignInLockoutConfig locks an email out of sign-in once MaxFailures failed attempts
+	// pile up within Window. Each further failure doubles the lockout, from BaseDelay
+	// up to MaxDelay.
+	SignInLockoutConfig struct {
+		MaxFailures int           `mapstructure:"max_failures"`
+		Window      time.Duration `mapstructure:"window"`
+		BaseDelay   time.Duration `mapstructure:"base_delay"`
+		MaxDelay    time.Duration `mapstructure:"max_delay"`
+	}
+
+	// RateLimitConfig holds the throttling policies by name. Routes refer to policies
+	// by name; a route whose policy is not configured is not limited.
+	RateLimitConfig struct {
+		Policies      map[string]RateLimitPolicy `mapstructure:"policies"`
+		SignInLockout SignInLockoutConfig        `mapstructure:"sign_in_lockout"`
+	}
 )
@@ -15,18 +15,32 @@ import (
 )
 
 type config struct {
-	App      ApplicationConfig `mapstructure:"app"`
-	Postgres PostgresConfig    `mapstructure:"db"`
-	Minio    MinioConfig       `mapstructure:"minio"`
-	Redis    RedisConfig       `mapstructure:"redis"`
-	Elastic  ElasticConfig     `mapstructure:"elastic"`
-	Cache    CacheConfig       `mapstructure:"cache"`
-	Tokens   TokensConfig      `mapstructure:"tokens"`
-	GRPC     GrpcConfig        `mapstructure:"grpc"`
-	Kafka    KafkaConfig       `mapstructure:"kafka"`
-	JWT      JWTConfig
+	App       ApplicationConfig `mapstructure:"app"`
+	Postgres  PostgresConfig    `mapstructure:"db"`
+	Minio     MinioConfig       `mapstructure:"minio"`
+	Redis     RedisConfig       `mapstructure:"redis"`
+	Mailer    MailerConfig      `mapstructure:"mailer"`
+	Elastic   ElasticConfig     `mapstructure:"elastic"`
+	Cache     CacheConfig       `mapstructure:"cache"`
+	Tokens    TokensConfig      `mapstructure:"tokens"`
+	GRPC      GrpcConfig        `mapstructure:"grpc"`
+	Kafka     KafkaConfig       `mapstructure:"kafka"`
+	Outbox    OutboxConfig      `mapstructure:"outbox"`
+	Timeline  TimelineConfig    `mapstructure:"timeline"`
+	Trends    TrendsConfig      `mapstructure:"trends"`
+	Tweets    TweetsConfig      `mapstructure:"tweets"`
+	Drafts    DraftsConfig      `mapstructure:"drafts"`
+	Uploads   UploadsConfig     `mapstructure:"uploads"`
+	RateLimit RateLimitConfig   `mapstructure:"rate_limit"`
+	JWT       JWTConfig
 }
 
+// minUploadChunkSize is the smallest part object storage accepts in a multipart upload.
+const minUploadChunkSize = 5 * 1024 * 1024
+
+// defaultJWTKeyID names the signing key when JWT_KEY_ID is not set.
+const defaultJWTKeyID = "primary"
+
 var (
 	instance *config
 	once     sync.Once
@@ -37,6 +51,10 @@ func Get() *config {
 	if err != nil {
 		logrus.Fatalf("Failed to load RSA keys: %v", err)
 	}
+	previousPublicKeys, err := loadPreviousPublicKeys(os.Getenv("JWT_PREVIOUS_PUBLIC_KEYS"))
+	if err != nil {
+		logrus.Fatalf("Failed to load previous RSA public keys: %v", err)
+	}
 
 	once.Do(func() {
 		var cfg config
@@ -50,9 +68,15 @@ func Get() *config {
 		cfg.Minio.User = os.Getenv("MINIO_USER")
 		cfg.Minio.Password = os.Getenv("MINIO_PASSWORD")
 		cfg.Redis.Password = os.Getenv("REDIS_PASSWORD")
+		cfg.Mailer.Password = os.Getenv("SMTP_PASSWORD")
 
+		cfg.JWT.KeyID = os.Getenv("JWT_KEY_ID")
+		if cfg.JWT.KeyID == "" {
+			cfg.JWT.KeyID = defaultJWTKeyID
+		}
 		cfg.JWT.PrivateKey = privateKey
 		cfg.JWT.PublicKey = publicKey
+		cfg.JWT.PreviousPublicKeys = previousPublicKeys
 
 		instance = &cfg
 	})
@@ -65,6 +89,9 @@ func (c *config) Validate() error {
 	if c.App.Port == "" {
 		allErrs = append(allErrs, "app: port is required")
 	}
+	if c.App.ClientURL == "" {
+		allErrs = append(allErrs, "app: client url is required")
+	}
 
 	pg := c.Postgres
 	validModes := map[string]bool{
@@ -121,6 +148,23 @@ func (c *config) Validate() error {
 		allErrs = append(allErrs, "redis: db must be >= 0")
 	}
 
+	mailer := c.Mailer
+	switch mailer.Driver {
+	case "smtp":
+		if mailer.Host == "" {
+			allErrs = append(allErrs, "mailer: host is required")
+		}
+		if mailer.Port == "" {
+			allErrs = append(allErrs, "mailer: port is required")
+		}
+		if mailer.From == "" {
+			allErrs = append(allErrs, "mailer: from is required")
+		}
+	case "file":
+	default:
+		allErrs = append(allErrs, "mailer: driver must be smtp or file")
+	}
+
 	el := c.Elastic
 	if el.Host == "" {
 		allErrs = append(allErrs, "elastic: host is required")
@@ -146,12 +190,37 @@ func (c *config) Validate() error {
 	if c.Tokens.RecoveryTTL <= 0 {
 		allErrs = append(allErrs, "tokens: recovery ttl must be > 0")
 	}
+	if c.Tokens.VerificationTTL <= 0 {
+		allErrs = append(allErrs, "tokens: verification ttl must be > 0")
+	}
+	for name, policy := range c.RateLimit.Policies {
+		if policy.Limit <= 0 {
+			allErrs = append(allErrs, fmt.Sprintf("rate limit: policy %s: limit must be > 0", name))
+		}
+		if policy.Window <= 0 {
+			allErrs = append(allErrs, fmt.Sprintf("rate limit: policy %s: window must be > 0", name))
+		}
+	}
+	lockout := c.RateLimit.SignInLockout
+	if lockout.MaxFailures <= 0 {
+		allErrs = append(allErrs, "rate limit: sign-in lockout max failures must be > 0")
+	}
+	if lockout.Window <= 0 {
+		allErrs = append(allErrs, "rate limit: sign-in lockout window must be > 0")
+	}
+	if lockout.BaseDelay <= 0 || lockout.MaxDelay < lockout.BaseDelay {
+		allErrs = append(allErrs, "rate limit: sign-in lockout delays must satisfy 0 < base_delay <= max_delay")
+	}
+
 	if c.JWT.PrivateKey == nil {
 		allErrs = append(allErrs, "jwt: private key path is required")
 	}
 	if c.JWT.PublicKey == nil {
 		allErrs = append(allErrs, "jwt: public key path is required")
 	}
+	if _, ok := c.JWT.PreviousPublicKeys[c.JWT.KeyID]; ok {
+		allErrs = append(allErrs, "jwt: key id must differ from previous key ids")
+	}
 
 	if c.GRPC.Host == "" {
 		allErrs = append(allErrs, "grpc: host is required")
@@ -173,6 +242,75 @@ func (c *config) Validate() error {
 		allErrs = append(allErrs, "kafka: consumer group id is required")
 	}
 
+	if c.Outbox.PollInterval <= 0 {
+		allErrs = append(allErrs, "outbox: poll interval must be > 0")
+	}
+	if c.Outbox.BatchSize <= 0 {
+		allErrs = append(allErrs, "outbox: batch size must be > 0")
+	}
+	if c.Outbox.Retention <= 0 {
+		allErrs = append(allErrs, "outbox: retention must be > 0")
+	}
+
+	if c.Timeline.MaxLength <= 0 {
+		allErrs = append(allErrs, "timeline: max length must be > 0")
+	}
+	if c.Timeline.CelebrityThreshold <= 0 {
+		allErrs = append(allErrs, "timeline: celebrity threshold must be > 0")
+	}
+	if c.Timeline.BackfillSize <= 0 {
+		allErrs = append(allErrs, "timeline: backfill size must be > 0")
+	}
+	if c.Timeline.TTL <= 0 {
+		allErrs = append(allErrs, "timeline: ttl must be > 0")
+	}
+
+	if c.Trends.Window <= 0 {
+		allErrs = append(allErrs, "trends: window must be > 0")
+	}
+	if c.Trends.Baseline <= c.Trends.Window {
+		allErrs = append(allErrs, "trends: baseline must be longer than window")
+	}
+	if c.Trends.MinCount <= 0 {
+		allErrs = append(allErrs, "trends: min count must be > 0")
+	}
+	if c.Trends.MaxSize <= 0 {
+		allErrs = append(allErrs, "trends: max size must be > 0")
+	}
+	if c.Trends.CacheTTL <= 0 {
+		allErrs = append(allErrs, "trends: cache ttl must be > 0")
+	}
+
+	if c.Tweets.EditWindow < 0 {
+		allErrs = append(allErrs, "tweets: edit window must be >= 0")
+	}
+	if c.Tweets.MaxEdits < 0 {
+		allErrs = append(allErrs, "tweets: max edits must be >= 0")
+	}
+
+	if c.Drafts.PollInterval <= 0 {
+		allErrs = append(allErrs, "drafts: poll interval must be > 0")
+	}
+	if c.Drafts.BatchSize <= 0 {
+		allErrs = append(allErrs, "drafts: batch size must be > 0")
+	}
+	if c.Drafts.Lease <= 0 {
+		allErrs = append(allErrs, "drafts: lease must be > 0")
+	}
+	if c.Drafts.MaxAttempts <= 0 {
+		allErrs = append(allErrs, "drafts: max attempts must be > 0")
+	}
+
+	if c.Uploads.ChunkSize < minUploadChunkSize {
+		allErrs = append(allErrs, fmt.Sprintf("uploads: chunk size must be >= %d", minUploadChunkSize))
+	}
+	if c.Uploads.TTL <= 0 {
+		allErrs = append(allErrs, "uploads: ttl must be > 0")
+	}
+	if c.Uploads.CleanupInterval <= 0 {
+		allErrs = append(allErrs, "uploads: cleanup interval must be > 0")
+	}
+
 	if len(allErrs) > 0 {
 		return errors.New("config validation errors: " + strings.Join(allErrs, " "))
 	}
@@ -223,3 +361,29 @@ func loadRSAKeys(privateKeyPath, publicKeyPath string) (*rsa.PrivateKey, *rsa.Pu
 
 	return privateKey, publicKey, nil
 }
+
+// loadPreviousPublicKeys parses "kid=path,kid=path" into public keys by key ID.
+func loadPreviousPublicKeys(spec string) (map[string]*rsa.PublicKey, error) {
+	keys := make(map[string]*rsa.PublicKey)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, path, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid entry %q, want kid=path", entry)
+		}
+
+		publicPEM, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key %s: %w", kid, err)
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key %s: %w", kid, err)
+		}
+		keys[kid] = publicKey
+	}
+	return keys, nil
+}
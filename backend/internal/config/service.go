@@ -6,9 +6,14 @@ import (
 )
 
 type AuthServiceConfig struct {
-	PrivateKey  *rsa.PrivateKey
-	PublicKey   *rsa.PublicKey
-	AccessTTL   time.Duration
-	RefreshTTL  time.Duration
-	RecoveryTTL time.Duration
+	KeyID              string
+	PrivateKey         *rsa.PrivateKey
+	PublicKey          *rsa.PublicKey
+	PreviousPublicKeys map[string]*rsa.PublicKey
+	AccessTTL          time.Duration
+	RefreshTTL         time.Duration
+	RecoveryTTL        time.Duration
+	VerificationTTL    time.Duration
+	// ClientURL is the web client base that recovery and verification links point to.
+	ClientURL string
 }
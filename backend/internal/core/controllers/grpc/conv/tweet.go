@@ -8,16 +8,37 @@ import (
 )
 
 func FromDomainToTweetProto(tweet *entity.Tweet) *tweetproto.Tweet {
-	return &tweetproto.Tweet{
+	res := &tweetproto.Tweet{
 		Id:            int64(tweet.ID),
 		Content:       tweet.Content,
 		CreatedAt:     tweet.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:     tweet.UpdatedAt.Format(time.RFC3339),
 		ParentTweetId: int64(ptrOrZero(tweet.ParentTweetID)),
-		MediaUrl:      tweet.MediaUrl,
+		Media:         FromDomainToTweetMediaTweetProto(tweet.Media),
 		Author:        FromDomainToTweetAuthorTweetProto(tweet.Author),
 		Counters:      FromDomainToTweetCountersTweetProto(tweet.Counters),
+		QuotedTweetId: int64(ptrOrZero(tweet.QuotedTweetID)),
+		Edited:        tweet.RevisionCount > 0,
+		RevisionCount: int32(tweet.RevisionCount),
 	}
+	if tweet.QuotedTweet != nil {
+		res.QuotedTweet = FromDomainToTweetProto(tweet.QuotedTweet)
+	}
+	return res
+}
+
+func FromDomainToTweetMediaTweetProto(media []entity.TweetMedia) []*tweetproto.TweetMedia {
+	res := make([]*tweetproto.TweetMedia, 0, len(media))
+	for i := range media {
+		res = append(res, &tweetproto.TweetMedia{
+			Id:       int64(media[i].ID),
+			Url:      media[i].Path,
+			MimeType: media[i].MimeType,
+			AltText:  media[i].AltText,
+			Position: int32(media[i].Position),
+		})
+	}
+	return res
 }
 
 func FromDomainToTweetAuthorTweetProto(user *entity.SmallUser) *tweetproto.TweetAuthor {
@@ -30,20 +51,47 @@ func FromDomainToTweetAuthorTweetProto(user *entity.SmallUser) *tweetproto.Tweet
 
 func FromDomainToTweetCountersTweetProto(counters *entity.Counters) *tweetproto.TweetCounters {
 	return &tweetproto.TweetCounters{
-		ReplyCount:   int64(counters.ReplyCount),
-		RetweetCount: int64(counters.RetweetCount),
-		LikeCount:    int64(counters.LikeCount),
+		ReplyCount:    int64(counters.ReplyCount),
+		RetweetCount:  int64(counters.RetweetCount),
+		LikeCount:     int64(counters.LikeCount),
+		BookmarkCount: int64(counters.BookmarkCount),
+		QuoteCount:    int64(counters.QuoteCount),
 	}
 }
 
-func FromDomainToTweetListTweetProto(tweets []entity.Tweet) *tweetproto.TweetList {
+func FromDomainToTweetListTweetProto(tweets []entity.Tweet, next *entity.Cursor) *tweetproto.TweetList {
 	res := make([]*tweetproto.Tweet, 0, len(tweets))
 	for i := range tweets {
 		res = append(res, FromDomainToTweetProto(&tweets[i]))
 	}
 	return &tweetproto.TweetList{
-		Tweets: res,
+		Tweets:     res,
+		NextCursor: next.Encode(),
+	}
+}
+
+func FromDomainToThreadTweetProto(thread *entity.Thread, next *entity.Cursor) *tweetproto.Thread {
+	ancestors := make([]*tweetproto.Tweet, 0, len(thread.Ancestors))
+	for i := range thread.Ancestors {
+		ancestors = append(ancestors, FromDomainToTweetProto(&thread.Ancestors[i]))
+	}
+	return &tweetproto.Thread{
+		Ancestors:  ancestors,
+		Tweet:      FromDomainToTweetProto(thread.Tweet),
+		Replies:    FromDomainToThreadRepliesTweetProto(thread.Replies),
+		NextCursor: next.Encode(),
+	}
+}
+
+func FromDomainToThreadRepliesTweetProto(replies []entity.ThreadReply) []*tweetproto.ThreadReply {
+	res := make([]*tweetproto.ThreadReply, 0, len(replies))
+	for i := range replies {
+		res = append(res, &tweetproto.ThreadReply{
+			Tweet:   FromDomainToTweetProto(&replies[i].Tweet),
+			Replies: FromDomainToThreadRepliesTweetProto(replies[i].Replies),
+		})
 	}
+	return res
 }
 
 func FromDomainToSmallTweetProto(user *entity.SmallUser) *tweetproto.Liker {
@@ -54,12 +102,13 @@ func FromDomainToSmallTweetProto(user *entity.SmallUser) *tweetproto.Liker {
 	}
 }
 
-func FromDomainToSmallUserListTweetProto(users []entity.SmallUser) *tweetproto.LikersList {
+func FromDomainToSmallUserListTweetProto(users []entity.SmallUser, next *entity.Cursor) *tweetproto.LikersList {
 	res := make([]*tweetproto.Liker, 0, len(users))
 	for i := range users {
 		res = append(res, FromDomainToSmallTweetProto(&users[i]))
 	}
 	return &tweetproto.LikersList{
-		Users: res,
+		Users:      res,
+		NextCursor: next.Encode(),
 	}
 }
@@ -18,13 +18,15 @@ func FromDomainToUserProto(user *entity.User) *userproto.User {
 		AvatarUrl:   user.AvatarUrl,
 		IsSuperuser: user.IsSuperuser,
 		IsActive:    user.IsActive,
+		IsPrivate:   user.IsPrivate,
 	}
 }
 
 func FromDomainToUserProfileProto(profile *entity.UserProfile) *userproto.UserProfile {
 	return &userproto.UserProfile{
-		User:   FromDomainToUserProto(profile.User),
-		Tweets: FromDomainToTweetListUserProto(profile.Tweets),
+		User:       FromDomainToUserProto(profile.User),
+		Tweets:     FromDomainToTweetListUserProto(profile.Tweets),
+		NextCursor: profile.NextCursor.Encode(),
 	}
 }
 
@@ -36,27 +38,49 @@ func FromDomainToSmallUserProto(user *entity.SmallUser) *userproto.SmallUser {
 	}
 }
 
-func FromDomainToSmallUserListUserProto(users []entity.SmallUser) *userproto.SmallUserList {
+func FromDomainToSmallUserListUserProto(users []entity.SmallUser, next *entity.Cursor) *userproto.SmallUserList {
 	res := make([]*userproto.SmallUser, 0, len(users))
 	for i := range users {
 		res = append(res, FromDomainToSmallUserProto(&users[i]))
 	}
 	return &userproto.SmallUserList{
-		Users: res,
+		Users:      res,
+		NextCursor: next.Encode(),
 	}
 }
 
 func FromDomainToTweetUserProto(tweet *entity.Tweet) *userproto.Tweet {
-	return &userproto.Tweet{
+	res := &userproto.Tweet{
 		Id:            int64(tweet.ID),
 		Content:       tweet.Content,
 		CreatedAt:     tweet.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:     tweet.UpdatedAt.Format(time.RFC3339),
 		ParentTweetId: int64(ptrOrZero(tweet.ParentTweetID)),
-		MediaUrl:      tweet.MediaUrl,
+		Media:         FromDomainToTweetMediaUserProto(tweet.Media),
 		Author:        FromDomainToTweetAuthorUserProto(tweet.Author),
 		Counters:      FromDomainToTweetCountersUserProto(tweet.Counters),
+		QuotedTweetId: int64(ptrOrZero(tweet.QuotedTweetID)),
+		Edited:        tweet.RevisionCount > 0,
+		RevisionCount: int32(tweet.RevisionCount),
 	}
+	if tweet.QuotedTweet != nil {
+		res.QuotedTweet = FromDomainToTweetUserProto(tweet.QuotedTweet)
+	}
+	return res
+}
+
+func FromDomainToTweetMediaUserProto(media []entity.TweetMedia) []*userproto.TweetMedia {
+	res := make([]*userproto.TweetMedia, 0, len(media))
+	for i := range media {
+		res = append(res, &userproto.TweetMedia{
+			Id:       int64(media[i].ID),
+			Url:      media[i].Path,
+			MimeType: media[i].MimeType,
+			AltText:  media[i].AltText,
+			Position: int32(media[i].Position),
+		})
+	}
+	return res
 }
 
 func FromDomainToTweetListUserProto(tweets []entity.Tweet) []*userproto.Tweet {
@@ -77,8 +101,10 @@ func FromDomainToTweetAuthorUserProto(user *entity.SmallUser) *userproto.TweetAu
 
 func FromDomainToTweetCountersUserProto(counters *entity.Counters) *userproto.TweetCounters {
 	return &userproto.TweetCounters{
-		ReplyCount:   int64(counters.ReplyCount),
-		RetweetCount: int64(counters.RetweetCount),
-		LikeCount:    int64(counters.LikeCount),
+		ReplyCount:    int64(counters.ReplyCount),
+		RetweetCount:  int64(counters.RetweetCount),
+		LikeCount:     int64(counters.LikeCount),
+		BookmarkCount: int64(counters.BookmarkCount),
+		QuoteCount:    int64(counters.QuoteCount),
 	}
 }
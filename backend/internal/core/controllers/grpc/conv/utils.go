@@ -1,6 +1,10 @@
 package conv
 
-import "time"
+import (
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
 
 func parseProtoTime(timeStr string) time.Time {
 	t, _ := time.Parse(time.RFC3339, timeStr)
@@ -21,3 +25,15 @@ func intToPtr(i int) *int {
 	res := i
 	return &res
 }
+
+func FromProtoToPage(limit, offset int32, cursor string) (*entity.Page, error) {
+	after, err := entity.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Page{
+		Limit:  int(limit),
+		Offset: int(offset),
+		After:  after,
+	}, nil
+}
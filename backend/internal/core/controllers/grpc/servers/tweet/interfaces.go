@@ -9,8 +9,9 @@ import (
 type (
 	tweetService interface {
 		GetTweetById(ctx context.Context, tweetID int) (*entity.Tweet, error)
-		GetRepliesToTweet(ctx context.Context, tweetID, limit, offset int) ([]entity.Tweet, error)
-		GetTweetsAndRetweetsByUsername(ctx context.Context, username string, limit, offset int) ([]entity.Tweet, error)
-		GetLikes(ctx context.Context, tweetID, limit, offset int) ([]entity.SmallUser, error)
+		GetRepliesToTweet(ctx context.Context, tweetID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetThread(ctx context.Context, tweetID, depth int, page *entity.Page) (*entity.Thread, *entity.Cursor, error)
+		GetTweetsAndRetweetsByUsername(ctx context.Context, username string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetLikes(ctx context.Context, tweetID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
 	}
 )
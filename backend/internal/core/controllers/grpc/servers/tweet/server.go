@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/kust1q/Zapp/backend/internal/core/controllers/grpc/conv"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/kust1q/Zapp/backend/internal/errs"
 	tweetproto "github.com/kust1q/Zapp/backend/pkg/gen/proto/tweet"
 	"google.golang.org/grpc/codes"
@@ -23,9 +24,10 @@ func NewTweetServer(tweetService tweetService) *tweetServerAPI {
 }
 
 func (s *tweetServerAPI) GetTweetById(ctx context.Context, req *tweetproto.GetTweetByIdRequest) (*tweetproto.Tweet, error) {
+	ctx = entity.WithViewer(ctx, int(req.ViewerId))
 	tweet, err := s.tweetService.GetTweetById(ctx, int(req.TweetId))
 	if err != nil {
-		if !errors.Is(err, errs.ErrTweetNotFound) {
+		if errors.Is(err, errs.ErrTweetNotFound) {
 			return nil, status.Error(codes.NotFound, "tweet not found")
 		}
 		return nil, status.Error(codes.Internal, "internal server error")
@@ -34,34 +36,65 @@ func (s *tweetServerAPI) GetTweetById(ctx context.Context, req *tweetproto.GetTw
 }
 
 func (s *tweetServerAPI) GetRepliesToTweet(ctx context.Context, req *tweetproto.GetRepliesToTweetRequest) (*tweetproto.TweetList, error) {
-	replies, err := s.tweetService.GetRepliesToTweet(ctx, int(req.TweetId), int(req.Limit), int(req.Offset))
+	page, err := conv.FromProtoToPage(req.Limit, req.Offset, req.Cursor)
 	if err != nil {
-		if !errors.Is(err, errs.ErrTweetNotFound) {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	ctx = entity.WithViewer(ctx, int(req.ViewerId))
+	replies, next, err := s.tweetService.GetRepliesToTweet(ctx, int(req.TweetId), page)
+	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
 			return nil, status.Error(codes.NotFound, "tweet not found")
 		}
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
-	return conv.FromDomainToTweetListTweetProto(replies), nil
+	return conv.FromDomainToTweetListTweetProto(replies, next), nil
+}
+
+func (s *tweetServerAPI) GetThread(ctx context.Context, req *tweetproto.GetThreadRequest) (*tweetproto.Thread, error) {
+	page, err := conv.FromProtoToPage(req.Limit, req.Offset, req.Cursor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	ctx = entity.WithViewer(ctx, int(req.ViewerId))
+	thread, next, err := s.tweetService.GetThread(ctx, int(req.TweetId), int(req.Depth), page)
+	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
+			return nil, status.Error(codes.NotFound, "tweet not found")
+		}
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	return conv.FromDomainToThreadTweetProto(thread, next), nil
 }
 
 func (s *tweetServerAPI) GetTweetsAndRetweetsByUsername(ctx context.Context, req *tweetproto.GetTweetsAndRetweetsByUsernameRequest) (*tweetproto.TweetList, error) {
-	tweets, err := s.tweetService.GetTweetsAndRetweetsByUsername(ctx, req.Username, int(req.Limit), int(req.Offset))
+	page, err := conv.FromProtoToPage(req.Limit, req.Offset, req.Cursor)
 	if err != nil {
-		if !errors.Is(err, errs.ErrTweetNotFound) {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	ctx = entity.WithViewer(ctx, int(req.ViewerId))
+	tweets, next, err := s.tweetService.GetTweetsAndRetweetsByUsername(ctx, req.Username, page)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
-	return conv.FromDomainToTweetListTweetProto(tweets), nil
+	return conv.FromDomainToTweetListTweetProto(tweets, next), nil
 }
 
-func (s *tweetServerAPI) GetLikes(ctx context.Context, req *tweetproto.GetTweetLikesRequest) (*tweetproto.LikersList, error) {
-	likers, err := s.tweetService.GetLikes(ctx, int(req.TweetId), int(req.Limit), int(req.Offset))
+func (s *tweetServerAPI) GetTweetLikes(ctx context.Context, req *tweetproto.GetTweetLikesRequest) (*tweetproto.LikersList, error) {
+	page, err := conv.FromProtoToPage(req.Limit, req.Offset, req.Cursor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	ctx = entity.WithViewer(ctx, int(req.ViewerId))
+	likers, next, err := s.tweetService.GetLikes(ctx, int(req.TweetId), page)
 	if err != nil {
-		if !errors.Is(err, errs.ErrTweetNotFound) {
+		if errors.Is(err, errs.ErrTweetNotFound) {
 			return nil, status.Error(codes.NotFound, "tweet not found")
 		}
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
-	return conv.FromDomainToSmallUserListTweetProto(likers), nil
+	return conv.FromDomainToSmallUserListTweetProto(likers, next), nil
 }
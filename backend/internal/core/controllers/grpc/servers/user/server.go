@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/kust1q/Zapp/backend/internal/core/controllers/grpc/conv"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/kust1q/Zapp/backend/internal/errs"
 	userproto "github.com/kust1q/Zapp/backend/pkg/gen/proto/user"
 	"google.golang.org/grpc/codes"
@@ -47,7 +48,12 @@ func (s *userServerAPI) GetUserByUsername(ctx context.Context, req *userproto.Ge
 }
 
 func (s *userServerAPI) GetUserProfile(ctx context.Context, req *userproto.GetUserProfileRequest) (*userproto.UserProfile, error) {
-	profile, err := s.userService.GetUserProfile(ctx, req.Username, int(req.Limit), int(req.Offset))
+	page, err := conv.FromProtoToPage(req.Limit, req.Offset, req.Cursor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	ctx = entity.WithViewer(ctx, int(req.ViewerId))
+	profile, err := s.userService.GetUserProfile(ctx, req.Username, page)
 	if err != nil {
 		if errors.Is(err, errs.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, "user not found")
@@ -58,23 +64,31 @@ func (s *userServerAPI) GetUserProfile(ctx context.Context, req *userproto.GetUs
 }
 
 func (s *userServerAPI) GetFollowers(ctx context.Context, req *userproto.GetFollowersRequest) (*userproto.SmallUserList, error) {
-	users, err := s.userService.GetFollowers(ctx, req.Username, int(req.Limit), int(req.Offset))
+	page, err := conv.FromProtoToPage(req.Limit, req.Offset, req.Cursor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	users, next, err := s.userService.GetFollowers(ctx, req.Username, page)
 	if err != nil {
 		if errors.Is(err, errs.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
-	return conv.FromDomainToSmallUserListUserProto(users), nil
+	return conv.FromDomainToSmallUserListUserProto(users, next), nil
 }
 
 func (s *userServerAPI) GetFollowings(ctx context.Context, req *userproto.GetFollowingsRequest) (*userproto.SmallUserList, error) {
-	users, err := s.userService.GetFollowings(ctx, req.Username, int(req.Limit), int(req.Offset))
+	page, err := conv.FromProtoToPage(req.Limit, req.Offset, req.Cursor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	users, next, err := s.userService.GetFollowings(ctx, req.Username, page)
 	if err != nil {
 		if errors.Is(err, errs.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
-	return conv.FromDomainToSmallUserListUserProto(users), nil
+	return conv.FromDomainToSmallUserListUserProto(users, next), nil
 }
@@ -0,0 +1,48 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/response"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromDomainToAdminUserPageResponse(users []entity.User, next *entity.Cursor) *response.AdminUserList {
+	res := make([]response.AdminUser, 0, len(users))
+	for _, user := range users {
+		var email string
+		if user.Credential != nil {
+			email = user.Credential.Email
+		}
+		res = append(res, response.AdminUser{
+			ID:            user.ID,
+			Username:      user.Username,
+			Email:         email,
+			CreatedAt:     user.CreatedAt,
+			IsActive:      user.IsActive,
+			IsSuperuser:   user.IsSuperuser,
+			IsPrivate:     user.IsPrivate,
+			EmailVerified: user.EmailVerified,
+		})
+	}
+	return &response.AdminUserList{
+		Users:      res,
+		NextCursor: next.Encode(),
+	}
+}
+
+func FromDomainToAuditLogResponse(entries []entity.AuditEntry, next *entity.Cursor) *response.AuditLog {
+	res := make([]response.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		res = append(res, response.AuditEntry{
+			ID:         entry.ID,
+			AdminID:    entry.AdminID,
+			Action:     string(entry.Action),
+			TargetType: string(entry.TargetType),
+			TargetID:   entry.TargetID,
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+	return &response.AuditLog{
+		Entries:    res,
+		NextCursor: next.Encode(),
+	}
+}
@@ -82,6 +82,16 @@ func FromRecoveryPasswordRequestToDomain(req *request.RecoveryPassword) *entity.
 	}
 }
 
+func FromVerifyEmailRequestToDomain(req *request.VerifyEmail) *entity.VerifyEmail {
+	if req == nil {
+		return nil
+	}
+
+	return &entity.VerifyEmail{
+		VerificationToken: req.VerificationToken,
+	}
+}
+
 // Responses
 func FromDomainToSignUpResponse(user *entity.User) *response.SignUp {
 	if user == nil {
@@ -94,13 +104,14 @@ func FromDomainToSignUpResponse(user *entity.User) *response.SignUp {
 	}
 
 	return &response.SignUp{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     email,
-		Bio:       user.Bio,
-		Gen:       user.Gen,
-		AvatarUrl: user.AvatarUrl,
-		CreatedAt: user.CreatedAt,
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         email,
+		Bio:           user.Bio,
+		Gen:           user.Gen,
+		AvatarUrl:     user.AvatarUrl,
+		CreatedAt:     user.CreatedAt,
+		EmailVerified: user.EmailVerified,
 	}
 }
 
@@ -113,13 +124,3 @@ func FromDomainToAccessResponse(tokens *entity.Tokens) *response.Access {
 		Access: tokens.Access.Access,
 	}
 }
-
-func FromDomainToRecoveryResponse(recovery *entity.Recovery) *response.Recovery {
-	if recovery == nil {
-		return nil
-	}
-
-	return &response.Recovery{
-		RecoveryToken: recovery.Recovery,
-	}
-}
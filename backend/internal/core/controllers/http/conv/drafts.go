@@ -0,0 +1,66 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/response"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// Requests
+func FromDraftRequestToDomain(userID int, file *entity.File, req *request.Draft) *entity.Draft {
+	if req == nil {
+		return nil
+	}
+
+	return &entity.Draft{
+		UserID:        userID,
+		Content:       req.Content,
+		ParentTweetID: req.ParentTweetID,
+		QuotedTweetID: req.QuotedTweetID,
+		ScheduledAt:   req.ScheduledAt,
+		File:          file,
+	}
+}
+
+func FromDraftUpdateRequestToDomain(userID, draftID int, file *entity.File, req *request.UpdateDraft) *entity.Draft {
+	if req == nil {
+		return nil
+	}
+
+	return &entity.Draft{
+		ID:      draftID,
+		UserID:  userID,
+		Content: req.Content,
+		File:    file,
+	}
+}
+
+// Responses
+func FromDomainToDraftResponse(draft *entity.Draft) *response.Draft {
+	if draft == nil {
+		return nil
+	}
+
+	return &response.Draft{
+		ID:            draft.ID,
+		Content:       draft.Content,
+		ParentTweetID: draft.ParentTweetID,
+		QuotedTweetID: draft.QuotedTweetID,
+		MediaUrl:      draft.MediaUrl,
+		ScheduledAt:   draft.ScheduledAt,
+		LastError:     draft.LastError,
+		CreatedAt:     draft.CreatedAt,
+		UpdatedAt:     draft.UpdatedAt,
+	}
+}
+
+func FromDomainToDraftPageResponse(drafts []entity.Draft, next *entity.Cursor) *response.DraftList {
+	res := make([]response.Draft, 0, len(drafts))
+	for i := range drafts {
+		res = append(res, *FromDomainToDraftResponse(&drafts[i]))
+	}
+	return &response.DraftList{
+		Drafts:     res,
+		NextCursor: next.Encode(),
+	}
+}
@@ -1,6 +1,7 @@
 package conv
 
 import (
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
 	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/response"
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 )
@@ -23,8 +24,52 @@ func FromDomainToMediaResponse(media *entity.TweetMedia) *response.TweetMedia {
 	}
 
 	return &response.TweetMedia{
+		ID:        media.ID,
+		Position:  media.Position,
 		MediaUrl:  media.Path,
 		MimeType:  media.MimeType,
 		SizeBytes: media.SizeBytes,
+		AltText:   media.AltText,
 	}
 }
+
+func FromDomainToMediaListResponse(media []entity.TweetMedia) []response.TweetMedia {
+	res := make([]response.TweetMedia, 0, len(media))
+	for i := range media {
+		res = append(res, *FromDomainToMediaResponse(&media[i]))
+	}
+	return res
+}
+
+func FromDomainToTweetMediaListResponse(tweetID int, media []entity.TweetMedia) *response.TweetMediaList {
+	return &response.TweetMediaList{
+		TweetID: tweetID,
+		Media:   FromDomainToMediaListResponse(media),
+	}
+}
+
+func FromDomainToUploadResponse(upload *entity.Upload) *response.Upload {
+	if upload == nil {
+		return nil
+	}
+
+	return &response.Upload{
+		ID:        upload.ID,
+		Filename:  upload.Filename,
+		MediaType: string(upload.MediaType),
+		Size:      upload.Size,
+		Offset:    upload.Offset,
+		ChunkSize: upload.ChunkSize,
+		Completed: upload.Completed(),
+		ExpiresAt: upload.ExpiresAt,
+	}
+}
+
+// FromTweetMediaRequestToDomain turns the uploads attached to a JSON tweet into attachments.
+func FromTweetMediaRequestToDomain(media []request.TweetMedia) []entity.Attachment {
+	attachments := make([]entity.Attachment, 0, len(media))
+	for _, m := range media {
+		attachments = append(attachments, entity.Attachment{UploadID: m.UploadID, AltText: m.AltText})
+	}
+	return attachments
+}
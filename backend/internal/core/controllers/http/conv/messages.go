@@ -0,0 +1,110 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/response"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromDomainToParticipantResponse(participant *entity.Participant) *response.Participant {
+	if participant == nil {
+		return nil
+	}
+
+	return &response.Participant{
+		ID:                participant.User.ID,
+		Username:          participant.User.Username,
+		AvatarUrl:         participant.User.AvatarUrl,
+		LastReadMessageID: participant.LastReadMessageID,
+		LastReadAt:        participant.LastReadAt,
+	}
+}
+
+func FromDomainToConversationResponse(conversation *entity.Conversation) *response.Conversation {
+	if conversation == nil {
+		return nil
+	}
+
+	participants := make([]response.Participant, 0, len(conversation.Participants))
+	for i := range conversation.Participants {
+		participants = append(participants, *FromDomainToParticipantResponse(&conversation.Participants[i]))
+	}
+
+	return &response.Conversation{
+		ID:            conversation.ID,
+		CreatorID:     conversation.CreatorID,
+		IsGroup:       conversation.IsGroup,
+		Participants:  participants,
+		LastMessage:   FromDomainToDirectMessageResponse(conversation.LastMessage),
+		UnreadCount:   conversation.UnreadCount,
+		CreatedAt:     conversation.CreatedAt,
+		LastMessageAt: conversation.LastMessageAt,
+	}
+}
+
+func FromDomainToConversationPageResponse(conversations []entity.Conversation, next *entity.Cursor) *response.ConversationList {
+	res := make([]response.Conversation, 0, len(conversations))
+	for i := range conversations {
+		res = append(res, *FromDomainToConversationResponse(&conversations[i]))
+	}
+	return &response.ConversationList{
+		Conversations: res,
+		NextCursor:    next.Encode(),
+	}
+}
+
+func FromDomainToDirectMessageResponse(message *entity.DirectMessage) *response.DirectMessage {
+	if message == nil {
+		return nil
+	}
+
+	res := &response.DirectMessage{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		Sender:         FromDomainToSmallUserResponse(message.Sender),
+		Content:        message.Content,
+		MediaUrl:       message.MediaUrl,
+		CreatedAt:      message.CreatedAt,
+	}
+	if message.Sender != nil {
+		res.SenderID = message.Sender.ID
+	}
+	return res
+}
+
+func FromDomainToDirectMessagePageResponse(messages []entity.DirectMessage, next *entity.Cursor) *response.DirectMessageList {
+	res := make([]response.DirectMessage, 0, len(messages))
+	for i := range messages {
+		res = append(res, *FromDomainToDirectMessageResponse(&messages[i]))
+	}
+	return &response.DirectMessageList{
+		Messages:   res,
+		NextCursor: next.Encode(),
+	}
+}
+
+func FromDomainToReadReceiptResponse(receipt *entity.ReadReceipt) *response.ReadReceipt {
+	if receipt == nil {
+		return nil
+	}
+
+	return &response.ReadReceipt{
+		ConversationID: receipt.ConversationID,
+		UserID:         receipt.UserID,
+		MessageID:      receipt.MessageID,
+		ReadAt:         receipt.ReadAt,
+	}
+}
+
+func FromDirectMessageRequestToDomain(senderID, conversationID int, file *entity.File, req *request.DirectMessage) *entity.DirectMessage {
+	if req == nil {
+		return nil
+	}
+
+	return &entity.DirectMessage{
+		ConversationID: conversationID,
+		Sender:         &entity.SmallUser{ID: senderID},
+		Content:        req.Content,
+		File:           file,
+	}
+}
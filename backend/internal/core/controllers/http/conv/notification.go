@@ -0,0 +1,44 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/response"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromDomainToNotificationResponse(notification *entity.Notification) *response.Notification {
+	if notification == nil {
+		return nil
+	}
+
+	return &response.Notification{
+		ID:          notification.ID,
+		Type:        string(notification.Type),
+		ActorID:     notification.ActorID,
+		ActorName:   notification.ActorName,
+		ActorAvatar: notification.ActorAvatar,
+		TweetID:     notification.TweetID,
+		TweetText:   notification.TweetText,
+		ReportID:    notification.ReportID,
+		Resolution:  notification.Resolution,
+		Timestamp:   notification.Timestamp,
+		Read:        notification.Read,
+	}
+}
+
+func FromDomainToNotificationListResponse(notifications []entity.Notification) []response.Notification {
+	res := make([]response.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		notificationResponse := FromDomainToNotificationResponse(&n)
+		if notificationResponse != nil {
+			res = append(res, *notificationResponse)
+		}
+	}
+	return res
+}
+
+func FromDomainToNotificationPageResponse(notifications []entity.Notification, next *entity.Cursor) *response.NotificationList {
+	return &response.NotificationList{
+		Notifications: FromDomainToNotificationListResponse(notifications),
+		NextCursor:    next.Encode(),
+	}
+}
@@ -0,0 +1,55 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/response"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// Requests
+func FromReportRequestToDomain(reporterID int, req *request.Report) *entity.Report {
+	if req == nil {
+		return nil
+	}
+
+	return &entity.Report{
+		ReporterID: reporterID,
+		TargetType: entity.ReportTarget(req.TargetType),
+		TargetID:   req.TargetID,
+		Reason:     entity.ReportReason(req.Reason),
+		Details:    req.Details,
+	}
+}
+
+// Responses
+func FromDomainToReportResponse(report *entity.Report) *response.Report {
+	if report == nil {
+		return nil
+	}
+
+	return &response.Report{
+		ID:             report.ID,
+		ReporterID:     report.ReporterID,
+		TargetType:     string(report.TargetType),
+		TargetID:       report.TargetID,
+		ReportedUserID: report.ReportedUserID,
+		Reason:         string(report.Reason),
+		Details:        report.Details,
+		Status:         string(report.Status),
+		ClaimedBy:      report.ClaimedBy,
+		Resolution:     string(report.Resolution),
+		CreatedAt:      report.CreatedAt,
+		ResolvedAt:     report.ResolvedAt,
+	}
+}
+
+func FromDomainToReportPageResponse(reports []entity.Report, next *entity.Cursor) *response.ReportList {
+	res := make([]response.Report, 0, len(reports))
+	for i := range reports {
+		res = append(res, *FromDomainToReportResponse(&reports[i]))
+	}
+	return &response.ReportList{
+		Reports:    res,
+		NextCursor: next.Encode(),
+	}
+}
@@ -0,0 +1,32 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/response"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromDomainToSessionResponse(session *entity.Session) *response.Session {
+	if session == nil {
+		return nil
+	}
+
+	return &response.Session{
+		ID:         session.ID,
+		UserAgent:  session.Device.UserAgent,
+		IP:         session.Device.IP,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		Current:    session.Current,
+	}
+}
+
+func FromDomainToSessionListResponse(sessions []entity.Session) []response.Session {
+	res := make([]response.Session, 0, len(sessions))
+	for _, s := range sessions {
+		sessionResponse := FromDomainToSessionResponse(&s)
+		if sessionResponse != nil {
+			res = append(res, *sessionResponse)
+		}
+	}
+	return res
+}
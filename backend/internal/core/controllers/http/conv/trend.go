@@ -0,0 +1,21 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/response"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromDomainToTrendListResponse(trends []entity.Trend) *response.TrendList {
+	res := make([]response.Trend, 0, len(trends))
+	for _, trend := range trends {
+		res = append(res, response.Trend{
+			Term:     trend.Term,
+			Kind:     string(trend.Kind),
+			Count:    trend.Count,
+			Velocity: trend.Velocity,
+		})
+	}
+	return &response.TrendList{
+		Trends: res,
+	}
+}
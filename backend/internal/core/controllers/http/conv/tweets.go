@@ -9,7 +9,7 @@ import (
 )
 
 // Requests
-func FromTweetRequestToDomain(userID int, parent_tweet_id *int, file *entity.File, req *request.Tweet) *entity.Tweet {
+func FromTweetRequestToDomain(userID int, parent_tweet_id *int, attachments []entity.Attachment, req *request.Tweet) *entity.Tweet {
 	if req == nil {
 		return nil
 	}
@@ -19,7 +19,7 @@ func FromTweetRequestToDomain(userID int, parent_tweet_id *int, file *entity.Fil
 		Content:       req.Content,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
-		File:          file,
+		Attachments:   attachments,
 		Author: &entity.SmallUser{
 			ID: userID,
 		},
@@ -27,16 +27,16 @@ func FromTweetRequestToDomain(userID int, parent_tweet_id *int, file *entity.Fil
 	}
 }
 
-func FromTweetUpdateRequestToDomain(userID, tweetID int, file *entity.File, req *request.Tweet) *entity.Tweet {
+func FromTweetUpdateRequestToDomain(userID, tweetID int, attachments []entity.Attachment, req *request.Tweet) *entity.Tweet {
 	if req == nil {
 		return nil
 	}
 
 	return &entity.Tweet{
-		ID:        tweetID,
-		Content:   req.Content,
-		UpdatedAt: time.Now(),
-		File:      file,
+		ID:          tweetID,
+		Content:     req.Content,
+		UpdatedAt:   time.Now(),
+		Attachments: attachments,
 		Author: &entity.SmallUser{
 			ID: userID,
 		},
@@ -54,16 +54,31 @@ func FromDomainToTweetResponse(tweet *entity.Tweet) *response.Tweet {
 		Content:       tweet.Content,
 		CreatedAt:     tweet.CreatedAt,
 		UpdatedAt:     tweet.UpdatedAt,
+		Edited:        tweet.RevisionCount > 0,
+		RevisionCount: tweet.RevisionCount,
 		ParentTweetID: tweet.ParentTweetID,
-		MediaUrl:      tweet.MediaUrl,
+		QuotedTweetID: tweet.QuotedTweetID,
+		QuotedTweet:   FromDomainToTweetResponse(tweet.QuotedTweet),
+		Media:         FromDomainToMediaListResponse(tweet.Media),
 		Author:        FromDomainToSmallUserResponse(tweet.Author),
+		RetweetedBy:   FromDomainToSmallUserResponse(tweet.RetweetedBy),
 	}
 
 	if tweet.Counters != nil {
 		responseTweet.Counters = &response.Counters{
-			ReplyCount:   tweet.Counters.ReplyCount,
-			RetweetCount: tweet.Counters.RetweetCount,
-			LikeCount:    tweet.Counters.LikeCount,
+			ReplyCount:    tweet.Counters.ReplyCount,
+			RetweetCount:  tweet.Counters.RetweetCount,
+			LikeCount:     tweet.Counters.LikeCount,
+			BookmarkCount: tweet.Counters.BookmarkCount,
+			QuoteCount:    tweet.Counters.QuoteCount,
+		}
+	}
+
+	if tweet.Viewer != nil {
+		responseTweet.Viewer = &response.Viewer{
+			Liked:      tweet.Viewer.Liked,
+			Retweeted:  tweet.Viewer.Retweeted,
+			Bookmarked: tweet.Viewer.Bookmarked,
 		}
 	}
 
@@ -84,3 +99,50 @@ func FromDomainToTweetListResponse(tweets []entity.Tweet) []response.Tweet {
 	}
 	return res
 }
+
+func FromDomainToTweetPageResponse(tweets []entity.Tweet, next *entity.Cursor) *response.TweetList {
+	return &response.TweetList{
+		Tweets:     FromDomainToTweetListResponse(tweets),
+		NextCursor: next.Encode(),
+	}
+}
+
+func FromDomainToThreadResponse(thread *entity.Thread, next *entity.Cursor) *response.Thread {
+	if thread == nil {
+		return nil
+	}
+
+	return &response.Thread{
+		Ancestors:  FromDomainToTweetListResponse(thread.Ancestors),
+		Tweet:      FromDomainToTweetResponse(thread.Tweet),
+		Replies:    FromDomainToThreadRepliesResponse(thread.Replies),
+		NextCursor: next.Encode(),
+	}
+}
+
+func FromDomainToThreadRepliesResponse(replies []entity.ThreadReply) []response.ThreadReply {
+	res := make([]response.ThreadReply, 0, len(replies))
+	for i := range replies {
+		res = append(res, response.ThreadReply{
+			Tweet:   *FromDomainToTweetResponse(&replies[i].Tweet),
+			Replies: FromDomainToThreadRepliesResponse(replies[i].Replies),
+		})
+	}
+	return res
+}
+
+func FromDomainToTweetHistoryResponse(tweetID int, revisions []entity.TweetRevision, next *entity.Cursor) *response.TweetHistory {
+	res := make([]response.TweetRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		res = append(res, response.TweetRevision{
+			Content:    revision.Content,
+			CreatedAt:  revision.CreatedAt,
+			ReplacedAt: revision.ReplacedAt,
+		})
+	}
+	return &response.TweetHistory{
+		TweetID:    tweetID,
+		Revisions:  res,
+		NextCursor: next.Encode(),
+	}
+}
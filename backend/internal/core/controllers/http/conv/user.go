@@ -18,6 +18,17 @@ func FromUpdateBioRequestToDomain(userID int, req *request.UpdateBio) *entity.Up
 	}
 }
 
+func FromUpdatePrivacyRequestToDomain(userID int, req *request.UpdatePrivacy) *entity.UpdatePrivacy {
+	if req == nil || req.IsPrivate == nil {
+		return nil
+	}
+
+	return &entity.UpdatePrivacy{
+		UserID:    userID,
+		IsPrivate: *req.IsPrivate,
+	}
+}
+
 // Responses
 func FromDomainToSmallUserResponse(user *entity.SmallUser) *response.SmallUser {
 	if user == nil {
@@ -25,6 +36,7 @@ func FromDomainToSmallUserResponse(user *entity.SmallUser) *response.SmallUser {
 	}
 
 	return &response.SmallUser{
+		ID:        user.ID,
 		Username:  user.Username,
 		AvatarUrl: user.AvatarUrl,
 	}
@@ -41,13 +53,15 @@ func FromDomainToUserResponse(user *entity.User) *response.User {
 	}
 
 	return &response.User{
-		ID:        user.ID,
-		Username:  user.Username,
-		Bio:       user.Bio,
-		Gen:       user.Gen,
-		Email:     email,
-		CreatedAt: user.CreatedAt,
-		AvatarUrl: user.AvatarUrl,
+		ID:            user.ID,
+		Username:      user.Username,
+		Bio:           user.Bio,
+		Gen:           user.Gen,
+		Email:         email,
+		CreatedAt:     user.CreatedAt,
+		AvatarUrl:     user.AvatarUrl,
+		IsPrivate:     user.IsPrivate,
+		EmailVerified: user.EmailVerified,
 	}
 }
 
@@ -72,8 +86,9 @@ func FromDomainToUserProfileResponse(userProfile *entity.UserProfile) *response.
 	}
 
 	return &response.UserProfile{
-		User:   FromDomainToUserResponse(userProfile.User),
-		Tweets: FromDomainToTweetListResponse(userProfile.Tweets),
+		User:       FromDomainToUserResponse(userProfile.User),
+		Tweets:     FromDomainToTweetListResponse(userProfile.Tweets),
+		NextCursor: userProfile.NextCursor.Encode(),
 	}
 }
 
@@ -85,6 +100,7 @@ func FromDomainToFollow(follow *entity.Follow) *response.Follow {
 	return &response.Follow{
 		FollowerID:  follow.FollowerID,
 		FollowingID: follow.FollowingID,
+		Pending:     follow.Pending,
 		CreatedAt:   follow.CreatedAt,
 	}
 }
@@ -103,3 +119,10 @@ func FromDomainToSmallUserListResponse(users []entity.SmallUser) []response.Smal
 	}
 	return responses
 }
+
+func FromDomainToSmallUserPageResponse(users []entity.SmallUser, next *entity.Cursor) *response.SmallUserList {
+	return &response.SmallUserList{
+		Users:      FromDomainToSmallUserListResponse(users),
+		NextCursor: next.Encode(),
+	}
+}
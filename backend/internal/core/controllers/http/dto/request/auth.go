@@ -31,4 +31,8 @@ type (
 		RecoveryToken string `json:"recovery_token" binding:"required,min=8,max=100"`
 		NewPassword   string `json:"new_password" binding:"required,alphanum,min=8,max=64"`
 	}
+
+	VerifyEmail struct {
+		VerificationToken string `json:"verification_token" binding:"required,min=8,max=100"`
+	}
 )
@@ -0,0 +1,21 @@
+package request
+
+import "time"
+
+type (
+	// Draft is sent as JSON, or as multipart/form-data together with a media file.
+	Draft struct {
+		Content       string     `json:"content" form:"content" binding:"max=280"`
+		ParentTweetID *int       `json:"parent_tweet_id" form:"parent_tweet_id" binding:"omitempty,min=1"`
+		QuotedTweetID *int       `json:"quoted_tweet_id" form:"quoted_tweet_id" binding:"omitempty,min=1"`
+		ScheduledAt   *time.Time `json:"scheduled_at" form:"scheduled_at" time_format:"2006-01-02T15:04:05Z07:00"`
+	}
+
+	UpdateDraft struct {
+		Content string `json:"content" form:"content" binding:"max=280"`
+	}
+
+	ScheduleDraft struct {
+		ScheduledAt time.Time `json:"scheduled_at" binding:"required"`
+	}
+)
@@ -0,0 +1,6 @@
+package request
+
+type CreateUpload struct {
+	Filename string `json:"filename" binding:"required,max=255"`
+	Size     int64  `json:"size" binding:"required,min=1"`
+}
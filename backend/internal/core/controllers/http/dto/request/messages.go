@@ -0,0 +1,16 @@
+package request
+
+type (
+	Conversation struct {
+		ParticipantIDs []int `json:"participant_ids" binding:"required,min=1"`
+	}
+
+	DirectMessage struct {
+		Content string `json:"content" binding:"required,min=1,max=1000"`
+	}
+
+	// MessageID is optional; without it the whole conversation is marked as read.
+	ReadReceipt struct {
+		MessageID int `json:"message_id" binding:"omitempty,min=1"`
+	}
+)
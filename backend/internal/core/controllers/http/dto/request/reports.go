@@ -0,0 +1,14 @@
+package request
+
+type (
+	Report struct {
+		TargetType string `json:"target_type" binding:"required,oneof=tweet user"`
+		TargetID   int    `json:"target_id" binding:"required,min=1"`
+		Reason     string `json:"reason" binding:"required,oneof=spam harassment hate violence adult_content misinformation other"`
+		Details    string `json:"details" binding:"max=500"`
+	}
+
+	ResolveReport struct {
+		Resolution string `json:"resolution" binding:"required,oneof=dismiss delete_content suspend_user"`
+	}
+)
@@ -1,7 +1,15 @@
 package request
 
 type (
+	// Tweet attaches completed uploads through Media when sent as JSON.
 	Tweet struct {
-		Content string `json:"content" binding:"required,min=1,max=280"`
+		Content string       `json:"content" binding:"max=280"`
+		Media   []TweetMedia `json:"media" binding:"max=4,dive"`
+	}
+
+	// TweetMedia attaches a completed upload to a tweet.
+	TweetMedia struct {
+		UploadID int    `json:"upload_id" binding:"required,min=1"`
+		AltText  string `json:"alt_text"`
 	}
 )
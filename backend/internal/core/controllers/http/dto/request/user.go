@@ -4,4 +4,8 @@ type (
 	UpdateBio struct {
 		Bio string `json:"bio"`
 	}
+
+	UpdatePrivacy struct {
+		IsPrivate *bool `json:"is_private" binding:"required"`
+	}
 )
@@ -0,0 +1,35 @@
+package response
+
+import "time"
+
+type (
+	AdminUser struct {
+		ID            int       `json:"id"`
+		Username      string    `json:"username"`
+		Email         string    `json:"email"`
+		CreatedAt     time.Time `json:"created_at"`
+		IsActive      bool      `json:"is_active"`
+		IsSuperuser   bool      `json:"is_superuser"`
+		IsPrivate     bool      `json:"is_private"`
+		EmailVerified bool      `json:"email_verified"`
+	}
+
+	AdminUserList struct {
+		Users      []AdminUser `json:"users"`
+		NextCursor string      `json:"next_cursor,omitempty"`
+	}
+
+	AuditEntry struct {
+		ID         int       `json:"id"`
+		AdminID    int       `json:"admin_id"`
+		Action     string    `json:"action"`
+		TargetType string    `json:"target_type"`
+		TargetID   int       `json:"target_id"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	AuditLog struct {
+		Entries    []AuditEntry `json:"entries"`
+		NextCursor string       `json:"next_cursor,omitempty"`
+	}
+)
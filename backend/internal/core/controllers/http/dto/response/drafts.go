@@ -0,0 +1,22 @@
+package response
+
+import "time"
+
+type (
+	Draft struct {
+		ID            int        `json:"id"`
+		Content       string     `json:"content"`
+		ParentTweetID *int       `json:"parent_tweet_id,omitempty"`
+		QuotedTweetID *int       `json:"quoted_tweet_id,omitempty"`
+		MediaUrl      string     `json:"media_url"`
+		ScheduledAt   *time.Time `json:"scheduled_at,omitempty"`
+		LastError     string     `json:"last_error,omitempty"`
+		CreatedAt     time.Time  `json:"created_at"`
+		UpdatedAt     time.Time  `json:"updated_at"`
+	}
+
+	DraftList struct {
+		Drafts     []Draft `json:"drafts"`
+		NextCursor string  `json:"next_cursor,omitempty"`
+	}
+)
@@ -1,9 +1,19 @@
 package response
 
+import "time"
+
 type TweetMedia struct {
+	ID        int    `json:"id"`
+	Position  int    `json:"position"`
 	MediaUrl  string `json:"media_url"`
 	MimeType  string `json:"mime_type"`
 	SizeBytes int64  `json:"size_bytes"`
+	AltText   string `json:"alt_text"`
+}
+
+type TweetMediaList struct {
+	TweetID int          `json:"tweet_id"`
+	Media   []TweetMedia `json:"media"`
 }
 
 type Avatar struct {
@@ -11,3 +21,15 @@ type Avatar struct {
 	MimeType  string `json:"mime_type"`
 	SizeBytes int64  `json:"size_bytes"`
 }
+
+// Upload reports the progress of a resumable upload; the next chunk is written at Offset.
+type Upload struct {
+	ID        int       `json:"id"`
+	Filename  string    `json:"filename"`
+	MediaType string    `json:"media_type"`
+	Size      int64     `json:"size"`
+	Offset    int64     `json:"offset"`
+	ChunkSize int64     `json:"chunk_size"`
+	Completed bool      `json:"completed"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
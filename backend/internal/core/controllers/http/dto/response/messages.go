@@ -0,0 +1,51 @@
+package response
+
+import "time"
+
+type (
+	Participant struct {
+		ID                int        `json:"id"`
+		Username          string     `json:"username"`
+		AvatarUrl         string     `json:"avatar_url"`
+		LastReadMessageID *int       `json:"last_read_message_id,omitempty"`
+		LastReadAt        *time.Time `json:"last_read_at,omitempty"`
+	}
+
+	Conversation struct {
+		ID            int            `json:"id"`
+		CreatorID     int            `json:"creator_id"`
+		IsGroup       bool           `json:"is_group"`
+		Participants  []Participant  `json:"participants"`
+		LastMessage   *DirectMessage `json:"last_message,omitempty"`
+		UnreadCount   int            `json:"unread_count"`
+		CreatedAt     time.Time      `json:"created_at"`
+		LastMessageAt time.Time      `json:"last_message_at"`
+	}
+
+	DirectMessage struct {
+		ID             int        `json:"id"`
+		ConversationID int        `json:"conversation_id"`
+		SenderID       int        `json:"sender_id"`
+		Sender         *SmallUser `json:"sender"`
+		Content        string     `json:"content"`
+		MediaUrl       string     `json:"media_url"`
+		CreatedAt      time.Time  `json:"created_at"`
+	}
+
+	ReadReceipt struct {
+		ConversationID int       `json:"conversation_id"`
+		UserID         int       `json:"user_id"`
+		MessageID      int       `json:"message_id"`
+		ReadAt         time.Time `json:"read_at"`
+	}
+
+	ConversationList struct {
+		Conversations []Conversation `json:"conversations"`
+		NextCursor    string         `json:"next_cursor,omitempty"`
+	}
+
+	DirectMessageList struct {
+		Messages   []DirectMessage `json:"messages"`
+		NextCursor string          `json:"next_cursor,omitempty"`
+	}
+)
@@ -0,0 +1,29 @@
+package response
+
+import "time"
+
+type (
+	Notification struct {
+		ID          string    `json:"id"`
+		Type        string    `json:"type"`
+		ActorID     int       `json:"actor_id"`
+		ActorName   string    `json:"actor_name"`
+		ActorAvatar string    `json:"actor_avatar,omitempty"`
+		TweetID     *int      `json:"tweet_id,omitempty"`
+		TweetText   *string   `json:"tweet_text,omitempty"`
+		ReportID    *int      `json:"report_id,omitempty"`
+		Resolution  *string   `json:"resolution,omitempty"`
+		Timestamp   time.Time `json:"timestamp"`
+		Read        bool      `json:"read"`
+	}
+
+	// For docs
+	UnreadCount struct {
+		Count int `json:"count"`
+	}
+
+	NotificationList struct {
+		Notifications []Notification `json:"notifications"`
+		NextCursor    string         `json:"next_cursor,omitempty"`
+	}
+)
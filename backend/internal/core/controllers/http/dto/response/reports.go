@@ -0,0 +1,25 @@
+package response
+
+import "time"
+
+type (
+	Report struct {
+		ID             int        `json:"id"`
+		ReporterID     int        `json:"reporter_id"`
+		TargetType     string     `json:"target_type"`
+		TargetID       int        `json:"target_id"`
+		ReportedUserID int        `json:"reported_user_id,omitempty"`
+		Reason         string     `json:"reason"`
+		Details        string     `json:"details,omitempty"`
+		Status         string     `json:"status"`
+		ClaimedBy      int        `json:"claimed_by,omitempty"`
+		Resolution     string     `json:"resolution,omitempty"`
+		CreatedAt      time.Time  `json:"created_at"`
+		ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	}
+
+	ReportList struct {
+		Reports    []Report `json:"reports"`
+		NextCursor string   `json:"next_cursor,omitempty"`
+	}
+)
@@ -0,0 +1,14 @@
+package response
+
+type (
+	Trend struct {
+		Term     string  `json:"term"`
+		Kind     string  `json:"kind"`
+		Count    int     `json:"count"`
+		Velocity float64 `json:"velocity"`
+	}
+
+	TrendList struct {
+		Trends []Trend `json:"trends"`
+	}
+)
@@ -6,24 +6,63 @@ import (
 
 type (
 	Tweet struct {
-		ID            int        `json:"id"`
-		Content       string     `json:"content"`
-		CreatedAt     time.Time  `json:"created_at"`
-		UpdatedAt     time.Time  `json:"updated_at"`
-		ParentTweetID *int       `json:"parent_tweet_id,omitempty"`
-		MediaUrl      string     `json:"media_url"`
-		Author        *SmallUser `json:"author"`
-		Counters      *Counters  `json:"counters"`
+		ID            int          `json:"id"`
+		Content       string       `json:"content"`
+		CreatedAt     time.Time    `json:"created_at"`
+		UpdatedAt     time.Time    `json:"updated_at"`
+		Edited        bool         `json:"edited"`
+		RevisionCount int          `json:"revision_count"`
+		ParentTweetID *int         `json:"parent_tweet_id,omitempty"`
+		QuotedTweetID *int         `json:"quoted_tweet_id,omitempty"`
+		QuotedTweet   *Tweet       `json:"quoted_tweet,omitempty"`
+		Media         []TweetMedia `json:"media"`
+		Author        *SmallUser   `json:"author"`
+		RetweetedBy   *SmallUser   `json:"retweeted_by,omitempty"`
+		Counters      *Counters    `json:"counters"`
+		Viewer        *Viewer      `json:"viewer,omitempty"`
+	}
+
+	TweetRevision struct {
+		Content    string    `json:"content"`
+		CreatedAt  time.Time `json:"created_at"`
+		ReplacedAt time.Time `json:"replaced_at"`
+	}
+
+	TweetHistory struct {
+		TweetID    int             `json:"tweet_id"`
+		Revisions  []TweetRevision `json:"revisions"`
+		NextCursor string          `json:"next_cursor,omitempty"`
+	}
+
+	// Viewer is present only for authenticated requests.
+	Viewer struct {
+		Liked      bool `json:"liked"`
+		Retweeted  bool `json:"retweeted"`
+		Bookmarked bool `json:"bookmarked"`
 	}
 
 	Counters struct {
-		ReplyCount   int `json:"reply_count"`
-		RetweetCount int `json:"retweet_count"`
-		LikeCount    int `json:"like_count"`
+		ReplyCount    int `json:"reply_count"`
+		RetweetCount  int `json:"retweet_count"`
+		LikeCount     int `json:"like_count"`
+		BookmarkCount int `json:"bookmark_count"`
+		QuoteCount    int `json:"quote_count"`
+	}
+
+	Thread struct {
+		Ancestors  []Tweet       `json:"ancestors"`
+		Tweet      *Tweet        `json:"tweet"`
+		Replies    []ThreadReply `json:"replies"`
+		NextCursor string        `json:"next_cursor,omitempty"`
+	}
+
+	ThreadReply struct {
+		Tweet
+		Replies []ThreadReply `json:"replies"`
 	}
 
-	// For docs
 	TweetList struct {
-		Tweets []Tweet `json:"tweets"`
+		Tweets     []Tweet `json:"tweets"`
+		NextCursor string  `json:"next_cursor,omitempty"`
 	}
 )
@@ -4,33 +4,38 @@ import "time"
 
 type (
 	SmallUser struct {
+		ID        int    `json:"id"`
 		Username  string `json:"username"`
 		AvatarUrl string `json:"avatar_url"`
 	}
 
 	User struct {
-		ID        int       `json:"id"`
-		Username  string    `json:"username"`
-		Bio       string    `json:"bio"`
-		Gen       string    `json:"gen"`
-		Email     string    `json:"email"`
-		CreatedAt time.Time `json:"created_at"`
-		AvatarUrl string    `json:"avatar_url"`
+		ID            int       `json:"id"`
+		Username      string    `json:"username"`
+		Bio           string    `json:"bio"`
+		Gen           string    `json:"gen"`
+		Email         string    `json:"email"`
+		CreatedAt     time.Time `json:"created_at"`
+		AvatarUrl     string    `json:"avatar_url"`
+		IsPrivate     bool      `json:"is_private"`
+		EmailVerified bool      `json:"email_verified"`
 	}
 
 	UserProfile struct {
-		User   *User   `json:"user"`
-		Tweets []Tweet `json:"tweets"`
+		User       *User   `json:"user"`
+		Tweets     []Tweet `json:"tweets"`
+		NextCursor string  `json:"next_cursor,omitempty"`
 	}
 
 	Follow struct {
 		FollowerID  int       `json:"follower_id"`
 		FollowingID int       `json:"following_id"`
+		Pending     bool      `json:"pending"`
 		CreatedAt   time.Time `json:"created_at"`
 	}
 
-	// For docs
 	SmallUserList struct {
-		Users []SmallUser `json:"users"`
+		Users      []SmallUser `json:"users"`
+		NextCursor string      `json:"next_cursor,omitempty"`
 	}
 )
@@ -0,0 +1,332 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// deactivateUser blocks sign-in and all tokens of a user.
+//
+// @Summary      Deactivate user
+// @Description  Deactivate user by ID and revoke their sessions. Admin only.
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        user_id  path      int  true  "User ID to deactivate"
+// @Success      200      {object}  response.Message
+// @Failure      400      {object}  response.Error "Invalid user ID or target is yourself"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      403      {object}  response.Error "Forbidden"
+// @Failure      404      {object}  response.Error "User not found"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /admin/users/{user_id}/deactivate [post]
+func (h *Handler) deactivateUser(c *gin.Context) {
+	adminID, ok := c.Get(userCtx)
+	if !ok || adminID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	targetID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil || targetID == 0 {
+		logrus.WithError(err).Error("failed to deactivate - invalid user id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	err = h.adminService.DeactivateUser(c.Request.Context(), adminID.(int), targetID)
+	if errors.Is(err, errs.ErrInvalidInput) {
+		logrus.WithField("admin_id", adminID.(int)).Warn("failed to deactivate - target is yourself")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot deactivate yourself"})
+		return
+	} else if errors.Is(err, errs.ErrUserNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"admin_id":  adminID.(int),
+			"target_id": targetID,
+		}).Warn("failed to deactivate - user not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "user not found",
+		})
+		return
+	} else if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"admin_id":  adminID.(int),
+			"target_id": targetID,
+			"error":     err,
+		}).Error("failed to deactivate - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"admin_id":  adminID.(int),
+		"target_id": targetID,
+	}).Info("user deactivated")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully deactivated",
+	})
+}
+
+// reactivateUser restores sign-in for a deactivated user.
+//
+// @Summary      Reactivate user
+// @Description  Reactivate user by ID. Admin only.
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        user_id  path      int  true  "User ID to reactivate"
+// @Success      200      {object}  response.Message
+// @Failure      400      {object}  response.Error "Invalid user ID"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      403      {object}  response.Error "Forbidden"
+// @Failure      404      {object}  response.Error "User not found"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /admin/users/{user_id}/reactivate [post]
+func (h *Handler) reactivateUser(c *gin.Context) {
+	adminID, ok := c.Get(userCtx)
+	if !ok || adminID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	targetID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil || targetID == 0 {
+		logrus.WithError(err).Error("failed to reactivate - invalid user id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	err = h.adminService.ReactivateUser(c.Request.Context(), adminID.(int), targetID)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"admin_id":  adminID.(int),
+			"target_id": targetID,
+		}).Warn("failed to reactivate - user not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "user not found",
+		})
+		return
+	} else if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"admin_id":  adminID.(int),
+			"target_id": targetID,
+			"error":     err,
+		}).Error("failed to reactivate - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"admin_id":  adminID.(int),
+		"target_id": targetID,
+	}).Info("user reactivated")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully reactivated",
+	})
+}
+
+// forceDeleteTweet deletes any tweet regardless of its author.
+//
+// @Summary      Force delete tweet
+// @Description  Delete tweet and its media by ID regardless of the author. Admin only.
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        tweet_id  path      int  true  "Tweet ID"
+// @Success      200       {object}  response.Message
+// @Failure      400       {object}  response.Error "Invalid tweet ID"
+// @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      403       {object}  response.Error "Forbidden"
+// @Failure      404       {object}  response.Error "Tweet not found"
+// @Failure      500       {object}  response.Error "Internal server error"
+// @Router       /admin/tweets/{tweet_id} [delete]
+func (h *Handler) forceDeleteTweet(c *gin.Context) {
+	adminID, ok := c.Get(userCtx)
+	if !ok || adminID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	tweetID, err := strconv.Atoi(c.Param("tweet_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to force delete tweet - invalid tweet id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tweet id"})
+		return
+	}
+
+	err = h.adminService.DeleteTweet(c.Request.Context(), adminID.(int), tweetID)
+	if errors.Is(err, errs.ErrTweetNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"admin_id": adminID.(int),
+			"tweet_id": tweetID,
+		}).Warn("failed to force delete tweet - tweet not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tweet not found",
+		})
+		return
+	} else if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"admin_id": adminID.(int),
+			"tweet_id": tweetID,
+			"error":    err,
+		}).Error("failed to force delete tweet - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"admin_id": adminID.(int),
+		"tweet_id": tweetID,
+	}).Info("tweet force deleted")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully deleted",
+	})
+}
+
+// forceDeleteTweetMedia deletes media of any tweet regardless of its author.
+//
+// @Summary      Force delete tweet media
+// @Description  Delete media attached to the specified tweet regardless of the author. Admin only.
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        tweet_id  path      int  true  "Tweet ID"
+// @Success      200       {object}  response.Message
+// @Failure      400       {object}  response.Error "Invalid tweet ID"
+// @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      403       {object}  response.Error "Forbidden"
+// @Failure      404       {object}  response.Error "Tweet media not found"
+// @Failure      500       {object}  response.Error "Internal server error"
+// @Router       /admin/tweets/{tweet_id}/media [delete]
+func (h *Handler) forceDeleteTweetMedia(c *gin.Context) {
+	adminID, ok := c.Get(userCtx)
+	if !ok || adminID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	tweetID, err := strconv.Atoi(c.Param("tweet_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to force delete tweet media - invalid tweet id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tweet id"})
+		return
+	}
+
+	err = h.adminService.DeleteTweetMedia(c.Request.Context(), adminID.(int), tweetID)
+	if errors.Is(err, errs.ErrTweetMediaNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"admin_id": adminID.(int),
+			"tweet_id": tweetID,
+		}).Warn("failed to force delete tweet media - media not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tweet media not found",
+		})
+		return
+	} else if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"admin_id": adminID.(int),
+			"tweet_id": tweetID,
+			"error":    err,
+		}).Error("failed to force delete tweet media - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"admin_id": adminID.(int),
+		"tweet_id": tweetID,
+	}).Info("tweet media force deleted")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully deleted",
+	})
+}
+
+// getRecentSignups returns the newest accounts.
+//
+// @Summary      List recent signups
+// @Description  List accounts newest signup first, including inactive ones. Admin only.
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
+// @Success      200  {object}  response.AdminUserList
+// @Failure      400  {object}  response.Error "Invalid cursor"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      403  {object}  response.Error "Forbidden"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /admin/users [get]
+func (h *Handler) getRecentSignups(c *gin.Context) {
+	page, err := parsePage(c, 20, 100)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get recent signups - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	users, next, err := h.adminService.GetRecentSignups(c.Request.Context(), page)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get recent signups - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conv.FromDomainToAdminUserPageResponse(users, next))
+}
+
+// getAuditLog returns admin actions.
+//
+// @Summary      Get audit log
+// @Description  List admin actions, most recent first. Admin only.
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
+// @Success      200  {object}  response.AuditLog
+// @Failure      400  {object}  response.Error "Invalid cursor"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      403  {object}  response.Error "Forbidden"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /admin/audit-log [get]
+func (h *Handler) getAuditLog(c *gin.Context) {
+	page, err := parsePage(c, 50, 100)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get audit log - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	entries, next, err := h.adminService.GetAuditLog(c.Request.Context(), page)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get audit log - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conv.FromDomainToAuditLogResponse(entries, next))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
 	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/kust1q/Zapp/backend/internal/errs"
 	"github.com/sirupsen/logrus"
 )
@@ -74,6 +75,8 @@ func (h *Handler) signUp(c *gin.Context) {
 // @Success      200      {object}  response.Access
 // @Failure      400      {object}  response.Error "Invalid request body"
 // @Failure      401      {object}  response.Error "Invalid credentials"
+// @Failure      403      {object}  response.Error "Account is deactivated"
+// @Failure      429      {object}  response.Error "Too many failed sign in attempts"
 // @Failure      500      {object}  response.Error "Internal server error"
 // @Router       /auth/sign-in [post]
 func (h *Handler) signIn(c *gin.Context) {
@@ -83,14 +86,37 @@ func (h *Handler) signIn(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 		return
 	}
-	tokens, err := h.authService.SignIn(c.Request.Context(), conv.FromSignInRequestToDomain(&req))
+	lockout, err := h.rateLimitService.SignInLockout(c.Request.Context(), req.Email)
+	if err != nil {
+		logrus.WithError(err).Error("failed to check sign in lockout")
+	}
+	if lockout > 0 {
+		logrus.WithField("email", req.Email).Warn("sign in rejected - email is locked out")
+		abortTooManyRequests(c, lockout, "too many failed sign in attempts")
+		return
+	}
+	tokens, err := h.authService.SignIn(c.Request.Context(), conv.FromSignInRequestToDomain(&req), clientDevice(c))
 	if err != nil {
 		if errors.Is(err, errs.ErrInvalidCredentials) {
 			logrus.WithField("email", req.Email).Warn("sign in failed - invalid credentials")
+			if lockout, err := h.rateLimitService.RegisterSignInFailure(c.Request.Context(), req.Email); err != nil {
+				logrus.WithError(err).Error("failed to register sign in failure")
+			} else if lockout > 0 {
+				logrus.WithFields(logrus.Fields{
+					"email":   req.Email,
+					"lockout": lockout,
+				}).Warn("email locked out of sign in")
+			}
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "invalid credentials",
 			})
 			return
+		} else if errors.Is(err, errs.ErrUserInactive) {
+			logrus.WithField("email", req.Email).Warn("sign in failed - account is deactivated")
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "account is deactivated",
+			})
+			return
 		}
 		logrus.WithFields(logrus.Fields{
 			"email": req.Email,
@@ -101,6 +127,9 @@ func (h *Handler) signIn(c *gin.Context) {
 		})
 		return
 	}
+	if err := h.rateLimitService.ResetSignInFailures(c.Request.Context(), req.Email); err != nil {
+		logrus.WithError(err).Error("failed to reset sign in failures")
+	}
 
 	c.SetSameSite(http.SameSiteStrictMode)
 
@@ -125,7 +154,7 @@ func (h *Handler) signIn(c *gin.Context) {
 // @Produce      json
 // @Success      200  {object}  response.Access
 // @Failure      401  {object}  response.Error "Unauthorized or invalid token"
-// @Failure      403  {object}  response.Error "User not found"
+// @Failure      403  {object}  response.Error "User not found or deactivated"
 // @Failure      500  {object}  response.Error "Internal server error"
 // @Router       /auth/refresh [patch]
 func (h *Handler) refresh(c *gin.Context) {
@@ -136,17 +165,24 @@ func (h *Handler) refresh(c *gin.Context) {
 		return
 	}
 
-	tokens, err := h.authService.Refresh(c.Request.Context(), conv.FromRefreshRequestToDomain(refreshToken))
+	tokens, err := h.authService.Refresh(c.Request.Context(), conv.FromRefreshRequestToDomain(refreshToken), clientDevice(c))
 	if err != nil {
-		if errors.Is(err, errs.ErrTokenNotFound) || errors.Is(err, errs.ErrInvalidRefreshToken) {
+		if errors.Is(err, errs.ErrRefreshTokenReused) {
+			c.SetCookie(RefreshTokenCookieName, "", -1, "/", "", false, true)
+			logrus.Warn("token refresh failed - refresh token reused, session closed")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "unauthorized",
+			})
+			return
+		} else if errors.Is(err, errs.ErrTokenNotFound) || errors.Is(err, errs.ErrInvalidRefreshToken) {
 			c.SetCookie(RefreshTokenCookieName, "", -1, "/", "", false, true)
 			logrus.Warn("token refresh failed - invalid token, cookie removed")
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "unauthorized",
 			})
 			return
-		} else if errors.Is(err, errs.ErrUserNotFound) {
-			logrus.Warn("token refresh failed - user not found")
+		} else if errors.Is(err, errs.ErrUserNotFound) || errors.Is(err, errs.ErrUserInactive) {
+			logrus.WithError(err).Warn("token refresh failed - user not found or deactivated")
 			c.JSON(http.StatusForbidden, gin.H{
 				"error": "forbidden",
 			})
@@ -182,21 +218,28 @@ func (h *Handler) refresh(c *gin.Context) {
 // signOut removes refresh token and signs user out.
 //
 // @Summary      Sign out
-// @Description  Remove refresh token cookie and invalidate session.
+// @Description  Remove refresh token cookie and invalidate session. An access token sent in the Authorization header is revoked too.
 // @Tags         auth
 // @Produce      json
 // @Success      200  {object}  response.Message
 // @Failure      500  {object}  response.Error "Internal server error"
 // @Router       /auth/sign-out [delete]
 func (h *Handler) signOut(c *gin.Context) {
+	var access *entity.Claims
+	if token := bearerToken(c); token != "" {
+		if claims, err := h.authService.VerifyAccessToken(token); err == nil {
+			access = claims
+		}
+	}
+
 	refreshToken, err := c.Cookie(RefreshTokenCookieName)
-	if err != nil {
+	if err != nil && access == nil {
 		c.SetCookie(RefreshTokenCookieName, "", -1, "/", "", false, true)
 		c.JSON(http.StatusOK, gin.H{"message": "successfully signed out"})
 		return
 	}
 
-	if err := h.authService.SignOut(c.Request.Context(), conv.FromRefreshRequestToDomain(refreshToken)); err != nil {
+	if err := h.authService.SignOut(c.Request.Context(), conv.FromRefreshRequestToDomain(refreshToken), access); err != nil {
 		logrus.WithError(err).Error("failed to sign out - internal server error")
 		c.SetCookie(RefreshTokenCookieName, "", -1, "/", "", false, true) // Удалить cookie
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -207,7 +250,7 @@ func (h *Handler) signOut(c *gin.Context) {
 
 	c.SetCookie("refresh_token", "", -1, "/", "", false, true)
 
-	logrus.WithField("refresh", refreshToken[:10]+"...").Info("user signed out")
+	logrus.Info("user signed out")
 	c.JSON(http.StatusOK, gin.H{
 		"message": "successfully signed out",
 	})
@@ -263,12 +306,12 @@ func (h *Handler) updatePassword(c *gin.Context) {
 // forgotPassword sends password recovery instructions to user's email.
 //
 // @Summary      Forgot password
-// @Description  Generate recovery token and send password reset instructions to email.
+// @Description  Mail a password recovery link to the account. The response is the same whether or not the email is registered.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request  body      request.ForgotPassword  true  "Forgot password data"
-// @Success      200      {object}  response.Recovery
+// @Success      200      {object}  response.Message
 // @Failure      400      {object}  response.Error "Invalid request body"
 // @Failure      500      {object}  response.Error "Internal server error"
 // @Router       /auth/forgot-password [post]
@@ -280,8 +323,8 @@ func (h *Handler) forgotPassword(c *gin.Context) {
 		return
 	}
 
-	recovery, err := h.authService.ForgotPassword(c.Request.Context(), conv.FromForgotPasswordRequestToDomain(&req))
-	if err != nil {
+	err := h.authService.ForgotPassword(c.Request.Context(), conv.FromForgotPasswordRequestToDomain(&req))
+	if err != nil && !errors.Is(err, errs.ErrUserNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"email": req.Email,
 			"error": err,
@@ -292,20 +335,22 @@ func (h *Handler) forgotPassword(c *gin.Context) {
 		return
 	}
 
-	logrus.WithField("email", req.Email).Info("password reset requested	")
-	c.JSON(http.StatusOK, conv.FromDomainToRecoveryResponse(recovery))
+	logrus.WithField("email", req.Email).Info("password reset requested")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "if the email is registered, a recovery link has been sent",
+	})
 }
 
 // recoveryPassword resets password using recovery token.
 //
 // @Summary      Recovery password
-// @Description  Reset password using recovery token received via email.
+// @Description  Reset password using recovery token received via email. Signs out every session of the account.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request  body      request.RecoveryPassword  true  "Recovery password data"
 // @Success      200      {object}  response.Message
-// @Failure      400      {object}  response.Error "Invalid request body"
+// @Failure      400      {object}  response.Error "Invalid request body or expired token"
 // @Failure      500      {object}  response.Error "Internal server error"
 // @Router       /auth/recovery-password [patch]
 func (h *Handler) recoveryPassword(c *gin.Context) {
@@ -317,9 +362,16 @@ func (h *Handler) recoveryPassword(c *gin.Context) {
 	}
 
 	if err := h.authService.RecoveryPassword(c.Request.Context(), conv.FromRecoveryPasswordRequestToDomain(&req)); err != nil {
+		if errors.Is(err, errs.ErrInvalidRecoveryToken) || errors.Is(err, errs.ErrUserNotFound) {
+			logrus.WithField("recovery_token", req.RecoveryToken[:8]+"...").Warn("recovery password failed - invalid token")
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid or expired recovery token",
+			})
+			return
+		}
 		logrus.WithFields(logrus.Fields{
 			"error":          err,
-			"recovery_token": req.RecoveryToken[:10] + "...",
+			"recovery_token": req.RecoveryToken[:8] + "...",
 		}).Error("recovery password failed - internal server error")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "internal server error",
@@ -327,8 +379,94 @@ func (h *Handler) recoveryPassword(c *gin.Context) {
 		return
 	}
 
-	logrus.WithField("recovery_token", req.RecoveryToken[:10]+"...").Info("password recovery")
+	logrus.WithField("recovery_token", req.RecoveryToken[:8]+"...").Info("password recovery")
 	c.JSON(http.StatusOK, gin.H{
 		"message": "successfully recovery password",
 	})
 }
+
+// verifyEmail confirms the email address of an account.
+//
+// @Summary      Verify email
+// @Description  Confirm the account email using the token from the link mailed on sign-up.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      request.VerifyEmail  true  "Verification data"
+// @Success      200      {object}  response.Message
+// @Failure      400      {object}  response.Error "Invalid request body or expired token"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /auth/verify-email [post]
+func (h *Handler) verifyEmail(c *gin.Context) {
+	var req request.VerifyEmail
+	if err := c.BindJSON(&req); err != nil {
+		logrus.WithError(err).Error("failed to verify email - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.authService.VerifyEmail(c.Request.Context(), conv.FromVerifyEmailRequestToDomain(&req)); err != nil {
+		if errors.Is(err, errs.ErrInvalidVerificationToken) || errors.Is(err, errs.ErrUserNotFound) {
+			logrus.WithField("verification_token", req.VerificationToken[:8]+"...").Warn("verify email failed - invalid token")
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid or expired verification token",
+			})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"error":              err,
+			"verification_token": req.VerificationToken[:8] + "...",
+		}).Error("verify email failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "email verified",
+	})
+}
+
+// resendVerification mails a new verification link to the current user.
+//
+// @Summary      Resend verification email
+// @Description  Send another email verification link to the current user.
+// @Tags         auth
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {object}  response.Message
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      409  {object}  response.Error "Email already verified"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/resend-verification [post]
+func (h *Handler) resendVerification(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	if err := h.authService.ResendVerification(c.Request.Context(), userID.(int)); err != nil {
+		if errors.Is(err, errs.ErrEmailAlreadyVerified) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "email already verified",
+			})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("resend verification failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "verification email sent",
+	})
+}
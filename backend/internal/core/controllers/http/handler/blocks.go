@@ -0,0 +1,334 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// blockUser blocks another user for authenticated user and removes follows between them.
+//
+// @Summary      Block user
+// @Description  Block user by ID as current authenticated user.
+// @Tags         users
+// @Security     Bearer
+// @Produce      json
+// @Param        user_id  path      int  true  "User ID to block"
+// @Success      200      {object}  response.Message
+// @Failure      400      {object}  response.Error "Invalid user ID or target is yourself"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      404      {object}  response.Error "User not found"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /protected/users/{user_id}/block [post]
+func (h *Handler) blockUser(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	targetID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil || targetID == 0 {
+		logrus.WithError(err).Error("failed to block - invalid user id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	err = h.userService.BlockUser(c.Request.Context(), userID.(int), targetID)
+	if errors.Is(err, errs.ErrInvalidInput) {
+		logrus.WithField("user_id", userID.(int)).Warn("failed to block - target is yourself")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot block yourself"})
+		return
+	} else if errors.Is(err, errs.ErrUserNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":   userID.(int),
+			"target_id": targetID,
+		}).Warn("failed to block - user not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "user not found",
+		})
+		return
+	} else if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id":   userID.(int),
+			"target_id": targetID,
+			"error":     err,
+		}).Error("failed to block - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":   userID.(int),
+		"target_id": targetID,
+	}).Info("successfully block")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully block",
+	})
+}
+
+// unblockUser removes block of another user for authenticated user.
+//
+// @Summary      Unblock user
+// @Description  Unblock user by ID as current authenticated user.
+// @Tags         users
+// @Security     Bearer
+// @Produce      json
+// @Param        user_id  path      int  true  "User ID to unblock"
+// @Success      200      {object}  response.Message
+// @Failure      400      {object}  response.Error "Invalid user ID"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /protected/users/{user_id}/block [delete]
+func (h *Handler) unblockUser(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	targetID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil || targetID == 0 {
+		logrus.WithError(err).Error("failed to unblock - invalid user id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	err = h.userService.UnblockUser(c.Request.Context(), userID.(int), targetID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id":   userID.(int),
+			"target_id": targetID,
+			"error":     err,
+		}).Error("failed to unblock - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":   userID.(int),
+		"target_id": targetID,
+	}).Info("successfully unblock")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully unblock",
+	})
+}
+
+// muteUser hides another user from feed and notifications of authenticated user.
+//
+// @Summary      Mute user
+// @Description  Mute user by ID as current authenticated user.
+// @Tags         users
+// @Security     Bearer
+// @Produce      json
+// @Param        user_id  path      int  true  "User ID to mute"
+// @Success      200      {object}  response.Message
+// @Failure      400      {object}  response.Error "Invalid user ID or target is yourself"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      404      {object}  response.Error "User not found"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /protected/users/{user_id}/mute [post]
+func (h *Handler) muteUser(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	targetID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil || targetID == 0 {
+		logrus.WithError(err).Error("failed to mute - invalid user id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	err = h.userService.MuteUser(c.Request.Context(), userID.(int), targetID)
+	if errors.Is(err, errs.ErrInvalidInput) {
+		logrus.WithField("user_id", userID.(int)).Warn("failed to mute - target is yourself")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot mute yourself"})
+		return
+	} else if errors.Is(err, errs.ErrUserNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":   userID.(int),
+			"target_id": targetID,
+		}).Warn("failed to mute - user not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "user not found",
+		})
+		return
+	} else if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id":   userID.(int),
+			"target_id": targetID,
+			"error":     err,
+		}).Error("failed to mute - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":   userID.(int),
+		"target_id": targetID,
+	}).Info("successfully mute")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully mute",
+	})
+}
+
+// unmuteUser removes mute of another user for authenticated user.
+//
+// @Summary      Unmute user
+// @Description  Unmute user by ID as current authenticated user.
+// @Tags         users
+// @Security     Bearer
+// @Produce      json
+// @Param        user_id  path      int  true  "User ID to unmute"
+// @Success      200      {object}  response.Message
+// @Failure      400      {object}  response.Error "Invalid user ID"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /protected/users/{user_id}/mute [delete]
+func (h *Handler) unmuteUser(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	targetID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil || targetID == 0 {
+		logrus.WithError(err).Error("failed to unmute - invalid user id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	err = h.userService.UnmuteUser(c.Request.Context(), userID.(int), targetID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id":   userID.(int),
+			"target_id": targetID,
+			"error":     err,
+		}).Error("failed to unmute - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":   userID.(int),
+		"target_id": targetID,
+	}).Info("successfully unmute")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully unmute",
+	})
+}
+
+// getBlockedUsers returns users blocked by authenticated user.
+//
+// @Summary      List blocked users
+// @Description  List users blocked by current authenticated user, most recent first.
+// @Tags         users
+// @Security     Bearer
+// @Produce      json
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
+// @Success      200  {object}  response.SmallUserList
+// @Failure      400  {object}  response.Error "Invalid cursor"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/blocks [get]
+func (h *Handler) getBlockedUsers(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	page, err := parsePage(c, 20, 50)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get blocked users - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	users, next, err := h.userService.GetBlockedUsers(c.Request.Context(), userID.(int), page)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to get blocked users - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithField("user_id", userID.(int)).Info("blocked users got")
+	c.JSON(http.StatusOK, conv.FromDomainToSmallUserPageResponse(users, next))
+}
+
+// getMutedUsers returns users muted by authenticated user.
+//
+// @Summary      List muted users
+// @Description  List users muted by current authenticated user, most recent first.
+// @Tags         users
+// @Security     Bearer
+// @Produce      json
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
+// @Success      200  {object}  response.SmallUserList
+// @Failure      400  {object}  response.Error "Invalid cursor"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/mutes [get]
+func (h *Handler) getMutedUsers(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	page, err := parsePage(c, 20, 50)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get muted users - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	users, next, err := h.userService.GetMutedUsers(c.Request.Context(), userID.(int), page)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to get muted users - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithField("user_id", userID.(int)).Info("muted users got")
+	c.JSON(http.StatusOK, conv.FromDomainToSmallUserPageResponse(users, next))
+}
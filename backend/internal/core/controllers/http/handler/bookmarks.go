@@ -0,0 +1,177 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// bookmarkTweet saves tweet to bookmarks of authenticated user.
+//
+// @Summary      Bookmark tweet
+// @Description  Privately save tweet by ID for current authenticated user.
+// @Tags         bookmarks
+// @Security     Bearer
+// @Produce      json
+// @Param        tweet_id  path      int  true  "Tweet ID"
+// @Success      200       {object}  response.Message
+// @Failure      400       {object}  response.Error "Invalid tweet ID"
+// @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      404       {object}  response.Error "Tweet not found"
+// @Failure      500       {object}  response.Error "Internal server error"
+// @Router       /protected/tweets/{tweet_id}/bookmark [post]
+func (h *Handler) bookmarkTweet(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+	tweetID, err := strconv.Atoi(c.Param("tweet_id"))
+	if err != nil || tweetID == 0 {
+		logrus.WithError(err).Error("failed to bookmark tweet - invalid tweet id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tweet id"})
+		return
+	}
+
+	if err = h.tweetService.BookmarkTweet(c.Request.Context(), userID.(int), tweetID); err != nil && !errors.Is(err, errs.ErrTweetNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"tweet_id": tweetID,
+			"error":    err,
+		}).Error("bookmark tweet failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	} else if errors.Is(err, errs.ErrTweetNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"tweet_id": tweetID,
+			"error":    err,
+		}).Error("bookmark tweet failed - tweet not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tweet not found",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.(int),
+		"tweet_id": tweetID,
+	}).Info("tweet bookmarked")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully bookmark tweet",
+	})
+}
+
+// unbookmarkTweet removes tweet from bookmarks of authenticated user.
+//
+// @Summary      Remove bookmark
+// @Description  Remove tweet by ID from bookmarks of current authenticated user.
+// @Tags         bookmarks
+// @Security     Bearer
+// @Produce      json
+// @Param        tweet_id  path      int  true  "Tweet ID"
+// @Success      200       {object}  response.Message
+// @Failure      400       {object}  response.Error "Invalid tweet ID"
+// @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      404       {object}  response.Error "Bookmark not found"
+// @Failure      500       {object}  response.Error "Internal server error"
+// @Router       /protected/tweets/{tweet_id}/bookmark [delete]
+func (h *Handler) unbookmarkTweet(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+	tweetID, err := strconv.Atoi(c.Param("tweet_id"))
+	if err != nil || tweetID == 0 {
+		logrus.WithError(err).Error("failed to remove bookmark - invalid tweet id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tweet id"})
+		return
+	}
+
+	if err = h.tweetService.UnbookmarkTweet(c.Request.Context(), userID.(int), tweetID); err != nil && !errors.Is(err, errs.ErrBookmarkNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"tweet_id": tweetID,
+			"error":    err,
+		}).Error("remove bookmark failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	} else if errors.Is(err, errs.ErrBookmarkNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"tweet_id": tweetID,
+			"error":    err,
+		}).Error("remove bookmark failed - bookmark not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "bookmark not found",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.(int),
+		"tweet_id": tweetID,
+	}).Info("bookmark removed")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully remove bookmark",
+	})
+}
+
+// getBookmarks returns bookmarked tweets of authenticated user.
+//
+// @Summary      Get bookmarks
+// @Description  Get tweets bookmarked by current authenticated user, most recently saved first.
+// @Tags         bookmarks
+// @Security     Bearer
+// @Produce      json
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
+// @Success      200  {object}  response.TweetList
+// @Failure      400  {object}  response.Error "Invalid cursor"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/bookmarks [get]
+func (h *Handler) getBookmarks(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get bookmarks - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	tweets, next, err := h.tweetService.GetBookmarks(c.Request.Context(), userID.(int), page)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to get bookmarks - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithField("user_id", userID.(int)).Info("bookmarks got")
+	c.JSON(http.StatusOK, conv.FromDomainToTweetPageResponse(tweets, next))
+}
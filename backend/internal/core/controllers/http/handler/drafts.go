@@ -0,0 +1,572 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// createDraft saves a draft for authenticated user.
+//
+// @Summary      Create draft
+// @Description  Save a tweet draft with optional media file, reply or quote target and publish time. Supports JSON and multipart/form-data.
+// @Tags         drafts
+// @Security     Bearer
+// @Accept       json
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        content          formData  string  false  "Draft text content"
+// @Param        parent_tweet_id  formData  int     false  "Tweet the draft replies to"
+// @Param        quoted_tweet_id  formData  int     false  "Tweet the draft quotes"
+// @Param        scheduled_at     formData  string  false  "RFC 3339 time to publish the draft at"
+// @Param        file             formData  file    false  "Optional media file"
+// @Success      201      {object}  response.Draft
+// @Failure      400      {object}  response.Error "Invalid request body, empty draft or schedule in the past"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      429      {object}  response.Error "Too many requests"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /protected/drafts [post]
+func (h *Handler) createDraft(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req request.Draft
+	if err := c.ShouldBind(&req); err != nil {
+		logrus.WithError(err).Error("failed to create draft - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	file, closeFile, err := draftFile(c)
+	if err != nil {
+		logrus.WithError(err).Error("failed to create draft - open file error")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "open file error"})
+		return
+	}
+	defer closeFile()
+
+	draft, err := h.draftService.CreateDraft(c.Request.Context(), conv.FromDraftRequestToDomain(userID.(int), file, &req))
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrEmptyDraft):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "impossible create empty draft"})
+		case errors.Is(err, errs.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "draft cannot both reply and quote"})
+		case errors.Is(err, errs.ErrInvalidSchedule):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "scheduled time must be in the future"})
+		case errors.Is(err, errs.ErrInvalidmediaType):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid media type"})
+		case errors.Is(err, errs.ErrFileTooLarge):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file too large"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id": userID.(int),
+				"error":   err,
+			}).Error("create draft failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.(int),
+		"draft_id": draft.ID,
+	}).Info("draft created")
+	c.JSON(http.StatusCreated, conv.FromDomainToDraftResponse(draft))
+}
+
+// getDrafts returns drafts of authenticated user.
+//
+// @Summary      Get drafts
+// @Description  List drafts of authenticated user, newest first.
+// @Tags         drafts
+// @Security     Bearer
+// @Produce      json
+// @Param        limit   query  int     false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
+// @Success      200  {object}  response.DraftList
+// @Failure      400  {object}  response.Error "Invalid cursor"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/drafts [get]
+func (h *Handler) getDrafts(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	page, err := parsePage(c, 20, 100)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get drafts - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	drafts, next, err := h.draftService.GetDrafts(c.Request.Context(), userID.(int), page)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("get drafts failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conv.FromDomainToDraftPageResponse(drafts, next))
+}
+
+// getDraft returns a draft of authenticated user.
+//
+// @Summary      Get draft
+// @Description  Get a draft of authenticated user by ID.
+// @Tags         drafts
+// @Security     Bearer
+// @Produce      json
+// @Param        draft_id  path  int  true  "Draft ID"
+// @Success      200  {object}  response.Draft
+// @Failure      400  {object}  response.Error "Invalid draft ID"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Draft not found"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/drafts/{draft_id} [get]
+func (h *Handler) getDraft(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	draftID, err := strconv.Atoi(c.Param("draft_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to get draft - invalid draft id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid draft id"})
+		return
+	}
+
+	draft, err := h.draftService.GetDraft(c.Request.Context(), userID.(int), draftID)
+	if err != nil {
+		if errors.Is(err, errs.ErrDraftNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "draft not found"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"draft_id": draftID,
+			"error":    err,
+		}).Error("get draft failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conv.FromDomainToDraftResponse(draft))
+}
+
+// updateDraft updates a draft of authenticated user.
+//
+// @Summary      Update draft
+// @Description  Update draft content and optionally replace its media. Supports JSON and multipart/form-data.
+// @Tags         drafts
+// @Security     Bearer
+// @Accept       json
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        draft_id  path      int     true   "Draft ID"
+// @Param        content   formData  string  false  "Draft text content"
+// @Param        file      formData  file    false  "Optional media file replacing the current one"
+// @Success      200  {object}  response.Draft
+// @Failure      400  {object}  response.Error "Invalid request body or empty draft"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Draft not found"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/drafts/{draft_id} [patch]
+func (h *Handler) updateDraft(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	draftID, err := strconv.Atoi(c.Param("draft_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to update draft - invalid draft id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid draft id"})
+		return
+	}
+
+	var req request.UpdateDraft
+	if err := c.ShouldBind(&req); err != nil {
+		logrus.WithError(err).Error("failed to update draft - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	file, closeFile, err := draftFile(c)
+	if err != nil {
+		logrus.WithError(err).Error("failed to update draft - open file error")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "open file error"})
+		return
+	}
+	defer closeFile()
+
+	draft, err := h.draftService.UpdateDraft(c.Request.Context(), conv.FromDraftUpdateRequestToDomain(userID.(int), draftID, file, &req))
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrDraftNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "draft not found"})
+		case errors.Is(err, errs.ErrEmptyDraft):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "impossible save empty draft"})
+		case errors.Is(err, errs.ErrInvalidmediaType):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid media type"})
+		case errors.Is(err, errs.ErrFileTooLarge):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file too large"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id":  userID.(int),
+				"draft_id": draftID,
+				"error":    err,
+			}).Error("update draft failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.(int),
+		"draft_id": draftID,
+	}).Info("draft updated")
+	c.JSON(http.StatusOK, conv.FromDomainToDraftResponse(draft))
+}
+
+// deleteDraft deletes a draft of authenticated user.
+//
+// @Summary      Delete draft
+// @Description  Delete a draft together with its media.
+// @Tags         drafts
+// @Security     Bearer
+// @Param        draft_id  path  int  true  "Draft ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.Error "Invalid draft ID"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Draft not found"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/drafts/{draft_id} [delete]
+func (h *Handler) deleteDraft(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	draftID, err := strconv.Atoi(c.Param("draft_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to delete draft - invalid draft id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid draft id"})
+		return
+	}
+
+	if err := h.draftService.DeleteDraft(c.Request.Context(), userID.(int), draftID); err != nil {
+		if errors.Is(err, errs.ErrDraftNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "draft not found"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"draft_id": draftID,
+			"error":    err,
+		}).Error("delete draft failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.(int),
+		"draft_id": draftID,
+	}).Info("draft deleted")
+	c.Status(http.StatusNoContent)
+}
+
+// deleteDraftMedia removes media from a draft of authenticated user.
+//
+// @Summary      Delete draft media
+// @Description  Remove the media file attached to a draft. A draft without content must keep its media.
+// @Tags         drafts
+// @Security     Bearer
+// @Param        draft_id  path  int  true  "Draft ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.Error "Invalid draft ID or draft would be empty"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Draft or media not found"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/drafts/{draft_id}/media [delete]
+func (h *Handler) deleteDraftMedia(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	draftID, err := strconv.Atoi(c.Param("draft_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to delete draft media - invalid draft id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid draft id"})
+		return
+	}
+
+	if err := h.draftService.DeleteDraftMedia(c.Request.Context(), userID.(int), draftID); err != nil {
+		switch {
+		case errors.Is(err, errs.ErrDraftNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "draft not found"})
+		case errors.Is(err, errs.ErrTweetMediaNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "draft media not found"})
+		case errors.Is(err, errs.ErrEmptyDraft):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "draft without content must keep its media"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id":  userID.(int),
+				"draft_id": draftID,
+				"error":    err,
+			}).Error("delete draft media failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// scheduleDraft sets the publish time of a draft.
+//
+// @Summary      Schedule draft
+// @Description  Publish the draft automatically at the given time. Rescheduling replaces the previous time.
+// @Tags         drafts
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        draft_id  path  int                    true  "Draft ID"
+// @Param        request   body  request.ScheduleDraft  true  "Publish time"
+// @Success      200  {object}  response.Draft
+// @Failure      400  {object}  response.Error "Invalid request body or time in the past"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Draft not found"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/drafts/{draft_id}/schedule [put]
+func (h *Handler) scheduleDraft(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	draftID, err := strconv.Atoi(c.Param("draft_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to schedule draft - invalid draft id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid draft id"})
+		return
+	}
+
+	var req request.ScheduleDraft
+	if err := c.BindJSON(&req); err != nil {
+		logrus.WithError(err).Error("failed to schedule draft - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	draft, err := h.draftService.ScheduleDraft(c.Request.Context(), userID.(int), draftID, req.ScheduledAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrDraftNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "draft not found"})
+		case errors.Is(err, errs.ErrInvalidSchedule):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "scheduled time must be in the future"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id":  userID.(int),
+				"draft_id": draftID,
+				"error":    err,
+			}).Error("schedule draft failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":      userID.(int),
+		"draft_id":     draftID,
+		"scheduled_at": req.ScheduledAt,
+	}).Info("draft scheduled")
+	c.JSON(http.StatusOK, conv.FromDomainToDraftResponse(draft))
+}
+
+// unscheduleDraft cancels the scheduled publish of a draft.
+//
+// @Summary      Unschedule draft
+// @Description  Cancel the scheduled publish. The draft itself is kept.
+// @Tags         drafts
+// @Security     Bearer
+// @Produce      json
+// @Param        draft_id  path  int  true  "Draft ID"
+// @Success      200  {object}  response.Draft
+// @Failure      400  {object}  response.Error "Invalid draft ID"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Draft not found"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/drafts/{draft_id}/schedule [delete]
+func (h *Handler) unscheduleDraft(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	draftID, err := strconv.Atoi(c.Param("draft_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to unschedule draft - invalid draft id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid draft id"})
+		return
+	}
+
+	draft, err := h.draftService.UnscheduleDraft(c.Request.Context(), userID.(int), draftID)
+	if err != nil {
+		if errors.Is(err, errs.ErrDraftNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "draft not found"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"draft_id": draftID,
+			"error":    err,
+		}).Error("unschedule draft failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conv.FromDomainToDraftResponse(draft))
+}
+
+// publishDraft publishes a draft right away.
+//
+// @Summary      Publish draft
+// @Description  Publish the draft as a tweet now, whether it is scheduled or not. The draft is removed once published.
+// @Tags         drafts
+// @Security     Bearer
+// @Produce      json
+// @Param        draft_id  path  int  true  "Draft ID"
+// @Success      201  {object}  response.Tweet
+// @Failure      400  {object}  response.Error "Invalid draft ID"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      403  {object}  response.Error "Author of the replied tweet blocked you"
+// @Failure      404  {object}  response.Error "Draft or replied/quoted tweet not found"
+// @Failure      429  {object}  response.Error "Too many requests"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/drafts/{draft_id}/publish [post]
+func (h *Handler) publishDraft(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	draftID, err := strconv.Atoi(c.Param("draft_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to publish draft - invalid draft id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid draft id"})
+		return
+	}
+
+	tweet, err := h.draftService.PublishDraft(c.Request.Context(), userID.(int), draftID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrDraftNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "draft not found"})
+		case errors.Is(err, errs.ErrTweetNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "tweet not found"})
+		case errors.Is(err, errs.ErrBlocked):
+			c.JSON(http.StatusForbidden, gin.H{"error": "you are blocked by this user"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id":  userID.(int),
+				"draft_id": draftID,
+				"error":    err,
+			}).Error("publish draft failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.(int),
+		"draft_id": draftID,
+		"tweet_id": tweet.ID,
+	}).Info("draft published")
+	c.JSON(http.StatusCreated, conv.FromDomainToTweetResponse(tweet))
+}
+
+// draftFile opens the optional media file of a multipart draft request.
+// The returned close func is always safe to call.
+func draftFile(c *gin.Context) (*entity.File, func(), error) {
+	noop := func() {}
+	if !strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		return nil, noop, nil
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, noop, nil
+	}
+	if err != nil {
+		return nil, noop, err
+	}
+	opened, err := fileHeader.Open()
+	if err != nil {
+		return nil, noop, err
+	}
+	return &entity.File{File: opened, Header: fileHeader}, func() { opened.Close() }, nil
+}
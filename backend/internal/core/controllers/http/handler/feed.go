@@ -2,7 +2,6 @@ package http
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
@@ -16,6 +15,7 @@ import (
 // @Tags         feed
 // @Security     Bearer
 // @Produce      json
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
 // @Success      200  {object}  response.TweetList
 // @Failure      401  {object}  response.Error "Unauthorized"
 // @Failure      500  {object}  response.Error "Internal server error"
@@ -29,17 +29,14 @@ func (h *Handler) getFeed(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 30 {
-		limit = 30
-	}
-	if limit < 1 {
-		limit = 10
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get feed - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
 	}
 
-	feed, err := h.feedService.GetUserFeedByUserId(c.Request.Context(), userID.(int), limit, offset)
+	feed, next, err := h.feedService.GetUserFeedByUserId(c.Request.Context(), userID.(int), page)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"user_id": userID.(int),
@@ -51,7 +48,7 @@ func (h *Handler) getFeed(c *gin.Context) {
 		return
 	}
 	logrus.WithField("user_id", userID.(int)).Info("successfuly get feed")
-	c.JSON(http.StatusOK, conv.FromDomainToTweetListResponse(feed))
+	c.JSON(http.StatusOK, conv.FromDomainToTweetPageResponse(feed, next))
 }
 
 // getFeed returns feed for everybody.
@@ -60,21 +57,19 @@ func (h *Handler) getFeed(c *gin.Context) {
 // @Description  Get tweets feed for any user.
 // @Tags         feed
 // @Produce      json
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
 // @Success      200  {object}  response.TweetList
 // @Failure      500  {object}  response.Error "Internal server error"
 // @Router       /public [get]
 func (h *Handler) getDefaultFeed(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 30 {
-		limit = 30
-	}
-	if limit < 1 {
-		limit = 10
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get default feed - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
 	}
 
-	feed, err := h.feedService.GetDeafultFeed(c.Request.Context(), limit, offset)
+	feed, next, err := h.feedService.GetDeafultFeed(c.Request.Context(), page)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"error": err,
@@ -85,5 +80,5 @@ func (h *Handler) getDefaultFeed(c *gin.Context) {
 		return
 	}
 	logrus.Info("successfuly get feed")
-	c.JSON(http.StatusOK, conv.FromDomainToTweetListResponse(feed))
+	c.JSON(http.StatusOK, conv.FromDomainToTweetPageResponse(feed, next))
 }
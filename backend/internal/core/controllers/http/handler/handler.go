@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
@@ -50,6 +51,11 @@ type Handler struct {
 	mediaService        mediaService
 	webSocketService    webSocketService
 	notificationService notificationService
+	messageService      messageService
+	adminService        adminService
+	reportService       reportService
+	draftService        draftService
+	rateLimitService    rateLimitService
 }
 
 func NewHandler(
@@ -61,6 +67,11 @@ func NewHandler(
 	mediaService mediaService,
 	webSocketService webSocketService,
 	notificationService notificationService,
+	messageService messageService,
+	adminService adminService,
+	reportService reportService,
+	draftService draftService,
+	rateLimitService rateLimitService,
 ) *Handler {
 	return &Handler{
 		authService:         authService,
@@ -71,6 +82,11 @@ func NewHandler(
 		mediaService:        mediaService,
 		webSocketService:    webSocketService,
 		notificationService: notificationService,
+		messageService:      messageService,
+		adminService:        adminService,
+		reportService:       reportService,
+		draftService:        draftService,
+		rateLimitService:    rateLimitService,
 	}
 }
 
@@ -85,31 +101,35 @@ func (h *Handler) InitRouters() *gin.Engine {
 	config := cors.DefaultConfig()
 	config.AllowOrigins = []string{"http://localhost:3000"}
 	config.AllowWebSockets = true
-	config.AddAllowHeaders("Authorization", "Content-Type")
+	config.AddAllowHeaders("Authorization", "Content-Type", uploadOffsetHeader)
+	config.AddExposeHeaders("Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset")
 	config.AllowCredentials = true
 	router.Use(cors.New(config))
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 
-	api := router.Group("/api/v1")
+	api := router.Group("/api/v1", h.rateLimit("global"))
 
-	api.GET("/default", h.getDefaultFeed)
+	api.GET("/default", h.optionalAuthMiddleware, h.getDefaultFeed)
 
 	auth := api.Group("/auth")
 	{
-		auth.POST("/sign-up", h.signUp)
-		auth.POST("/sign-in", h.signIn)
-		auth.PATCH("/refresh", h.refresh)
+		auth.POST("/sign-up", h.rateLimit("sign_up"), h.signUp)
+		auth.POST("/sign-in", h.rateLimit("sign_in"), h.signIn)
+		auth.PATCH("/refresh", h.rateLimit("refresh"), h.refresh)
 		auth.DELETE("/sign-out", h.signOut)
-		auth.POST("/forgot-password", h.forgotPassword)
-		auth.PATCH("/recovery-password", h.recoveryPassword)
+		auth.POST("/forgot-password", h.rateLimit("forgot_password"), h.forgotPassword)
+		auth.PATCH("/recovery-password", h.rateLimit("forgot_password"), h.recoveryPassword)
+		auth.POST("/verify-email", h.rateLimit("verify_email"), h.verifyEmail)
 	}
 
-	public := api.Group("/public")
+	public := api.Group("/public", h.optionalAuthMiddleware)
 	{
 		public.GET("/", h.getDefaultFeed)
 		public.GET("/tweets/:tweet_id", h.getTweetById)
 		public.GET("/tweets/:tweet_id/replies", h.getReplies)
+		public.GET("/tweets/:tweet_id/thread", h.getThread)
+		public.GET("/tweets/:tweet_id/history", h.getTweetHistory)
 		public.GET("/tweets/:tweet_id/likes", h.getLikes)
 		public.GET("/tweets/media/:tweet_id", h.getTweetMedia)
 		public.GET("/users/:username/profile", h.getUserProfile)
@@ -117,7 +137,9 @@ func (h *Handler) InitRouters() *gin.Engine {
 		public.GET("/users/:username/followers", h.followers)
 		public.GET("/users/:username/following", h.following)
 		public.GET("/users/avatar/:user_id", h.getAvatar)
+		public.GET("/hashtags/:tag", h.getTweetsByHashtag)
 		public.GET("/search", h.search)
+		public.GET("/trends", h.getTrends)
 	}
 
 	protected := api.Group("/protected", h.authMiddleware)
@@ -125,33 +147,112 @@ func (h *Handler) InitRouters() *gin.Engine {
 		protected.GET("/ws", h.serveWs)
 
 		protected.PUT("/reset-password", h.updatePassword)
+		protected.POST("/resend-verification", h.rateLimit("verify_email"), h.resendVerification)
+
+		sessions := protected.Group("/sessions")
+		{
+			sessions.GET("", h.getSessions)
+			sessions.DELETE("", h.closeOtherSessions)
+			sessions.DELETE("/:session_id", h.closeSession)
+		}
 
 		tweets := protected.Group("/tweets")
 		{
-			tweets.POST("", h.createTweet)
-			tweets.PATCH("/:tweet_id", h.updateTweet)
+			tweets.POST("", h.rateLimit("tweet_write"), h.createTweet)
+			tweets.PATCH("/:tweet_id", h.rateLimit("tweet_write"), h.updateTweet)
 			tweets.DELETE("/:tweet_id", h.deleteTweet)
 
-			tweets.POST("/:tweet_id/like", h.likeTweet)
-			tweets.DELETE("/:tweet_id/like", h.unlikeTweet)
+			tweets.POST("/:tweet_id/like", h.rateLimit("engagement"), h.likeTweet)
+			tweets.DELETE("/:tweet_id/like", h.rateLimit("engagement"), h.unlikeTweet)
 
-			tweets.POST("/:tweet_id/reply", h.replyToTweet)
+			tweets.POST("/:tweet_id/reply", h.rateLimit("tweet_write"), h.replyToTweet)
+			tweets.POST("/:tweet_id/quote", h.rateLimit("tweet_write"), h.quoteTweet)
 
-			tweets.POST("/:tweet_id/retweet", h.retweet)
-			tweets.DELETE("/:tweet_id/retweet", h.deleteRetweet)
+			tweets.POST("/:tweet_id/retweet", h.rateLimit("engagement"), h.retweet)
+			tweets.DELETE("/:tweet_id/retweet", h.rateLimit("engagement"), h.deleteRetweet)
+
+			tweets.POST("/:tweet_id/bookmark", h.rateLimit("engagement"), h.bookmarkTweet)
+			tweets.DELETE("/:tweet_id/bookmark", h.rateLimit("engagement"), h.unbookmarkTweet)
 
 			tweets.DELETE("/:tweet_id/media", h.deleteTweetMedia)
+			tweets.DELETE("/:tweet_id/media/:media_id", h.deleteTweetMediaItem)
 		}
 
 		users := protected.Group("/users")
 		{
 			users.GET("/me", h.getMe)
 			users.PATCH("/me", h.updateMe)
+			users.PATCH("/me/privacy", h.updatePrivacy)
 			users.DELETE("/me", h.deleteMe)
-			users.POST("/:user_id/follow", h.followUser)
-			users.DELETE("/:user_id/follow", h.unfollowUser)
+			users.POST("/:user_id/follow", h.rateLimit("engagement"), h.followUser)
+			users.DELETE("/:user_id/follow", h.rateLimit("engagement"), h.unfollowUser)
+			users.POST("/:user_id/block", h.blockUser)
+			users.DELETE("/:user_id/block", h.unblockUser)
+			users.POST("/:user_id/mute", h.muteUser)
+			users.DELETE("/:user_id/mute", h.unmuteUser)
+		}
+
+		notifications := protected.Group("/notifications")
+		{
+			notifications.GET("", h.getNotifications)
+			notifications.GET("/unread-count", h.getUnreadNotificationsCount)
+			notifications.PATCH("/read-all", h.markAllNotificationsAsRead)
+			notifications.PATCH("/:notification_id/read", h.markNotificationAsRead)
+			notifications.DELETE("/:notification_id", h.deleteNotification)
+		}
+
+		conversations := protected.Group("/conversations")
+		{
+			conversations.POST("", h.rateLimit("message_write"), h.createConversation)
+			conversations.GET("", h.getConversations)
+			conversations.POST("/:conversation_id/messages", h.rateLimit("message_write"), h.sendMessage)
+			conversations.GET("/:conversation_id/messages", h.getMessages)
+			conversations.PATCH("/:conversation_id/read", h.markConversationAsRead)
+		}
+		uploads := protected.Group("/uploads")
+		{
+			uploads.POST("", h.rateLimit("upload"), h.createUpload)
+			uploads.GET("/:upload_id", h.getUpload)
+			uploads.PUT("/:upload_id", h.writeUploadChunk)
+			uploads.POST("/:upload_id/complete", h.completeUpload)
+			uploads.DELETE("/:upload_id", h.abortUpload)
+		}
+		drafts := protected.Group("/drafts")
+		{
+			drafts.POST("", h.rateLimit("tweet_write"), h.createDraft)
+			drafts.GET("", h.getDrafts)
+			drafts.GET("/:draft_id", h.getDraft)
+			drafts.PATCH("/:draft_id", h.updateDraft)
+			drafts.DELETE("/:draft_id", h.deleteDraft)
+			drafts.DELETE("/:draft_id/media", h.deleteDraftMedia)
+			drafts.PUT("/:draft_id/schedule", h.scheduleDraft)
+			drafts.DELETE("/:draft_id/schedule", h.unscheduleDraft)
+			drafts.POST("/:draft_id/publish", h.rateLimit("tweet_write"), h.publishDraft)
+		}
+		followRequests := protected.Group("/follow-requests")
+		{
+			followRequests.GET("", h.getFollowRequests)
+			followRequests.POST("/:user_id/approve", h.approveFollowRequest)
+			followRequests.DELETE("/:user_id", h.denyFollowRequest)
 		}
 		protected.GET("/feed", h.getFeed)
+		protected.GET("/bookmarks", h.getBookmarks)
+		protected.GET("/blocks", h.getBlockedUsers)
+		protected.GET("/mutes", h.getMutedUsers)
+		protected.POST("/reports", h.rateLimit("report"), h.createReport)
+	}
+
+	admin := api.Group("/admin", h.authMiddleware, h.requireRole(entity.RoleAdmin))
+	{
+		admin.GET("/users", h.getRecentSignups)
+		admin.POST("/users/:user_id/deactivate", h.deactivateUser)
+		admin.POST("/users/:user_id/reactivate", h.reactivateUser)
+		admin.DELETE("/tweets/:tweet_id", h.forceDeleteTweet)
+		admin.DELETE("/tweets/:tweet_id/media", h.forceDeleteTweetMedia)
+		admin.GET("/audit-log", h.getAuditLog)
+		admin.GET("/reports", h.getReports)
+		admin.POST("/reports/:report_id/claim", h.claimReport)
+		admin.POST("/reports/:report_id/resolve", h.resolveReport)
 	}
 
 	router.GET("/health", func(c *gin.Context) {
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// getTweetsByHashtag returns tweets tagged with given hashtag.
+//
+// @Summary      Get hashtag tweets
+// @Description  Get tweets containing given hashtag, newest first. The tag is matched case-insensitively, with or without leading '#'.
+// @Tags         hashtags
+// @Produce      json
+// @Param        tag     path      string  true   "Hashtag"
+// @Param        limit   query     int     false  "Limit (max 30)"  default(10)
+// @Param        cursor  query     string  false  "Cursor from next_cursor of the previous page"
+// @Success      200     {object}  response.TweetList
+// @Failure      400     {object}  response.Error "Invalid hashtag or cursor"
+// @Failure      500     {object}  response.Error "Internal server error"
+// @Router       /public/hashtags/{tag} [get]
+func (h *Handler) getTweetsByHashtag(c *gin.Context) {
+	tag := c.Param("tag")
+
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get hashtag tweets - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	tweets, next, err := h.tweetService.GetTweetsByHashtag(c.Request.Context(), tag, page)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidHashtag) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hashtag"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"tag":   tag,
+			"error": err,
+		}).Error("failed to get hashtag tweets - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithField("tag", tag).Info("hashtag tweets got")
+	c.JSON(http.StatusOK, conv.FromDomainToTweetPageResponse(tweets, next))
+}
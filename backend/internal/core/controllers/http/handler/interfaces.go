@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"time"
 
@@ -11,13 +12,20 @@ import (
 type (
 	authService interface {
 		SignUp(ctx context.Context, req *entity.User) (*entity.User, error)
-		SignIn(ctx context.Context, req *entity.Credential) (*entity.Tokens, error)
-		Refresh(ctx context.Context, req *entity.Refresh) (*entity.Tokens, error)
-		SignOut(ctx context.Context, req *entity.Refresh) error
-		VerifyAccessToken(tokenString string) (int, error)
+		SignIn(ctx context.Context, req *entity.Credential, device *entity.Device) (*entity.Tokens, error)
+		Refresh(ctx context.Context, req *entity.Refresh, device *entity.Device) (*entity.Tokens, error)
+		SignOut(ctx context.Context, req *entity.Refresh, access *entity.Claims) error
+		VerifyAccessToken(tokenString string) (*entity.Claims, error)
+		CheckUserActive(ctx context.Context, userID int) error
+		CheckAccessToken(ctx context.Context, claims *entity.Claims) error
+		GetSessions(ctx context.Context, userID int, currentSessionID string) ([]entity.Session, error)
+		CloseSession(ctx context.Context, userID int, sessionID string) error
+		CloseOtherSessions(ctx context.Context, userID int, currentSessionID string) error
 		UpdatePassword(ctx context.Context, req *entity.UpdatePassword) error
-		ForgotPassword(ctx context.Context, req *entity.ForgotPassword) (*entity.Recovery, error)
+		ForgotPassword(ctx context.Context, req *entity.ForgotPassword) error
 		RecoveryPassword(ctx context.Context, req *entity.RecoveryPassword) error
+		VerifyEmail(ctx context.Context, req *entity.VerifyEmail) error
+		ResendVerification(ctx context.Context, userID int) error
 		GetRefreshTTL() time.Duration
 	}
 
@@ -25,41 +33,72 @@ type (
 		CreateTweet(ctx context.Context, req *entity.Tweet) (*entity.Tweet, error)
 		GetTweetById(ctx context.Context, tweetID int) (*entity.Tweet, error)
 		UpdateTweet(ctx context.Context, req *entity.Tweet) (*entity.Tweet, error)
+		GetTweetHistory(ctx context.Context, tweetID int, page *entity.Page) ([]entity.TweetRevision, *entity.Cursor, error)
 		LikeTweet(ctx context.Context, userID, tweetID int) error
 		UnlikeTweet(ctx context.Context, userID, tweetID int) error
-		GetRepliesToTweet(ctx context.Context, tweetID, limit, offset int) ([]entity.Tweet, error)
+		GetRepliesToTweet(ctx context.Context, tweetID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetThread(ctx context.Context, tweetID, depth int, page *entity.Page) (*entity.Thread, *entity.Cursor, error)
 		CreateRetweet(ctx context.Context, userID, tweetID int) error
 		DeleteRetweet(ctx context.Context, userID, retweetID int) error
-		GetTweetsAndRetweetsByUsername(ctx context.Context, username string, limit, offset int) ([]entity.Tweet, error)
-		GetLikes(ctx context.Context, tweetID, limit, offset int) ([]entity.SmallUser, error)
+		GetTweetsAndRetweetsByUsername(ctx context.Context, username string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetLikes(ctx context.Context, tweetID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
 		DeleteTweet(ctx context.Context, userID, tweetID int) error
+		BookmarkTweet(ctx context.Context, userID, tweetID int) error
+		UnbookmarkTweet(ctx context.Context, userID, tweetID int) error
+		GetBookmarks(ctx context.Context, userID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetTweetsByHashtag(ctx context.Context, tag string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
 	}
 
 	userService interface {
 		Update(ctx context.Context, req *entity.UpdateBio) error
 		FollowToUser(ctx context.Context, followerID, followingID int) (*entity.Follow, error)
 		UnfollowUser(ctx context.Context, followerID, followingID int) error
-		GetFollowers(ctx context.Context, username string, limit, offset int) ([]entity.SmallUser, error)
-		GetFollowings(ctx context.Context, username string, limit, offset int) ([]entity.SmallUser, error)
-		GetUserProfile(ctx context.Context, username string, limit, offset int) (*entity.UserProfile, error)
-		GetMe(ctx context.Context, userID, limit, offset int) (*entity.UserProfile, error)
+		GetFollowers(ctx context.Context, username string, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
+		GetFollowings(ctx context.Context, username string, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
+		BlockUser(ctx context.Context, blockerID, blockedID int) error
+		UnblockUser(ctx context.Context, blockerID, blockedID int) error
+		MuteUser(ctx context.Context, muterID, mutedID int) error
+		UnmuteUser(ctx context.Context, muterID, mutedID int) error
+		GetBlockedUsers(ctx context.Context, userID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
+		GetMutedUsers(ctx context.Context, userID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
+		UpdatePrivacy(ctx context.Context, req *entity.UpdatePrivacy) error
+		GetFollowRequests(ctx context.Context, userID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
+		ApproveFollowRequest(ctx context.Context, userID, requesterID int) (*entity.Follow, error)
+		DenyFollowRequest(ctx context.Context, userID, requesterID int) error
+		GetUserProfile(ctx context.Context, username string, page *entity.Page) (*entity.UserProfile, error)
+		GetMe(ctx context.Context, userID int, page *entity.Page) (*entity.UserProfile, error)
 		DeleteUser(ctx context.Context, userID int) error
 	}
 
 	clientSearchService interface {
 		SearchTweets(ctx context.Context, query string) ([]entity.Tweet, error)
 		SearchUsers(ctx context.Context, query string) ([]entity.User, error)
+		GetTrends(ctx context.Context, limit int) ([]entity.Trend, error)
 	}
 
 	feedService interface {
-		GetUserFeedByUserId(ctx context.Context, userID, limit, offset int) ([]entity.Tweet, error)
-		GetDeafultFeed(ctx context.Context, limit, offset int) ([]entity.Tweet, error)
+		GetUserFeedByUserId(ctx context.Context, userID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetDeafultFeed(ctx context.Context, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
 	}
 
 	mediaService interface {
-		GetMediaDataByTweetID(ctx context.Context, tweetID int) (*entity.TweetMedia, error)
+		GetTweetMedia(ctx context.Context, tweetID int) ([]entity.TweetMedia, error)
 		GetAvatarDataByUserID(ctx context.Context, userID int) (*entity.Avatar, error)
 		DeleteTweetMedia(ctx context.Context, tweetID, userID int) error
+		DeleteTweetMediaItem(ctx context.Context, tweetID, mediaID, userID int) error
+		CreateUpload(ctx context.Context, userID int, filename string, size int64) (*entity.Upload, error)
+		GetUpload(ctx context.Context, userID, uploadID int) (*entity.Upload, error)
+		WriteUploadChunk(ctx context.Context, userID, uploadID int, offset int64, chunk io.Reader, size int64) (*entity.Upload, error)
+		CompleteUpload(ctx context.Context, userID, uploadID int) (*entity.Upload, error)
+		AbortUpload(ctx context.Context, userID, uploadID int) error
+	}
+
+	messageService interface {
+		CreateConversation(ctx context.Context, creatorID int, participantIDs []int) (*entity.Conversation, error)
+		GetConversations(ctx context.Context, userID int, page *entity.Page) ([]entity.Conversation, *entity.Cursor, error)
+		SendMessage(ctx context.Context, message *entity.DirectMessage) (*entity.DirectMessage, error)
+		GetMessages(ctx context.Context, userID, conversationID int, page *entity.Page) ([]entity.DirectMessage, *entity.Cursor, error)
+		MarkAsRead(ctx context.Context, userID, conversationID, messageID int) (*entity.ReadReceipt, error)
 	}
 
 	webSocketService interface {
@@ -70,6 +109,51 @@ type (
 		NotifyLike(ctx context.Context, actorID, tweetID int) error
 		NotifyRetweet(ctx context.Context, actorID, tweetID int) error
 		NotifyReply(ctx context.Context, actorID, tweetID int) error
+		NotifyQuote(ctx context.Context, actorID, tweetID int) error
+		NotifyMention(ctx context.Context, actorID, tweetID, mentionedID int) error
 		NotifyFollow(ctx context.Context, followerID, followingID int) error
+		NotifyFollowRequest(ctx context.Context, followerID, followingID int) error
+		NotifyFollowAccept(ctx context.Context, followingID, followerID int) error
+		NotifyReportResolved(ctx context.Context, report *entity.Report) error
+		GetNotifications(ctx context.Context, userID int, page *entity.Page) ([]entity.Notification, *entity.Cursor, error)
+		GetUnreadCount(ctx context.Context, userID int) (int, error)
+		MarkAsRead(ctx context.Context, userID int, notificationID string) error
+		MarkAllAsRead(ctx context.Context, userID int) error
+		DeleteNotification(ctx context.Context, userID int, notificationID string) error
+	}
+
+	adminService interface {
+		DeactivateUser(ctx context.Context, adminID, userID int) error
+		ReactivateUser(ctx context.Context, adminID, userID int) error
+		DeleteTweet(ctx context.Context, adminID, tweetID int) error
+		DeleteTweetMedia(ctx context.Context, adminID, tweetID int) error
+		GetRecentSignups(ctx context.Context, page *entity.Page) ([]entity.User, *entity.Cursor, error)
+		GetAuditLog(ctx context.Context, page *entity.Page) ([]entity.AuditEntry, *entity.Cursor, error)
+	}
+
+	reportService interface {
+		CreateReport(ctx context.Context, req *entity.Report) (*entity.Report, error)
+		GetReports(ctx context.Context, status entity.ReportStatus, page *entity.Page) ([]entity.Report, *entity.Cursor, error)
+		ClaimReport(ctx context.Context, adminID, reportID int) (*entity.Report, error)
+		ResolveReport(ctx context.Context, adminID, reportID int, resolution entity.ReportResolution) (*entity.Report, error)
+	}
+
+	draftService interface {
+		CreateDraft(ctx context.Context, draft *entity.Draft) (*entity.Draft, error)
+		GetDraft(ctx context.Context, userID, draftID int) (*entity.Draft, error)
+		GetDrafts(ctx context.Context, userID int, page *entity.Page) ([]entity.Draft, *entity.Cursor, error)
+		UpdateDraft(ctx context.Context, draft *entity.Draft) (*entity.Draft, error)
+		DeleteDraftMedia(ctx context.Context, userID, draftID int) error
+		DeleteDraft(ctx context.Context, userID, draftID int) error
+		ScheduleDraft(ctx context.Context, userID, draftID int, scheduledAt time.Time) (*entity.Draft, error)
+		UnscheduleDraft(ctx context.Context, userID, draftID int) (*entity.Draft, error)
+		PublishDraft(ctx context.Context, userID, draftID int) (*entity.Tweet, error)
+	}
+
+	rateLimitService interface {
+		Allow(ctx context.Context, policy, subject string) (*entity.RateLimit, error)
+		SignInLockout(ctx context.Context, email string) (time.Duration, error)
+		RegisterSignInFailure(ctx context.Context, email string) (time.Duration, error)
+		ResetSignInFailures(ctx context.Context, email string) error
 	}
 )
@@ -1,18 +1,22 @@
 package http
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
 	"github.com/sirupsen/logrus"
 )
 
-// deleteTweetMedia deletes media attached to a tweet of the authenticated user.
+// deleteTweetMedia deletes all media attached to a tweet of the authenticated user.
 //
 // @Summary      Delete tweet media
-// @Description  Delete media attached to the specified tweet. Only owner can delete.
+// @Description  Delete every attachment of the specified tweet. Only owner can delete.
 // @Tags         media
 // @Security     Bearer
 // @Param        tweet_id  path      int  true  "Tweet ID"
@@ -20,6 +24,7 @@ import (
 // @Success      200       {object}  response.Message
 // @Failure      400       {object}  response.Error "Invalid tweet ID"
 // @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      404       {object}  response.Error "Tweet media not found"
 // @Failure      500       {object}  response.Error "Internal server error"
 // @Router       /protected/tweets/{tweet_id}/media [delete]
 func (h *Handler) deleteTweetMedia(c *gin.Context) {
@@ -39,6 +44,10 @@ func (h *Handler) deleteTweetMedia(c *gin.Context) {
 	}
 
 	if err := h.mediaService.DeleteTweetMedia(c.Request.Context(), tweetID, userID.(int)); err != nil {
+		if errors.Is(err, errs.ErrTweetMediaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "tweet media not found"})
+			return
+		}
 		logrus.WithFields(logrus.Fields{
 			"user_id":  userID.(int),
 			"tweet_id": tweetID,
@@ -59,14 +68,78 @@ func (h *Handler) deleteTweetMedia(c *gin.Context) {
 	})
 }
 
+// deleteTweetMediaItem deletes a single attachment of a tweet of the authenticated user.
+//
+// @Summary      Delete tweet attachment
+// @Description  Delete one attachment of the specified tweet; the remaining ones keep their order. Only owner can delete.
+// @Tags         media
+// @Security     Bearer
+// @Param        tweet_id  path      int  true  "Tweet ID"
+// @Param        media_id  path      int  true  "Media ID"
+// @Produce      json
+// @Success      200       {object}  response.Message
+// @Failure      400       {object}  response.Error "Invalid tweet or media ID"
+// @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      404       {object}  response.Error "Tweet media not found"
+// @Failure      500       {object}  response.Error "Internal server error"
+// @Router       /protected/tweets/{tweet_id}/media/{media_id} [delete]
+func (h *Handler) deleteTweetMediaItem(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	tweetID, err := strconv.Atoi(c.Param("tweet_id"))
+	if err != nil || tweetID == 0 {
+		logrus.WithError(err).Error("failed to delete tweet attachment - invalid tweet id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tweetID"})
+		return
+	}
+	mediaID, err := strconv.Atoi(c.Param("media_id"))
+	if err != nil || mediaID == 0 {
+		logrus.WithError(err).Error("failed to delete tweet attachment - invalid media id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid media id"})
+		return
+	}
+
+	if err := h.mediaService.DeleteTweetMediaItem(c.Request.Context(), tweetID, mediaID, userID.(int)); err != nil {
+		if errors.Is(err, errs.ErrTweetMediaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "tweet media not found"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"tweet_id": tweetID,
+			"media_id": mediaID,
+			"error":    err,
+		}).Error("failed to delete tweet attachment - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":  userID.(int),
+		"tweet_id": tweetID,
+		"media_id": mediaID,
+	}).Info("successfully delete tweet attachment")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully delete tweet attachment",
+	})
+}
+
 // getTweetMedia returns media attached to a tweet.
 //
 // @Summary      Get tweet media
-// @Description  Get media data attached to the specified tweet.
+// @Description  Get the attachments of the specified tweet in order.
 // @Tags         media
 // @Param        tweet_id  path      int  true  "Tweet ID"
 // @Produce      json
-// @Success      200       {object}  response.TweetMedia
+// @Success      200       {object}  response.TweetMediaList
 // @Failure      400       {object}  response.Error "Invalid tweet ID"
 // @Failure      500       {object}  response.Error "In
 func (h *Handler) getTweetMedia(c *gin.Context) {
@@ -77,7 +150,7 @@ func (h *Handler) getTweetMedia(c *gin.Context) {
 		return
 	}
 
-	tweetMedia, err := h.mediaService.GetMediaDataByTweetID(c.Request.Context(), tweetID)
+	tweetMedia, err := h.mediaService.GetTweetMedia(c.Request.Context(), tweetID)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"tweet_id": tweetID,
@@ -86,11 +159,12 @@ func (h *Handler) getTweetMedia(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "internal server error",
 		})
+		return
 	}
 	logrus.WithFields(logrus.Fields{
 		"tweet_id": tweetID,
 	}).Info("successfully get tweet media")
-	c.JSON(http.StatusOK, conv.FromDomainToMediaResponse(tweetMedia))
+	c.JSON(http.StatusOK, conv.FromDomainToTweetMediaListResponse(tweetID, tweetMedia))
 }
 
 // getAvatar returns avatar image data for given user.
@@ -127,3 +201,71 @@ func (h *Handler) getAvatar(c *gin.Context) {
 	}).Info("successfully get avatar")
 	c.JSON(http.StatusOK, conv.FromDomainToAvatarResponse(avatar))
 }
+
+// tweetAttachments opens the media files of a multipart tweet request in form order,
+// followed by the uploads named by upload_id, and pairs each with the alt_text value at
+// the same index. The returned close func is always safe to call.
+func tweetAttachments(c *gin.Context) ([]entity.Attachment, func(), error) {
+	opened := make([]entity.Attachment, 0)
+	closeAll := func() {
+		for _, attachment := range opened {
+			attachment.File.File.Close()
+		}
+	}
+	form := c.Request.MultipartForm
+	if form == nil {
+		return opened, closeAll, nil
+	}
+
+	altTexts := form.Value["alt_text"]
+	for i, fileHeader := range form.File["file"] {
+		file, err := fileHeader.Open()
+		if err != nil {
+			closeAll()
+			return nil, func() {}, err
+		}
+		attachment := entity.Attachment{File: &entity.File{File: file, Header: fileHeader}}
+		if i < len(altTexts) {
+			attachment.AltText = altTexts[i]
+		}
+		opened = append(opened, attachment)
+	}
+
+	attachments := opened
+	for _, value := range form.Value["upload_id"] {
+		uploadID, err := strconv.Atoi(value)
+		if err != nil || uploadID <= 0 {
+			closeAll()
+			return nil, func() {}, fmt.Errorf("%w: invalid upload id %q", errs.ErrUploadNotFound, value)
+		}
+		attachment := entity.Attachment{UploadID: uploadID}
+		if i := len(attachments); i < len(altTexts) {
+			attachment.AltText = altTexts[i]
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, closeAll, nil
+}
+
+// abortInvalidAttachments answers with 400 when err rejects the attachments of a tweet.
+func abortInvalidAttachments(c *gin.Context, err error) bool {
+	var message string
+	switch {
+	case errors.Is(err, errs.ErrInvalidmediaType):
+		message = "invalid media type"
+	case errors.Is(err, errs.ErrTooManyAttachments):
+		message = "too many attachments: up to 4 images or 1 video, gif or audio"
+	case errors.Is(err, errs.ErrMixedAttachments):
+		message = "attachments must share one media type"
+	case errors.Is(err, errs.ErrAltTextTooLong):
+		message = "alt text must be at most 1000 characters"
+	case errors.Is(err, errs.ErrUploadNotFound):
+		message = "upload not found or expired"
+	case errors.Is(err, errs.ErrUploadIncomplete):
+		message = "upload is not completed"
+	default:
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": message})
+	return true
+}
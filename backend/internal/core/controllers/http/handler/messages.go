@@ -0,0 +1,359 @@
+package http
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// createConversation starts a direct or group conversation for authenticated user.
+//
+// @Summary      Create conversation
+// @Description  Start a conversation with the given users. Every participant must follow the current user and be followed back. Asking for an existing one-to-one conversation returns it.
+// @Tags         messages
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        input  body      request.Conversation  true  "Participants"
+// @Success      201    {object}  response.Conversation
+// @Failure      400    {object}  response.Error "Invalid request body or too many participants"
+// @Failure      401    {object}  response.Error "Unauthorized"
+// @Failure      403    {object}  response.Error "Participants do not follow each other or a participant is blocked"
+// @Failure      500    {object}  response.Error "Internal server error"
+// @Router       /protected/conversations [post]
+func (h *Handler) createConversation(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req request.Conversation
+	if err := c.BindJSON(&req); err != nil {
+		logrus.WithError(err).Error("failed to create conversation - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	conversation, err := h.messageService.CreateConversation(c.Request.Context(), userID.(int), req.ParticipantIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "no participants"})
+		case errors.Is(err, errs.ErrTooManyParticipants):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "too many participants"})
+		case errors.Is(err, errs.ErrNotMutualFollow):
+			c.JSON(http.StatusForbidden, gin.H{"error": "participants must follow each other"})
+		case errors.Is(err, errs.ErrBlocked):
+			c.JSON(http.StatusForbidden, gin.H{"error": "user is blocked"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id": userID.(int),
+				"error":   err,
+			}).Error("create conversation failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":         userID.(int),
+		"conversation_id": conversation.ID,
+	}).Info("conversation created")
+	c.JSON(http.StatusCreated, conv.FromDomainToConversationResponse(conversation))
+}
+
+// getConversations returns conversations of authenticated user.
+//
+// @Summary      Get conversations
+// @Description  Get conversations of the currently authenticated user, most recently active first, with the last message and unread count.
+// @Tags         messages
+// @Security     Bearer
+// @Produce      json
+// @Param        limit   query     int     false  "Limit (max 50)"  default(20)
+// @Param        cursor  query     string  false  "Cursor from next_cursor of the previous page"
+// @Success      200     {object}  response.ConversationList
+// @Failure      400     {object}  response.Error "Invalid cursor"
+// @Failure      401     {object}  response.Error "Unauthorized"
+// @Failure      500     {object}  response.Error "Internal server error"
+// @Router       /protected/conversations [get]
+func (h *Handler) getConversations(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	page, err := parsePage(c, 20, 50)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get conversations - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	conversations, next, err := h.messageService.GetConversations(c.Request.Context(), userID.(int), page)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to get conversations - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithField("user_id", userID.(int)).Info("conversations got")
+	c.JSON(http.StatusOK, conv.FromDomainToConversationPageResponse(conversations, next))
+}
+
+// sendMessage sends a message to a conversation of authenticated user.
+//
+// @Summary      Send message
+// @Description  Send a message with optional media file to a conversation. Supports JSON and multipart/form-data. Other participants receive it over the WebSocket as a "message" frame.
+// @Tags         messages
+// @Security     Bearer
+// @Accept       json
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        conversation_id  path      int     true   "Conversation ID"
+// @Param        content          formData  string  false  "Message text"
+// @Param        file             formData  file    false  "Optional media file"
+// @Success      201              {object}  response.DirectMessage
+// @Failure      400              {object}  response.Error "Invalid conversation ID, body or empty message"
+// @Failure      401              {object}  response.Error "Unauthorized"
+// @Failure      403              {object}  response.Error "A participant is blocked"
+// @Failure      404              {object}  response.Error "Conversation not found"
+// @Failure      500              {object}  response.Error "Internal server error"
+// @Router       /protected/conversations/{conversation_id}/messages [post]
+func (h *Handler) sendMessage(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+	conversationID, err := strconv.Atoi(c.Param("conversation_id"))
+	if err != nil || conversationID == 0 {
+		logrus.WithError(err).Error("failed to send message - invalid conversation id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conversation id"})
+		return
+	}
+
+	var req request.DirectMessage
+	var fileHeader *multipart.FileHeader
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		if err := c.Request.ParseMultipartForm(maxMemoryForm); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse form data"})
+			return
+		}
+		req.Content = c.PostForm("content")
+		fileHeader, err = c.FormFile("file")
+		if err != nil && err != http.ErrMissingFile {
+			logrus.WithFields(logrus.Fields{
+				"user_id": userID.(int),
+				"error":   err,
+			}).Error("send message failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+			return
+		}
+	} else if err := c.BindJSON(&req); err != nil {
+		logrus.WithError(err).Error("failed to send message - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	var file *entity.File
+	if fileHeader != nil {
+		openedFile, err := fileHeader.Open()
+		if err != nil {
+			logrus.WithError(err).Error("failed to send message - open file error")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "open file error"})
+			return
+		}
+		defer openedFile.Close()
+		file = &entity.File{
+			File:   openedFile,
+			Header: fileHeader,
+		}
+	}
+
+	message, err := h.messageService.SendMessage(c.Request.Context(), conv.FromDirectMessageRequestToDomain(userID.(int), conversationID, file, &req))
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrEmptyMessage):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "impossible send empty message"})
+		case errors.Is(err, errs.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "message is too long"})
+		case errors.Is(err, errs.ErrConversationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "conversation not found"})
+		case errors.Is(err, errs.ErrBlocked):
+			c.JSON(http.StatusForbidden, gin.H{"error": "user is blocked"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id":         userID.(int),
+				"conversation_id": conversationID,
+				"error":           err,
+			}).Error("send message failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":         userID.(int),
+		"conversation_id": conversationID,
+		"message_id":      message.ID,
+	}).Info("message sent")
+	c.JSON(http.StatusCreated, conv.FromDomainToDirectMessageResponse(message))
+}
+
+// getMessages returns history of a conversation of authenticated user.
+//
+// @Summary      Get messages
+// @Description  Get messages of a conversation, newest first.
+// @Tags         messages
+// @Security     Bearer
+// @Produce      json
+// @Param        conversation_id  path      int     true   "Conversation ID"
+// @Param        limit            query     int     false  "Limit (max 100)"  default(30)
+// @Param        cursor           query     string  false  "Cursor from next_cursor of the previous page"
+// @Success      200              {object}  response.DirectMessageList
+// @Failure      400              {object}  response.Error "Invalid conversation ID or cursor"
+// @Failure      401              {object}  response.Error "Unauthorized"
+// @Failure      404              {object}  response.Error "Conversation not found"
+// @Failure      500              {object}  response.Error "Internal server error"
+// @Router       /protected/conversations/{conversation_id}/messages [get]
+func (h *Handler) getMessages(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+	conversationID, err := strconv.Atoi(c.Param("conversation_id"))
+	if err != nil || conversationID == 0 {
+		logrus.WithError(err).Error("failed to get messages - invalid conversation id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conversation id"})
+		return
+	}
+
+	page, err := parsePage(c, 30, 100)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get messages - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	messages, next, err := h.messageService.GetMessages(c.Request.Context(), userID.(int), conversationID, page)
+	if err != nil {
+		if errors.Is(err, errs.ErrConversationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "conversation not found"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"user_id":         userID.(int),
+			"conversation_id": conversationID,
+			"error":           err,
+		}).Error("failed to get messages - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":         userID.(int),
+		"conversation_id": conversationID,
+	}).Info("messages got")
+	c.JSON(http.StatusOK, conv.FromDomainToDirectMessagePageResponse(messages, next))
+}
+
+// markConversationAsRead moves read receipt of authenticated user in a conversation.
+//
+// @Summary      Mark conversation as read
+// @Description  Mark messages of a conversation as read up to message_id, or up to the latest message when it is omitted. Other participants receive a "read_receipt" frame over the WebSocket.
+// @Tags         messages
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        conversation_id  path      int                  true   "Conversation ID"
+// @Param        input            body      request.ReadReceipt  false  "Last read message"
+// @Success      200              {object}  response.ReadReceipt
+// @Failure      400              {object}  response.Error "Invalid conversation ID or request body"
+// @Failure      401              {object}  response.Error "Unauthorized"
+// @Failure      404              {object}  response.Error "Conversation or message not found"
+// @Failure      500              {object}  response.Error "Internal server error"
+// @Router       /protected/conversations/{conversation_id}/read [patch]
+func (h *Handler) markConversationAsRead(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+	conversationID, err := strconv.Atoi(c.Param("conversation_id"))
+	if err != nil || conversationID == 0 {
+		logrus.WithError(err).Error("failed to mark conversation as read - invalid conversation id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conversation id"})
+		return
+	}
+
+	var req request.ReadReceipt
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&req); err != nil {
+			logrus.WithError(err).Error("failed to mark conversation as read - invalid request body")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	receipt, err := h.messageService.MarkAsRead(c.Request.Context(), userID.(int), conversationID, req.MessageID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrConversationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "conversation not found"})
+		case errors.Is(err, errs.ErrMessageNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "message not found"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id":         userID.(int),
+				"conversation_id": conversationID,
+				"error":           err,
+			}).Error("failed to mark conversation as read - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":         userID.(int),
+		"conversation_id": conversationID,
+		"message_id":      receipt.MessageID,
+	}).Info("conversation marked as read")
+	c.JSON(http.StatusOK, conv.FromDomainToReadReceiptResponse(receipt))
+}
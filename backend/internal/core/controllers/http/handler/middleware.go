@@ -1,18 +1,23 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
 	"github.com/sirupsen/logrus"
 )
 
 const (
 	authHeader = "Authorization"
 	userCtx    = "userID"
+	roleCtx    = "role"
+	sessionCtx = "sessionID"
 )
 
 func (h *Handler) authMiddleware(c *gin.Context) {
@@ -21,35 +26,104 @@ func (h *Handler) authMiddleware(c *gin.Context) {
 		return
 	}
 
-	var token string
-
-	header := c.GetHeader(authHeader)
-	if header != "" {
-		parts := strings.SplitN(header, " ", 2)
-		if len(parts) == 2 && parts[0] == "Bearer" {
-			token = parts[1]
+	token := bearerToken(c)
+	if token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: token missing"})
+		return
+	}
+	claims, err := h.authService.VerifyAccessToken(token)
+	if err != nil {
+		logrus.Error(err)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: invalid token"})
+		return
+	}
+	if err := h.authService.CheckUserActive(c.Request.Context(), claims.UserID); err != nil {
+		if errors.Is(err, errs.ErrUserInactive) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden: account is deactivated"})
+			return
 		}
+		logrus.WithError(err).WithField("user_id", claims.UserID).Warn("rejecting token of unknown user")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: invalid token"})
+		return
+	}
+	if err := h.authService.CheckAccessToken(c.Request.Context(), claims); err != nil {
+		if !errors.Is(err, errs.ErrSessionNotFound) && !errors.Is(err, errs.ErrAccessRevoked) {
+			logrus.WithError(err).WithField("user_id", claims.UserID).Error("failed to check access token")
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: token revoked"})
+		return
 	}
 
-	if token == "" {
-		token = c.Query("token")
+	setViewer(c, claims.UserID)
+	c.Set(roleCtx, claims.Role)
+	c.Set(sessionCtx, claims.SessionID)
+	c.Next()
+}
+
+// requireRole lets the request through only when the access token carries the given role.
+// It must run after authMiddleware.
+func (h *Handler) requireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(roleCtx) != role {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden: insufficient role"})
+			return
+		}
+		c.Next()
 	}
+}
 
+// optionalAuthMiddleware identifies the caller on public routes when a valid token is sent,
+// so responses can include viewer-specific state. Anonymous requests pass through untouched.
+func (h *Handler) optionalAuthMiddleware(c *gin.Context) {
+	token := bearerToken(c)
 	if token == "" {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: token missing"})
+		c.Next()
 		return
 	}
-	userID, err := h.authService.VerifyAccessToken(token)
+	claims, err := h.authService.VerifyAccessToken(token)
 	if err != nil {
-		logrus.Error(err)
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: invalid token"})
+		logrus.WithError(err).Debug("ignoring invalid token on public route")
+		c.Next()
+		return
+	}
+	if err := h.authService.CheckUserActive(c.Request.Context(), claims.UserID); err != nil {
+		logrus.WithError(err).Debug("ignoring token of inactive user on public route")
+		c.Next()
+		return
+	}
+	if err := h.authService.CheckAccessToken(c.Request.Context(), claims); err != nil {
+		logrus.WithError(err).Debug("ignoring revoked token on public route")
+		c.Next()
 		return
 	}
 
-	c.Set(userCtx, userID)
+	setViewer(c, claims.UserID)
 	c.Next()
 }
 
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader(authHeader)
+	if header != "" {
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			return parts[1]
+		}
+	}
+	return c.Query("token")
+}
+
+func clientDevice(c *gin.Context) *entity.Device {
+	return &entity.Device{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
+
+func setViewer(c *gin.Context, userID int) {
+	c.Set(userCtx, userID)
+	c.Request = c.Request.WithContext(entity.WithViewer(c.Request.Context(), userID))
+}
+
 func (h *Handler) metricsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -0,0 +1,246 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// getNotifications returns notifications of authenticated user.
+//
+// @Summary      Get notifications
+// @Description  Get notifications of the currently authenticated user, newest first.
+// @Tags         notifications
+// @Security     Bearer
+// @Produce      json
+// @Param        limit   query     int     false  "Limit (max 50)"  default(20)
+// @Param        cursor  query     string  false  "Cursor from next_cursor of the previous page"
+// @Success      200     {object}  response.NotificationList
+// @Failure      400     {object}  response.Error "Invalid cursor"
+// @Failure      401     {object}  response.Error "Unauthorized"
+// @Failure      500     {object}  response.Error "Internal server error"
+// @Router       /protected/notifications [get]
+func (h *Handler) getNotifications(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	page, err := parsePage(c, 20, 50)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get notifications - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	notifications, next, err := h.notificationService.GetNotifications(c.Request.Context(), userID.(int), page)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to get notifications - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+	logrus.WithField("user_id", userID.(int)).Info("successfully get notifications")
+	c.JSON(http.StatusOK, conv.FromDomainToNotificationPageResponse(notifications, next))
+}
+
+// getUnreadNotificationsCount returns number of unread notifications of authenticated user.
+//
+// @Summary      Get unread notifications count
+// @Description  Get number of unread notifications of the currently authenticated user.
+// @Tags         notifications
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {object}  response.UnreadCount
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/notifications/unread-count [get]
+func (h *Handler) getUnreadNotificationsCount(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	count, err := h.notificationService.GetUnreadCount(c.Request.Context(), userID.(int))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to get unread notifications count - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"count": count,
+	})
+}
+
+// markNotificationAsRead marks notification of authenticated user as read.
+//
+// @Summary      Mark notification as read
+// @Description  Mark notification by ID as read for current authenticated user.
+// @Tags         notifications
+// @Security     Bearer
+// @Produce      json
+// @Param        notification_id  path      string  true  "Notification ID"
+// @Success      200              {object}  response.Message
+// @Failure      400              {object}  response.Error "Invalid notification ID"
+// @Failure      401              {object}  response.Error "Unauthorized"
+// @Failure      404              {object}  response.Error "Notification not found"
+// @Failure      500              {object}  response.Error "Internal server error"
+// @Router       /protected/notifications/{notification_id}/read [patch]
+func (h *Handler) markNotificationAsRead(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+	notificationID := c.Param("notification_id")
+	if err := uuid.Validate(notificationID); err != nil {
+		logrus.WithError(err).Error("failed to mark notification as read - invalid notification id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification id"})
+		return
+	}
+
+	if err := h.notificationService.MarkAsRead(c.Request.Context(), userID.(int), notificationID); err != nil && !errors.Is(err, errs.ErrNotificationNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":         userID.(int),
+			"notification_id": notificationID,
+			"error":           err,
+		}).Error("mark notification as read failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	} else if errors.Is(err, errs.ErrNotificationNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":         userID.(int),
+			"notification_id": notificationID,
+			"error":           err,
+		}).Error("mark notification as read failed - notification not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "notification not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully mark notification as read",
+	})
+}
+
+// markAllNotificationsAsRead marks all notifications of authenticated user as read.
+//
+// @Summary      Mark all notifications as read
+// @Description  Mark every unread notification of the currently authenticated user as read.
+// @Tags         notifications
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {object}  response.Message
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/notifications/read-all [patch]
+func (h *Handler) markAllNotificationsAsRead(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	if err := h.notificationService.MarkAllAsRead(c.Request.Context(), userID.(int)); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("mark all notifications as read failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithField("user_id", userID.(int)).Info("all notifications marked as read")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully mark all notifications as read",
+	})
+}
+
+// deleteNotification deletes notification of authenticated user.
+//
+// @Summary      Delete notification
+// @Description  Delete notification by ID for current authenticated user.
+// @Tags         notifications
+// @Security     Bearer
+// @Produce      json
+// @Param        notification_id  path      string  true  "Notification ID"
+// @Success      200              {object}  response.Message
+// @Failure      400              {object}  response.Error "Invalid notification ID"
+// @Failure      401              {object}  response.Error "Unauthorized"
+// @Failure      404              {object}  response.Error "Notification not found"
+// @Failure      500              {object}  response.Error "Internal server error"
+// @Router       /protected/notifications/{notification_id} [delete]
+func (h *Handler) deleteNotification(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+	notificationID := c.Param("notification_id")
+	if err := uuid.Validate(notificationID); err != nil {
+		logrus.WithError(err).Error("failed to delete notification - invalid notification id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification id"})
+		return
+	}
+
+	if err := h.notificationService.DeleteNotification(c.Request.Context(), userID.(int), notificationID); err != nil && !errors.Is(err, errs.ErrNotificationNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":         userID.(int),
+			"notification_id": notificationID,
+			"error":           err,
+		}).Error("notification delete failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	} else if errors.Is(err, errs.ErrNotificationNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":         userID.(int),
+			"notification_id": notificationID,
+			"error":           err,
+		}).Error("notification delete failed - notification not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "notification not found",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":         userID.(int),
+		"notification_id": notificationID,
+	}).Info("successfully delete notification")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully delete notification",
+	})
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// parsePage reads limit, offset and cursor query parameters. A cursor takes
+// precedence over offset, which is still accepted for clients that have not
+// switched to next_cursor yet.
+func parsePage(c *gin.Context, defaultLimit, maxLimit int) (*entity.Page, error) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLimit)))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	after, err := entity.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Page{
+		Limit:  limit,
+		Offset: offset,
+		After:  after,
+	}, nil
+}
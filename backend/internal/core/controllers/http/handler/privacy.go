@@ -0,0 +1,242 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// updatePrivacy makes account of authenticated user private or public.
+//
+// @Summary      Update current user privacy
+// @Description  Make account of the currently authenticated user private or public. Making it public approves all pending follow requests.
+// @Tags         users
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        request  body      request.UpdatePrivacy  true  "Privacy setting"
+// @Success      200      {object}  response.Message
+// @Failure      400      {object}  response.Error "Invalid request body"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      404      {object}  response.Error "User not found"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /protected/users/me/privacy [patch]
+func (h *Handler) updatePrivacy(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+	var req request.UpdatePrivacy
+	if err := c.BindJSON(&req); err != nil {
+		logrus.WithError(err).Error("failed to update privacy - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.userService.UpdatePrivacy(c.Request.Context(), conv.FromUpdatePrivacyRequestToDomain(userID.(int), &req)); err != nil && !errors.Is(err, errs.ErrUserNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to update privacy - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	} else if errors.Is(err, errs.ErrUserNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to update privacy - user not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "user not found",
+		})
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"user_id":    userID.(int),
+		"is_private": *req.IsPrivate,
+	}).Info("successfully update privacy")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully update privacy",
+	})
+}
+
+// getFollowRequests returns users waiting for authenticated user to approve their follow.
+//
+// @Summary      List follow requests
+// @Description  List pending follow requests to current authenticated user, most recent first.
+// @Tags         users
+// @Security     Bearer
+// @Produce      json
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
+// @Success      200  {object}  response.SmallUserList
+// @Failure      400  {object}  response.Error "Invalid cursor"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/follow-requests [get]
+func (h *Handler) getFollowRequests(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	page, err := parsePage(c, 20, 50)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get follow requests - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	users, next, err := h.userService.GetFollowRequests(c.Request.Context(), userID.(int), page)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to get follow requests - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithField("user_id", userID.(int)).Info("follow requests got")
+	c.JSON(http.StatusOK, conv.FromDomainToSmallUserPageResponse(users, next))
+}
+
+// approveFollowRequest lets requesting user follow authenticated user.
+//
+// @Summary      Approve follow request
+// @Description  Approve pending follow request of user by ID to current authenticated user.
+// @Tags         users
+// @Security     Bearer
+// @Produce      json
+// @Param        user_id  path      int  true  "Requesting user ID"
+// @Success      200      {object}  response.Follow
+// @Failure      400      {object}  response.Error "Invalid user ID"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      404      {object}  response.Error "Follow request not found"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /protected/follow-requests/{user_id}/approve [post]
+func (h *Handler) approveFollowRequest(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	requesterID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil || requesterID == 0 {
+		logrus.WithError(err).Error("failed to approve follow request - invalid user id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	follow, err := h.userService.ApproveFollowRequest(c.Request.Context(), userID.(int), requesterID)
+	if err != nil && !errors.Is(err, errs.ErrFollowRequestNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":      userID.(int),
+			"requester_id": requesterID,
+			"error":        err,
+		}).Error("failed to approve follow request - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	} else if errors.Is(err, errs.ErrFollowRequestNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":      userID.(int),
+			"requester_id": requesterID,
+		}).Warn("failed to approve follow request - not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "follow request not found",
+		})
+		return
+	}
+
+	go func() {
+		if err := h.notificationService.NotifyFollowAccept(context.Background(), userID.(int), requesterID); err != nil {
+			logrus.WithError(err).Warn("failed to notify follow accept")
+		}
+	}()
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":      userID.(int),
+		"requester_id": requesterID,
+	}).Info("follow request approved")
+	c.JSON(http.StatusOK, conv.FromDomainToFollow(follow))
+}
+
+// denyFollowRequest rejects follow request to authenticated user.
+//
+// @Summary      Deny follow request
+// @Description  Deny pending follow request of user by ID to current authenticated user.
+// @Tags         users
+// @Security     Bearer
+// @Produce      json
+// @Param        user_id  path      int  true  "Requesting user ID"
+// @Success      200      {object}  response.Message
+// @Failure      400      {object}  response.Error "Invalid user ID"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      404      {object}  response.Error "Follow request not found"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /protected/follow-requests/{user_id} [delete]
+func (h *Handler) denyFollowRequest(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	requesterID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil || requesterID == 0 {
+		logrus.WithError(err).Error("failed to deny follow request - invalid user id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	if err := h.userService.DenyFollowRequest(c.Request.Context(), userID.(int), requesterID); err != nil && !errors.Is(err, errs.ErrFollowRequestNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":      userID.(int),
+			"requester_id": requesterID,
+			"error":        err,
+		}).Error("failed to deny follow request - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	} else if errors.Is(err, errs.ErrFollowRequestNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":      userID.(int),
+			"requester_id": requesterID,
+		}).Warn("failed to deny follow request - not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "follow request not found",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":      userID.(int),
+		"requester_id": requesterID,
+	}).Info("follow request denied")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "successfully deny follow request",
+	})
+}
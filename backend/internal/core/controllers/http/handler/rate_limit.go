@@ -0,0 +1,59 @@
+package http
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// rateLimit throttles requests by the named policy from config. Authenticated callers
+// are counted per user, anonymous ones per IP, so it must run after authMiddleware to
+// limit by user. Requests pass when the limiter is unavailable.
+func (h *Handler) rateLimit(policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == "OPTIONS" {
+			c.Next()
+			return
+		}
+
+		limit, err := h.rateLimitService.Allow(c.Request.Context(), policy, rateLimitSubject(c))
+		if err != nil {
+			logrus.WithError(err).WithField("policy", policy).Error("rate limiter unavailable")
+			c.Next()
+			return
+		}
+		if limit == nil {
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(limit.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(limit.ResetAt.Unix(), 10))
+		if !limit.Allowed {
+			logrus.WithFields(logrus.Fields{
+				"policy": policy,
+				"ip":     c.ClientIP(),
+			}).Warn("rate limit exceeded")
+			abortTooManyRequests(c, limit.RetryAfter, "too many requests")
+			return
+		}
+		c.Next()
+	}
+}
+
+func rateLimitSubject(c *gin.Context) string {
+	if userID, ok := c.Get(userCtx); ok {
+		return "user:" + strconv.Itoa(userID.(int))
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func abortTooManyRequests(c *gin.Context, retryAfter time.Duration, message string) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": message})
+}
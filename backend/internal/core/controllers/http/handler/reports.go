@@ -0,0 +1,253 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// createReport reports a tweet or an account for moderation.
+//
+// @Summary      Report tweet or user
+// @Description  Report a tweet or an account with a reason category. Reporting the same target again while the first report is unresolved returns that report.
+// @Tags         reports
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        request  body      request.Report  true  "Report data"
+// @Success      201      {object}  response.Report
+// @Failure      400      {object}  response.Error "Invalid request body or target is yourself"
+// @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      404      {object}  response.Error "Tweet or user not found"
+// @Failure      500      {object}  response.Error "Internal server error"
+// @Router       /protected/reports [post]
+func (h *Handler) createReport(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req request.Report
+	if err := c.BindJSON(&req); err != nil {
+		logrus.WithError(err).Error("failed to create report - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	report, err := h.reportService.CreateReport(c.Request.Context(), conv.FromReportRequestToDomain(userID.(int), &req))
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot report yourself"})
+		case errors.Is(err, errs.ErrTweetNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "tweet not found"})
+		case errors.Is(err, errs.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id": userID.(int),
+				"error":   err,
+			}).Error("create report failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":   userID.(int),
+		"report_id": report.ID,
+	}).Info("report created")
+	c.JSON(http.StatusCreated, conv.FromDomainToReportResponse(report))
+}
+
+// getReports returns the moderation queue.
+//
+// @Summary      Get moderation queue
+// @Description  List reports in the given status, oldest first. Admin only.
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        status  query  string  false  "Report status: open (default), claimed or resolved"
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
+// @Success      200  {object}  response.ReportList
+// @Failure      400  {object}  response.Error "Invalid status or cursor"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      403  {object}  response.Error "Forbidden"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /admin/reports [get]
+func (h *Handler) getReports(c *gin.Context) {
+	status := entity.ReportStatus(c.DefaultQuery("status", string(entity.ReportOpen)))
+	if status != entity.ReportOpen && status != entity.ReportClaimed && status != entity.ReportResolved {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+
+	page, err := parsePage(c, 20, 100)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get reports - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	reports, next, err := h.reportService.GetReports(c.Request.Context(), status, page)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get reports - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conv.FromDomainToReportPageResponse(reports, next))
+}
+
+// claimReport assigns a report to the authenticated admin.
+//
+// @Summary      Claim report
+// @Description  Take an open report off the queue. Admin only.
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        report_id  path      int  true  "Report ID"
+// @Success      200        {object}  response.Report
+// @Failure      400        {object}  response.Error "Invalid report ID"
+// @Failure      401        {object}  response.Error "Unauthorized"
+// @Failure      403        {object}  response.Error "Forbidden"
+// @Failure      404        {object}  response.Error "Report not found"
+// @Failure      409        {object}  response.Error "Report is claimed by another moderator or already resolved"
+// @Failure      500        {object}  response.Error "Internal server error"
+// @Router       /admin/reports/{report_id}/claim [post]
+func (h *Handler) claimReport(c *gin.Context) {
+	adminID, ok := c.Get(userCtx)
+	if !ok || adminID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	reportID, err := strconv.Atoi(c.Param("report_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to claim report - invalid report id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report id"})
+		return
+	}
+
+	report, err := h.reportService.ClaimReport(c.Request.Context(), adminID.(int), reportID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrReportNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+		case errors.Is(err, errs.ErrReportNotClaimable):
+			c.JSON(http.StatusConflict, gin.H{"error": "report is claimed by another moderator or already resolved"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"admin_id":  adminID.(int),
+				"report_id": reportID,
+				"error":     err,
+			}).Error("claim report failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"admin_id":  adminID.(int),
+		"report_id": reportID,
+	}).Info("report claimed")
+	c.JSON(http.StatusOK, conv.FromDomainToReportResponse(report))
+}
+
+// resolveReport closes a report claimed by the authenticated admin and notifies the reporter.
+//
+// @Summary      Resolve report
+// @Description  Dismiss the report, delete the reported tweet or suspend the reported account. The report must be claimed by the caller. Admin only.
+// @Tags         admin
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        report_id  path      int                    true  "Report ID"
+// @Param        request    body      request.ResolveReport  true  "Resolution"
+// @Success      200        {object}  response.Report
+// @Failure      400        {object}  response.Error "Invalid report ID, body or resolution for this target"
+// @Failure      401        {object}  response.Error "Unauthorized"
+// @Failure      403        {object}  response.Error "Forbidden"
+// @Failure      404        {object}  response.Error "Report not found"
+// @Failure      409        {object}  response.Error "Report is not claimed by you"
+// @Failure      500        {object}  response.Error "Internal server error"
+// @Router       /admin/reports/{report_id}/resolve [post]
+func (h *Handler) resolveReport(c *gin.Context) {
+	adminID, ok := c.Get(userCtx)
+	if !ok || adminID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	reportID, err := strconv.Atoi(c.Param("report_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to resolve report - invalid report id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report id"})
+		return
+	}
+
+	var req request.ResolveReport
+	if err := c.BindJSON(&req); err != nil {
+		logrus.WithError(err).Error("failed to resolve report - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	report, err := h.reportService.ResolveReport(c.Request.Context(), adminID.(int), reportID, entity.ReportResolution(req.Resolution))
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrReportNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+		case errors.Is(err, errs.ErrReportNotClaimed):
+			c.JSON(http.StatusConflict, gin.H{"error": "report is not claimed by you"})
+		case errors.Is(err, errs.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "resolution does not apply to this report"})
+		case errors.Is(err, errs.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "reported user not found"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"admin_id":  adminID.(int),
+				"report_id": reportID,
+				"error":     err,
+			}).Error("resolve report failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	go func() {
+		if err := h.notificationService.NotifyReportResolved(context.Background(), report); err != nil {
+			logrus.WithError(err).Warn("failed to notify report resolution")
+		}
+	}()
+
+	logrus.WithFields(logrus.Fields{
+		"admin_id":   adminID.(int),
+		"report_id":  reportID,
+		"resolution": req.Resolution,
+	}).Info("report resolved")
+	c.JSON(http.StatusOK, conv.FromDomainToReportResponse(report))
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// getSessions returns active sessions of authenticated user.
+//
+// @Summary      Get sessions
+// @Description  List devices signed in to the current account, most recently used first. The session of the request is marked as current.
+// @Tags         sessions
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {array}   response.Session
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/sessions [get]
+func (h *Handler) getSessions(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	sessions, err := h.authService.GetSessions(c.Request.Context(), userID.(int), c.GetString(sessionCtx))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to get sessions - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, conv.FromDomainToSessionListResponse(sessions))
+}
+
+// closeSession signs out one device of authenticated user.
+//
+// @Summary      Close session
+// @Description  Revoke a session of the current account; its refresh and access tokens stop working.
+// @Tags         sessions
+// @Security     Bearer
+// @Produce      json
+// @Param        session_id  path      string  true  "Session ID"
+// @Success      200         {object}  response.Message
+// @Failure      401         {object}  response.Error "Unauthorized"
+// @Failure      404         {object}  response.Error "Session not found"
+// @Failure      500         {object}  response.Error "Internal server error"
+// @Router       /protected/sessions/{session_id} [delete]
+func (h *Handler) closeSession(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+	sessionID := c.Param("session_id")
+
+	if err := h.authService.CloseSession(c.Request.Context(), userID.(int), sessionID); err != nil {
+		if errors.Is(err, errs.ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "session not found",
+			})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"user_id":    userID.(int),
+			"session_id": sessionID,
+			"error":      err,
+		}).Error("failed to close session - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":    userID.(int),
+		"session_id": sessionID,
+	}).Info("session closed")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "session closed",
+	})
+}
+
+// closeOtherSessions signs out every other device of authenticated user.
+//
+// @Summary      Close other sessions
+// @Description  Revoke every session of the current account except the one making the request.
+// @Tags         sessions
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {object}  response.Message
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/sessions [delete]
+func (h *Handler) closeOtherSessions(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	if err := h.authService.CloseOtherSessions(c.Request.Context(), userID.(int), c.GetString(sessionCtx)); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id": userID.(int),
+			"error":   err,
+		}).Error("failed to close other sessions - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithField("user_id", userID.(int)).Info("other sessions closed")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "other sessions closed",
+	})
+}
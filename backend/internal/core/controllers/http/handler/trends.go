@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/sirupsen/logrus"
+)
+
+// getTrends returns trending hashtags and topics.
+//
+// @Summary      Get trends
+// @Description  Get hashtags and topics gaining use fastest compared with their usual rate. Velocity above 1 means a term is picking up.
+// @Tags         search
+// @Produce      json
+// @Param        limit  query     int  false  "Limit (max 20)"  default(10)
+// @Success      200    {object}  response.TrendList
+// @Failure      500    {object}  response.Error "Internal server error"
+// @Router       /public/trends [get]
+func (h *Handler) getTrends(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 {
+		limit = 10
+	}
+
+	trends, err := h.clientSearchService.GetTrends(c.Request.Context(), limit)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get trends - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithField("limit", limit).Info("trends got")
+	c.JSON(http.StatusOK, conv.FromDomainToTrendListResponse(trends))
+}
@@ -3,7 +3,6 @@ package http
 import (
 	"context"
 	"errors"
-	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
@@ -23,17 +22,20 @@ const (
 // createTweet creates a new tweet for authenticated user.
 //
 // @Summary      Create tweet
-// @Description  Create a new tweet with optional media file. Supports multipart/form-data only.
+// @Description  Create a new tweet with up to four media attachments: 4 images or 1 video, gif or audio. Large files are attached by the id of a completed upload.
 // @Tags         tweets
 // @Security     Bearer
+// @Accept       json
 // @Accept       multipart/form-data
 // @Produce      json
-// @Param        content  formData  string              false  "Tweet text content"
-// @Param        file     formData  file                false  "Optional media file"
-// @Success      201      {object}  response.Tweet
-// @Failure      400      {object}  response.Error "Invalid request body or empty tweet"
-// @Failure      401      {object}  response.Error "Unauthorized"
-// @Failure      500      {object}  response.Error "Internal server error"
+// @Param        content   formData  string              false  "Tweet text content"
+// @Param        file      formData  file                false  "Media file, repeated per attachment"
+// @Param        upload_id formData  int                 false  "Completed upload to attach after the files, repeated per attachment"
+// @Param        alt_text  formData  string              false  "Alt text of the attachment at the same index, repeated per attachment"
+// @Success      201       {object}  response.Tweet
+// @Failure      400       {object}  response.Error "Invalid request body, empty tweet or invalid attachments"
+// @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      500       {object}  response.Error "Internal server error"
 // @Router       /protected/tweets [post]
 func (h *Handler) createTweet(c *gin.Context) {
 	userID, ok := c.Get(userCtx)
@@ -45,7 +47,7 @@ func (h *Handler) createTweet(c *gin.Context) {
 	}
 
 	var req request.Tweet
-	var fileHeader *multipart.FileHeader
+	var attachments []entity.Attachment
 	var err error
 	ct := c.ContentType()
 	if strings.HasPrefix(ct, "multipart/form-data") {
@@ -54,49 +56,36 @@ func (h *Handler) createTweet(c *gin.Context) {
 			return
 		}
 		req.Content = c.PostForm("content")
-		fileHeader, err = c.FormFile("file")
-		if err != nil && err != http.ErrMissingFile {
-			logrus.WithFields(logrus.Fields{
-				"user_id": userID.(int),
-				"error":   err,
-			}).Error("create tweet failed - internal server error")
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "internal server error",
-			})
+		var closeFiles func()
+		attachments, closeFiles, err = tweetAttachments(c)
+		if err != nil {
+			if abortInvalidAttachments(c, err) {
+				return
+			}
+			logrus.WithError(err).Error("failed to create tweet - open file error")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "open file error"})
 			return
 		}
+		defer closeFiles()
 	} else {
 		if err := c.BindJSON(&req); err != nil {
 			logrus.WithError(err).Error("failed to create tweet - invalid request body")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 			return
 		}
-		fileHeader = nil
+		attachments = conv.FromTweetMediaRequestToDomain(req.Media)
 	}
 
-	var file *entity.File
-	if fileHeader == nil && strings.TrimSpace(req.Content) == "" {
+	if len(attachments) == 0 && strings.TrimSpace(req.Content) == "" {
 		logrus.WithError(err).Error("failed to create tweet - impossible create empty tweet")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "impossible create empty tweet"})
 		return
-	} else if fileHeader != nil {
-		openedFile, err := fileHeader.Open()
-		if err != nil {
-			logrus.WithError(err).Error("failed to create tweet - open file error")
-			c.JSON(http.StatusBadRequest, gin.H{"error": "open file error"})
-			return
-		}
-		defer openedFile.Close()
-		file = &entity.File{
-			File:   openedFile,
-			Header: fileHeader,
-		}
-	} else {
-		file = nil
 	}
 
-	tweet, err := h.tweetService.CreateTweet(c.Request.Context(), conv.FromTweetRequestToDomain(userID.(int), nil, file, &req))
-
+	tweet, err := h.tweetService.CreateTweet(c.Request.Context(), conv.FromTweetRequestToDomain(userID.(int), nil, attachments, &req))
+	if abortInvalidAttachments(c, err) {
+		return
+	}
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"user_id": userID.(int),
@@ -108,6 +97,8 @@ func (h *Handler) createTweet(c *gin.Context) {
 		return
 	}
 
+	h.notifyMentions(userID.(int), tweet)
+
 	logrus.WithFields(logrus.Fields{
 		"user_id":  userID.(int),
 		"tweet_id": tweet.ID,
@@ -118,7 +109,7 @@ func (h *Handler) createTweet(c *gin.Context) {
 // updateTweet updates an existing tweet of authenticated user.
 //
 // @Summary      Update tweet
-// @Description  Update tweet content and optionally replace media. Supports multipart/form-data only.
+// @Description  Update tweet content and optionally replace all attachments. The replaced version is kept in the tweet's edit history. Supports multipart/form-data only.
 // @Tags         tweets
 // @Security     Bearer
 // @Accept       json
@@ -126,10 +117,13 @@ func (h *Handler) createTweet(c *gin.Context) {
 // @Produce      json
 // @Param        tweet_id  path      int           true   "Tweet ID"
 // @Param        content   formData  string        false  "Tweet text content"
-// @Param        file      formData  file          false  "Optional media file"
+// @Param        file      formData  file          false  "Media file, repeated per attachment"
+// @Param        upload_id formData  int           false  "Completed upload to attach after the files, repeated per attachment"
+// @Param        alt_text  formData  string        false  "Alt text of the attachment at the same index, repeated per attachment"
 // @Success      200       {object}  response.Tweet
-// @Failure      400       {object}  response.Error "Invalid tweet ID or request body"
+// @Failure      400       {object}  response.Error "Invalid tweet ID, request body or attachments"
 // @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      403       {object}  response.Error "Not the author, edit window closed or edit limit reached"
 // @Failure      404       {object}  response.Error "Tweet not found"
 // @Failure      500       {object}  response.Error "Internal server error"
 // @Router       /protected/tweets/{tweet_id} [patch]
@@ -150,7 +144,7 @@ func (h *Handler) updateTweet(c *gin.Context) {
 	}
 
 	var req request.Tweet
-	var fileHeader *multipart.FileHeader
+	var attachments []entity.Attachment
 	ct := c.ContentType()
 	if strings.HasPrefix(ct, "multipart/form-data") {
 		if err := c.Request.ParseMultipartForm(maxMemoryForm); err != nil {
@@ -158,70 +152,68 @@ func (h *Handler) updateTweet(c *gin.Context) {
 			return
 		}
 		req.Content = c.PostForm("content")
-		fileHeader, err = c.FormFile("file")
-		if err != nil && err != http.ErrMissingFile {
-			logrus.WithFields(logrus.Fields{
-				"user_id": userID.(int),
-				"error":   err,
-			}).Error("update tweet failed - internal server error")
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "internal server error",
-			})
+		var closeFiles func()
+		attachments, closeFiles, err = tweetAttachments(c)
+		if err != nil {
+			if abortInvalidAttachments(c, err) {
+				return
+			}
+			logrus.WithError(err).Error("failed to update tweet - open file error")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "open file error"})
 			return
 		}
+		defer closeFiles()
 	} else {
 		if err := c.BindJSON(&req); err != nil {
 			logrus.WithError(err).Error("failed to update tweet - invalid request body")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 			return
 		}
-		fileHeader = nil
+		attachments = conv.FromTweetMediaRequestToDomain(req.Media)
 	}
 
-	var file *entity.File
-	if fileHeader == nil && strings.TrimSpace(req.Content) == "" {
+	if len(attachments) == 0 && strings.TrimSpace(req.Content) == "" {
 		logrus.WithError(err).Error("failed to update tweet - impossible update empty tweet")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "impossible update empty tweet"})
 		return
-	} else if fileHeader != nil {
-		openedFile, err := fileHeader.Open()
-		if err != nil {
-			logrus.WithError(err).Error("failed to update tweet - open file error")
-			c.JSON(http.StatusBadRequest, gin.H{"error": "open file error"})
-			return
-		}
-		defer openedFile.Close()
-		file = &entity.File{
-			File:   openedFile,
-			Header: fileHeader,
-		}
-	} else {
-		file = nil
 	}
 
-	tweet, err := h.tweetService.UpdateTweet(c.Request.Context(), conv.FromTweetUpdateRequestToDomain(userID.(int), tweetID, file, &req))
-	if err != nil && !errors.Is(err, errs.ErrTweetNotFound) {
-		logrus.WithFields(logrus.Fields{
-			"user_id":  userID.(int),
-			"tweet_id": tweetID,
-			"error":    err,
-		}).Error("update tweet failed - internal server error")
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "internal server error",
-		})
+	tweet, err := h.tweetService.UpdateTweet(c.Request.Context(), conv.FromTweetUpdateRequestToDomain(userID.(int), tweetID, attachments, &req))
+	if abortInvalidAttachments(c, err) {
 		return
-	} else if errors.Is(err, errs.ErrTweetNotFound) {
-		logrus.WithFields(logrus.Fields{
-			"user_id":  userID.(int),
-			"tweet_id": tweetID,
-			"error":    err,
-		}).Error("update tweet failed - tweet not found")
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "tweet not found",
-		})
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrTweetNotFound):
+			logrus.WithFields(logrus.Fields{
+				"user_id":  userID.(int),
+				"tweet_id": tweetID,
+				"error":    err,
+			}).Error("update tweet failed - tweet not found")
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "tweet not found",
+			})
+		case errors.Is(err, errs.ErrUnauthorizedUpdate):
+			c.JSON(http.StatusForbidden, gin.H{"error": "tweet belongs to another user"})
+		case errors.Is(err, errs.ErrEditWindowClosed):
+			c.JSON(http.StatusForbidden, gin.H{"error": "tweet can no longer be edited"})
+		case errors.Is(err, errs.ErrEditLimitReached):
+			c.JSON(http.StatusForbidden, gin.H{"error": "tweet edit limit reached"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id":  userID.(int),
+				"tweet_id": tweetID,
+				"error":    err,
+			}).Error("update tweet failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
 		return
 	}
 
+	h.notifyMentions(userID.(int), tweet)
+
 	c.JSON(http.StatusOK, conv.FromDomainToTweetResponse(tweet))
 }
 
@@ -236,6 +228,7 @@ func (h *Handler) updateTweet(c *gin.Context) {
 // @Success      200       {object}  response.Message
 // @Failure      400       {object}  response.Error "Invalid tweet ID"
 // @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      403       {object}  response.Error "Tweet author is blocked"
 // @Failure      404       {object}  response.Error "Tweet not found"
 // @Failure      500       {object}  response.Error "Internal server error"
 // @Router       /protected/tweets/{tweet_id}/like [post]
@@ -254,7 +247,18 @@ func (h *Handler) likeTweet(c *gin.Context) {
 		return
 	}
 
-	if err = h.tweetService.LikeTweet(c.Request.Context(), userID.(int), tweetID); err != nil && !errors.Is(err, errs.ErrTweetNotFound) {
+	err = h.tweetService.LikeTweet(c.Request.Context(), userID.(int), tweetID)
+	if errors.Is(err, errs.ErrBlocked) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"tweet_id": tweetID,
+		}).Warn("like tweet failed - blocked")
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "user is blocked",
+		})
+		return
+	}
+	if err != nil && !errors.Is(err, errs.ErrTweetNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"user_id":  userID.(int),
 			"tweet_id": tweetID,
@@ -362,6 +366,7 @@ func (h *Handler) unlikeTweet(c *gin.Context) {
 // @Success      200       {object}  response.Message
 // @Failure      400       {object}  response.Error "Invalid tweet ID"
 // @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      403       {object}  response.Error "Blocked or tweet of a private account"
 // @Failure      404       {object}  response.Error "Tweet not found"
 // @Failure      500       {object}  response.Error "Internal server error"
 // @Router       /protected/tweets/{tweet_id}/retweet [post]
@@ -380,24 +385,34 @@ func (h *Handler) retweet(c *gin.Context) {
 		return
 	}
 
-	if err = h.tweetService.CreateRetweet(c.Request.Context(), userID.(int), tweetID); err != nil && !errors.Is(err, errs.ErrTweetNotFound) {
+	err = h.tweetService.CreateRetweet(c.Request.Context(), userID.(int), tweetID)
+	if errors.Is(err, errs.ErrTweetNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"user_id":  userID.(int),
 			"tweet_id": tweetID,
 			"error":    err,
-		}).Error("retweet failed - internal server error")
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "internal server error",
+		}).Error("retweet failed - tweet not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tweet not found",
 		})
 		return
-	} else if errors.Is(err, errs.ErrTweetNotFound) {
+	} else if errors.Is(err, errs.ErrBlocked) || errors.Is(err, errs.ErrPrivateRetweet) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":  userID.(int),
+			"tweet_id": tweetID,
+		}).Warnf("retweet failed - %s", err)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	} else if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"user_id":  userID.(int),
 			"tweet_id": tweetID,
 			"error":    err,
-		}).Error("retweet failed - tweet not found")
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "tweet not found",
+		}).Error("retweet failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
 		})
 		return
 	}
@@ -488,10 +503,14 @@ func (h *Handler) deleteRetweet(c *gin.Context) {
 // @Produce      json
 // @Param        tweet_id  path      int           true   "Parent tweet ID"
 // @Param        content   formData  string        false  "Reply text content"
-// @Param        file      formData  file          false  "Optional media file"
+// @Param        file      formData  file          false  "Media file, repeated per attachment"
+// @Param        upload_id formData  int           false  "Completed upload to attach after the files, repeated per attachment"
+// @Param        alt_text  formData  string        false  "Alt text of the attachment at the same index, repeated per attachment"
 // @Success      201       {object}  response.Tweet
-// @Failure      400       {object}  response.Error "Invalid tweet ID, body or empty reply"
+// @Failure      400       {object}  response.Error "Invalid tweet ID, body, attachments or empty reply"
 // @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      403       {object}  response.Error "Parent tweet author is blocked"
+// @Failure      404       {object}  response.Error "Parent tweet not found"
 // @Failure      500       {object}  response.Error "Internal server error"
 // @Router       /protected/tweets/{tweet_id}/reply [post]
 func (h *Handler) replyToTweet(c *gin.Context) {
@@ -511,7 +530,7 @@ func (h *Handler) replyToTweet(c *gin.Context) {
 	}
 
 	var req request.Tweet
-	var fileHeader *multipart.FileHeader
+	var attachments []entity.Attachment
 	ct := c.ContentType()
 	if strings.HasPrefix(ct, "multipart/form-data") {
 		if err := c.Request.ParseMultipartForm(maxMemoryForm); err != nil {
@@ -519,56 +538,169 @@ func (h *Handler) replyToTweet(c *gin.Context) {
 			return
 		}
 		req.Content = c.PostForm("content")
-		fileHeader, err = c.FormFile("file")
-		if err != nil && err != http.ErrMissingFile {
-			logrus.WithFields(logrus.Fields{
-				"user_id":         userID.(int),
-				"parent_tweet_id": parentTweetID,
-				"error":           err,
-			}).Error("reply to tweet failed - internal server error")
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "internal server error",
-			})
+		var closeFiles func()
+		attachments, closeFiles, err = tweetAttachments(c)
+		if err != nil {
+			if abortInvalidAttachments(c, err) {
+				return
+			}
+			logrus.WithError(err).Error("failed to reply to tweet - open file error")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "open file error"})
 			return
 		}
+		defer closeFiles()
 	} else {
 		if err := c.BindJSON(&req); err != nil {
 			logrus.WithError(err).Error("failed to reply to tweet - invalid request body")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 			return
 		}
-		fileHeader = nil
+		attachments = conv.FromTweetMediaRequestToDomain(req.Media)
 	}
 
-	var file *entity.File
-	if fileHeader == nil && strings.TrimSpace(req.Content) == "" {
+	if len(attachments) == 0 && strings.TrimSpace(req.Content) == "" {
 		logrus.WithError(err).Error("failed to reply to tweet - impossible create empty tweet")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "impossible reply to empty tweet"})
 		return
-	} else if fileHeader != nil {
-		openedFile, err := fileHeader.Open()
+	}
+
+	tweet, err := h.tweetService.CreateTweet(c.Request.Context(), conv.FromTweetRequestToDomain(userID.(int), &parentTweetID, attachments, &req))
+	if abortInvalidAttachments(c, err) {
+		return
+	}
+	if errors.Is(err, errs.ErrTweetNotFound) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":         userID.(int),
+			"parent_tweet_id": parentTweetID,
+		}).Warn("reply to tweet failed - tweet not found")
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tweet not found",
+		})
+		return
+	} else if errors.Is(err, errs.ErrBlocked) {
+		logrus.WithFields(logrus.Fields{
+			"user_id":         userID.(int),
+			"parent_tweet_id": parentTweetID,
+		}).Warn("reply to tweet failed - blocked")
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "user is blocked",
+		})
+		return
+	} else if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"user_id":         userID.(int),
+			"parent_tweet_id": parentTweetID,
+			"error":           err,
+		}).Error("create tweet failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	go func() {
+		if err := h.notificationService.NotifyReply(context.Background(), userID.(int), parentTweetID); err != nil {
+			logrus.WithError(err).Warn("failed to notify reply")
+		}
+	}()
+
+	h.notifyMentions(userID.(int), tweet)
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":         userID.(int),
+		"parent_tweet_id": parentTweetID,
+	}).Info("reply to tweet created")
+	c.JSON(http.StatusCreated, conv.FromDomainToTweetResponse(tweet))
+}
+
+// quoteTweet creates tweet quoting given tweet for authenticated user.
+//
+// @Summary      Quote tweet
+// @Description  Create tweet that embeds given tweet, with optional text and media.
+// @Tags         tweets
+// @Security     Bearer
+// @Accept       json
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        tweet_id  path      int           true   "Quoted tweet ID"
+// @Param        content   formData  string        false  "Quote text content"
+// @Param        file      formData  file          false  "Media file, repeated per attachment"
+// @Param        upload_id formData  int           false  "Completed upload to attach after the files, repeated per attachment"
+// @Param        alt_text  formData  string        false  "Alt text of the attachment at the same index, repeated per attachment"
+// @Success      201       {object}  response.Tweet
+// @Failure      400       {object}  response.Error "Invalid tweet ID, body, attachments or empty quote"
+// @Failure      401       {object}  response.Error "Unauthorized"
+// @Failure      404       {object}  response.Error "Quoted tweet not found"
+// @Failure      500       {object}  response.Error "Internal server error"
+// @Router       /protected/tweets/{tweet_id}/quote [post]
+func (h *Handler) quoteTweet(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	quotedTweetID, err := strconv.Atoi(c.Param("tweet_id"))
+	if err != nil || quotedTweetID == 0 {
+		logrus.WithError(err).Error("failed to quote - invalid tweet id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tweet id"})
+		return
+	}
+
+	var req request.Tweet
+	var attachments []entity.Attachment
+	ct := c.ContentType()
+	if strings.HasPrefix(ct, "multipart/form-data") {
+		if err := c.Request.ParseMultipartForm(maxMemoryForm); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse form data"})
+			return
+		}
+		req.Content = c.PostForm("content")
+		var closeFiles func()
+		attachments, closeFiles, err = tweetAttachments(c)
 		if err != nil {
-			logrus.WithError(err).Error("failed to reply to tweet - open file error")
+			if abortInvalidAttachments(c, err) {
+				return
+			}
+			logrus.WithError(err).Error("failed to quote tweet - open file error")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "open file error"})
 			return
 		}
-		defer openedFile.Close()
-		file = &entity.File{
-			File:   openedFile,
-			Header: fileHeader,
-		}
+		defer closeFiles()
 	} else {
-		file = nil
+		if err := c.BindJSON(&req); err != nil {
+			logrus.WithError(err).Error("failed to quote tweet - invalid request body")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		attachments = conv.FromTweetMediaRequestToDomain(req.Media)
+	}
+
+	if len(attachments) == 0 && strings.TrimSpace(req.Content) == "" {
+		logrus.Error("failed to quote tweet - impossible create empty tweet")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "impossible create empty quote"})
+		return
 	}
 
-	tweet, err := h.tweetService.CreateTweet(c.Request.Context(), conv.FromTweetRequestToDomain(userID.(int), &parentTweetID, file, &req))
+	quote := conv.FromTweetRequestToDomain(userID.(int), nil, attachments, &req)
+	quote.QuotedTweetID = &quotedTweetID
 
+	tweet, err := h.tweetService.CreateTweet(c.Request.Context(), quote)
+	if abortInvalidAttachments(c, err) {
+		return
+	}
 	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "tweet not found"})
+			return
+		}
 		logrus.WithFields(logrus.Fields{
 			"user_id":         userID.(int),
-			"parent_tweet_id": parentTweetID,
+			"quoted_tweet_id": quotedTweetID,
 			"error":           err,
-		}).Error("create tweet failed - internal server error")
+		}).Error("create quote failed - internal server error")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "internal server error",
 		})
@@ -576,15 +708,17 @@ func (h *Handler) replyToTweet(c *gin.Context) {
 	}
 
 	go func() {
-		if err := h.notificationService.NotifyReply(context.Background(), userID.(int), parentTweetID); err != nil {
-			logrus.WithError(err).Warn("failed to notify reply")
+		if err := h.notificationService.NotifyQuote(context.Background(), userID.(int), quotedTweetID); err != nil {
+			logrus.WithError(err).Warn("failed to notify quote")
 		}
 	}()
 
+	h.notifyMentions(userID.(int), tweet)
+
 	logrus.WithFields(logrus.Fields{
 		"user_id":         userID.(int),
-		"parent_tweet_id": parentTweetID,
-	}).Info("reply to tweet created")
+		"quoted_tweet_id": quotedTweetID,
+	}).Info("quote tweet created")
 	c.JSON(http.StatusCreated, conv.FromDomainToTweetResponse(tweet))
 }
 
@@ -595,6 +729,7 @@ func (h *Handler) replyToTweet(c *gin.Context) {
 // @Tags         tweets
 // @Produce      json
 // @Param        tweet_id  path      int  true  "Tweet ID"
+// @Param        cursor    query     string  false  "Cursor from next_cursor of the previous page"
 // @Success      200       {object}  response.TweetList
 // @Failure      400       {object}  response.Error "Invalid tweet ID"
 // @Failure      404       {object}  response.Error "Tweet not found"
@@ -608,17 +743,14 @@ func (h *Handler) getReplies(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 30 {
-		limit = 30
-	}
-	if limit < 1 {
-		limit = 10
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get replies - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
 	}
 
-	replies, err := h.tweetService.GetRepliesToTweet(c.Request.Context(), tweetID, limit, offset)
+	replies, next, err := h.tweetService.GetRepliesToTweet(c.Request.Context(), tweetID, page)
 	if err != nil && !errors.Is(err, errs.ErrTweetNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"tweet_id": tweetID,
@@ -638,7 +770,100 @@ func (h *Handler) getReplies(c *gin.Context) {
 	}
 
 	logrus.WithField("tweet_id", tweetID).Info("replies got")
-	c.JSON(http.StatusOK, conv.FromDomainToTweetListResponse(replies))
+	c.JSON(http.StatusOK, conv.FromDomainToTweetPageResponse(replies, next))
+}
+
+// getTweetHistory returns earlier versions of an edited tweet.
+//
+// @Summary      Get tweet edit history
+// @Description  Get the versions an edit replaced, most recent first. The current version is the tweet itself.
+// @Tags         tweets
+// @Produce      json
+// @Param        tweet_id  path      int     true   "Tweet ID"
+// @Param        cursor    query     string  false  "Cursor from next_cursor of the previous page"
+// @Success      200       {object}  response.TweetHistory
+// @Failure      400       {object}  response.Error "Invalid tweet ID"
+// @Failure      404       {object}  response.Error "Tweet not found"
+// @Failure      500       {object}  response.Error "Internal server error"
+// @Router       /public/tweets/{tweet_id}/history [get]
+func (h *Handler) getTweetHistory(c *gin.Context) {
+	tweetID, err := strconv.Atoi(c.Param("tweet_id"))
+	if err != nil || tweetID == 0 {
+		logrus.WithError(err).Error("failed to get tweet history - invalid tweet id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tweet id"})
+		return
+	}
+
+	page, err := parsePage(c, 20, 100)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get tweet history - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	revisions, next, err := h.tweetService.GetTweetHistory(c.Request.Context(), tweetID, page)
+	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "tweet not found"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"tweet_id": tweetID,
+			"error":    err,
+		}).Error("failed to get tweet history - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, conv.FromDomainToTweetHistoryResponse(tweetID, revisions, next))
+}
+
+// getThread returns conversation around given tweet.
+//
+// @Summary      Get tweet thread
+// @Description  Get ancestors of the tweet up to the root, the tweet itself and a depth-limited tree of replies. Only direct replies are paginated.
+// @Tags         tweets
+// @Produce      json
+// @Param        tweet_id  path      int     true   "Tweet ID"
+// @Param        depth     query     int     false  "Levels of nested replies (default 3, max 10)"
+// @Param        cursor    query     string  false  "Cursor from next_cursor of the previous page"
+// @Success      200       {object}  response.Thread
+// @Failure      400       {object}  response.Error "Invalid tweet ID"
+// @Failure      404       {object}  response.Error "Tweet not found"
+// @Failure      500       {object}  response.Error "Internal server error"
+// @Router       /public/tweets/{tweet_id}/thread [get]
+func (h *Handler) getThread(c *gin.Context) {
+	tweetID, err := strconv.Atoi(c.Param("tweet_id"))
+	if err != nil || tweetID == 0 {
+		logrus.WithError(err).Error("failed to get thread - invalid tweet id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tweet id"})
+		return
+	}
+
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get thread - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+	depth, _ := strconv.Atoi(c.Query("depth"))
+
+	thread, next, err := h.tweetService.GetThread(c.Request.Context(), tweetID, depth, page)
+	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "tweet not found"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"tweet_id": tweetID,
+			"error":    err,
+		}).Error("failed to get thread - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	logrus.WithField("tweet_id", tweetID).Info("thread got")
+	c.JSON(http.StatusOK, conv.FromDomainToThreadResponse(thread, next))
 }
 
 // getTweetById returns tweet by ID.
@@ -691,6 +916,7 @@ func (h *Handler) getTweetById(c *gin.Context) {
 // @Tags         tweets
 // @Produce      json
 // @Param        username  path      string  true  "Username"
+// @Param        cursor    query     string  false  "Cursor from next_cursor of the previous page"
 // @Success      200       {object}  response.TweetList
 // @Failure      400       {object}  response.Error "Invalid username"
 // @Failure      500       {object}  response.Error "Internal server error"
@@ -703,17 +929,14 @@ func (h *Handler) getTweetsAndRetweetsByUsername(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 30 {
-		limit = 30
-	}
-	if limit < 1 {
-		limit = 10
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get tweets - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
 	}
 
-	tweets, err := h.tweetService.GetTweetsAndRetweetsByUsername(c.Request.Context(), username, limit, offset)
+	tweets, next, err := h.tweetService.GetTweetsAndRetweetsByUsername(c.Request.Context(), username, page)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"username": username,
@@ -724,7 +947,7 @@ func (h *Handler) getTweetsAndRetweetsByUsername(c *gin.Context) {
 	}
 
 	logrus.WithField("username", username).Info("tweets got")
-	c.JSON(http.StatusOK, conv.FromDomainToTweetListResponse(tweets))
+	c.JSON(http.StatusOK, conv.FromDomainToTweetPageResponse(tweets, next))
 }
 
 // getLikes returns list of users who liked the tweet.
@@ -734,6 +957,7 @@ func (h *Handler) getTweetsAndRetweetsByUsername(c *gin.Context) {
 // @Tags         tweets
 // @Produce      json
 // @Param        tweet_id  path      int  true  "Tweet ID"
+// @Param        cursor    query     string  false  "Cursor from next_cursor of the previous page"
 // @Success      200       {object}  response.SmallUserList
 // @Failure      400       {object}  response.Error "Invalid tweet ID"
 // @Failure      404       {object}  response.Error "Tweet not found"
@@ -747,17 +971,14 @@ func (h *Handler) getLikes(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 50 {
-		limit = 50
-	}
-	if limit < 1 {
-		limit = 10
+	page, err := parsePage(c, 20, 50)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get likes - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
 	}
 
-	likes, err := h.tweetService.GetLikes(c.Request.Context(), tweetID, limit, offset)
+	likes, next, err := h.tweetService.GetLikes(c.Request.Context(), tweetID, page)
 	if err != nil && !errors.Is(err, errs.ErrTweetNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"tweet_id": tweetID,
@@ -777,7 +998,7 @@ func (h *Handler) getLikes(c *gin.Context) {
 	}
 
 	logrus.WithField("tweet_id", tweetID).Info("likes got")
-	c.JSON(http.StatusOK, conv.FromDomainToSmallUserListResponse(likes))
+	c.JSON(http.StatusOK, conv.FromDomainToSmallUserPageResponse(likes, next))
 }
 
 // deleteTweet deletes tweet of authenticated user by ID.
@@ -839,3 +1060,14 @@ func (h *Handler) deleteTweet(c *gin.Context) {
 		"message": "successfully delete tweet",
 	})
 }
+
+// notifyMentions notifies users mentioned in a created or edited tweet in the background.
+func (h *Handler) notifyMentions(actorID int, tweet *entity.Tweet) {
+	for _, mentioned := range tweet.Mentions {
+		go func(mentionedID int) {
+			if err := h.notificationService.NotifyMention(context.Background(), actorID, tweet.ID, mentionedID); err != nil {
+				logrus.WithError(err).Warn("failed to notify mention")
+			}
+		}(mentioned.ID)
+	}
+}
@@ -0,0 +1,315 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	conv "github.com/kust1q/Zapp/backend/internal/core/controllers/http/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/controllers/http/dto/request"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// uploadOffsetHeader carries the offset a chunk of a resumable upload is written at.
+const uploadOffsetHeader = "Upload-Offset"
+
+// createUpload starts a resumable upload for authenticated user.
+//
+// @Summary      Create upload
+// @Description  Start a resumable upload of a large media file. Chunks are then written with PUT at the returned offset, each of chunk_size bytes but the last one, and the upload is completed before it is attached to a tweet.
+// @Tags         media
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        input  body      request.CreateUpload  true  "File name and size in bytes"
+// @Success      201    {object}  response.Upload
+// @Failure      400    {object}  response.Error "Invalid request body, media type or file too large"
+// @Failure      401    {object}  response.Error "Unauthorized"
+// @Failure      429    {object}  response.Error "Too many requests"
+// @Failure      500    {object}  response.Error "Internal server error"
+// @Router       /protected/uploads [post]
+func (h *Handler) createUpload(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req request.CreateUpload
+	if err := c.BindJSON(&req); err != nil {
+		logrus.WithError(err).Error("failed to create upload - invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	upload, err := h.mediaService.CreateUpload(c.Request.Context(), userID.(int), req.Filename, req.Size)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		case errors.Is(err, errs.ErrInvalidmediaType):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid media type"})
+		case errors.Is(err, errs.ErrFileTooLarge):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file too large"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id": userID.(int),
+				"error":   err,
+			}).Error("create upload failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":   userID.(int),
+		"upload_id": upload.ID,
+	}).Info("upload created")
+	c.JSON(http.StatusCreated, conv.FromDomainToUploadResponse(upload))
+}
+
+// getUpload returns the progress of an upload of authenticated user.
+//
+// @Summary      Get upload
+// @Description  Get the offset to resume an upload at.
+// @Tags         media
+// @Security     Bearer
+// @Produce      json
+// @Param        upload_id  path  int  true  "Upload ID"
+// @Success      200  {object}  response.Upload
+// @Failure      400  {object}  response.Error "Invalid upload ID"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Upload not found"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/uploads/{upload_id} [get]
+func (h *Handler) getUpload(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	uploadID, err := strconv.Atoi(c.Param("upload_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to get upload - invalid upload id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload id"})
+		return
+	}
+
+	upload, err := h.mediaService.GetUpload(c.Request.Context(), userID.(int), uploadID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUploadNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "upload not found"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"user_id":   userID.(int),
+			"upload_id": uploadID,
+			"error":     err,
+		}).Error("get upload failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conv.FromDomainToUploadResponse(upload))
+}
+
+// writeUploadChunk stores the next chunk of an upload of authenticated user.
+//
+// @Summary      Write upload chunk
+// @Description  Stream the request body as the chunk at the Upload-Offset header, which must be the current offset of the upload. A chunk that failed is written again at the same offset.
+// @Tags         media
+// @Security     Bearer
+// @Accept       application/octet-stream
+// @Produce      json
+// @Param        upload_id      path    int  true  "Upload ID"
+// @Param        Upload-Offset  header  int  true  "Offset of the chunk"
+// @Success      200  {object}  response.Upload
+// @Failure      400  {object}  response.Error "Invalid upload ID, offset, chunk size or media type"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Upload not found"
+// @Failure      409  {object}  response.Error "Offset mismatch, chunk in progress or upload completed"
+// @Failure      411  {object}  response.Error "Content length required"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/uploads/{upload_id} [put]
+func (h *Handler) writeUploadChunk(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	uploadID, err := strconv.Atoi(c.Param("upload_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to write upload chunk - invalid upload id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload id"})
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader(uploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload offset"})
+		return
+	}
+	if c.Request.ContentLength < 0 {
+		c.JSON(http.StatusLengthRequired, gin.H{"error": "content length required"})
+		return
+	}
+
+	upload, err := h.mediaService.WriteUploadChunk(c.Request.Context(), userID.(int), uploadID, offset, c.Request.Body, c.Request.ContentLength)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrUploadNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "upload not found"})
+		case errors.Is(err, errs.ErrUploadOffsetMismatch):
+			c.JSON(http.StatusConflict, gin.H{"error": "upload offset mismatch"})
+		case errors.Is(err, errs.ErrUploadCompleted):
+			c.JSON(http.StatusConflict, gin.H{"error": "upload is already completed"})
+		case errors.Is(err, errs.ErrInvalidChunk):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chunk size"})
+		case errors.Is(err, errs.ErrInvalidmediaType):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid media type"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id":   userID.(int),
+				"upload_id": uploadID,
+				"offset":    offset,
+				"error":     err,
+			}).Error("write upload chunk failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, conv.FromDomainToUploadResponse(upload))
+}
+
+// completeUpload assembles an upload of authenticated user whose chunks are all written.
+//
+// @Summary      Complete upload
+// @Description  Assemble the written chunks into the media file. The completed upload is attached to a tweet by its ID before it expires.
+// @Tags         media
+// @Security     Bearer
+// @Produce      json
+// @Param        upload_id  path  int  true  "Upload ID"
+// @Success      200  {object}  response.Upload
+// @Failure      400  {object}  response.Error "Invalid upload ID"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Upload not found"
+// @Failure      409  {object}  response.Error "Chunks missing, chunk in progress or upload completed"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/uploads/{upload_id}/complete [post]
+func (h *Handler) completeUpload(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	uploadID, err := strconv.Atoi(c.Param("upload_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to complete upload - invalid upload id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload id"})
+		return
+	}
+
+	upload, err := h.mediaService.CompleteUpload(c.Request.Context(), userID.(int), uploadID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrUploadNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "upload not found"})
+		case errors.Is(err, errs.ErrUploadIncomplete):
+			c.JSON(http.StatusConflict, gin.H{"error": "upload is incomplete"})
+		case errors.Is(err, errs.ErrUploadOffsetMismatch):
+			c.JSON(http.StatusConflict, gin.H{"error": "upload is being written"})
+		case errors.Is(err, errs.ErrUploadCompleted):
+			c.JSON(http.StatusConflict, gin.H{"error": "upload is already completed"})
+		default:
+			logrus.WithFields(logrus.Fields{
+				"user_id":   userID.(int),
+				"upload_id": uploadID,
+				"error":     err,
+			}).Error("complete upload failed - internal server error")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+			})
+		}
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":   userID.(int),
+		"upload_id": uploadID,
+	}).Info("upload completed")
+	c.JSON(http.StatusOK, conv.FromDomainToUploadResponse(upload))
+}
+
+// abortUpload drops an upload of authenticated user that was not attached yet.
+//
+// @Summary      Abort upload
+// @Description  Drop an upload together with its written chunks.
+// @Tags         media
+// @Security     Bearer
+// @Produce      json
+// @Param        upload_id  path  int  true  "Upload ID"
+// @Success      200  {object}  response.Message
+// @Failure      400  {object}  response.Error "Invalid upload ID"
+// @Failure      401  {object}  response.Error "Unauthorized"
+// @Failure      404  {object}  response.Error "Upload not found"
+// @Failure      500  {object}  response.Error "Internal server error"
+// @Router       /protected/uploads/{upload_id} [delete]
+func (h *Handler) abortUpload(c *gin.Context) {
+	userID, ok := c.Get(userCtx)
+	if !ok || userID.(int) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	uploadID, err := strconv.Atoi(c.Param("upload_id"))
+	if err != nil {
+		logrus.WithError(err).Error("failed to abort upload - invalid upload id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload id"})
+		return
+	}
+
+	if err := h.mediaService.AbortUpload(c.Request.Context(), userID.(int), uploadID); err != nil {
+		if errors.Is(err, errs.ErrUploadNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "upload not found"})
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"user_id":   userID.(int),
+			"upload_id": uploadID,
+			"error":     err,
+		}).Error("abort upload failed - internal server error")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"user_id":   userID.(int),
+		"upload_id": uploadID,
+	}).Info("upload aborted")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "upload aborted",
+	})
+}
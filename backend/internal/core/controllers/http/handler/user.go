@@ -20,6 +20,7 @@ import (
 // @Tags         users
 // @Security     Bearer
 // @Produce      json
+// @Param        cursor  query  string  false  "Cursor from next_cursor of the previous page"
 // @Success      200  {object}  response.UserProfile
 // @Failure      401  {object}  response.Error "Unauthorized"
 // @Failure      404  {object}  response.Error "User not found"
@@ -34,17 +35,14 @@ func (h *Handler) getMe(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 30 {
-		limit = 30
-	}
-	if limit < 1 {
-		limit = 10
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get user profile - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
 	}
 
-	userProfile, err := h.userService.GetMe(c.Request.Context(), userID.(int), limit, offset)
+	userProfile, err := h.userService.GetMe(c.Request.Context(), userID.(int), page)
 	if err != nil && !errors.Is(err, errs.ErrUserNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"user_id": userID.(int),
@@ -178,6 +176,7 @@ func (h *Handler) deleteMe(c *gin.Context) {
 // @Tags         users
 // @Produce      json
 // @Param        username  path      string  true  "Username"
+// @Param        cursor    query     string  false  "Cursor from next_cursor of the previous page"
 // @Success      200       {object}  response.SmallUserList
 // @Failure      400       {object}  response.Error "Invalid username"
 // @Failure      404       {object}  response.Error "User not found"
@@ -191,17 +190,14 @@ func (h *Handler) followers(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 30 {
-		limit = 30
-	}
-	if limit < 1 {
-		limit = 10
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get followers - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
 	}
 
-	followers, err := h.userService.GetFollowers(c.Request.Context(), username, limit, offset)
+	followers, next, err := h.userService.GetFollowers(c.Request.Context(), username, page)
 	if err != nil && !errors.Is(err, errs.ErrUserNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"username": username,
@@ -222,7 +218,7 @@ func (h *Handler) followers(c *gin.Context) {
 		return
 	}
 	logrus.WithField("username", username).Info("successfully get followers")
-	c.JSON(http.StatusOK, conv.FromDomainToSmallUserListResponse(followers))
+	c.JSON(http.StatusOK, conv.FromDomainToSmallUserPageResponse(followers, next))
 }
 
 // following returns list of followings for given username.
@@ -232,6 +228,7 @@ func (h *Handler) followers(c *gin.Context) {
 // @Tags         users
 // @Produce      json
 // @Param        username  path      string  true  "Username"
+// @Param        cursor    query     string  false  "Cursor from next_cursor of the previous page"
 // @Success      200       {object}  response.SmallUserList
 // @Failure      400       {object}  response.Error "Invalid username"
 // @Failure      404       {object}  response.Error "User not found"
@@ -245,17 +242,14 @@ func (h *Handler) following(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 50 {
-		limit = 50
-	}
-	if limit < 1 {
-		limit = 10
+	page, err := parsePage(c, 20, 50)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get followings - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
 	}
 
-	followings, err := h.userService.GetFollowings(c.Request.Context(), username, limit, offset)
+	followings, next, err := h.userService.GetFollowings(c.Request.Context(), username, page)
 	if err != nil && !errors.Is(err, errs.ErrUserNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"username": username,
@@ -276,13 +270,13 @@ func (h *Handler) following(c *gin.Context) {
 		return
 	}
 	logrus.WithField("username", username).Info("successfully get followers")
-	c.JSON(http.StatusOK, conv.FromDomainToSmallUserListResponse(followings))
+	c.JSON(http.StatusOK, conv.FromDomainToSmallUserPageResponse(followings, next))
 }
 
 // followUser subscribes authenticated user to another user.
 //
 // @Summary      Follow user
-// @Description  Follow user by ID as current authenticated user.
+// @Description  Follow user by ID as current authenticated user. Following a private account creates a pending follow request instead.
 // @Tags         users
 // @Security     Bearer
 // @Produce      json
@@ -290,6 +284,7 @@ func (h *Handler) following(c *gin.Context) {
 // @Success      200      {object}  response.Follow
 // @Failure      400      {object}  response.Error "Invalid user ID"
 // @Failure      401      {object}  response.Error "Unauthorized"
+// @Failure      403      {object}  response.Error "User is blocked"
 // @Failure      404      {object}  response.Error "User not found"
 // @Failure      500      {object}  response.Error "Internal server error"
 // @Router       /protected/users/{user_id}/follow [post]
@@ -310,6 +305,16 @@ func (h *Handler) followUser(c *gin.Context) {
 	}
 
 	follow, err := h.userService.FollowToUser(c.Request.Context(), followerID.(int), followingID)
+	if errors.Is(err, errs.ErrBlocked) {
+		logrus.WithFields(logrus.Fields{
+			"follower_id":  followerID,
+			"following_id": followingID,
+		}).Warn("failed to follow - blocked")
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "user is blocked",
+		})
+		return
+	}
 	if err != nil && !errors.Is(err, errs.ErrUserNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"follower_id":  followerID,
@@ -333,8 +338,12 @@ func (h *Handler) followUser(c *gin.Context) {
 	}
 
 	go func() {
-		if err := h.notificationService.NotifyFollow(context.Background(), followerID.(int), followingID); err != nil {
-			logrus.WithError(err).Error("failed to notify reply")
+		notify := h.notificationService.NotifyFollow
+		if follow.Pending {
+			notify = h.notificationService.NotifyFollowRequest
+		}
+		if err := notify(context.Background(), followerID.(int), followingID); err != nil {
+			logrus.WithError(err).Error("failed to notify follow")
 		}
 	}()
 
@@ -348,7 +357,7 @@ func (h *Handler) followUser(c *gin.Context) {
 // unfollowUser unsubscribes authenticated user from another user.
 //
 // @Summary      Unfollow user
-// @Description  Unfollow user by ID as current authenticated user.
+// @Description  Unfollow user by ID as current authenticated user, or withdraw a pending follow request.
 // @Tags         users
 // @Security     Bearer
 // @Produce      json
@@ -412,6 +421,7 @@ func (h *Handler) unfollowUser(c *gin.Context) {
 // @Tags         users
 // @Produce      json
 // @Param        username  path      string  true  "Username"
+// @Param        cursor    query     string  false  "Cursor from next_cursor of the previous page"
 // @Success      200       {object}  response.UserProfile
 // @Failure      400       {object}  response.Error "Invalid username"
 // @Failure      404       {object}  response.Error "User not found"
@@ -425,17 +435,14 @@ func (h *Handler) getUserProfile(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 30 {
-		limit = 30
-	}
-	if limit < 1 {
-		limit = 10
+	page, err := parsePage(c, 10, 30)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get user profile - invalid cursor")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
 	}
 
-	profile, err := h.userService.GetUserProfile(c.Request.Context(), username, limit, offset)
+	profile, err := h.userService.GetUserProfile(c.Request.Context(), username, page)
 	if err != nil && !errors.Is(err, errs.ErrUserNotFound) {
 		logrus.WithFields(logrus.Fields{
 			"username": username,
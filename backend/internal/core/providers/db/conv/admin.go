@@ -0,0 +1,26 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromAuditEntryModelToDomain(entry *models.AuditEntry) *entity.AuditEntry {
+	if entry == nil {
+		return nil
+	}
+
+	var adminID int
+	if entry.AdminID != nil {
+		adminID = *entry.AdminID
+	}
+
+	return &entity.AuditEntry{
+		ID:         entry.ID,
+		AdminID:    adminID,
+		Action:     entity.AuditAction(entry.Action),
+		TargetType: entity.AuditTarget(entry.TargetType),
+		TargetID:   entry.TargetID,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
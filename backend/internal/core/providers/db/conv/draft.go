@@ -0,0 +1,64 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromDomainToDraftModel(draft *entity.Draft) *models.Draft {
+	if draft == nil {
+		return nil
+	}
+
+	res := &models.Draft{
+		ID:            draft.ID,
+		UserID:        draft.UserID,
+		Content:       draft.Content,
+		ParentTweetID: draft.ParentTweetID,
+		QuotedTweetID: draft.QuotedTweetID,
+		ScheduledAt:   draft.ScheduledAt,
+		Attempts:      draft.Attempts,
+		CreatedAt:     draft.CreatedAt,
+		UpdatedAt:     draft.UpdatedAt,
+	}
+	if draft.Media != nil {
+		res.MediaPath = &draft.Media.Path
+		res.MediaMimeType = &draft.Media.MimeType
+		res.MediaSizeBytes = &draft.Media.SizeBytes
+	}
+	if draft.LastError != "" {
+		res.LastError = &draft.LastError
+	}
+	return res
+}
+
+func FromDraftModelToDomain(draft *models.Draft) *entity.Draft {
+	if draft == nil {
+		return nil
+	}
+
+	res := &entity.Draft{
+		ID:            draft.ID,
+		UserID:        draft.UserID,
+		Content:       draft.Content,
+		ParentTweetID: draft.ParentTweetID,
+		QuotedTweetID: draft.QuotedTweetID,
+		ScheduledAt:   draft.ScheduledAt,
+		Attempts:      draft.Attempts,
+		CreatedAt:     draft.CreatedAt,
+		UpdatedAt:     draft.UpdatedAt,
+	}
+	if draft.MediaPath != nil {
+		res.Media = &entity.UploadedMedia{Path: *draft.MediaPath}
+		if draft.MediaMimeType != nil {
+			res.Media.MimeType = *draft.MediaMimeType
+		}
+		if draft.MediaSizeBytes != nil {
+			res.Media.SizeBytes = *draft.MediaSizeBytes
+		}
+	}
+	if draft.LastError != nil {
+		res.LastError = *draft.LastError
+	}
+	return res
+}
@@ -13,9 +13,11 @@ func FromDomainToTweetMediaModel(media *entity.TweetMedia) *models.TweetMedia {
 	return &models.TweetMedia{
 		ID:        media.ID,
 		TweetID:   media.TweetID,
+		Position:  media.Position,
 		Path:      media.Path,
 		MimeType:  media.MimeType,
 		SizeBytes: media.SizeBytes,
+		AltText:   media.AltText,
 	}
 }
 
@@ -27,12 +29,22 @@ func FromTweetMediaModelToDomain(media *models.TweetMedia) *entity.TweetMedia {
 	return &entity.TweetMedia{
 		ID:        media.ID,
 		TweetID:   media.TweetID,
+		Position:  media.Position,
 		Path:      media.Path,
 		MimeType:  media.MimeType,
 		SizeBytes: media.SizeBytes,
+		AltText:   media.AltText,
 	}
 }
 
+func FromTweetMediaModelToDomainList(mediaModels []models.TweetMedia) []entity.TweetMedia {
+	media := make([]entity.TweetMedia, 0, len(mediaModels))
+	for i := range mediaModels {
+		media = append(media, *FromTweetMediaModelToDomain(&mediaModels[i]))
+	}
+	return media
+}
+
 func FromDomainToAvatarModel(avatar *entity.Avatar) *models.Avatar {
 	if avatar == nil {
 		return nil
@@ -60,3 +72,31 @@ func FromAvatarModelToDomain(avatar *models.Avatar) *entity.Avatar {
 		SizeBytes: avatar.SizeBytes,
 	}
 }
+
+func FromDomainToMessageMediaModel(media *entity.MessageMedia) *models.MessageMedia {
+	if media == nil {
+		return nil
+	}
+
+	return &models.MessageMedia{
+		ID:        media.ID,
+		MessageID: media.MessageID,
+		Path:      media.Path,
+		MimeType:  media.MimeType,
+		SizeBytes: media.SizeBytes,
+	}
+}
+
+func FromMessageMediaModelToDomain(media *models.MessageMedia) *entity.MessageMedia {
+	if media == nil {
+		return nil
+	}
+
+	return &entity.MessageMedia{
+		ID:        media.ID,
+		MessageID: media.MessageID,
+		Path:      media.Path,
+		MimeType:  media.MimeType,
+		SizeBytes: media.SizeBytes,
+	}
+}
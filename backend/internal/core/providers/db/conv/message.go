@@ -0,0 +1,49 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromConversationModelToDomain(conversation *models.Conversation) *entity.Conversation {
+	if conversation == nil {
+		return nil
+	}
+
+	return &entity.Conversation{
+		ID:            conversation.ID,
+		CreatorID:     int(conversation.CreatorID.Int64),
+		IsGroup:       conversation.IsGroup,
+		CreatedAt:     conversation.CreatedAt,
+		LastMessageAt: conversation.LastMessageAt,
+	}
+}
+
+func FromParticipantModelToDomain(participant *models.Participant) *entity.Participant {
+	if participant == nil {
+		return nil
+	}
+
+	return &entity.Participant{
+		User: entity.SmallUser{
+			ID:       participant.UserID,
+			Username: participant.Username,
+		},
+		LastReadMessageID: participant.LastReadMessageID,
+		LastReadAt:        participant.LastReadAt,
+	}
+}
+
+func FromDirectMessageModelToDomain(message *models.DirectMessage) *entity.DirectMessage {
+	if message == nil {
+		return nil
+	}
+
+	return &entity.DirectMessage{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		Sender:         &entity.SmallUser{ID: message.SenderID},
+		Content:        message.Content,
+		CreatedAt:      message.CreatedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromDomainToNotificationModel(notification *entity.Notification) *models.Notification {
+	if notification == nil {
+		return nil
+	}
+
+	return &models.Notification{
+		ID:          notification.ID,
+		Type:        string(notification.Type),
+		RecipientID: notification.RecipientID,
+		ActorID:     notification.ActorID,
+		ActorName:   notification.ActorName,
+		TweetID:     notification.TweetID,
+		TweetText:   notification.TweetText,
+		ReportID:    notification.ReportID,
+		Resolution:  notification.Resolution,
+		IsRead:      notification.Read,
+		CreatedAt:   notification.Timestamp,
+	}
+}
+
+func FromNotificationModelToDomain(notification *models.Notification) *entity.Notification {
+	if notification == nil {
+		return nil
+	}
+
+	return &entity.Notification{
+		ID:          notification.ID,
+		Type:        entity.NotificationType(notification.Type),
+		RecipientID: notification.RecipientID,
+		ActorID:     notification.ActorID,
+		ActorName:   notification.ActorName,
+		TweetID:     notification.TweetID,
+		TweetText:   notification.TweetText,
+		ReportID:    notification.ReportID,
+		Resolution:  notification.Resolution,
+		Timestamp:   notification.CreatedAt,
+		Read:        notification.IsRead,
+	}
+}
+
+func FromNotificationModelToDomainList(notificationModels []models.Notification) []entity.Notification {
+	notifications := make([]entity.Notification, 0, len(notificationModels))
+	for _, notification := range notificationModels {
+		notifications = append(notifications, *FromNotificationModelToDomain(&notification))
+	}
+	return notifications
+}
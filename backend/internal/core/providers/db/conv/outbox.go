@@ -0,0 +1,28 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromOutboxEventModelToDomain(event *models.OutboxEvent) *entity.OutboxEvent {
+	if event == nil {
+		return nil
+	}
+
+	return &entity.OutboxEvent{
+		ID:        event.ID,
+		Topic:     event.Topic,
+		Payload:   event.Payload,
+		Attempts:  event.Attempts,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+func FromOutboxEventModelToDomainList(eventModels []models.OutboxEvent) []entity.OutboxEvent {
+	events := make([]entity.OutboxEvent, 0, len(eventModels))
+	for _, event := range eventModels {
+		events = append(events, *FromOutboxEventModelToDomain(&event))
+	}
+	return events
+}
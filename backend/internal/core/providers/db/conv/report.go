@@ -0,0 +1,34 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromReportModelToDomain(report *models.Report) *entity.Report {
+	if report == nil {
+		return nil
+	}
+
+	res := &entity.Report{
+		ID:         report.ID,
+		ReporterID: report.ReporterID,
+		TargetType: entity.ReportTarget(report.TargetType),
+		TargetID:   report.TargetID,
+		Reason:     entity.ReportReason(report.Reason),
+		Details:    report.Details,
+		Status:     entity.ReportStatus(report.Status),
+		CreatedAt:  report.CreatedAt,
+		ResolvedAt: report.ResolvedAt,
+	}
+	if report.ReportedUserID != nil {
+		res.ReportedUserID = *report.ReportedUserID
+	}
+	if report.ClaimedBy != nil {
+		res.ClaimedBy = *report.ClaimedBy
+	}
+	if report.Resolution != nil {
+		res.Resolution = entity.ReportResolution(*report.Resolution)
+	}
+	return res
+}
@@ -14,9 +14,11 @@ func FromDomainToTweetModel(tweet *entity.Tweet) *models.Tweet {
 		ID:            tweet.ID,
 		UserID:        tweet.Author.ID,
 		ParentTweetID: tweet.ParentTweetID,
+		QuotedTweetID: tweet.QuotedTweetID,
 		Content:       tweet.Content,
 		CreatedAt:     tweet.CreatedAt,
 		UpdatedAt:     tweet.UpdatedAt,
+		RevisionCount: tweet.RevisionCount,
 	}
 }
 
@@ -25,16 +27,22 @@ func FromTweetModelToDomain(tweet *models.Tweet) *entity.Tweet {
 		return nil
 	}
 
-	return &entity.Tweet{
+	res := &entity.Tweet{
 		ID:            tweet.ID,
 		ParentTweetID: tweet.ParentTweetID,
+		QuotedTweetID: tweet.QuotedTweetID,
 		Content:       tweet.Content,
 		CreatedAt:     tweet.CreatedAt,
 		UpdatedAt:     tweet.UpdatedAt,
+		RevisionCount: tweet.RevisionCount,
 		Author: &entity.SmallUser{
 			ID: tweet.UserID,
 		},
 	}
+	if tweet.RetweetedBy != nil {
+		res.RetweetedBy = &entity.SmallUser{ID: *tweet.RetweetedBy}
+	}
+	return res
 }
 
 func FromTweetModelToDomainList(tweetsModels []models.Tweet) []entity.Tweet {
@@ -45,6 +53,28 @@ func FromTweetModelToDomainList(tweetsModels []models.Tweet) []entity.Tweet {
 	return tweets
 }
 
+func FromTweetRevisionModelToDomain(revision *models.TweetRevision) *entity.TweetRevision {
+	if revision == nil {
+		return nil
+	}
+
+	return &entity.TweetRevision{
+		ID:         revision.ID,
+		TweetID:    revision.TweetID,
+		Content:    revision.Content,
+		CreatedAt:  revision.CreatedAt,
+		ReplacedAt: revision.ReplacedAt,
+	}
+}
+
+func FromTweetRevisionModelToDomainList(revisionModels []models.TweetRevision) []entity.TweetRevision {
+	revisions := make([]entity.TweetRevision, 0, len(revisionModels))
+	for i := range revisionModels {
+		revisions = append(revisions, *FromTweetRevisionModelToDomain(&revisionModels[i]))
+	}
+	return revisions
+}
+
 func FromDomainToRetweetModel(retweet *entity.Retweet) *models.Retweet {
 	if retweet == nil {
 		return nil
@@ -103,8 +133,10 @@ func FromLikeModelToDomainList(likes []models.Like) []entity.Like {
 
 func FromCountersModelToDomain(counters *models.Counters) *entity.Counters {
 	return &entity.Counters{
-		ReplyCount:   counters.ReplyCount,
-		RetweetCount: counters.RetweetCount,
-		LikeCount:    counters.LikeCount,
+		ReplyCount:    counters.ReplyCount,
+		RetweetCount:  counters.RetweetCount,
+		LikeCount:     counters.LikeCount,
+		BookmarkCount: counters.BookmarkCount,
+		QuoteCount:    counters.QuoteCount,
 	}
 }
@@ -0,0 +1,42 @@
+package conv
+
+import (
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func FromUploadModelToDomain(upload *models.Upload) *entity.Upload {
+	if upload == nil {
+		return nil
+	}
+
+	res := &entity.Upload{
+		ID:          upload.ID,
+		UserID:      upload.UserID,
+		Filename:    upload.Filename,
+		MediaType:   entity.MediaType(upload.MediaType),
+		Size:        upload.SizeBytes,
+		Offset:      upload.Offset,
+		CompletedAt: upload.CompletedAt,
+		CreatedAt:   upload.CreatedAt,
+		ExpiresAt:   upload.ExpiresAt,
+	}
+	if upload.Path != nil {
+		res.Path = *upload.Path
+	}
+	if upload.MimeType != nil {
+		res.MimeType = *upload.MimeType
+	}
+	if upload.StorageUploadID != nil {
+		res.StorageUploadID = *upload.StorageUploadID
+	}
+	return res
+}
+
+func FromUploadModelToDomainList(uploadModels []models.Upload) []entity.Upload {
+	uploads := make([]entity.Upload, 0, len(uploadModels))
+	for i := range uploadModels {
+		uploads = append(uploads, *FromUploadModelToDomain(&uploadModels[i]))
+	}
+	return uploads
+}
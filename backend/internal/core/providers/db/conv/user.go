@@ -17,14 +17,17 @@ func FromDomainToUserModel(user *entity.User) *models.User {
 	}
 
 	return &models.User{
-		ID:          user.ID,
-		Username:    user.Username,
-		Email:       email,
-		Password:    password,
-		Bio:         user.Bio,
-		Gen:         user.Gen,
-		CreatedAt:   user.CreatedAt,
-		IsSuperuser: user.IsSuperuser,
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         email,
+		Password:      password,
+		Bio:           user.Bio,
+		Gen:           user.Gen,
+		CreatedAt:     user.CreatedAt,
+		IsSuperuser:   user.IsSuperuser,
+		IsActive:      user.IsActive,
+		IsPrivate:     user.IsPrivate,
+		EmailVerified: user.EmailVerified,
 	}
 }
 
@@ -34,12 +37,15 @@ func FromUserModelToDomain(user *models.User) *entity.User {
 	}
 
 	return &entity.User{
-		ID:          user.ID,
-		Username:    user.Username,
-		Gen:         user.Gen,
-		Bio:         user.Bio,
-		CreatedAt:   user.CreatedAt,
-		IsSuperuser: user.IsSuperuser,
+		ID:            user.ID,
+		Username:      user.Username,
+		Gen:           user.Gen,
+		Bio:           user.Bio,
+		CreatedAt:     user.CreatedAt,
+		IsSuperuser:   user.IsSuperuser,
+		IsActive:      user.IsActive,
+		IsPrivate:     user.IsPrivate,
+		EmailVerified: user.EmailVerified,
 		Credential: &entity.Credential{
 			Email:    user.Email,
 			Password: user.Password,
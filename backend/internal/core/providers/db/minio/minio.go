@@ -66,6 +66,83 @@ func (s *minioDB) Upload(ctx context.Context, file io.Reader, mediaType entity.M
 	return path, mimeType, nil
 }
 
+// CheckUpload tells whether a file of size bytes may be uploaded as mediaType before any
+// of it is received.
+func (s *minioDB) CheckUpload(mediaType entity.MediaType, filename string, size int64) error {
+	policy, ok := s.mediaPolicies[mediaType]
+	if !ok {
+		return errs.ErrInvalidmediaType
+	}
+	if !s.isValidExtension(strings.ToLower(filepath.Ext(filename)), policy) {
+		return errs.ErrInvalidmediaType
+	}
+	if size > policy.MaxSize {
+		return errs.ErrFileTooLarge
+	}
+	return nil
+}
+
+// StartMultipartUpload starts a multipart upload of a file whose first bytes are head;
+// head decides the mime type of the object the same way Upload does.
+func (s *minioDB) StartMultipartUpload(ctx context.Context, mediaType entity.MediaType, filename string, head []byte) (path string, mimeType string, uploadID string, err error) {
+	policy, ok := s.mediaPolicies[mediaType]
+	if !ok {
+		return "", "", "", fmt.Errorf("unsupported media type: %s", mediaType)
+	}
+	detectedType := http.DetectContentType(head)
+	if !s.isAllowedMimeType(detectedType, policy) {
+		return "", "", "", fmt.Errorf("%w: %s", errs.ErrInvalidmediaType, detectedType)
+	}
+	if policy.ForceMimeType != "" {
+		mimeType = policy.ForceMimeType
+	} else {
+		mimeType = detectedType
+	}
+
+	path = filepath.Join(string(mediaType), uuid.New().String(), filename)
+	uploadID, err = s.core().NewMultipartUpload(ctx, s.config.BucketName, path, minio.PutObjectOptions{
+		ContentType: mimeType,
+	})
+	if err != nil {
+		return "", "", "", err
+	}
+	return path, mimeType, uploadID, nil
+}
+
+// UploadPart streams one part of a multipart upload to the bucket.
+func (s *minioDB) UploadPart(ctx context.Context, path, uploadID string, partNumber int, data io.Reader, size int64) error {
+	_, err := s.core().PutObjectPart(ctx, s.config.BucketName, path, uploadID, partNumber, data, size, minio.PutObjectPartOptions{})
+	return err
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the object at path.
+func (s *minioDB) CompleteMultipartUpload(ctx context.Context, path, uploadID string) error {
+	core := s.core()
+	var parts []minio.CompletePart
+	marker := 0
+	for {
+		result, err := core.ListObjectParts(ctx, s.config.BucketName, path, uploadID, marker, 1000)
+		if err != nil {
+			return err
+		}
+		for _, part := range result.ObjectParts {
+			parts = append(parts, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+		}
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.NextPartNumberMarker
+	}
+
+	_, err := core.CompleteMultipartUpload(ctx, s.config.BucketName, path, uploadID, parts, minio.PutObjectOptions{})
+	return err
+}
+
+// AbortMultipartUpload drops a multipart upload together with its uploaded parts.
+func (s *minioDB) AbortMultipartUpload(ctx context.Context, path, uploadID string) error {
+	return s.core().AbortMultipartUpload(ctx, s.config.BucketName, path, uploadID)
+}
+
 func (s *minioDB) Remove(ctx context.Context, objectPath string) error {
 	return s.client.RemoveObject(
 		ctx,
@@ -83,6 +160,10 @@ func (s *minioDB) GetPresignedURL(ctx context.Context, objectPath string) (strin
 	return url.String(), nil
 }
 
+func (s *minioDB) core() *minio.Core {
+	return &minio.Core{Client: s.client}
+}
+
 func (s *minioDB) readAndValidate(reader io.Reader, ext string, policy entity.MediaPolicy) ([]byte, error) {
 	limitedReader := io.LimitReader(reader, policy.MaxSize+1)
 	data, err := io.ReadAll(limitedReader)
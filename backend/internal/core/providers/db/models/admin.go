@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type AuditEntry struct {
+	ID         int       `db:"id"`
+	AdminID    *int      `db:"admin_id"`
+	Action     string    `db:"action"`
+	TargetType string    `db:"target_type"`
+	TargetID   int       `db:"target_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type Draft struct {
+	ID             int        `db:"id"`
+	UserID         int        `db:"user_id"`
+	Content        string     `db:"content"`
+	ParentTweetID  *int       `db:"parent_tweet_id"`
+	QuotedTweetID  *int       `db:"quoted_tweet_id"`
+	MediaPath      *string    `db:"media_path"`
+	MediaMimeType  *string    `db:"media_mime_type"`
+	MediaSizeBytes *int64     `db:"media_size_bytes"`
+	ScheduledAt    *time.Time `db:"scheduled_at"`
+	Attempts       int        `db:"publish_attempts"`
+	LastError      *string    `db:"last_error"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
@@ -4,9 +4,11 @@ type (
 	TweetMedia struct {
 		ID        int    `db:"id"`
 		TweetID   int    `db:"tweet_id"`
+		Position  int    `db:"position"`
 		Path      string `db:"path"`
 		MimeType  string `db:"mime_type"`
 		SizeBytes int64  `db:"size_bytes"`
+		AltText   string `db:"alt_text"`
 	}
 
 	Avatar struct {
@@ -16,4 +18,12 @@ type (
 		MimeType  string `db:"mime_type"`
 		SizeBytes int64  `db:"size_bytes"`
 	}
+
+	MessageMedia struct {
+		ID        int    `db:"id"`
+		MessageID int    `db:"message_id"`
+		Path      string `db:"path"`
+		MimeType  string `db:"mime_type"`
+		SizeBytes int64  `db:"size_bytes"`
+	}
 )
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type (
+	Conversation struct {
+		ID            int           `db:"id"`
+		CreatorID     sql.NullInt64 `db:"creator_id"`
+		IsGroup       bool          `db:"is_group"`
+		CreatedAt     time.Time     `db:"created_at"`
+		LastMessageAt time.Time     `db:"last_message_at"`
+	}
+
+	Participant struct {
+		ConversationID    int        `db:"conversation_id"`
+		UserID            int        `db:"user_id"`
+		Username          string     `db:"username"`
+		LastReadMessageID *int       `db:"last_read_message_id"`
+		LastReadAt        *time.Time `db:"last_read_at"`
+	}
+
+	DirectMessage struct {
+		ID             int       `db:"id"`
+		ConversationID int       `db:"conversation_id"`
+		SenderID       int       `db:"sender_id"`
+		Content        string    `db:"content"`
+		CreatedAt      time.Time `db:"created_at"`
+	}
+)
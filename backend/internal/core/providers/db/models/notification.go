@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type Notification struct {
+	ID          string    `db:"id"`
+	Type        string    `db:"type"`
+	RecipientID int       `db:"recipient_id"`
+	ActorID     int       `db:"actor_id"`
+	ActorName   string    `db:"actor_name"`
+	TweetID     *int      `db:"tweet_id"`
+	TweetText   *string   `db:"tweet_text"`
+	ReportID    *int      `db:"report_id"`
+	Resolution  *string   `db:"resolution"`
+	IsRead      bool      `db:"is_read"`
+	CreatedAt   time.Time `db:"created_at"`
+	// Seq orders notifications created at the same instant for keyset pagination.
+	Seq int `db:"seq"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type Report struct {
+	ID             int        `db:"id"`
+	ReporterID     int        `db:"reporter_id"`
+	TargetType     string     `db:"target_type"`
+	TargetID       int        `db:"target_id"`
+	ReportedUserID *int       `db:"reported_user_id"`
+	Reason         string     `db:"reason"`
+	Details        string     `db:"details"`
+	Status         string     `db:"status"`
+	ClaimedBy      *int       `db:"claimed_by"`
+	Resolution     *string    `db:"resolution"`
+	CreatedAt      time.Time  `db:"created_at"`
+	ResolvedAt     *time.Time `db:"resolved_at"`
+}
@@ -9,9 +9,20 @@ type (
 		ID            int       `db:"id"`
 		UserID        int       `db:"user_id"`
 		ParentTweetID *int      `db:"parent_tweet_id"`
+		QuotedTweetID *int      `db:"quoted_tweet_id"`
 		Content       string    `db:"content"`
 		CreatedAt     time.Time `db:"created_at"`
 		UpdatedAt     time.Time `db:"updated_at"`
+		RevisionCount int       `db:"revision_count"`
+		RetweetedBy   *int      `db:"retweeted_by"`
+	}
+
+	TweetRevision struct {
+		ID         int       `db:"id"`
+		TweetID    int       `db:"tweet_id"`
+		Content    string    `db:"content"`
+		CreatedAt  time.Time `db:"created_at"`
+		ReplacedAt time.Time `db:"replaced_at"`
 	}
 
 	Retweet struct {
@@ -27,8 +38,10 @@ type (
 	}
 
 	Counters struct {
-		ReplyCount   int
-		RetweetCount int
-		LikeCount    int
+		ReplyCount    int
+		RetweetCount  int
+		LikeCount     int
+		BookmarkCount int
+		QuoteCount    int
 	}
 )
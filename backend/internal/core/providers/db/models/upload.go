@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type Upload struct {
+	ID              int        `db:"id"`
+	UserID          int        `db:"user_id"`
+	Filename        string     `db:"filename"`
+	MediaType       string     `db:"media_type"`
+	SizeBytes       int64      `db:"size_bytes"`
+	Offset          int64      `db:"upload_offset"`
+	Path            *string    `db:"path"`
+	MimeType        *string    `db:"mime_type"`
+	StorageUploadID *string    `db:"storage_upload_id"`
+	CompletedAt     *time.Time `db:"completed_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	ExpiresAt       time.Time  `db:"expires_at"`
+}
@@ -6,15 +6,19 @@ import (
 
 type (
 	User struct {
-		ID          int       `db:"id"`
-		Username    string    `db:"username"`
-		Email       string    `db:"email"`
-		Password    string    `db:"password"`
-		Bio         string    `db:"bio"`
-		Gen         string    `db:"gen"`
-		CreatedAt   time.Time `db:"created_at"`
-		IsActive    bool      `db:"is_active"`
-		IsSuperuser bool      `db:"is_superuser"`
+		ID             int       `db:"id"`
+		Username       string    `db:"username"`
+		Email          string    `db:"email"`
+		Password       string    `db:"password"`
+		Bio            string    `db:"bio"`
+		Gen            string    `db:"gen"`
+		CreatedAt      time.Time `db:"created_at"`
+		UpdatedAt      time.Time `db:"updated_at"`
+		IsActive       bool      `db:"is_active"`
+		IsSuperuser    bool      `db:"is_superuser"`
+		IsPrivate      bool      `db:"is_private"`
+		EmailVerified  bool      `db:"email_verified"`
+		FollowersCount int       `db:"followers_count"`
 	}
 
 	Follow struct {
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+func (pg *PostgresDB) SetUserActive(ctx context.Context, userID int, isActive bool) error {
+	query := fmt.Sprintf("UPDATE %s SET is_active = $1 WHERE id = $2", UserTable)
+	result, err := pg.db.ExecContext(ctx, query, isActive, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrUserNotFound
+	}
+	return pg.Cache.InvalidateUser(ctx, userID)
+}
+
+// GetRecentUsers lists accounts newest signup first.
+func (pg *PostgresDB) GetRecentUsers(ctx context.Context, page *entity.Page) ([]entity.User, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT id, username, email, password, bio, gen, created_at, is_active, is_superuser, is_private, email_verified
+		FROM %s
+		WHERE $1::timestamptz IS NULL OR (created_at, id) < ($1, $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`,
+		UserTable)
+
+	after, afterID, offset := keysetArgs(page)
+	var userModels []models.User
+	if err := pg.db.SelectContext(ctx, &userModels, query, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	users := make([]entity.User, 0, len(userModels))
+	for i := range userModels {
+		users = append(users, *conv.FromUserModelToDomain(&userModels[i]))
+	}
+
+	var next *entity.Cursor
+	if len(userModels) > 0 && len(userModels) == page.Limit {
+		last := userModels[len(userModels)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return users, next, nil
+}
+
+func (pg *PostgresDB) CreateAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (admin_id, action, target_type, target_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		AuditLogTable)
+	_, err := pg.db.ExecContext(ctx, query, entry.AdminID, string(entry.Action), string(entry.TargetType), entry.TargetID, entry.CreatedAt)
+	return err
+}
+
+// GetAuditLog lists admin actions, most recent first.
+func (pg *PostgresDB) GetAuditLog(ctx context.Context, page *entity.Page) ([]entity.AuditEntry, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT id, admin_id, action, target_type, target_id, created_at
+		FROM %s
+		WHERE $1::timestamptz IS NULL OR (created_at, id) < ($1, $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`,
+		AuditLogTable)
+
+	after, afterID, offset := keysetArgs(page)
+	var entryModels []models.AuditEntry
+	if err := pg.db.SelectContext(ctx, &entryModels, query, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	entries := make([]entity.AuditEntry, 0, len(entryModels))
+	for i := range entryModels {
+		entries = append(entries, *conv.FromAuditEntryModelToDomain(&entryModels[i]))
+	}
+
+	var next *entity.Cursor
+	if len(entryModels) > 0 && len(entryModels) == page.Limit {
+		last := entryModels[len(entryModels)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return entries, next, nil
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+// BlockUser stores the block and drops follows and follow requests between the two users
+// in both directions.
+func (pg *PostgresDB) BlockUser(ctx context.Context, blockerID, blockedID int, createdAt time.Time) error {
+	if err := pg.checkUserExists(ctx, blockedID); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		WITH blocked AS (
+			INSERT INTO %s (blocker_id, blocked_id, created_at) VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		), requests AS (
+			DELETE FROM %s
+			WHERE (requester_id = $1 AND target_id = $2) OR (requester_id = $2 AND target_id = $1)
+		)
+		DELETE FROM %s
+		WHERE (follower_id = $1 AND following_id = $2) OR (follower_id = $2 AND following_id = $1)`,
+		BlocksTable, FollowRequestsTable, FollowsTable)
+	_, err := pg.db.ExecContext(ctx, query, blockerID, blockedID, createdAt)
+	return err
+}
+
+func (pg *PostgresDB) UnblockUser(ctx context.Context, blockerID, blockedID int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE blocker_id = $1 AND blocked_id = $2", BlocksTable)
+	_, err := pg.db.ExecContext(ctx, query, blockerID, blockedID)
+	return err
+}
+
+func (pg *PostgresDB) MuteUser(ctx context.Context, muterID, mutedID int, createdAt time.Time) error {
+	if err := pg.checkUserExists(ctx, mutedID); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (muter_id, muted_id, created_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", MutesTable)
+	_, err := pg.db.ExecContext(ctx, query, muterID, mutedID, createdAt)
+	return err
+}
+
+func (pg *PostgresDB) UnmuteUser(ctx context.Context, muterID, mutedID int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE muter_id = $1 AND muted_id = $2", MutesTable)
+	_, err := pg.db.ExecContext(ctx, query, muterID, mutedID)
+	return err
+}
+
+// IsBlockedBetween reports whether either user has blocked the other.
+func (pg *PostgresDB) IsBlockedBetween(ctx context.Context, userID, otherID int) (bool, error) {
+	query := fmt.Sprintf(`
+		SELECT EXISTS(
+			SELECT 1 FROM %s
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)`,
+		BlocksTable)
+
+	var blocked bool
+	if err := pg.db.GetContext(ctx, &blocked, query, userID, otherID); err != nil {
+		return false, err
+	}
+	return blocked, nil
+}
+
+func (pg *PostgresDB) IsMuted(ctx context.Context, muterID, mutedID int) (bool, error) {
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE muter_id = $1 AND muted_id = $2)", MutesTable)
+
+	var muted bool
+	if err := pg.db.GetContext(ctx, &muted, query, muterID, mutedID); err != nil {
+		return false, err
+	}
+	return muted, nil
+}
+
+// GetBlockedUserIDs returns the users hidden from userID by a block in either direction.
+func (pg *PostgresDB) GetBlockedUserIDs(ctx context.Context, userID int) ([]int, error) {
+	query := fmt.Sprintf(`
+		SELECT blocked_id FROM %s WHERE blocker_id = $1
+		UNION
+		SELECT blocker_id FROM %s WHERE blocked_id = $1`,
+		BlocksTable, BlocksTable)
+
+	var ids []int
+	if err := pg.db.SelectContext(ctx, &ids, query, userID); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (pg *PostgresDB) GetMutedUserIDs(ctx context.Context, userID int) ([]int, error) {
+	query := fmt.Sprintf("SELECT muted_id FROM %s WHERE muter_id = $1", MutesTable)
+
+	var ids []int
+	if err := pg.db.SelectContext(ctx, &ids, query, userID); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetBlocksIds lists the users blocked by userID, most recently blocked first.
+func (pg *PostgresDB) GetBlocksIds(ctx context.Context, userID int, page *entity.Page) ([]int, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT blocked_id AS id, created_at
+		FROM %s
+		WHERE blocker_id = $1 AND ($2::timestamptz IS NULL OR (created_at, blocked_id) < ($2, $3))
+		ORDER BY created_at DESC, blocked_id DESC
+		LIMIT $4 OFFSET $5`,
+		BlocksTable)
+
+	return pg.selectRelationIds(ctx, query, userID, page)
+}
+
+// GetMutesIds lists the users muted by userID, most recently muted first.
+func (pg *PostgresDB) GetMutesIds(ctx context.Context, userID int, page *entity.Page) ([]int, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT muted_id AS id, created_at
+		FROM %s
+		WHERE muter_id = $1 AND ($2::timestamptz IS NULL OR (created_at, muted_id) < ($2, $3))
+		ORDER BY created_at DESC, muted_id DESC
+		LIMIT $4 OFFSET $5`,
+		MutesTable)
+
+	return pg.selectRelationIds(ctx, query, userID, page)
+}
+
+func (pg *PostgresDB) selectRelationIds(ctx context.Context, query string, userID int, page *entity.Page) ([]int, *entity.Cursor, error) {
+	type relationRow struct {
+		ID        int       `db:"id"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	after, afterID, offset := keysetArgs(page)
+	var rows []relationRow
+	if err := pg.db.SelectContext(ctx, &rows, query, userID, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	res := make([]int, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, row.ID)
+	}
+
+	var next *entity.Cursor
+	if len(rows) > 0 && len(rows) == page.Limit {
+		last := rows[len(rows)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return res, next, nil
+}
+
+func (pg *PostgresDB) checkUserExists(ctx context.Context, userID int) error {
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)", UserTable)
+	if err := pg.db.QueryRowContext(ctx, query, userID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errs.ErrUserNotFound
+	}
+	return nil
+}
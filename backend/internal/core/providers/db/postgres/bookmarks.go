@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+func (pg *PostgresDB) BookmarkTweet(ctx context.Context, userID, tweetID int, createdAt time.Time) error {
+	var exists bool
+	checkQuery := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)", TweetsTable)
+	if err := pg.db.QueryRowContext(ctx, checkQuery, tweetID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errs.ErrTweetNotFound
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (user_id, tweet_id, created_at) VALUES ($1, $2, $3) ON CONFLICT (user_id, tweet_id) DO NOTHING", BookmarksTable)
+	if _, err := pg.db.ExecContext(ctx, query, userID, tweetID, createdAt); err != nil {
+		return err
+	}
+
+	pg.invalidateCountersAsync(tweetID)
+	return nil
+}
+
+func (pg *PostgresDB) UnbookmarkTweet(ctx context.Context, userID, tweetID int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1 AND tweet_id = $2", BookmarksTable)
+	result, err := pg.db.ExecContext(ctx, query, userID, tweetID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrBookmarkNotFound
+	}
+
+	pg.invalidateCountersAsync(tweetID)
+	return nil
+}
+
+// GetBookmarkedTweets lists the user's bookmarks, most recently saved first.
+// The cursor refers to the bookmark time, not to the tweet's creation time.
+func (pg *PostgresDB) GetBookmarkedTweets(ctx context.Context, userID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT t.id, t.user_id, t.parent_tweet_id, t.quoted_tweet_id, t.content, t.created_at, t.updated_at, t.revision_count, b.created_at AS bookmarked_at
+		FROM %s b
+		JOIN %s t ON b.tweet_id = t.id
+		WHERE b.user_id = $1 AND ($2::timestamptz IS NULL OR (b.created_at, b.tweet_id) < ($2, $3))
+		ORDER BY b.created_at DESC, b.tweet_id DESC
+		LIMIT $4 OFFSET $5`,
+		BookmarksTable, TweetsTable)
+
+	type bookmarkRow struct {
+		models.Tweet
+		BookmarkedAt time.Time `db:"bookmarked_at"`
+	}
+
+	after, afterID, offset := keysetArgs(page)
+	var rows []bookmarkRow
+	if err := pg.db.SelectContext(ctx, &rows, query, userID, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	tweets := make([]entity.Tweet, 0, len(rows))
+	for i := range rows {
+		tweets = append(tweets, *conv.FromTweetModelToDomain(&rows[i].Tweet))
+	}
+
+	var next *entity.Cursor
+	if len(rows) > 0 && len(rows) == page.Limit {
+		last := rows[len(rows)-1]
+		next = &entity.Cursor{CreatedAt: last.BookmarkedAt, ID: last.ID}
+	}
+	return tweets, next, nil
+}
+
+func (pg *PostgresDB) invalidateCountersAsync(tweetID int) {
+	go func() {
+		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := pg.Cache.InvalidateTweetCounters(cntx, tweetID); err != nil {
+			logrus.WithError(err).Warn("invalidate Cached counters failed")
+		}
+	}()
+}
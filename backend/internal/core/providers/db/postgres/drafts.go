@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+const draftColumns = `id, user_id, content, parent_tweet_id, quoted_tweet_id, media_path, media_mime_type,
+	media_size_bytes, scheduled_at, publish_attempts, last_error, created_at, updated_at`
+
+func (pg *PostgresDB) CreateDraft(ctx context.Context, draft *entity.Draft) (*entity.Draft, error) {
+	draftModel := conv.FromDomainToDraftModel(draft)
+	if draftModel == nil {
+		return nil, fmt.Errorf("cannot convert nil entity to DB model")
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (user_id, content, parent_tweet_id, quoted_tweet_id, media_path, media_mime_type,
+			media_size_bytes, scheduled_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING %s`,
+		DraftsTable, draftColumns)
+
+	var created models.Draft
+	if err := pg.db.GetContext(ctx, &created, query,
+		draftModel.UserID, draftModel.Content, draftModel.ParentTweetID, draftModel.QuotedTweetID, draftModel.MediaPath,
+		draftModel.MediaMimeType, draftModel.MediaSizeBytes, draftModel.ScheduledAt, draftModel.CreatedAt, draftModel.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return conv.FromDraftModelToDomain(&created), nil
+}
+
+func (pg *PostgresDB) GetDraftByID(ctx context.Context, userID, draftID int) (*entity.Draft, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 AND user_id = $2", draftColumns, DraftsTable)
+
+	var draftModel models.Draft
+	if err := pg.db.GetContext(ctx, &draftModel, query, draftID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return conv.FromDraftModelToDomain(&draftModel), nil
+}
+
+// GetDrafts lists the drafts of userID, newest first.
+func (pg *PostgresDB) GetDrafts(ctx context.Context, userID int, page *entity.Page) ([]entity.Draft, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE user_id = $1 AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4 OFFSET $5`,
+		draftColumns, DraftsTable)
+
+	after, afterID, offset := keysetArgs(page)
+	var draftModels []models.Draft
+	if err := pg.db.SelectContext(ctx, &draftModels, query, userID, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	drafts := make([]entity.Draft, 0, len(draftModels))
+	for i := range draftModels {
+		drafts = append(drafts, *conv.FromDraftModelToDomain(&draftModels[i]))
+	}
+
+	var next *entity.Cursor
+	if len(draftModels) > 0 && len(draftModels) == page.Limit {
+		last := draftModels[len(draftModels)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return drafts, next, nil
+}
+
+// UpdateDraft replaces the content, references and media of a draft owned by draft.UserID.
+func (pg *PostgresDB) UpdateDraft(ctx context.Context, draft *entity.Draft) (*entity.Draft, error) {
+	draftModel := conv.FromDomainToDraftModel(draft)
+	if draftModel == nil {
+		return nil, fmt.Errorf("cannot convert nil entity to DB model")
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s SET content = $3, parent_tweet_id = $4, quoted_tweet_id = $5, media_path = $6,
+			media_mime_type = $7, media_size_bytes = $8, updated_at = $9
+		WHERE id = $1 AND user_id = $2
+		RETURNING %s`,
+		DraftsTable, draftColumns)
+
+	var updated models.Draft
+	if err := pg.db.GetContext(ctx, &updated, query,
+		draftModel.ID, draftModel.UserID, draftModel.Content, draftModel.ParentTweetID, draftModel.QuotedTweetID,
+		draftModel.MediaPath, draftModel.MediaMimeType, draftModel.MediaSizeBytes, draftModel.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return conv.FromDraftModelToDomain(&updated), nil
+}
+
+// ScheduleDraft sets when a draft is published, or unschedules it when scheduledAt is nil.
+// Publishing starts over, so earlier failures are forgotten.
+func (pg *PostgresDB) ScheduleDraft(ctx context.Context, userID, draftID int, scheduledAt *time.Time) (*entity.Draft, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET scheduled_at = $3, locked_until = NULL, publish_attempts = 0, last_error = NULL
+		WHERE id = $1 AND user_id = $2
+		RETURNING %s`,
+		DraftsTable, draftColumns)
+
+	var updated models.Draft
+	if err := pg.db.GetContext(ctx, &updated, query, draftID, userID, scheduledAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return conv.FromDraftModelToDomain(&updated), nil
+}
+
+// DeleteDraft removes a draft and returns it, so that its media can be cleaned up.
+func (pg *PostgresDB) DeleteDraft(ctx context.Context, userID, draftID int) (*entity.Draft, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING %s", DraftsTable, draftColumns)
+
+	var deleted models.Draft
+	if err := pg.db.GetContext(ctx, &deleted, query, draftID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return conv.FromDraftModelToDomain(&deleted), nil
+}
+
+// DeleteDraftTx consumes a draft in the transaction that publishes it. A concurrent
+// publisher blocks on the row until that transaction ends and then finds it gone.
+func (pg *PostgresDB) DeleteDraftTx(ctx context.Context, tx *sql.Tx, userID, draftID int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", DraftsTable)
+
+	result, err := tx.ExecContext(ctx, query, draftID, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrDraftNotFound
+	}
+	return nil
+}
+
+// ClaimDueDrafts leases up to limit drafts scheduled before now until lockedUntil and
+// counts a publish attempt for each. Drafts leased by another replica are skipped
+// until their lease runs out.
+func (pg *PostgresDB) ClaimDueDrafts(ctx context.Context, now, lockedUntil time.Time, limit int) ([]entity.Draft, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET locked_until = $2, publish_attempts = publish_attempts + 1
+		WHERE id IN (
+			SELECT id FROM %s
+			WHERE scheduled_at <= $1 AND (locked_until IS NULL OR locked_until <= $1)
+			ORDER BY scheduled_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED)
+		RETURNING %s`,
+		DraftsTable, DraftsTable, draftColumns)
+
+	var draftModels []models.Draft
+	if err := pg.db.SelectContext(ctx, &draftModels, query, now, lockedUntil, limit); err != nil {
+		return nil, err
+	}
+
+	drafts := make([]entity.Draft, 0, len(draftModels))
+	for i := range draftModels {
+		drafts = append(drafts, *conv.FromDraftModelToDomain(&draftModels[i]))
+	}
+	return drafts, nil
+}
+
+// FailDraftPublish records why publishing a draft failed. A draft that is not
+// unscheduled stays leased and is retried once the lease runs out.
+func (pg *PostgresDB) FailDraftPublish(ctx context.Context, draftID int, reason string, unschedule bool) error {
+	query := fmt.Sprintf(`
+		UPDATE %s SET last_error = $2,
+			scheduled_at = CASE WHEN $3 THEN NULL ELSE scheduled_at END,
+			locked_until = CASE WHEN $3 THEN NULL ELSE locked_until END
+		WHERE id = $1`,
+		DraftsTable)
+
+	_, err := pg.db.ExecContext(ctx, query, draftID, reason, unschedule)
+	return err
+}
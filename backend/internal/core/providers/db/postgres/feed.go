@@ -7,45 +7,25 @@ import (
 	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
 	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
-	"github.com/lib/pq"
 )
 
-func (s *PostgresDB) GetFeedByAuthorsIds(ctx context.Context, userIDs []int, limit, offset int) ([]entity.Tweet, error) {
+func (pg *PostgresDB) GetAllTweets(ctx context.Context, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
 	query := fmt.Sprintf(`
-        SELECT id, user_id, parent_tweet_id, content, created_at, updated_at
+        SELECT id, user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at, revision_count 
         FROM %s
-        WHERE user_id = ANY($1)
-		UNION ALL
-		SELECT t.id, t.user_id, t.parent_tweet_id, t.content, r.created_at, t.updated_at
-		FROM %s r
-		JOIN %s t ON r.tweet_id = t.id
-		WHERE r.user_id = ANY($1)
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3`,
-		TweetsTable, RetweetsTable, TweetsTable)
-
-	var tweetModels []models.Tweet
-	if err := s.db.SelectContext(ctx, &tweetModels, query, pq.Array(userIDs), limit, offset); err != nil {
-		return nil, err
-	}
-	return conv.FromTweetModelToDomainList(tweetModels), nil
-}
-
-func (pg *PostgresDB) GetAllTweets(ctx context.Context, limit, offset int) ([]entity.Tweet, error) {
-	query := fmt.Sprintf(`
-        SELECT id, user_id, parent_tweet_id, content, created_at, updated_at 
-        FROM %s
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2`,
+        WHERE $1::timestamptz IS NULL OR (created_at, id) < ($1, $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`,
 		TweetsTable)
 
 	var tweetModels []models.Tweet
 
-	err := pg.db.SelectContext(ctx, &tweetModels, query, limit, offset)
+	after, afterID, offset := keysetArgs(page)
+	err := pg.db.SelectContext(ctx, &tweetModels, query, after, afterID, page.Limit, offset)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return conv.FromTweetModelToDomainList(tweetModels), nil
+	return conv.FromTweetModelToDomainList(tweetModels), nextTweetsCursor(tweetModels, page.Limit), nil
 }
@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// GetUsersMapByIDs resolves users from the cache with a single MGET and loads the misses in one query.
+func (pg *PostgresDB) GetUsersMapByIDs(ctx context.Context, ids []int) (map[int]*entity.User, error) {
+	result := make(map[int]*entity.User, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	cached, err := pg.Cache.MGetUsers(ctx, ids)
+	if err != nil {
+		logrus.WithError(err).Warn("mget users from Cache failed")
+	}
+	missing := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if model, ok := cached[id]; ok {
+			result[id] = conv.FromUserModelToDomain(model)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	query := fmt.Sprintf(`
+			SELECT id, username, email, password, bio, gen, created_at, is_active, is_superuser, is_private, email_verified
+			FROM %s
+			WHERE id = ANY($1)`,
+		UserTable)
+
+	var userModels []models.User
+	if err := pg.db.SelectContext(ctx, &userModels, query, pq.Array(missing)); err != nil {
+		return nil, err
+	}
+	for i := range userModels {
+		result[userModels[i].ID] = conv.FromUserModelToDomain(&userModels[i])
+	}
+
+	go func(userModels []models.User) {
+		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for i := range userModels {
+			if err := pg.Cache.SetUser(cntx, &userModels[i]); err != nil {
+				logrus.WithError(err).WithField("user_id", userModels[i].ID).Warn("failed to set user in Cache")
+			}
+		}
+	}(userModels)
+
+	return result, nil
+}
+
+// GetCountsByTweetIDs resolves counters from the cache with a single MGET and aggregates the misses in one query.
+func (pg *PostgresDB) GetCountsByTweetIDs(ctx context.Context, tweetIDs []int) (map[int]*entity.Counters, error) {
+	result := make(map[int]*entity.Counters, len(tweetIDs))
+	if len(tweetIDs) == 0 {
+		return result, nil
+	}
+
+	cached, err := pg.Cache.MGetTweetCounters(ctx, tweetIDs)
+	if err != nil {
+		logrus.WithError(err).Warn("mget tweet counters from Cache failed")
+	}
+	missing := make([]int, 0, len(tweetIDs))
+	for _, id := range tweetIDs {
+		if model, ok := cached[id]; ok {
+			result[id] = conv.FromCountersModelToDomain(model)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT ids.id,
+			(SELECT COUNT(*) FROM %s WHERE tweet_id = ids.id) AS likes,
+			(SELECT COUNT(*) FROM %s WHERE tweet_id = ids.id) AS retweets,
+			(SELECT COUNT(*) FROM %s WHERE parent_tweet_id = ids.id) AS replies,
+			(SELECT COUNT(*) FROM %s WHERE tweet_id = ids.id) AS bookmarks,
+			(SELECT COUNT(*) FROM %s WHERE quoted_tweet_id = ids.id) AS quotes
+		FROM UNNEST($1::int[]) AS ids(id)`,
+		LikesTable, RetweetsTable, TweetsTable, BookmarksTable, TweetsTable)
+
+	type countersRow struct {
+		ID        int `db:"id"`
+		Likes     int `db:"likes"`
+		Retweets  int `db:"retweets"`
+		Replies   int `db:"replies"`
+		Bookmarks int `db:"bookmarks"`
+		Quotes    int `db:"quotes"`
+	}
+
+	var rows []countersRow
+	if err := pg.db.SelectContext(ctx, &rows, query, pq.Array(missing)); err != nil {
+		return nil, err
+	}
+
+	loaded := make(map[int]*models.Counters, len(rows))
+	for _, row := range rows {
+		model := &models.Counters{
+			LikeCount:     row.Likes,
+			RetweetCount:  row.Retweets,
+			ReplyCount:    row.Replies,
+			BookmarkCount: row.Bookmarks,
+			QuoteCount:    row.Quotes,
+		}
+		loaded[row.ID] = model
+		result[row.ID] = conv.FromCountersModelToDomain(model)
+	}
+
+	go func() {
+		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := pg.Cache.MSetTweetCounters(cntx, loaded); err != nil {
+			logrus.WithError(err).Warn("set tweet counters to Cache failed")
+		}
+	}()
+
+	return result, nil
+}
+
+// GetViewerStates reports in one query which of the tweets the viewer has liked, retweeted or bookmarked.
+func (pg *PostgresDB) GetViewerStates(ctx context.Context, viewerID int, tweetIDs []int) (map[int]*entity.ViewerState, error) {
+	result := make(map[int]*entity.ViewerState, len(tweetIDs))
+	if len(tweetIDs) == 0 {
+		return result, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT ids.id,
+			EXISTS(SELECT 1 FROM %s WHERE user_id = $1 AND tweet_id = ids.id) AS liked,
+			EXISTS(SELECT 1 FROM %s WHERE user_id = $1 AND tweet_id = ids.id) AS retweeted,
+			EXISTS(SELECT 1 FROM %s WHERE user_id = $1 AND tweet_id = ids.id) AS bookmarked
+		FROM UNNEST($2::int[]) AS ids(id)`,
+		LikesTable, RetweetsTable, BookmarksTable)
+
+	type viewerRow struct {
+		ID         int  `db:"id"`
+		Liked      bool `db:"liked"`
+		Retweeted  bool `db:"retweeted"`
+		Bookmarked bool `db:"bookmarked"`
+	}
+
+	var rows []viewerRow
+	if err := pg.db.SelectContext(ctx, &rows, query, viewerID, pq.Array(tweetIDs)); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.ID] = &entity.ViewerState{
+			Liked:      row.Liked,
+			Retweeted:  row.Retweeted,
+			Bookmarked: row.Bookmarked,
+		}
+	}
+	return result, nil
+}
+
+// GetMediaByTweetIDs returns attachments ordered by position and keyed by tweet id;
+// tweets without media are absent.
+func (pg *PostgresDB) GetMediaByTweetIDs(ctx context.Context, tweetIDs []int) (map[int][]entity.TweetMedia, error) {
+	result := make(map[int][]entity.TweetMedia, len(tweetIDs))
+	if len(tweetIDs) == 0 {
+		return result, nil
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE tweet_id = ANY($1) ORDER BY tweet_id, position", TweetMediaTable)
+
+	var mediaModels []models.TweetMedia
+	if err := pg.db.SelectContext(ctx, &mediaModels, query, pq.Array(tweetIDs)); err != nil {
+		return nil, err
+	}
+	for _, media := range conv.FromTweetMediaModelToDomainList(mediaModels) {
+		result[media.TweetID] = append(result[media.TweetID], media)
+	}
+	return result, nil
+}
+
+// GetAvatarPathsByUserIDs returns object paths keyed by user id; users without an avatar are absent.
+func (pg *PostgresDB) GetAvatarPathsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error) {
+	return pg.selectPathsByOwner(ctx, AvatarsTable, "user_id", userIDs)
+}
+
+func (pg *PostgresDB) selectPathsByOwner(ctx context.Context, table, ownerColumn string, ids []int) (map[int]string, error) {
+	result := make(map[int]string, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	query := fmt.Sprintf("SELECT %s AS owner_id, path FROM %s WHERE %s = ANY($1)", ownerColumn, table, ownerColumn)
+
+	type pathRow struct {
+		OwnerID int    `db:"owner_id"`
+		Path    string `db:"path"`
+	}
+
+	var rows []pathRow
+	if err := pg.db.SelectContext(ctx, &rows, query, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.OwnerID] = row.Path
+	}
+	return result, nil
+}
@@ -10,6 +10,7 @@ type (
 	cache interface {
 		SetUser(ctx context.Context, user *models.User) error
 		GetUserByID(ctx context.Context, id int) (*models.User, error)
+		MGetUsers(ctx context.Context, ids []int) (map[int]*models.User, error)
 		GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 		GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 		ExistsByUsername(ctx context.Context, username string) (bool, error)
@@ -35,6 +36,8 @@ type (
 
 		SetTweetCounters(ctx context.Context, tweetID int, counters *models.Counters) error
 		GetTweetCounters(ctx context.Context, tweetID int) (*models.Counters, error)
+		MGetTweetCounters(ctx context.Context, tweetIDs []int) (map[int]*models.Counters, error)
+		MSetTweetCounters(ctx context.Context, counters map[int]*models.Counters) error
 		InvalidateTweetCounters(ctx context.Context, tweetID int) error
 	}
 )
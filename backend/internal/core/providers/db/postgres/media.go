@@ -12,80 +12,119 @@ import (
 	"github.com/kust1q/Zapp/backend/internal/errs"
 )
 
-// UpsertByTweetIdTx
-func (pg *PostgresDB) UpsertByTweetIdTx(ctx context.Context, tx *sql.Tx, media *entity.TweetMedia) (*entity.TweetMedia, error) {
+// InsertTweetMediaTx attaches one media to a tweet at the given position.
+func (pg *PostgresDB) InsertTweetMediaTx(ctx context.Context, tx *sql.Tx, media *entity.TweetMedia) (*entity.TweetMedia, error) {
 	mediaModel := conv.FromDomainToTweetMediaModel(media)
 	if mediaModel == nil {
 		return nil, fmt.Errorf("cannot convert nil entity to DB model")
 	}
 
 	query := fmt.Sprintf(`
-        INSERT INTO %s (tweet_id, path, mime_type, size_bytes)
-        VALUES ($1, $2, $3, $4)
-        ON CONFLICT (tweet_id) DO UPDATE
-        SET path = EXCLUDED.path,
-            mime_type = EXCLUDED.mime_type,
-            size_bytes = EXCLUDED.size_bytes
+        INSERT INTO %s (tweet_id, position, path, mime_type, size_bytes, alt_text)
+        VALUES ($1, $2, $3, $4, $5, $6)
         RETURNING id
     `, TweetMediaTable)
 
 	var id int
-	if err := tx.QueryRowContext(ctx, query, mediaModel.TweetID, mediaModel.Path, mediaModel.MimeType, mediaModel.SizeBytes).Scan(&id); err != nil {
+	if err := tx.QueryRowContext(ctx, query, mediaModel.TweetID, mediaModel.Position, mediaModel.Path, mediaModel.MimeType, mediaModel.SizeBytes, mediaModel.AltText).Scan(&id); err != nil {
 		return nil, err
 	}
 
 	mediaModel.ID = id
-	updatedMedia := conv.FromTweetMediaModelToDomain(mediaModel)
+	insertedMedia := conv.FromTweetMediaModelToDomain(mediaModel)
 
-	return updatedMedia, nil
+	return insertedMedia, nil
 }
 
-func (pg *PostgresDB) GetMediaPathByTweetID(ctx context.Context, tweetID int) (string, error) {
-	query := fmt.Sprintf("SELECT path FROM %s WHERE tweet_id = $1", TweetMediaTable)
-	var path string
-	if err := pg.db.GetContext(ctx, &path, query, tweetID); err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return "", err
-	}
-	return path, nil
-}
-
-func (pg *PostgresDB) GetMediaDataByTweetID(ctx context.Context, tweetID int) (*entity.TweetMedia, error) {
-	query := fmt.Sprintf("SELECT * FROM %s WHERE tweet_id = $1", TweetMediaTable)
-	var mediaModel models.TweetMedia
-	if err := pg.db.GetContext(ctx, &mediaModel, query, tweetID); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errs.ErrTweetMediaNotFound
-		}
+// GetMediaByTweetID returns the attachments of a tweet ordered by position.
+func (pg *PostgresDB) GetMediaByTweetID(ctx context.Context, tweetID int) ([]entity.TweetMedia, error) {
+	query := fmt.Sprintf("SELECT * FROM %s WHERE tweet_id = $1 ORDER BY position", TweetMediaTable)
+	var mediaModels []models.TweetMedia
+	if err := pg.db.SelectContext(ctx, &mediaModels, query, tweetID); err != nil {
 		return nil, err
 	}
-
-	media := conv.FromTweetMediaModelToDomain(&mediaModel)
-	return media, nil
+	return conv.FromTweetMediaModelToDomainList(mediaModels), nil
 }
 
-func (pg *PostgresDB) DeleteMediaByTweetID(ctx context.Context, tweetID, userID int) error {
+// DeleteMediaByTweetID removes every attachment of a tweet of the given author and
+// returns the object paths of the removed media.
+func (pg *PostgresDB) DeleteMediaByTweetID(ctx context.Context, tweetID, userID int) ([]string, error) {
 	query := fmt.Sprintf(`
         DELETE FROM %s m
         WHERE m.tweet_id = $1 
         AND EXISTS (
             SELECT 1 FROM %s t 
-            WHERE t.id = m.tweet_id AND t.user_id = $2)`,
+            WHERE t.id = m.tweet_id AND t.user_id = $2)
+        RETURNING m.path`,
 		TweetMediaTable,
 		TweetsTable)
 
-	result, err := pg.db.ExecContext(ctx, query, tweetID, userID)
-	if err != nil {
-		return err
+	var paths []string
+	if err := pg.db.SelectContext(ctx, &paths, query, tweetID, userID); err != nil {
+		return nil, err
 	}
-	rowsAffected, err := result.RowsAffected()
+	if len(paths) == 0 {
+		return nil, errs.ErrTweetMediaNotFound
+	}
+
+	return paths, nil
+}
+
+// DeleteMediaByTweetIDTx removes every attachment of a tweet within tx and returns the
+// object paths of the removed media; a tweet without media yields no paths.
+func (pg *PostgresDB) DeleteMediaByTweetIDTx(ctx context.Context, tx *sql.Tx, tweetID int) ([]string, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE tweet_id = $1 RETURNING path", TweetMediaTable)
+	rows, err := tx.QueryContext(ctx, query, tweetID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if rowsAffected == 0 {
-		return errs.ErrTweetMediaNotFound
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
 	}
+	return paths, rows.Err()
+}
 
-	return nil
+// DeleteMediaItem removes a single attachment of a tweet of the given author and returns
+// its object path.
+func (pg *PostgresDB) DeleteMediaItem(ctx context.Context, tweetID, mediaID, userID int) (string, error) {
+	query := fmt.Sprintf(`
+        DELETE FROM %s m
+        WHERE m.id = $1 AND m.tweet_id = $2
+        AND EXISTS (
+            SELECT 1 FROM %s t
+            WHERE t.id = m.tweet_id AND t.user_id = $3)
+        RETURNING m.path`,
+		TweetMediaTable,
+		TweetsTable)
+
+	var path string
+	if err := pg.db.GetContext(ctx, &path, query, mediaID, tweetID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.ErrTweetMediaNotFound
+		}
+		return "", err
+	}
+	return path, nil
+}
+
+// ForceDeleteMediaByTweetID removes tweet media regardless of the tweet author.
+func (pg *PostgresDB) ForceDeleteMediaByTweetID(ctx context.Context, tweetID int) ([]string, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE tweet_id = $1 RETURNING path", TweetMediaTable)
+	var paths []string
+	if err := pg.db.SelectContext(ctx, &paths, query, tweetID); err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, errs.ErrTweetMediaNotFound
+	}
+	return paths, nil
 }
 
 func (pg *PostgresDB) UploadAvatarTx(ctx context.Context, tx *sql.Tx, avatar *entity.Avatar) (*entity.Avatar, error) {
@@ -144,7 +183,15 @@ func (pg *PostgresDB) DeleteAvatarByUserID(ctx context.Context, userID int) erro
 }
 
 func (pg *PostgresDB) GetMediaUrlsByUserID(ctx context.Context, userID int) ([]string, error) {
-	query := fmt.Sprintf("SELECT tm.path FROM %s tm JOIN %s t ON tm.tweet_id = t.id WHERE t.user_id = $1", TweetMediaTable, TweetsTable)
+	query := fmt.Sprintf(`
+		SELECT tm.path FROM %s tm JOIN %s t ON tm.tweet_id = t.id WHERE t.user_id = $1
+		UNION ALL
+		SELECT mm.path FROM %s mm JOIN %s m ON mm.message_id = m.id WHERE m.sender_id = $1
+		UNION ALL
+		SELECT d.media_path FROM %s d WHERE d.user_id = $1 AND d.media_path IS NOT NULL
+		UNION ALL
+		SELECT u.path FROM %s u WHERE u.user_id = $1 AND u.completed_at IS NOT NULL`,
+		TweetMediaTable, TweetsTable, MessageMediaTable, MessagesTable, DraftsTable, MediaUploadsTable)
 
 	var urls []string
 	if err := pg.db.SelectContext(ctx, &urls, query, userID); err != nil {
@@ -0,0 +1,341 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/lib/pq"
+)
+
+// AreMutualFollowers reports whether userID and every user of otherIDs follow each other.
+func (pg *PostgresDB) AreMutualFollowers(ctx context.Context, userID int, otherIDs []int) (bool, error) {
+	query := fmt.Sprintf(`
+		SELECT COUNT(DISTINCT a.following_id)
+		FROM %s a
+		JOIN %s b ON b.follower_id = a.following_id AND b.following_id = a.follower_id
+		WHERE a.follower_id = $1 AND a.following_id = ANY($2)`,
+		FollowsTable, FollowsTable)
+
+	var count int
+	if err := pg.db.GetContext(ctx, &count, query, userID, pq.Array(otherIDs)); err != nil {
+		return false, err
+	}
+	return count == len(otherIDs), nil
+}
+
+// GetOrCreateDirectConversation returns the one-to-one conversation between userID and
+// otherID, creating it when there is none. The pair is a unique key of direct
+// conversations, so concurrent requests for the same pair end up in one conversation.
+func (pg *PostgresDB) GetOrCreateDirectConversation(ctx context.Context, userID, otherID int, createdAt time.Time) (*entity.Conversation, error) {
+	directKey := fmt.Sprintf("%d:%d", min(userID, otherID), max(userID, otherID))
+
+	query := fmt.Sprintf(`
+		WITH created AS (
+			INSERT INTO %s (creator_id, is_group, direct_key, created_at, last_message_at)
+			VALUES ($1, FALSE, $2, $3, $3)
+			ON CONFLICT (direct_key) WHERE NOT is_group DO NOTHING
+			RETURNING id
+		), joined AS (
+			INSERT INTO %s (conversation_id, user_id, joined_at)
+			SELECT created.id, UNNEST($4::int[]), $3 FROM created
+		)
+		SELECT id FROM created`,
+		ConversationsTable, ParticipantsTable)
+
+	var id int
+	err := pg.db.GetContext(ctx, &id, query, userID, directKey, createdAt, pq.Array([]int{userID, otherID}))
+	if err == nil {
+		return &entity.Conversation{
+			ID:            id,
+			CreatorID:     userID,
+			CreatedAt:     createdAt,
+			LastMessageAt: createdAt,
+		}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	query = fmt.Sprintf(`
+		SELECT id, creator_id, is_group, created_at, last_message_at
+		FROM %s
+		WHERE direct_key = $1 AND NOT is_group`,
+		ConversationsTable)
+
+	var model models.Conversation
+	if err := pg.db.GetContext(ctx, &model, query, directKey); err != nil {
+		return nil, err
+	}
+	return conv.FromConversationModelToDomain(&model), nil
+}
+
+// CreateGroupConversation stores a group conversation of creatorID with participantIDs in a single statement.
+func (pg *PostgresDB) CreateGroupConversation(ctx context.Context, creatorID int, participantIDs []int, createdAt time.Time) (*entity.Conversation, error) {
+	query := fmt.Sprintf(`
+		WITH created AS (
+			INSERT INTO %s (creator_id, is_group, created_at, last_message_at)
+			VALUES ($1, TRUE, $2, $2)
+			RETURNING id
+		), joined AS (
+			INSERT INTO %s (conversation_id, user_id, joined_at)
+			SELECT created.id, UNNEST($3::int[]), $2 FROM created
+		)
+		SELECT id FROM created`,
+		ConversationsTable, ParticipantsTable)
+
+	members := append([]int{creatorID}, participantIDs...)
+	var id int
+	if err := pg.db.GetContext(ctx, &id, query, creatorID, createdAt, pq.Array(members)); err != nil {
+		return nil, err
+	}
+
+	return &entity.Conversation{
+		ID:            id,
+		CreatorID:     creatorID,
+		IsGroup:       true,
+		CreatedAt:     createdAt,
+		LastMessageAt: createdAt,
+	}, nil
+}
+
+// GetConversationForUser returns the conversation only if userID takes part in it,
+// so outsiders cannot tell a foreign conversation from a missing one.
+func (pg *PostgresDB) GetConversationForUser(ctx context.Context, conversationID, userID int) (*entity.Conversation, error) {
+	query := fmt.Sprintf(`
+		SELECT c.id, c.creator_id, c.is_group, c.created_at, c.last_message_at
+		FROM %s c
+		JOIN %s p ON p.conversation_id = c.id
+		WHERE c.id = $1 AND p.user_id = $2`,
+		ConversationsTable, ParticipantsTable)
+
+	var model models.Conversation
+	if err := pg.db.GetContext(ctx, &model, query, conversationID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrConversationNotFound
+		}
+		return nil, err
+	}
+	return conv.FromConversationModelToDomain(&model), nil
+}
+
+// GetConversationsByUserID lists the conversations of a user, most recently active first,
+// with the last message and the number of messages the user has not read yet.
+// The cursor refers to the time of the last message.
+func (pg *PostgresDB) GetConversationsByUserID(ctx context.Context, userID int, page *entity.Page) ([]entity.Conversation, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT c.id, c.creator_id, c.is_group, c.created_at, c.last_message_at,
+			lm.id AS last_message_id, lm.sender_id AS last_message_sender_id,
+			lm.content AS last_message_content, lm.created_at AS last_message_created_at,
+			(
+				SELECT COUNT(*) FROM %s m
+				WHERE m.conversation_id = c.id AND m.sender_id <> $1
+					AND m.id > COALESCE(p.last_read_message_id, 0)
+			) AS unread_count
+		FROM %s p
+		JOIN %s c ON c.id = p.conversation_id
+		LEFT JOIN LATERAL (
+			SELECT id, sender_id, content, created_at FROM %s
+			WHERE conversation_id = c.id
+			ORDER BY created_at DESC, id DESC
+			LIMIT 1
+		) lm ON TRUE
+		WHERE p.user_id = $1 AND ($2::timestamptz IS NULL OR (c.last_message_at, c.id) < ($2, $3))
+		ORDER BY c.last_message_at DESC, c.id DESC
+		LIMIT $4 OFFSET $5`,
+		MessagesTable, ParticipantsTable, ConversationsTable, MessagesTable)
+
+	type conversationRow struct {
+		models.Conversation
+		LastMessageID        sql.NullInt64  `db:"last_message_id"`
+		LastMessageSenderID  sql.NullInt64  `db:"last_message_sender_id"`
+		LastMessageContent   sql.NullString `db:"last_message_content"`
+		LastMessageCreatedAt sql.NullTime   `db:"last_message_created_at"`
+		UnreadCount          int            `db:"unread_count"`
+	}
+
+	after, afterID, offset := keysetArgs(page)
+	var rows []conversationRow
+	if err := pg.db.SelectContext(ctx, &rows, query, userID, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	conversations := make([]entity.Conversation, 0, len(rows))
+	for i := range rows {
+		conversation := conv.FromConversationModelToDomain(&rows[i].Conversation)
+		conversation.UnreadCount = rows[i].UnreadCount
+		if rows[i].LastMessageID.Valid {
+			conversation.LastMessage = &entity.DirectMessage{
+				ID:             int(rows[i].LastMessageID.Int64),
+				ConversationID: conversation.ID,
+				Sender:         &entity.SmallUser{ID: int(rows[i].LastMessageSenderID.Int64)},
+				Content:        rows[i].LastMessageContent.String,
+				CreatedAt:      rows[i].LastMessageCreatedAt.Time,
+			}
+		}
+		conversations = append(conversations, *conversation)
+	}
+
+	var next *entity.Cursor
+	if len(rows) > 0 && len(rows) == page.Limit {
+		last := rows[len(rows)-1]
+		next = &entity.Cursor{CreatedAt: last.LastMessageAt, ID: last.ID}
+	}
+	return conversations, next, nil
+}
+
+// GetParticipantsByConversationIDs returns the members of several conversations with their read receipts.
+func (pg *PostgresDB) GetParticipantsByConversationIDs(ctx context.Context, conversationIDs []int) (map[int][]entity.Participant, error) {
+	result := make(map[int][]entity.Participant, len(conversationIDs))
+	if len(conversationIDs) == 0 {
+		return result, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.conversation_id, p.user_id, u.username, p.last_read_message_id, p.last_read_at
+		FROM %s p
+		JOIN %s u ON u.id = p.user_id
+		WHERE p.conversation_id = ANY($1)
+		ORDER BY p.conversation_id, p.joined_at, p.user_id`,
+		ParticipantsTable, UserTable)
+
+	var rows []models.Participant
+	if err := pg.db.SelectContext(ctx, &rows, query, pq.Array(conversationIDs)); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		result[rows[i].ConversationID] = append(result[rows[i].ConversationID], *conv.FromParticipantModelToDomain(&rows[i]))
+	}
+	return result, nil
+}
+
+// CreateMessageTx stores a message, moves the conversation to the top of the
+// list and marks the message as read by its sender.
+func (pg *PostgresDB) CreateMessageTx(ctx context.Context, tx *sql.Tx, message *entity.DirectMessage) (*entity.DirectMessage, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (conversation_id, sender_id, content, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		MessagesTable)
+
+	var id int
+	if err := tx.QueryRowContext(ctx, query, message.ConversationID, message.Sender.ID, message.Content, message.CreatedAt).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	query = fmt.Sprintf("UPDATE %s SET last_message_at = $2 WHERE id = $1", ConversationsTable)
+	if _, err := tx.ExecContext(ctx, query, message.ConversationID, message.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	query = fmt.Sprintf(`
+		UPDATE %s SET last_read_message_id = $3, last_read_at = $4
+		WHERE conversation_id = $1 AND user_id = $2`,
+		ParticipantsTable)
+	if _, err := tx.ExecContext(ctx, query, message.ConversationID, message.Sender.ID, id, message.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &entity.DirectMessage{
+		ID:             id,
+		ConversationID: message.ConversationID,
+		Sender:         message.Sender,
+		Content:        message.Content,
+		CreatedAt:      message.CreatedAt,
+	}, nil
+}
+
+// GetMessages lists the messages of a conversation, newest first.
+func (pg *PostgresDB) GetMessages(ctx context.Context, conversationID int, page *entity.Page) ([]entity.DirectMessage, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT id, conversation_id, sender_id, content, created_at
+		FROM %s
+		WHERE conversation_id = $1 AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4 OFFSET $5`,
+		MessagesTable)
+
+	after, afterID, offset := keysetArgs(page)
+	var rows []models.DirectMessage
+	if err := pg.db.SelectContext(ctx, &rows, query, conversationID, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	messages := make([]entity.DirectMessage, 0, len(rows))
+	for i := range rows {
+		messages = append(messages, *conv.FromDirectMessageModelToDomain(&rows[i]))
+	}
+
+	var next *entity.Cursor
+	if len(rows) > 0 && len(rows) == page.Limit {
+		last := rows[len(rows)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return messages, next, nil
+}
+
+// MarkConversationRead moves the read receipt of userID up to messageID, or up to
+// the latest message when messageID is zero. A receipt never moves backwards.
+func (pg *PostgresDB) MarkConversationRead(ctx context.Context, conversationID, userID, messageID int, readAt time.Time) (*entity.ReadReceipt, error) {
+	query := fmt.Sprintf(`
+		WITH target AS (
+			SELECT MAX(id) AS id FROM %s
+			WHERE conversation_id = $1 AND ($3 = 0 OR id <= $3)
+		)
+		UPDATE %s p
+		SET last_read_message_id = GREATEST(COALESCE(p.last_read_message_id, 0), target.id),
+			last_read_at = $4
+		FROM target
+		WHERE p.conversation_id = $1 AND p.user_id = $2 AND target.id IS NOT NULL
+		RETURNING p.last_read_message_id, p.last_read_at`,
+		MessagesTable, ParticipantsTable)
+
+	receipt := &entity.ReadReceipt{
+		ConversationID: conversationID,
+		UserID:         userID,
+	}
+	if err := pg.db.QueryRowContext(ctx, query, conversationID, userID, messageID, readAt).Scan(&receipt.MessageID, &receipt.ReadAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrMessageNotFound
+		}
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// UpsertByMessageIdTx
+func (pg *PostgresDB) UpsertByMessageIdTx(ctx context.Context, tx *sql.Tx, media *entity.MessageMedia) (*entity.MessageMedia, error) {
+	mediaModel := conv.FromDomainToMessageMediaModel(media)
+	if mediaModel == nil {
+		return nil, fmt.Errorf("cannot convert nil entity to DB model")
+	}
+
+	query := fmt.Sprintf(`
+        INSERT INTO %s (message_id, path, mime_type, size_bytes)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (message_id) DO UPDATE
+        SET path = EXCLUDED.path,
+            mime_type = EXCLUDED.mime_type,
+            size_bytes = EXCLUDED.size_bytes
+        RETURNING id
+    `, MessageMediaTable)
+
+	var id int
+	if err := tx.QueryRowContext(ctx, query, mediaModel.MessageID, mediaModel.Path, mediaModel.MimeType, mediaModel.SizeBytes).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	mediaModel.ID = id
+	return conv.FromMessageMediaModelToDomain(mediaModel), nil
+}
+
+// GetMediaPathsByMessageIDs returns object paths keyed by message id; messages without media are absent.
+func (pg *PostgresDB) GetMediaPathsByMessageIDs(ctx context.Context, messageIDs []int) (map[int]string, error) {
+	return pg.selectPathsByOwner(ctx, MessageMediaTable, "message_id", messageIDs)
+}
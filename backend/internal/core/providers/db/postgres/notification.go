@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+func (pg *PostgresDB) CreateNotification(ctx context.Context, notification *entity.Notification) error {
+	notificationModel := conv.FromDomainToNotificationModel(notification)
+	if notificationModel == nil {
+		return fmt.Errorf("cannot convert nil entity to DB model")
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (id, type, recipient_id, actor_id, tweet_id, report_id, is_read, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", NotificationsTable)
+	_, err := pg.db.ExecContext(ctx, query,
+		notificationModel.ID,
+		notificationModel.Type,
+		notificationModel.RecipientID,
+		notificationModel.ActorID,
+		notificationModel.TweetID,
+		notificationModel.ReportID,
+		notificationModel.IsRead,
+		notificationModel.CreatedAt,
+	)
+	return err
+}
+
+// NotificationExists reports whether recipient was already notified of type about tweetID.
+func (pg *PostgresDB) NotificationExists(ctx context.Context, recipientID int, notificationType entity.NotificationType, tweetID int) (bool, error) {
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE recipient_id = $1 AND type = $2 AND tweet_id = $3)", NotificationsTable)
+	var exists bool
+	if err := pg.db.GetContext(ctx, &exists, query, recipientID, string(notificationType), tweetID); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// GetNotificationsByRecipientID lists notifications of recipientID newest first. The
+// cursor of the next page points at (created_at, seq) of the last notification.
+func (pg *PostgresDB) GetNotificationsByRecipientID(ctx context.Context, recipientID int, page *entity.Page) ([]entity.Notification, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT n.id, n.type, n.recipient_id, n.actor_id, u.username AS actor_name, n.tweet_id, t.content AS tweet_text,
+			n.report_id, r.resolution, n.is_read, n.created_at, n.seq
+		FROM %s n
+		JOIN %s u ON u.id = n.actor_id
+		LEFT JOIN %s t ON t.id = n.tweet_id
+		LEFT JOIN %s r ON r.id = n.report_id
+		WHERE n.recipient_id = $1 AND ($2::timestamptz IS NULL OR (n.created_at, n.seq) < ($2, $3))
+		ORDER BY n.created_at DESC, n.seq DESC
+		LIMIT $4 OFFSET $5`,
+		NotificationsTable, UserTable, TweetsTable, ReportsTable)
+
+	after, afterSeq, offset := keysetArgs(page)
+	var notificationModels []models.Notification
+	if err := pg.db.SelectContext(ctx, &notificationModels, query, recipientID, after, afterSeq, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	var next *entity.Cursor
+	if len(notificationModels) > 0 && len(notificationModels) == page.Limit {
+		last := notificationModels[len(notificationModels)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.Seq}
+	}
+	return conv.FromNotificationModelToDomainList(notificationModels), next, nil
+}
+
+func (pg *PostgresDB) GetUnreadNotificationsCount(ctx context.Context, recipientID int) (int, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE recipient_id = $1 AND is_read = FALSE", NotificationsTable)
+	var count int
+	if err := pg.db.GetContext(ctx, &count, query, recipientID); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (pg *PostgresDB) MarkNotificationAsRead(ctx context.Context, recipientID int, notificationID string) error {
+	query := fmt.Sprintf("UPDATE %s SET is_read = TRUE WHERE id = $1 AND recipient_id = $2", NotificationsTable)
+	result, err := pg.db.ExecContext(ctx, query, notificationID, recipientID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (pg *PostgresDB) MarkAllNotificationsAsRead(ctx context.Context, recipientID int) error {
+	query := fmt.Sprintf("UPDATE %s SET is_read = TRUE WHERE recipient_id = $1 AND is_read = FALSE", NotificationsTable)
+	_, err := pg.db.ExecContext(ctx, query, recipientID)
+	return err
+}
+
+func (pg *PostgresDB) DeleteNotification(ctx context.Context, recipientID int, notificationID string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND recipient_id = $2", NotificationsTable)
+	result, err := pg.db.ExecContext(ctx, query, notificationID, recipientID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNotificationNotFound
+	}
+	return nil
+}
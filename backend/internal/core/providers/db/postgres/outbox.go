@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// outboxRelayLockKey identifies the advisory lock held by the replica relaying the outbox.
+const outboxRelayLockKey = 0x6f7574626f78
+
+func (pg *PostgresDB) CreateOutboxEventTx(ctx context.Context, tx *sql.Tx, topic string, event any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("json marshal failed: %w", err)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (topic, payload, created_at) VALUES ($1, $2, $3)", OutboxTable)
+	_, err = tx.ExecContext(ctx, query, topic, payload, time.Now())
+	return err
+}
+
+// TryLockOutboxRelayTx takes the relay lock for the lifetime of tx. It reports false
+// when another relay holds it, so that only one relay publishes at a time.
+func (pg *PostgresDB) TryLockOutboxRelayTx(ctx context.Context, tx *sql.Tx) (bool, error) {
+	var locked bool
+	if err := tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxRelayLockKey).Scan(&locked); err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+// GetPendingOutboxEventsTx returns the oldest unsent events. The caller must hold the relay lock.
+func (pg *PostgresDB) GetPendingOutboxEventsTx(ctx context.Context, tx *sql.Tx, limit int) ([]entity.OutboxEvent, error) {
+	query := fmt.Sprintf(`
+		SELECT id, topic, payload, attempts, created_at
+		FROM %s
+		WHERE sent_at IS NULL
+		ORDER BY id
+		LIMIT $1`,
+		OutboxTable)
+
+	rows, err := tx.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var eventModels []models.OutboxEvent
+	for rows.Next() {
+		var event models.OutboxEvent
+		if err := rows.Scan(&event.ID, &event.Topic, &event.Payload, &event.Attempts, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		eventModels = append(eventModels, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return conv.FromOutboxEventModelToDomainList(eventModels), nil
+}
+
+func (pg *PostgresDB) MarkOutboxEventSentTx(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	query := fmt.Sprintf("UPDATE %s SET sent_at = $1, attempts = attempts + 1, last_error = NULL WHERE id = $2", OutboxTable)
+	_, err := tx.ExecContext(ctx, query, time.Now(), eventID)
+	return err
+}
+
+func (pg *PostgresDB) MarkOutboxEventFailedTx(ctx context.Context, tx *sql.Tx, eventID int64, reason string) error {
+	query := fmt.Sprintf("UPDATE %s SET attempts = attempts + 1, last_error = $1 WHERE id = $2", OutboxTable)
+	_, err := tx.ExecContext(ctx, query, reason, eventID)
+	return err
+}
+
+func (pg *PostgresDB) DeleteSentOutboxEvents(ctx context.Context, sentBefore time.Time) (int64, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE sent_at IS NOT NULL AND sent_at < $1", OutboxTable)
+	result, err := pg.db.ExecContext(ctx, query, sentBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
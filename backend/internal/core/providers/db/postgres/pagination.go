@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// keysetArgs returns the cursor bound and the offset for page. A null bound
+// disables the keyset condition; the offset is dropped once a cursor is set.
+func keysetArgs(page *entity.Page) (sql.NullTime, int, int) {
+	if page.After == nil {
+		return sql.NullTime{}, 0, page.Offset
+	}
+	return sql.NullTime{Time: page.After.CreatedAt, Valid: true}, page.After.ID, 0
+}
+
+// isFirstPage reports whether page is the head of the list, the only page kept in cache.
+func isFirstPage(page *entity.Page) bool {
+	return page.After == nil && page.Offset == 0
+}
+
+// nextTweetsCursor returns the cursor after the last tweet of a full page, or nil when the list is exhausted.
+func nextTweetsCursor(tweetModels []models.Tweet, limit int) *entity.Cursor {
+	if len(tweetModels) == 0 || len(tweetModels) < limit {
+		return nil
+	}
+	last := tweetModels[len(tweetModels)-1]
+	return &entity.Cursor{
+		CreatedAt: last.CreatedAt,
+		ID:        last.ID,
+	}
+}
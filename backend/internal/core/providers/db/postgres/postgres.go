@@ -16,6 +16,24 @@ const (
 	TweetMediaTable     = "tweet_media"
 	SecretQuestionTable = "secret_questions"
 	AvatarsTable        = "avatars"
+	NotificationsTable  = "notifications"
+	OutboxTable         = "outbox"
+	BookmarksTable      = "bookmarks"
+	HashtagsTable       = "hashtags"
+	TweetHashtagsTable  = "tweet_hashtags"
+	TweetMentionsTable  = "tweet_mentions"
+	ConversationsTable  = "conversations"
+	ParticipantsTable   = "conversation_participants"
+	MessagesTable       = "messages"
+	MessageMediaTable   = "message_media"
+	BlocksTable         = "blocks"
+	MutesTable          = "mutes"
+	FollowRequestsTable = "follow_requests"
+	AuditLogTable       = "admin_audit_log"
+	ReportsTable        = "reports"
+	DraftsTable         = "drafts"
+	TweetRevisionsTable = "tweet_revisions"
+	MediaUploadsTable   = "media_uploads"
 )
 
 type PostgresDB struct {
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/lib/pq"
+)
+
+func (pg *PostgresDB) UpdateUserPrivacy(ctx context.Context, userID int, isPrivate bool) error {
+	query := fmt.Sprintf("UPDATE %s SET is_private = $1 WHERE id = $2", UserTable)
+	result, err := pg.db.ExecContext(ctx, query, isPrivate, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrUserNotFound
+	}
+	return pg.Cache.InvalidateUser(ctx, userID)
+}
+
+func (pg *PostgresDB) IsFollowing(ctx context.Context, followerID, followingID int) (bool, error) {
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE follower_id = $1 AND following_id = $2)", FollowsTable)
+
+	var following bool
+	if err := pg.db.GetContext(ctx, &following, query, followerID, followingID); err != nil {
+		return false, err
+	}
+	return following, nil
+}
+
+// GetFollowedUserIDs returns those of userIDs that followerID follows.
+func (pg *PostgresDB) GetFollowedUserIDs(ctx context.Context, followerID int, userIDs []int) ([]int, error) {
+	if len(userIDs) == 0 {
+		return []int{}, nil
+	}
+
+	query := fmt.Sprintf("SELECT following_id FROM %s WHERE follower_id = $1 AND following_id = ANY($2)", FollowsTable)
+
+	var ids []int
+	if err := pg.db.SelectContext(ctx, &ids, query, followerID, pq.Array(userIDs)); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (pg *PostgresDB) CreateFollowRequest(ctx context.Context, requesterID, targetID int, createdAt time.Time) error {
+	if err := pg.checkUserExists(ctx, targetID); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (requester_id, target_id, created_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", FollowRequestsTable)
+	_, err := pg.db.ExecContext(ctx, query, requesterID, targetID, createdAt)
+	return err
+}
+
+// ApproveFollowRequest turns the pending request of requesterID into a follow of targetID.
+func (pg *PostgresDB) ApproveFollowRequest(ctx context.Context, requesterID, targetID int, createdAt time.Time) (*entity.Follow, error) {
+	query := fmt.Sprintf(`
+		WITH approved AS (
+			DELETE FROM %s WHERE requester_id = $1 AND target_id = $2
+			RETURNING requester_id, target_id
+		), followed AS (
+			INSERT INTO %s (follower_id, following_id, created_at)
+			SELECT requester_id, target_id, $3 FROM approved
+			ON CONFLICT DO NOTHING
+		)
+		SELECT COUNT(*) FROM approved`,
+		FollowRequestsTable, FollowsTable)
+
+	var approved int
+	if err := pg.db.GetContext(ctx, &approved, query, requesterID, targetID, createdAt); err != nil {
+		return nil, err
+	}
+	if approved == 0 {
+		return nil, errs.ErrFollowRequestNotFound
+	}
+	return &entity.Follow{
+		FollowerID:  requesterID,
+		FollowingID: targetID,
+		CreatedAt:   createdAt,
+	}, nil
+}
+
+// ApproveAllFollowRequests turns every pending request to targetID into a follow and
+// returns the ids of the new followers.
+func (pg *PostgresDB) ApproveAllFollowRequests(ctx context.Context, targetID int, createdAt time.Time) ([]int, error) {
+	query := fmt.Sprintf(`
+		WITH approved AS (
+			DELETE FROM %s WHERE target_id = $1
+			RETURNING requester_id
+		)
+		INSERT INTO %s (follower_id, following_id, created_at)
+		SELECT requester_id, $1, $2 FROM approved
+		ON CONFLICT DO NOTHING
+		RETURNING follower_id`,
+		FollowRequestsTable, FollowsTable)
+
+	var ids []int
+	if err := pg.db.SelectContext(ctx, &ids, query, targetID, createdAt); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (pg *PostgresDB) DeleteFollowRequest(ctx context.Context, requesterID, targetID int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE requester_id = $1 AND target_id = $2", FollowRequestsTable)
+	result, err := pg.db.ExecContext(ctx, query, requesterID, targetID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrFollowRequestNotFound
+	}
+	return nil
+}
+
+// GetFollowRequestsIds lists the users waiting for targetID to approve them, newest request first.
+func (pg *PostgresDB) GetFollowRequestsIds(ctx context.Context, targetID int, page *entity.Page) ([]int, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT requester_id AS id, created_at
+		FROM %s
+		WHERE target_id = $1 AND ($2::timestamptz IS NULL OR (created_at, requester_id) < ($2, $3))
+		ORDER BY created_at DESC, requester_id DESC
+		LIMIT $4 OFFSET $5`,
+		FollowRequestsTable)
+
+	return pg.selectRelationIds(ctx, query, targetID, page)
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+const reportColumns = `id, reporter_id, target_type, target_id, reported_user_id, reason, details,
+	status, claimed_by, resolution, created_at, resolved_at`
+
+// CreateReport stores the report unless the reporter already has an unresolved one about
+// the same target, in which case the existing report is returned.
+func (pg *PostgresDB) CreateReport(ctx context.Context, report *entity.Report) (*entity.Report, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (reporter_id, target_type, target_id, reported_user_id, reason, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (reporter_id, target_type, target_id) WHERE status <> 'resolved' DO NOTHING
+		RETURNING %s`,
+		ReportsTable, reportColumns)
+
+	var reportModel models.Report
+	err := pg.db.GetContext(ctx, &reportModel, query,
+		report.ReporterID, string(report.TargetType), report.TargetID, report.ReportedUserID,
+		string(report.Reason), report.Details, report.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		existing := fmt.Sprintf(`
+			SELECT %s FROM %s
+			WHERE reporter_id = $1 AND target_type = $2 AND target_id = $3 AND status <> 'resolved'`,
+			reportColumns, ReportsTable)
+		err = pg.db.GetContext(ctx, &reportModel, existing, report.ReporterID, string(report.TargetType), report.TargetID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conv.FromReportModelToDomain(&reportModel), nil
+}
+
+func (pg *PostgresDB) GetReportByID(ctx context.Context, reportID int) (*entity.Report, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1", reportColumns, ReportsTable)
+
+	var reportModel models.Report
+	if err := pg.db.GetContext(ctx, &reportModel, query, reportID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrReportNotFound
+		}
+		return nil, err
+	}
+	return conv.FromReportModelToDomain(&reportModel), nil
+}
+
+// GetReportsByStatus lists reports in the given status, oldest first so the queue is worked in order.
+func (pg *PostgresDB) GetReportsByStatus(ctx context.Context, status entity.ReportStatus, page *entity.Page) ([]entity.Report, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE status = $1 AND ($2::timestamptz IS NULL OR (created_at, id) > ($2, $3))
+		ORDER BY created_at, id
+		LIMIT $4 OFFSET $5`,
+		reportColumns, ReportsTable)
+
+	after, afterID, offset := keysetArgs(page)
+	var reportModels []models.Report
+	if err := pg.db.SelectContext(ctx, &reportModels, query, string(status), after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	reports := make([]entity.Report, 0, len(reportModels))
+	for i := range reportModels {
+		reports = append(reports, *conv.FromReportModelToDomain(&reportModels[i]))
+	}
+
+	var next *entity.Cursor
+	if len(reportModels) > 0 && len(reportModels) == page.Limit {
+		last := reportModels[len(reportModels)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return reports, next, nil
+}
+
+// ClaimReport assigns an open report to adminID. Claiming a report the admin already holds is a no-op.
+func (pg *PostgresDB) ClaimReport(ctx context.Context, reportID, adminID int) (*entity.Report, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET status = $3, claimed_by = $2
+		WHERE id = $1 AND (status = $4 OR (status = $3 AND claimed_by = $2))
+		RETURNING %s`,
+		ReportsTable, reportColumns)
+
+	var reportModel models.Report
+	err := pg.db.GetContext(ctx, &reportModel, query, reportID, adminID, string(entity.ReportClaimed), string(entity.ReportOpen))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := pg.GetReportByID(ctx, reportID); err != nil {
+			return nil, err
+		}
+		return nil, errs.ErrReportNotClaimable
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conv.FromReportModelToDomain(&reportModel), nil
+}
+
+// ResolveReport closes a report claimed by adminID with the given outcome.
+func (pg *PostgresDB) ResolveReport(ctx context.Context, reportID, adminID int, resolution entity.ReportResolution, resolvedAt time.Time) (*entity.Report, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET status = $3, resolution = $4, resolved_at = $5
+		WHERE id = $1 AND status = $6 AND claimed_by = $2
+		RETURNING %s`,
+		ReportsTable, reportColumns)
+
+	var reportModel models.Report
+	err := pg.db.GetContext(ctx, &reportModel, query,
+		reportID, adminID, string(entity.ReportResolved), string(resolution), resolvedAt, string(entity.ReportClaimed))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := pg.GetReportByID(ctx, reportID); err != nil {
+			return nil, err
+		}
+		return nil, errs.ErrReportNotClaimed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conv.FromReportModelToDomain(&reportModel), nil
+}
@@ -3,11 +3,13 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
 	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
 )
 
 func (pg *PostgresDB) GetTweetsByIDs(ctx context.Context, ids []int) ([]entity.Tweet, error) {
@@ -15,23 +17,45 @@ func (pg *PostgresDB) GetTweetsByIDs(ctx context.Context, ids []int) ([]entity.T
 		return []entity.Tweet{}, nil
 	}
 
-	query := fmt.Sprintf(`
-			SELECT id, user_id, parent_tweet_id, content, created_at, updated_at 
+	tweetMap := make(map[int]models.Tweet, len(ids))
+	cached, err := pg.Cache.MGetTweets(ctx, ids)
+	if err != nil {
+		logrus.WithError(err).Warn("mget tweets from Cache failed")
+	}
+	missing := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if t, ok := cached[id]; ok {
+			tweetMap[id] = *t
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		query := fmt.Sprintf(`
+			SELECT id, user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at, revision_count 
 			FROM %s 
 			WHERE id = ANY($1)`,
-		TweetsTable)
+			TweetsTable)
 
-	var tweetModels []models.Tweet
-
-	err := pg.db.SelectContext(ctx, &tweetModels, query, pq.Array(ids))
+		var tweetModels []models.Tweet
+		if err := pg.db.SelectContext(ctx, &tweetModels, query, pq.Array(missing)); err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return nil, err
-	}
+		for _, t := range tweetModels {
+			tweetMap[t.ID] = t
+		}
 
-	tweetMap := make(map[int]models.Tweet)
-	for _, t := range tweetModels {
-		tweetMap[t.ID] = t
+		go func(tweetModels []models.Tweet) {
+			cntx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			for i := range tweetModels {
+				if err := pg.Cache.SetTweet(cntx, &tweetModels[i]); err != nil {
+					logrus.WithError(err).Warnf("set tweet to Cache failed")
+				}
+			}
+		}(tweetModels)
 	}
 
 	var result []entity.Tweet
@@ -50,7 +74,7 @@ func (pg *PostgresDB) GetUsersByIDs(ctx context.Context, ids []int) ([]entity.Us
 	}
 
 	query := fmt.Sprintf(`
-			SELECT id, username, email, password, bio, gen, created_at, is_active, is_superuser 
+			SELECT id, username, email, password, bio, gen, created_at, is_active, is_superuser, is_private, email_verified
 			FROM %s 
 			WHERE id = ANY($1)`,
 		UserTable)
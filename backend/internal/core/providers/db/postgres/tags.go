@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/lib/pq"
+)
+
+// ReplaceTweetTagsTx stores the hashtags and mentions of a tweet in place of the ones it had.
+// Usernames that do not exist are skipped; the users that were mentioned are returned.
+func (pg *PostgresDB) ReplaceTweetTagsTx(ctx context.Context, tx *sql.Tx, tweetID int, createdAt time.Time, hashtags, mentions []string) ([]entity.SmallUser, error) {
+	for _, table := range []string{TweetHashtagsTable, TweetMentionsTable} {
+		query := fmt.Sprintf("DELETE FROM %s WHERE tweet_id = $1", table)
+		if _, err := tx.ExecContext(ctx, query, tweetID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(hashtags) > 0 {
+		query := fmt.Sprintf("INSERT INTO %s (tag) SELECT UNNEST($1::text[]) ON CONFLICT (tag) DO NOTHING", HashtagsTable)
+		if _, err := tx.ExecContext(ctx, query, pq.Array(hashtags)); err != nil {
+			return nil, err
+		}
+		query = fmt.Sprintf(`
+			INSERT INTO %s (tweet_id, hashtag_id, created_at)
+			SELECT $1, id, $2 FROM %s WHERE tag = ANY($3)`,
+			TweetHashtagsTable, HashtagsTable)
+		if _, err := tx.ExecContext(ctx, query, tweetID, createdAt, pq.Array(hashtags)); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(mentions) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf(`
+		WITH mentioned AS (
+			SELECT id, username FROM %s WHERE username = ANY($2)
+		), inserted AS (
+			INSERT INTO %s (tweet_id, user_id)
+			SELECT $1, id FROM mentioned
+		)
+		SELECT id, username FROM mentioned`,
+		UserTable, TweetMentionsTable)
+	rows, err := tx.QueryContext(ctx, query, tweetID, pq.Array(mentions))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []entity.SmallUser
+	for rows.Next() {
+		var user entity.SmallUser
+		if err := rows.Scan(&user.ID, &user.Username); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// GetTweetsByHashtag lists tweets tagged with tag, newest first.
+func (pg *PostgresDB) GetTweetsByHashtag(ctx context.Context, tag string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT t.id, t.user_id, t.parent_tweet_id, t.quoted_tweet_id, t.content, t.created_at, t.updated_at, t.revision_count
+		FROM %s th
+		JOIN %s h ON h.id = th.hashtag_id
+		JOIN %s t ON t.id = th.tweet_id
+		WHERE h.tag = $1 AND ($2::timestamptz IS NULL OR (th.created_at, th.tweet_id) < ($2, $3))
+		ORDER BY th.created_at DESC, th.tweet_id DESC
+		LIMIT $4 OFFSET $5`,
+		TweetHashtagsTable, HashtagsTable, TweetsTable)
+
+	after, afterID, offset := keysetArgs(page)
+	var tweetModels []models.Tweet
+	if err := pg.db.SelectContext(ctx, &tweetModels, query, tag, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+	return conv.FromTweetModelToDomainList(tweetModels), nextTweetsCursor(tweetModels, page.Limit), nil
+}
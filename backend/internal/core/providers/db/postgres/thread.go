@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// GetThreadAncestors walks parent_tweet_id up from tweetID and returns the chain
+// ordered from the root of the conversation down to the direct parent.
+func (pg *PostgresDB) GetThreadAncestors(ctx context.Context, tweetID int) ([]entity.Tweet, error) {
+	query := fmt.Sprintf(`
+		WITH RECURSIVE ancestors AS (
+			SELECT p.id, p.user_id, p.parent_tweet_id, p.quoted_tweet_id, p.content, p.created_at, p.updated_at, p.revision_count, 1 AS depth
+			FROM %[1]s t
+			JOIN %[1]s p ON p.id = t.parent_tweet_id
+			WHERE t.id = $1
+			UNION ALL
+			SELECT p.id, p.user_id, p.parent_tweet_id, p.quoted_tweet_id, p.content, p.created_at, p.updated_at, p.revision_count, a.depth + 1
+			FROM %[1]s p
+			JOIN ancestors a ON p.id = a.parent_tweet_id
+		)
+		SELECT id, user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at, revision_count
+		FROM ancestors
+		ORDER BY depth DESC`,
+		TweetsTable)
+
+	var tweetModels []models.Tweet
+	if err := pg.db.SelectContext(ctx, &tweetModels, query, tweetID); err != nil {
+		return nil, err
+	}
+	return conv.FromTweetModelToDomainList(tweetModels), nil
+}
+
+// GetThreadDescendants returns one page of direct replies to tweetID together with
+// their own replies down to maxDepth levels, at most maxReplies newest ones under each
+// reply. Only direct replies are paginated; the cursor is built from them. Rows come
+// back level by level, newest first.
+func (pg *PostgresDB) GetThreadDescendants(ctx context.Context, tweetID, maxDepth, maxReplies int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		WITH RECURSIVE top AS (
+			SELECT id, user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at, revision_count
+			FROM %[1]s
+			WHERE parent_tweet_id = $1 AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3))
+			ORDER BY created_at DESC, id DESC
+			LIMIT $4 OFFSET $5
+		), descendants AS (
+			SELECT id, user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at, revision_count, 1 AS depth
+			FROM top
+			UNION ALL
+			SELECT r.id, r.user_id, r.parent_tweet_id, r.quoted_tweet_id, r.content, r.created_at, r.updated_at, r.revision_count, d.depth + 1
+			FROM descendants d
+			CROSS JOIN LATERAL (
+				SELECT id, user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at, revision_count
+				FROM %[1]s t
+				WHERE t.parent_tweet_id = d.id
+				ORDER BY t.created_at DESC, t.id DESC
+				LIMIT $7
+			) r
+			WHERE d.depth < $6
+		)
+		SELECT id, user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at, revision_count
+		FROM descendants
+		ORDER BY depth, created_at DESC, id DESC`,
+		TweetsTable)
+
+	after, afterID, offset := keysetArgs(page)
+	var tweetModels []models.Tweet
+	if err := pg.db.SelectContext(ctx, &tweetModels, query, tweetID, after, afterID, page.Limit, offset, maxDepth, maxReplies); err != nil {
+		return nil, nil, err
+	}
+
+	var top []models.Tweet
+	for _, t := range tweetModels {
+		if t.ParentTweetID != nil && *t.ParentTweetID == tweetID {
+			top = append(top, t)
+		}
+	}
+
+	return conv.FromTweetModelToDomainList(tweetModels), nextTweetsCursor(top, page.Limit), nil
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/lib/pq"
+)
+
+// GetTimelineEntries returns tweets and retweets made by actorIDs, newest first.
+func (pg *PostgresDB) GetTimelineEntries(ctx context.Context, actorIDs []int, page *entity.Page) ([]entity.TimelineEntry, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+        SELECT tweet_id, actor_id, created_at
+        FROM (
+            SELECT id AS tweet_id, user_id AS actor_id, created_at
+            FROM %s
+            WHERE user_id = ANY($1)
+            UNION ALL
+            SELECT tweet_id, user_id AS actor_id, created_at
+            FROM %s
+            WHERE user_id = ANY($1)
+        ) entries
+        WHERE $2::timestamptz IS NULL OR (created_at, tweet_id) < ($2, $3)
+		ORDER BY created_at DESC, tweet_id DESC
+		LIMIT $4 OFFSET $5`,
+		TweetsTable, RetweetsTable)
+
+	type entryRow struct {
+		TweetID   int       `db:"tweet_id"`
+		ActorID   int       `db:"actor_id"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	after, afterID, offset := keysetArgs(page)
+	var rows []entryRow
+	if err := pg.db.SelectContext(ctx, &rows, query, pq.Array(actorIDs), after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	res := make([]entity.TimelineEntry, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, entity.TimelineEntry{
+			TweetID:   row.TweetID,
+			ActorID:   row.ActorID,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+
+	var next *entity.Cursor
+	if len(rows) > 0 && len(rows) == page.Limit {
+		last := rows[len(rows)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.TweetID}
+	}
+	return res, next, nil
+}
+
+func (pg *PostgresDB) GetFollowersCount(ctx context.Context, userID int) (int, error) {
+	query := fmt.Sprintf("SELECT followers_count FROM %s WHERE id = $1", UserTable)
+	var count int
+	if err := pg.db.GetContext(ctx, &count, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetAllFollowersIds returns every follower of userID, used to fan out new tweets.
+func (pg *PostgresDB) GetAllFollowersIds(ctx context.Context, userID int) ([]int, error) {
+	query := fmt.Sprintf("SELECT follower_id FROM %s WHERE following_id = $1", FollowsTable)
+	var res []int
+	if err := pg.db.SelectContext(ctx, &res, query, userID); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTimelineAuthorsIds splits the followings of userID into accounts whose tweets
+// are fanned out on write and celebrities with at least minFollowers followers,
+// which are read on demand. Follower counts come from users.followers_count, which
+// a trigger on follows keeps up to date.
+func (pg *PostgresDB) GetTimelineAuthorsIds(ctx context.Context, userID, minFollowers int) ([]int, []int, error) {
+	query := fmt.Sprintf(`
+        SELECT f.following_id AS id, u.followers_count >= $2 AS celebrity
+        FROM %s f
+        JOIN %s u ON u.id = f.following_id
+        WHERE f.follower_id = $1`,
+		FollowsTable, UserTable)
+
+	type authorRow struct {
+		ID        int  `db:"id"`
+		Celebrity bool `db:"celebrity"`
+	}
+
+	var rows []authorRow
+	if err := pg.db.SelectContext(ctx, &rows, query, userID, minFollowers); err != nil {
+		return nil, nil, err
+	}
+
+	var regular, celebrities []int
+	for _, row := range rows {
+		if row.Celebrity {
+			celebrities = append(celebrities, row.ID)
+		} else {
+			regular = append(regular, row.ID)
+		}
+	}
+	return regular, celebrities, nil
+}
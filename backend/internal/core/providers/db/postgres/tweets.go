@@ -20,9 +20,9 @@ func (pg *PostgresDB) CreateTweet(ctx context.Context, tweet *entity.Tweet) (*en
 		return nil, fmt.Errorf("cannot convert nil entity to DB model")
 	}
 
-	query := fmt.Sprintf("INSERT INTO %s (user_id, parent_tweet_id, content, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id", TweetsTable)
+	query := fmt.Sprintf("INSERT INTO %s (user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id", TweetsTable)
 	var id int
-	if err := pg.db.QueryRowContext(ctx, query, tweetModel.UserID, tweetModel.ParentTweetID, tweetModel.Content, tweetModel.CreatedAt, tweetModel.UpdatedAt).Scan(&id); err != nil {
+	if err := pg.db.QueryRowContext(ctx, query, tweetModel.UserID, tweetModel.ParentTweetID, tweetModel.QuotedTweetID, tweetModel.Content, tweetModel.CreatedAt, tweetModel.UpdatedAt).Scan(&id); err != nil {
 		return nil, err
 	}
 	tweetModel.ID = id
@@ -42,6 +42,9 @@ func (pg *PostgresDB) CreateTweet(ctx context.Context, tweet *entity.Tweet) (*en
 				logrus.WithError(err).Warnf("invalidate Cached counters failed")
 			}
 		}
+		if model.QuotedTweetID != nil {
+			pg.invalidateCountersAsync(*model.QuotedTweetID)
+		}
 	}(tweetModel)
 	createdtweet := conv.FromTweetModelToDomain(tweetModel)
 	return createdtweet, nil
@@ -53,9 +56,9 @@ func (pg *PostgresDB) CreateTweetTx(ctx context.Context, tx *sql.Tx, tweet *enti
 		return nil, fmt.Errorf("cannot convert nil entity to DB model")
 	}
 
-	query := fmt.Sprintf("INSERT INTO %s (user_id, parent_tweet_id, content, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id", TweetsTable)
+	query := fmt.Sprintf("INSERT INTO %s (user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id", TweetsTable)
 	var id int
-	if err := tx.QueryRowContext(ctx, query, tweetModel.UserID, tweetModel.ParentTweetID, tweetModel.Content, tweetModel.CreatedAt, tweetModel.UpdatedAt).Scan(&id); err != nil {
+	if err := tx.QueryRowContext(ctx, query, tweetModel.UserID, tweetModel.ParentTweetID, tweetModel.QuotedTweetID, tweetModel.Content, tweetModel.CreatedAt, tweetModel.UpdatedAt).Scan(&id); err != nil {
 		return nil, err
 	}
 	tweetModel.ID = id
@@ -73,6 +76,9 @@ func (pg *PostgresDB) CreateTweetTx(ctx context.Context, tx *sql.Tx, tweet *enti
 				logrus.WithError(err).Warnf("invalidate Cached counters failed")
 			}
 		}
+		if model.QuotedTweetID != nil {
+			pg.invalidateCountersAsync(*model.QuotedTweetID)
+		}
 	}(tweetModel)
 
 	createdtweet := conv.FromTweetModelToDomain(tweetModel)
@@ -109,21 +115,42 @@ func (pg *PostgresDB) GetTweetById(ctx context.Context, tweetID int) (*entity.Tw
 	return conv.FromTweetModelToDomain(&tweetModel), nil
 }
 
-func (pg *PostgresDB) UpdateTweet(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error) {
-	query := fmt.Sprintf("UPDATE %s SET content = $1, updated_at = $2 WHERE id = $3 RETURNING content, updated_at", TweetsTable)
-	var content string
-	var updatedAt time.Time
-	err := pg.db.QueryRowContext(ctx, query, tweet.Content, tweet.UpdatedAt, tweet.ID).Scan(&content, &updatedAt)
+// UpdateTweetTx replaces the content of a tweet and keeps the replaced version as a revision.
+// The tweet row is locked while it is read, so concurrent edits cannot exceed maxRevisions;
+// a maxRevisions of 0 allows any number of edits.
+func (pg *PostgresDB) UpdateTweetTx(ctx context.Context, tx *sql.Tx, tweet *entity.Tweet, maxRevisions int) (*entity.Tweet, error) {
+	var current models.Tweet
+	selectQuery := fmt.Sprintf("SELECT content, updated_at, revision_count FROM %s WHERE id = $1 FOR UPDATE", TweetsTable)
+	err := tx.QueryRowContext(ctx, selectQuery, tweet.ID).Scan(&current.Content, &current.UpdatedAt, &current.RevisionCount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrTweetNotFound
+		}
+		return nil, err
+	}
+	if maxRevisions > 0 && current.RevisionCount >= maxRevisions {
+		return nil, errs.ErrEditLimitReached
+	}
+
+	revisionQuery := fmt.Sprintf("INSERT INTO %s (tweet_id, content, created_at, replaced_at) VALUES ($1, $2, $3, $4)", TweetRevisionsTable)
+	if _, err := tx.ExecContext(ctx, revisionQuery, tweet.ID, current.Content, current.UpdatedAt, tweet.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	updateQuery := fmt.Sprintf(`
+		UPDATE %s SET content = $1, updated_at = $2, revision_count = revision_count + 1
+		WHERE id = $3
+		RETURNING content, updated_at, revision_count`,
+		TweetsTable)
+	err = tx.QueryRowContext(ctx, updateQuery, tweet.Content, tweet.UpdatedAt, tweet.ID).Scan(&tweet.Content, &tweet.UpdatedAt, &tweet.RevisionCount)
 	if err != nil {
 		return nil, err
 	}
-	tweet.Content = content
-	tweet.UpdatedAt = updatedAt
 
 	go func(tweetID int) {
 		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		err = pg.Cache.InvalidateTweet(cntx, tweetID)
+		err := pg.Cache.InvalidateTweet(cntx, tweetID)
 		if err != nil {
 			logrus.WithError(err).Warn("invalidate tweet in Cache failed")
 		}
@@ -131,9 +158,34 @@ func (pg *PostgresDB) UpdateTweet(ctx context.Context, tweet *entity.Tweet) (*en
 	return tweet, nil
 }
 
-func (pg *PostgresDB) DeleteTweet(ctx context.Context, userID, tweetID int) error {
+// GetTweetRevisions lists the superseded versions of a tweet, most recent first.
+func (pg *PostgresDB) GetTweetRevisions(ctx context.Context, tweetID int, page *entity.Page) ([]entity.TweetRevision, *entity.Cursor, error) {
+	query := fmt.Sprintf(`
+		SELECT id, tweet_id, content, created_at, replaced_at
+		FROM %s
+		WHERE tweet_id = $1 AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4 OFFSET $5`,
+		TweetRevisionsTable)
+
+	after, afterID, offset := keysetArgs(page)
+	var revisionModels []models.TweetRevision
+	if err := pg.db.SelectContext(ctx, &revisionModels, query, tweetID, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
+	}
+
+	var next *entity.Cursor
+	if len(revisionModels) > 0 && len(revisionModels) == page.Limit {
+		last := revisionModels[len(revisionModels)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return conv.FromTweetRevisionModelToDomainList(revisionModels), next, nil
+}
+
+// DeleteTweetTx removes a tweet of userID within tx.
+func (pg *PostgresDB) DeleteTweetTx(ctx context.Context, tx *sql.Tx, userID, tweetID int) error {
 	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", TweetsTable)
-	result, err := pg.db.ExecContext(ctx, query, tweetID, userID)
+	result, err := tx.ExecContext(ctx, query, tweetID, userID)
 	if err != nil {
 		return err
 	}
@@ -147,14 +199,33 @@ func (pg *PostgresDB) DeleteTweet(ctx context.Context, userID, tweetID int) erro
 	go func(tweetID int) {
 		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		err = pg.Cache.InvalidateTweet(cntx, tweetID)
-		if err != nil {
+		if err := pg.Cache.InvalidateTweet(cntx, tweetID); err != nil {
 			logrus.WithError(err).Warn("invalidate tweet in Cache failed")
 		}
 	}(tweetID)
 	return nil
 }
 
+// ForceDeleteTweetTx removes a tweet regardless of its author within tx and returns the author ID.
+func (pg *PostgresDB) ForceDeleteTweetTx(ctx context.Context, tx *sql.Tx, tweetID int) (int, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 RETURNING user_id", TweetsTable)
+	var authorID int
+	if err := tx.QueryRowContext(ctx, query, tweetID).Scan(&authorID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrTweetNotFound
+		}
+		return 0, err
+	}
+	go func(tweetID int) {
+		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := pg.Cache.InvalidateTweet(cntx, tweetID); err != nil {
+			logrus.WithError(err).Warn("invalidate tweet in Cache failed")
+		}
+	}(tweetID)
+	return authorID, nil
+}
+
 func (pg *PostgresDB) LikeTweet(ctx context.Context, userID, tweetID int) error {
 	var exists bool
 	checkQuery := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)", TweetsTable)
@@ -246,111 +317,144 @@ func (pg *PostgresDB) DeleteRetweet(ctx context.Context, userID, retweetID int)
 	return nil
 }
 
-func (pg *PostgresDB) GetRepliesToTweet(ctx context.Context, parentTweetID, limit, offset int) ([]entity.Tweet, error) {
+func (pg *PostgresDB) GetRepliesToTweet(ctx context.Context, parentTweetID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
 	var exists bool
 	checkQuery := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)", TweetsTable)
 	err := pg.db.QueryRowContext(ctx, checkQuery, parentTweetID).Scan(&exists)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !exists {
-		return nil, errs.ErrTweetNotFound
-	}
-
-	ids, err := pg.Cache.GetReplyIDs(ctx, parentTweetID)
-	if err != nil && !errors.Is(err, errs.ErrCacheKeyNotFound) {
-		logrus.WithError(err).Warnf("get reply ids from Cache failed")
-	} else if err == nil && len(ids) > 0 {
-		tweetsMap, err := pg.Cache.MGetTweets(ctx, ids)
-		if err == nil && len(tweetsMap) == len(ids) {
-			var res []models.Tweet
-			for _, id := range ids {
-				res = append(res, *tweetsMap[id])
+		return nil, nil, errs.ErrTweetNotFound
+	}
+
+	if isFirstPage(page) {
+		ids, err := pg.Cache.GetReplyIDs(ctx, parentTweetID)
+		if err != nil && !errors.Is(err, errs.ErrCacheKeyNotFound) {
+			logrus.WithError(err).Warnf("get reply ids from Cache failed")
+		} else if err == nil && len(ids) > 0 && len(ids) <= page.Limit {
+			tweetsMap, err := pg.Cache.MGetTweets(ctx, ids)
+			if err == nil && len(tweetsMap) == len(ids) {
+				var res []models.Tweet
+				for _, id := range ids {
+					res = append(res, *tweetsMap[id])
+				}
+				return conv.FromTweetModelToDomainList(res), nextTweetsCursor(res, page.Limit), nil
 			}
-			return conv.FromTweetModelToDomainList(res), nil
 		}
 	}
 
-	query := fmt.Sprintf("SELECT id, user_id, parent_tweet_id, content, created_at, updated_at FROM %s WHERE parent_tweet_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3", TweetsTable)
+	query := fmt.Sprintf(`
+		SELECT id, user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at, revision_count
+		FROM %s
+		WHERE parent_tweet_id = $1 AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4 OFFSET $5`,
+		TweetsTable)
+	after, afterID, offset := keysetArgs(page)
 	var tweetModels []models.Tweet
-	if err := pg.db.SelectContext(ctx, &tweetModels, query, parentTweetID, limit, offset); err != nil {
-		return nil, err
+	if err := pg.db.SelectContext(ctx, &tweetModels, query, parentTweetID, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
 	}
 
-	go func(tweetModels []models.Tweet) {
-		cntx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		var idList []int
-		for _, t := range tweetModels {
-			idList = append(idList, t.ID)
-			err = pg.Cache.SetTweet(cntx, &t)
+	// Retweets are rows of the retweeter's profile only, so a page with them is not cached
+	// as tweets.
+	if isFirstPage(page) && !hasRetweets(tweetModels) {
+		go func(tweetModels []models.Tweet) {
+			cntx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			var idList []int
+			for _, t := range tweetModels {
+				idList = append(idList, t.ID)
+				err = pg.Cache.SetTweet(cntx, &t)
+				if err != nil {
+					logrus.WithError(err).Warnf("set tweet to Cache failed")
+				}
+			}
+			err = pg.Cache.SetReplyIDs(cntx, parentTweetID, idList)
 			if err != nil {
-				logrus.WithError(err).Warnf("set tweet to Cache failed")
+				logrus.WithError(err).Warnf("set reply ids to Cache failed")
 			}
-		}
-		err = pg.Cache.SetReplyIDs(cntx, parentTweetID, idList)
-		if err != nil {
-			logrus.WithError(err).Warnf("set reply ids to Cache failed")
-		}
-	}(tweetModels)
+		}(tweetModels)
+	}
 
-	return conv.FromTweetModelToDomainList(tweetModels), nil
+	return conv.FromTweetModelToDomainList(tweetModels), nextTweetsCursor(tweetModels, page.Limit), nil
 }
 
-func (pg *PostgresDB) GetTweetsAndRetweetsByUsername(ctx context.Context, username string, limit, offset int) ([]entity.Tweet, error) {
-	ids, err := pg.Cache.GetUserTweetIDs(ctx, username)
-
-	if err != nil && !errors.Is(err, errs.ErrCacheKeyNotFound) {
-		logrus.WithError(err).Warnf("get reply ids from Cache failed")
-	} else if err == nil && len(ids) > 0 {
-		tweetsMap, err := pg.Cache.MGetTweets(ctx, ids)
-		if err == nil && len(tweetsMap) == len(ids) {
-			var res []models.Tweet
-			for _, id := range ids {
-				res = append(res, *tweetsMap[id])
+func (pg *PostgresDB) GetTweetsAndRetweetsByUsername(ctx context.Context, username string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	if isFirstPage(page) {
+		ids, err := pg.Cache.GetUserTweetIDs(ctx, username)
+
+		if err != nil && !errors.Is(err, errs.ErrCacheKeyNotFound) {
+			logrus.WithError(err).Warnf("get reply ids from Cache failed")
+		} else if err == nil && len(ids) > 0 && len(ids) <= page.Limit {
+			tweetsMap, err := pg.Cache.MGetTweets(ctx, ids)
+			if err == nil && len(tweetsMap) == len(ids) {
+				var res []models.Tweet
+				for _, id := range ids {
+					res = append(res, *tweetsMap[id])
+				}
+				return conv.FromTweetModelToDomainList(res), nextTweetsCursor(res, page.Limit), nil
 			}
-			return conv.FromTweetModelToDomainList(res), nil
 		}
 	}
 
 	query := fmt.Sprintf(`
-        SELECT t.id, t.user_id, t.parent_tweet_id, t.content, t.created_at, t.updated_at 
-        FROM %s t 
-        JOIN %s u ON t.user_id = u.id 
-        WHERE u.username = $1
-        UNION ALL
-        SELECT t.id, r.user_id, t.parent_tweet_id, t.content, r.created_at, t.updated_at
-        FROM %s r
-        JOIN %s t ON r.tweet_id = t.id 
-        JOIN %s u ON r.user_id = u.id
-        WHERE u.username = $1 
-        ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3`,
+        SELECT id, user_id, parent_tweet_id, quoted_tweet_id, content, created_at, updated_at, revision_count, retweeted_by
+        FROM (
+            SELECT t.id, t.user_id, t.parent_tweet_id, t.quoted_tweet_id, t.content, t.created_at, t.updated_at, t.revision_count, NULL::int AS retweeted_by
+            FROM %s t 
+            JOIN %s u ON t.user_id = u.id 
+            WHERE u.username = $1
+            UNION ALL
+            SELECT t.id, t.user_id, t.parent_tweet_id, t.quoted_tweet_id, t.content, r.created_at, t.updated_at, t.revision_count, r.user_id AS retweeted_by
+            FROM %s r
+            JOIN %s t ON r.tweet_id = t.id 
+            JOIN %s u ON r.user_id = u.id
+            WHERE u.username = $1 
+        ) timeline
+        WHERE $2::timestamptz IS NULL OR (created_at, id) < ($2, $3)
+        ORDER BY created_at DESC, id DESC
+		LIMIT $4 OFFSET $5`,
 		TweetsTable, UserTable, RetweetsTable, TweetsTable, UserTable)
 
+	after, afterID, offset := keysetArgs(page)
 	var tweetModels []models.Tweet
-	if err := pg.db.SelectContext(ctx, &tweetModels, query, username, limit, offset); err != nil {
-		return nil, err
+	if err := pg.db.SelectContext(ctx, &tweetModels, query, username, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
 	}
 
-	go func(tweetModels []models.Tweet) {
-		cntx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		var idList []int
-		for _, t := range tweetModels {
-			idList = append(idList, t.ID)
-			err = pg.Cache.SetTweet(cntx, &t)
+	// Retweets are rows of the retweeter's profile only, so a page with them is not cached
+	// as tweets.
+	if isFirstPage(page) && !hasRetweets(tweetModels) {
+		go func(tweetModels []models.Tweet) {
+			cntx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			var idList []int
+			for _, t := range tweetModels {
+				idList = append(idList, t.ID)
+				err := pg.Cache.SetTweet(cntx, &t)
+				if err != nil {
+					logrus.WithError(err).Warnf("set tweet to Cache failed")
+				}
+			}
+			err := pg.Cache.SetUserTweetIDs(cntx, username, idList)
 			if err != nil {
-				logrus.WithError(err).Warnf("set tweet to Cache failed")
+				logrus.WithError(err).Warnf("set user tweets ids to Cache failed")
 			}
-		}
-		err = pg.Cache.SetUserTweetIDs(cntx, username, idList)
-		if err != nil {
-			logrus.WithError(err).Warnf("set user tweets ids to Cache failed")
-		}
-	}(tweetModels)
+		}(tweetModels)
+	}
 
-	return conv.FromTweetModelToDomainList(tweetModels), nil
+	return conv.FromTweetModelToDomainList(tweetModels), nextTweetsCursor(tweetModels, page.Limit), nil
+}
+
+func hasRetweets(tweetModels []models.Tweet) bool {
+	for i := range tweetModels {
+		if tweetModels[i].RetweetedBy != nil {
+			return true
+		}
+	}
+	return false
 }
 
 func (pg *PostgresDB) GetCounts(ctx context.Context, tweetID int) (*entity.Counters, error) {
@@ -364,18 +468,22 @@ func (pg *PostgresDB) GetCounts(ctx context.Context, tweetID int) (*entity.Count
         SELECT 
             (SELECT COUNT(*) FROM %s WHERE tweet_id = $1) as likes,
             (SELECT COUNT(*) FROM %s WHERE tweet_id = $1) as retweets,
-            (SELECT COUNT(*) FROM %s WHERE parent_tweet_id = $1) as replies
-    `, LikesTable, RetweetsTable, TweetsTable)
+            (SELECT COUNT(*) FROM %s WHERE parent_tweet_id = $1) as replies,
+            (SELECT COUNT(*) FROM %s WHERE tweet_id = $1) as bookmarks,
+            (SELECT COUNT(*) FROM %s WHERE quoted_tweet_id = $1) as quotes
+    `, LikesTable, RetweetsTable, TweetsTable, BookmarksTable, TweetsTable)
 
-	var likes, retweets, replies int
-	err = pg.db.QueryRowContext(ctx, query, tweetID).Scan(&likes, &retweets, &replies)
+	var likes, retweets, replies, bookmarks, quotes int
+	err = pg.db.QueryRowContext(ctx, query, tweetID).Scan(&likes, &retweets, &replies, &bookmarks, &quotes)
 	if err != nil {
 		return nil, err
 	}
 	countersModel := &models.Counters{
-		LikeCount:    likes,
-		RetweetCount: retweets,
-		ReplyCount:   replies,
+		LikeCount:     likes,
+		RetweetCount:  retweets,
+		ReplyCount:    replies,
+		BookmarkCount: bookmarks,
+		QuoteCount:    quotes,
 	}
 
 	go func(tweetID int, model *models.Counters) {
@@ -390,35 +498,38 @@ func (pg *PostgresDB) GetCounts(ctx context.Context, tweetID int) (*entity.Count
 	return conv.FromCountersModelToDomain(countersModel), nil
 }
 
-func (pg *PostgresDB) GetLikes(ctx context.Context, tweetID, limit, offset int) ([]entity.SmallUser, error) {
+func (pg *PostgresDB) GetLikes(ctx context.Context, tweetID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error) {
 	var exists bool
 	checkQuery := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)", TweetsTable)
 	err := pg.db.QueryRowContext(ctx, checkQuery, tweetID).Scan(&exists)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !exists {
-		return nil, errs.ErrTweetNotFound
+		return nil, nil, errs.ErrTweetNotFound
 	}
 
 	query := fmt.Sprintf(
-		`SELECT u.id, u.username, a.path as avatar_path
+		`SELECT u.id, u.username, a.path as avatar_path, l.created_at
         FROM %s l
         JOIN %s u ON l.user_id = u.id
         JOIN %s a ON u.id = a.user_id
-        WHERE l.tweet_id = $1
-		LIMIT $2 OFFSET $3`,
+        WHERE l.tweet_id = $1 AND ($2::timestamptz IS NULL OR (l.created_at, l.user_id) < ($2, $3))
+		ORDER BY l.created_at DESC, l.user_id DESC
+		LIMIT $4 OFFSET $5`,
 		LikesTable, UserTable, AvatarsTable)
 
 	type LikerRow struct {
-		ID         int    `db:"id"`
-		Username   string `db:"username"`
-		AvatarPath string `db:"avatar_path"`
+		ID         int       `db:"id"`
+		Username   string    `db:"username"`
+		AvatarPath string    `db:"avatar_path"`
+		CreatedAt  time.Time `db:"created_at"`
 	}
 
+	after, afterID, offset := keysetArgs(page)
 	var rows []LikerRow
-	if err := pg.db.SelectContext(ctx, &rows, query, tweetID, limit, offset); err != nil {
-		return nil, err
+	if err := pg.db.SelectContext(ctx, &rows, query, tweetID, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
 	}
 
 	res := make([]entity.SmallUser, 0, len(rows))
@@ -433,14 +544,22 @@ func (pg *PostgresDB) GetLikes(ctx context.Context, tweetID, limit, offset int)
 		ids = append(ids, row.ID)
 	}
 
-	go func() {
-		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	var next *entity.Cursor
+	if len(rows) > 0 && len(rows) == page.Limit {
+		last := rows[len(rows)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
 
-		if err := pg.Cache.SetTweetLikerIDs(cntx, tweetID, ids); err != nil {
-			logrus.WithError(err).Warn("set tweet likers to cache failed")
-		}
-	}()
+	if isFirstPage(page) {
+		go func() {
+			cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := pg.Cache.SetTweetLikerIDs(cntx, tweetID, ids); err != nil {
+				logrus.WithError(err).Warn("set tweet likers to cache failed")
+			}
+		}()
+	}
 
-	return res, nil
+	return res, next, nil
 }
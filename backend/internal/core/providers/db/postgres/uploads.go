@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	conv "github.com/kust1q/Zapp/backend/internal/core/providers/db/conv"
+	"github.com/kust1q/Zapp/backend/internal/core/providers/db/models"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+const uploadColumns = `id, user_id, filename, media_type, size_bytes, upload_offset, path, mime_type,
+	storage_upload_id, completed_at, created_at, expires_at`
+
+func (pg *PostgresDB) CreateUpload(ctx context.Context, upload *entity.Upload) (*entity.Upload, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (user_id, filename, media_type, size_bytes, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING %s`,
+		MediaUploadsTable, uploadColumns)
+
+	var created models.Upload
+	if err := pg.db.GetContext(ctx, &created, query,
+		upload.UserID, upload.Filename, string(upload.MediaType), upload.Size, upload.CreatedAt, upload.ExpiresAt,
+	); err != nil {
+		return nil, err
+	}
+	return conv.FromUploadModelToDomain(&created), nil
+}
+
+// GetUploadByID returns an upload of userID that has not expired yet.
+func (pg *PostgresDB) GetUploadByID(ctx context.Context, userID, uploadID int) (*entity.Upload, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 AND user_id = $2 AND expires_at > NOW()", uploadColumns, MediaUploadsTable)
+
+	var uploadModel models.Upload
+	if err := pg.db.GetContext(ctx, &uploadModel, query, uploadID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrUploadNotFound
+		}
+		return nil, err
+	}
+	return conv.FromUploadModelToDomain(&uploadModel), nil
+}
+
+// ClaimUpload leases an incomplete upload of userID whose offset is still offset, so
+// that one request at a time writes to it. It fails with ErrUploadOffsetMismatch when
+// the offset moved or another request holds the lease.
+func (pg *PostgresDB) ClaimUpload(ctx context.Context, userID, uploadID int, offset int64, lockedUntil time.Time) (*entity.Upload, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET locked_until = $4
+		WHERE id = $1 AND user_id = $2 AND upload_offset = $3
+			AND completed_at IS NULL AND expires_at > NOW()
+			AND (locked_until IS NULL OR locked_until <= NOW())
+		RETURNING %s`,
+		MediaUploadsTable, uploadColumns)
+
+	var uploadModel models.Upload
+	if err := pg.db.GetContext(ctx, &uploadModel, query, uploadID, userID, offset, lockedUntil); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrUploadOffsetMismatch
+		}
+		return nil, err
+	}
+	return conv.FromUploadModelToDomain(&uploadModel), nil
+}
+
+// StartUpload records the object an upload is written to once its first chunk arrives.
+func (pg *PostgresDB) StartUpload(ctx context.Context, uploadID int, path, mimeType, storageUploadID string) error {
+	query := fmt.Sprintf("UPDATE %s SET path = $2, mime_type = $3, storage_upload_id = $4 WHERE id = $1", MediaUploadsTable)
+	_, err := pg.db.ExecContext(ctx, query, uploadID, path, mimeType, storageUploadID)
+	return err
+}
+
+// AdvanceUpload moves the offset of a claimed upload past a stored chunk and releases it.
+func (pg *PostgresDB) AdvanceUpload(ctx context.Context, uploadID int, offset int64) error {
+	query := fmt.Sprintf("UPDATE %s SET upload_offset = $2, locked_until = NULL WHERE id = $1", MediaUploadsTable)
+	_, err := pg.db.ExecContext(ctx, query, uploadID, offset)
+	return err
+}
+
+// ReleaseUpload gives up the lease of a claimed upload without moving its offset.
+func (pg *PostgresDB) ReleaseUpload(ctx context.Context, uploadID int) error {
+	query := fmt.Sprintf("UPDATE %s SET locked_until = NULL WHERE id = $1", MediaUploadsTable)
+	_, err := pg.db.ExecContext(ctx, query, uploadID)
+	return err
+}
+
+// CompleteUpload marks a claimed upload as completed and releases it; it then has to be
+// attached before expiresAt.
+func (pg *PostgresDB) CompleteUpload(ctx context.Context, uploadID int, expiresAt time.Time) (*entity.Upload, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s SET completed_at = NOW(), expires_at = $2, locked_until = NULL
+		WHERE id = $1
+		RETURNING %s`,
+		MediaUploadsTable, uploadColumns)
+
+	var uploadModel models.Upload
+	if err := pg.db.GetContext(ctx, &uploadModel, query, uploadID, expiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrUploadNotFound
+		}
+		return nil, err
+	}
+	return conv.FromUploadModelToDomain(&uploadModel), nil
+}
+
+// DeleteUpload removes an upload of userID and returns it, so that its object can be removed.
+func (pg *PostgresDB) DeleteUpload(ctx context.Context, userID, uploadID int) (*entity.Upload, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING %s", MediaUploadsTable, uploadColumns)
+
+	var uploadModel models.Upload
+	if err := pg.db.GetContext(ctx, &uploadModel, query, uploadID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrUploadNotFound
+		}
+		return nil, err
+	}
+	return conv.FromUploadModelToDomain(&uploadModel), nil
+}
+
+// ConsumeUploadTx removes a completed upload of userID within tx and returns it; its
+// object then belongs to whatever it is attached to. Rolling tx back keeps the upload.
+func (pg *PostgresDB) ConsumeUploadTx(ctx context.Context, tx *sql.Tx, userID, uploadID int) (*entity.Upload, error) {
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE id = $1 AND user_id = $2 AND completed_at IS NOT NULL AND expires_at > NOW()
+		RETURNING path, mime_type, size_bytes, media_type`,
+		MediaUploadsTable)
+
+	var upload models.Upload
+	if err := tx.QueryRowContext(ctx, query, uploadID, userID).Scan(&upload.Path, &upload.MimeType, &upload.SizeBytes, &upload.MediaType); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrUploadNotFound
+		}
+		return nil, err
+	}
+	upload.ID = uploadID
+	upload.UserID = userID
+	return conv.FromUploadModelToDomain(&upload), nil
+}
+
+// DeleteExpiredUploads removes up to limit uploads that expired by now and are not
+// being written to, and returns them so that their objects can be removed.
+func (pg *PostgresDB) DeleteExpiredUploads(ctx context.Context, now time.Time, limit int) ([]entity.Upload, error) {
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE id IN (
+			SELECT id FROM %s
+			WHERE expires_at <= $1 AND (locked_until IS NULL OR locked_until <= $1)
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED)
+		RETURNING %s`,
+		MediaUploadsTable, MediaUploadsTable, uploadColumns)
+
+	var uploadModels []models.Upload
+	if err := pg.db.SelectContext(ctx, &uploadModels, query, now, limit); err != nil {
+		return nil, err
+	}
+	return conv.FromUploadModelToDomainList(uploadModels), nil
+}
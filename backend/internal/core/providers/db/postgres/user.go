@@ -21,15 +21,15 @@ func (pg *PostgresDB) CreateUserTx(ctx context.Context, tx *sql.Tx, user *entity
 	}
 
 	query := fmt.Sprintf(`
-        INSERT INTO %s (username, email, password, bio, gen, created_at, is_active, is_superuser) 
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        INSERT INTO %s (username, email, password, bio, gen, created_at, is_active, is_superuser, email_verified) 
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
         RETURNING id`, UserTable)
 
 	var id int
 	err := tx.QueryRowContext(ctx, query,
 		userModel.Username, userModel.Email, userModel.Password,
 		userModel.Bio, userModel.Gen, userModel.CreatedAt,
-		userModel.IsActive, userModel.IsSuperuser).Scan(&id)
+		userModel.IsActive, userModel.IsSuperuser, userModel.EmailVerified).Scan(&id)
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +124,22 @@ func (pg *PostgresDB) GetUserByID(ctx context.Context, userID int) (*entity.User
 	return conv.FromUserModelToDomain(&userModel), nil
 }
 
+func (pg *PostgresDB) SetUserEmailVerified(ctx context.Context, userID int) error {
+	query := fmt.Sprintf("UPDATE %s SET email_verified = TRUE WHERE id = $1", UserTable)
+	result, err := pg.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrUserNotFound
+	}
+	return pg.Cache.InvalidateUser(ctx, userID)
+}
+
 func (pg *PostgresDB) UpdateUserPassword(ctx context.Context, userID int, password string) error {
 	query := fmt.Sprintf("UPDATE %s SET password = $1 WHERE id = $2", UserTable)
 	result, err := pg.db.ExecContext(ctx, query, password, userID)
@@ -141,7 +157,7 @@ func (pg *PostgresDB) UpdateUserPassword(ctx context.Context, userID int, passwo
 }
 
 func (pg *PostgresDB) UpdateUserBio(ctx context.Context, userID int, bio string) error {
-	query := fmt.Sprintf("UPDATE %s SET bio = $1 WHERE id = $2", UserTable)
+	query := fmt.Sprintf("UPDATE %s SET bio = $1, updated_at = NOW() WHERE id = $2", UserTable)
 	result, err := pg.db.ExecContext(ctx, query, bio, userID)
 	if err != nil {
 		return err
@@ -236,13 +252,17 @@ func (pg *PostgresDB) UnfollowUser(ctx context.Context, followerID, followingID
 		return errs.ErrUserNotFound
 	}
 
-	query := fmt.Sprintf("DELETE FROM %s WHERE follower_id = $1 AND following_id = $2", FollowsTable)
-	result, err := pg.db.ExecContext(ctx, query, followerID, followingID)
-	if err != nil {
-		return err
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
+	// A pending follow request is withdrawn the same way as a follow.
+	query := fmt.Sprintf(`
+		WITH requested AS (
+			DELETE FROM %s WHERE requester_id = $1 AND target_id = $2 RETURNING 1
+		), followed AS (
+			DELETE FROM %s WHERE follower_id = $1 AND following_id = $2 RETURNING 1
+		)
+		SELECT (SELECT COUNT(*) FROM requested) + (SELECT COUNT(*) FROM followed)`,
+		FollowRequestsTable, FollowsTable)
+	var rowsAffected int
+	if err := pg.db.GetContext(ctx, &rowsAffected, query, followerID, followingID); err != nil {
 		return err
 	}
 	if rowsAffected == 0 {
@@ -251,56 +271,75 @@ func (pg *PostgresDB) UnfollowUser(ctx context.Context, followerID, followingID
 	return nil
 }
 
-func (pg *PostgresDB) GetFollowersIds(ctx context.Context, username string, limit, offset int) ([]int, error) {
+func (pg *PostgresDB) GetFollowersIds(ctx context.Context, username string, page *entity.Page) ([]int, *entity.Cursor, error) {
 	var userExists bool
 	checkQuery := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE username = $1)", UserTable)
 	err := pg.db.QueryRowContext(ctx, checkQuery, username).Scan(&userExists)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !userExists {
-		return nil, errs.ErrUserNotFound
+		return nil, nil, errs.ErrUserNotFound
 	}
 
 	query := fmt.Sprintf(`
-        SELECT f.follower_id
+        SELECT f.follower_id AS id, f.created_at
         FROM %s f
         JOIN %s u ON f.following_id = u.id
-        WHERE u.username = $1
-		LIMIT $2 OFFSET $3`,
+        WHERE u.username = $1 AND ($2::timestamptz IS NULL OR (f.created_at, f.follower_id) < ($2, $3))
+		ORDER BY f.created_at DESC, f.follower_id DESC
+		LIMIT $4 OFFSET $5`,
 		FollowsTable, UserTable)
 
-	var res []int
-	if err := pg.db.SelectContext(ctx, &res, query, username, limit, offset); err != nil {
-		return nil, err
-	}
-	return res, nil
+	return pg.selectFollowIds(ctx, query, username, page)
 }
 
-func (pg *PostgresDB) GetFollowingsIds(ctx context.Context, username string, limit, offset int) ([]int, error) {
+func (pg *PostgresDB) GetFollowingsIds(ctx context.Context, username string, page *entity.Page) ([]int, *entity.Cursor, error) {
 	var userExists bool
 	checkQuery := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE username = $1)", UserTable)
 	err := pg.db.QueryRowContext(ctx, checkQuery, username).Scan(&userExists)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !userExists {
-		return nil, errs.ErrUserNotFound
+		return nil, nil, errs.ErrUserNotFound
 	}
 
 	query := fmt.Sprintf(`
-        SELECT f.following_id
+        SELECT f.following_id AS id, f.created_at
         FROM %s f
         JOIN %s u ON f.follower_id = u.id
-        WHERE u.username = $1
-		LIMIT $2 OFFSET $3`,
+        WHERE u.username = $1 AND ($2::timestamptz IS NULL OR (f.created_at, f.following_id) < ($2, $3))
+		ORDER BY f.created_at DESC, f.following_id DESC
+		LIMIT $4 OFFSET $5`,
 		FollowsTable, UserTable)
 
-	var res []int
-	if err := pg.db.SelectContext(ctx, &res, query, username, limit, offset); err != nil {
-		return nil, err
+	return pg.selectFollowIds(ctx, query, username, page)
+}
+
+func (pg *PostgresDB) selectFollowIds(ctx context.Context, query, username string, page *entity.Page) ([]int, *entity.Cursor, error) {
+	type followRow struct {
+		ID        int       `db:"id"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	after, afterID, offset := keysetArgs(page)
+	var rows []followRow
+	if err := pg.db.SelectContext(ctx, &rows, query, username, after, afterID, page.Limit, offset); err != nil {
+		return nil, nil, err
 	}
-	return res, nil
+
+	res := make([]int, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, row.ID)
+	}
+
+	var next *entity.Cursor
+	if len(rows) > 0 && len(rows) == page.Limit {
+		last := rows[len(rows)-1]
+		next = &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return res, next, nil
 }
 
 func (pg *PostgresDB) DeleteUser(ctx context.Context, userID int) error {
@@ -319,3 +358,27 @@ func (pg *PostgresDB) DeleteUser(ctx context.Context, userID int) error {
 
 	return pg.Cache.InvalidateUser(ctx, userID)
 }
+
+func (pg *PostgresDB) DeleteUserTx(ctx context.Context, tx *sql.Tx, userID int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1", UserTable)
+	result, err := tx.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	go func(userID int) {
+		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := pg.Cache.InvalidateUser(cntx, userID); err != nil {
+			logrus.WithError(err).Warn("invalidate user in Cache failed")
+		}
+	}(userID)
+	return nil
+}
@@ -188,6 +188,56 @@ func (c *cache) GetTweetCounters(ctx context.Context, tweetID int) (*models.Coun
 	return &counters, nil
 }
 
+func (c *cache) MGetTweetCounters(ctx context.Context, tweetIDs []int) (map[int]*models.Counters, error) {
+	if len(tweetIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(tweetIDs))
+	for i, id := range tweetIDs {
+		keys[i] = fmt.Sprintf("%s%d", countersCachePrefix, id)
+	}
+
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis mget error: %w", err)
+	}
+
+	result := make(map[int]*models.Counters)
+	for i, val := range values {
+		strVal, ok := val.(string)
+		if !ok {
+			continue
+		}
+
+		var counters models.Counters
+		if err := json.Unmarshal([]byte(strVal), &counters); err != nil {
+			continue
+		}
+		result[tweetIDs[i]] = &counters
+	}
+
+	return result, nil
+}
+
+func (c *cache) MSetTweetCounters(ctx context.Context, counters map[int]*models.Counters) error {
+	if len(counters) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for tweetID, model := range counters {
+		data, err := json.Marshal(model)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, fmt.Sprintf("%s%d", countersCachePrefix, tweetID), data, c.countersTtl)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func (c *cache) InvalidateTweetCounters(ctx context.Context, tweetID int) error {
 	return c.client.Del(ctx, fmt.Sprintf("%s%d", countersCachePrefix, tweetID)).Err()
 }
@@ -216,6 +266,38 @@ func (c *cache) GetUserByID(ctx context.Context, id int) (*models.User, error) {
 	return c.fetchUser(ctx, key)
 }
 
+func (c *cache) MGetUsers(ctx context.Context, ids []int) (map[int]*models.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf("%s%d", userIdCachePrefix, id)
+	}
+
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis mget error: %w", err)
+	}
+
+	result := make(map[int]*models.User)
+	for i, val := range values {
+		strVal, ok := val.(string)
+		if !ok {
+			continue
+		}
+
+		var user models.User
+		if err := json.Unmarshal([]byte(strVal), &user); err != nil {
+			continue
+		}
+		result[ids[i]] = &user
+	}
+
+	return result, nil
+}
+
 func (c *cache) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	indexKey := fmt.Sprintf("%s%s", usernameCachePrefix, username)
 
@@ -0,0 +1,134 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	prefixRateLimit      = "rate_limit:"
+	prefixSignInFailures = "sign_in_failures:"
+	prefixSignInLockout  = "sign_in_lockout:"
+)
+
+// slidingWindowScript keeps the request times of one counter in a sorted set and
+// admits a request only while fewer than limit requests fall inside the window.
+// It returns whether the request was admitted, the requests counted in the window
+// and when the oldest of them leaves it, all in milliseconds.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	redis.call('PEXPIRE', KEYS[1], window)
+	count = count + 1
+	allowed = 1
+end
+local reset = now + window
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if #oldest > 0 then
+	reset = tonumber(oldest[2]) + window
+end
+return {allowed, count, reset}
+`)
+
+type rateLimitDB struct {
+	redis *redis.Client
+}
+
+func NewRateLimitStorage(redis *redis.Client) *rateLimitDB {
+	return &rateLimitDB{
+		redis: redis,
+	}
+}
+
+// Hit counts a request against the counter key.
+func (s *rateLimitDB) Hit(ctx context.Context, key string, limit int, window time.Duration) (*entity.RateLimit, error) {
+	now := time.Now()
+	res, err := slidingWindowScript.Run(ctx, s.redis,
+		[]string{s.buildRateLimitKey(key)},
+		now.UnixMilli(), window.Milliseconds(), limit, uuid.New().String(),
+	).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+	if len(res) != 3 {
+		return nil, fmt.Errorf("unexpected rate limit reply of %d values", len(res))
+	}
+
+	resetAt := time.UnixMilli(res[2])
+	result := &entity.RateLimit{
+		Allowed:   res[0] == 1,
+		Limit:     limit,
+		Remaining: max(limit-int(res[1]), 0),
+		ResetAt:   resetAt,
+	}
+	if !result.Allowed {
+		result.RetryAfter = max(resetAt.Sub(now), time.Millisecond)
+	}
+	return result, nil
+}
+
+// RegisterSignInFailure counts a failed sign-in of email. The count is forgotten once
+// no failure happened for window.
+func (s *rateLimitDB) RegisterSignInFailure(ctx context.Context, email string, window time.Duration) (int, error) {
+	key := s.buildSignInFailuresKey(email)
+	pipe := s.redis.TxPipeline()
+	failures := pipe.Incr(ctx, key)
+	pipe.PExpire(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("redis error: %w", err)
+	}
+	return int(failures.Val()), nil
+}
+
+// LockSignIn rejects sign-ins of email for lockout. The failure count outlives the
+// lockout by window, so that the next failure after it escalates further.
+func (s *rateLimitDB) LockSignIn(ctx context.Context, email string, lockout, window time.Duration) error {
+	pipe := s.redis.TxPipeline()
+	pipe.Set(ctx, s.buildSignInLockoutKey(email), 1, lockout)
+	pipe.PExpire(ctx, s.buildSignInFailuresKey(email), lockout+window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+	return nil
+}
+
+// GetSignInLockout returns how long sign-ins of email stay locked, or zero.
+func (s *rateLimitDB) GetSignInLockout(ctx context.Context, email string) (time.Duration, error) {
+	ttl, err := s.redis.PTTL(ctx, s.buildSignInLockoutKey(email)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("redis error: %w", err)
+	}
+	// PTTL reports a missing key as a negative duration.
+	return max(ttl, 0), nil
+}
+
+func (s *rateLimitDB) ResetSignInFailures(ctx context.Context, email string) error {
+	err := s.redis.Del(ctx, s.buildSignInFailuresKey(email), s.buildSignInLockoutKey(email)).Err()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to reset sign in failures: %w", err)
+	}
+	return nil
+}
+
+func (s *rateLimitDB) buildRateLimitKey(key string) string {
+	return prefixRateLimit + key
+}
+
+func (s *rateLimitDB) buildSignInFailuresKey(email string) string {
+	return prefixSignInFailures + email
+}
+
+func (s *rateLimitDB) buildSignInLockoutKey(email string) string {
+	return prefixSignInLockout + email
+}
@@ -0,0 +1,249 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+// Each home timeline is a sorted set keyed by user. Members are "actorID:tweetID"
+// so that entries of one followed account can be dropped on unfollow, and the
+// score is the entry time in microseconds, matching Postgres precision.
+const prefixTimeline = "timeline:"
+
+// materializedMember is stored with score 0 in every rebuilt timeline, so that a
+// timeline with no entries still exists. Being the lowest, it is the first one
+// trimmed once the timeline is full, when it is no longer needed.
+const materializedMember = "materialized"
+
+// pushScript adds entries only to a timeline that is already materialized, so a
+// fan-out never leaves a partial timeline that would hide the rebuild on read.
+var pushScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+for i = 2, #ARGV, 2 do
+	redis.call('ZADD', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+redis.call('ZREMRANGEBYRANK', KEYS[1], 0, -tonumber(ARGV[1]) - 1)
+return 1
+`)
+
+type timelineDB struct {
+	redis  *redis.Client
+	maxLen int
+	ttl    time.Duration
+}
+
+func NewTimelineStorage(redis *redis.Client, maxLen int, ttl time.Duration) *timelineDB {
+	return &timelineDB{
+		redis:  redis,
+		maxLen: maxLen,
+		ttl:    ttl,
+	}
+}
+
+// PushToTimelines adds entry to the materialized timelines of userIDs.
+func (s *timelineDB) PushToTimelines(ctx context.Context, userIDs []int, entry *entity.TimelineEntry) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := s.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, userID := range userIDs {
+			pushScript.Eval(ctx, pipe, []string{s.buildTimelineKey(userID)}, s.maxLen, score(entry.CreatedAt), member(entry))
+		}
+		return nil
+	})
+	return err
+}
+
+// PushToTimeline adds entries to the timeline of userID if it is materialized.
+func (s *timelineDB) PushToTimeline(ctx context.Context, userID int, entries []entity.TimelineEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	args := make([]any, 0, 1+2*len(entries))
+	args = append(args, s.maxLen)
+	for i := range entries {
+		args = append(args, score(entries[i].CreatedAt), member(&entries[i]))
+	}
+	return pushScript.Run(ctx, s.redis, []string{s.buildTimelineKey(userID)}, args...).Err()
+}
+
+// ReplaceTimeline materializes the timeline of userID from entries, which may be empty.
+func (s *timelineDB) ReplaceTimeline(ctx context.Context, userID int, entries []entity.TimelineEntry) error {
+	key := s.buildTimelineKey(userID)
+	members := make([]redis.Z, 0, len(entries)+1)
+	for i := range entries {
+		members = append(members, redis.Z{Score: score(entries[i].CreatedAt), Member: member(&entries[i])})
+	}
+	members = append(members, redis.Z{Score: 0, Member: materializedMember})
+
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.ZAdd(ctx, key, members...)
+		pipe.ZRemRangeByRank(ctx, key, 0, int64(-s.maxLen-1))
+		pipe.Expire(ctx, key, s.ttl)
+		return nil
+	})
+	return err
+}
+
+// GetTimeline returns a page of the timeline of userID, ordered by entry time and
+// tweet id, newest first. Entries sharing a score are ordered by member in Redis, so
+// the tie groups at both ends of the page are read whole and sorted by tweet id.
+// It returns errs.ErrCacheKeyNotFound when the timeline is not materialized.
+func (s *timelineDB) GetTimeline(ctx context.Context, userID int, page *entity.Page) ([]entity.TimelineEntry, error) {
+	key := s.buildTimelineKey(userID)
+
+	var (
+		exists *redis.IntCmd
+		ties   *redis.ZSliceCmd
+		items  *redis.ZSliceCmd
+	)
+	_, err := s.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		exists = pipe.Exists(ctx, key)
+		max, offset := "+inf", page.Offset
+		if page.After != nil {
+			at := strconv.FormatInt(page.After.CreatedAt.UnixMicro(), 10)
+			max, offset = "("+at, 0
+			ties = pipe.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Min: at, Max: at})
+		}
+		items = pipe.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+			Max:    max,
+			Min:    "(0",
+			Offset: int64(offset),
+			Count:  int64(page.Limit),
+		})
+		pipe.Expire(ctx, key, s.ttl)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("redis timeline error: %w", err)
+	}
+	if exists.Val() == 0 {
+		return nil, errs.ErrCacheKeyNotFound
+	}
+
+	zs := items.Val()
+	if n := len(zs); n > 0 && n == page.Limit {
+		at := strconv.FormatInt(int64(zs[n-1].Score), 10)
+		rest, err := s.redis.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Min: at, Max: at}).Result()
+		if err != nil {
+			return nil, fmt.Errorf("redis timeline error: %w", err)
+		}
+		zs = append(zs, rest...)
+	}
+	if ties != nil {
+		zs = append(zs, ties.Val()...)
+	}
+
+	seen := make(map[string]struct{}, len(zs))
+	res := make([]entity.TimelineEntry, 0, len(zs))
+	for _, z := range zs {
+		m := z.Member.(string)
+		if m == materializedMember {
+			continue
+		}
+		if _, ok := seen[m]; ok {
+			continue
+		}
+		seen[m] = struct{}{}
+
+		entry, err := parseMember(m)
+		if err != nil {
+			return nil, err
+		}
+		entry.CreatedAt = time.UnixMicro(int64(z.Score)).UTC()
+		if page.After != nil && !olderThan(entry, page.After) {
+			continue
+		}
+		res = append(res, *entry)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return olderThan(&res[j], &entity.Cursor{CreatedAt: res[i].CreatedAt, ID: res[i].TweetID})
+	})
+	if len(res) > page.Limit {
+		res = res[:page.Limit]
+	}
+	return res, nil
+}
+
+// RemoveFromTimelines drops entry from the timelines of userIDs.
+func (s *timelineDB) RemoveFromTimelines(ctx context.Context, userIDs []int, entry *entity.TimelineEntry) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := s.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, userID := range userIDs {
+			pipe.ZRem(ctx, s.buildTimelineKey(userID), member(entry))
+		}
+		return nil
+	})
+	return err
+}
+
+// RemoveActorFromTimeline drops every entry brought by actorID from the timeline of userID.
+func (s *timelineDB) RemoveActorFromTimeline(ctx context.Context, userID, actorID int) error {
+	key := s.buildTimelineKey(userID)
+	iter := s.redis.ZScan(ctx, key, 0, fmt.Sprintf("%d:*", actorID), 100).Iterator()
+
+	var members []any
+	for iter.Next(ctx) {
+		members = append(members, iter.Val())
+		// ZSCAN yields member and score in turn.
+		iter.Next(ctx)
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("redis timeline scan error: %w", err)
+	}
+	if len(members) == 0 {
+		return nil
+	}
+	return s.redis.ZRem(ctx, key, members...).Err()
+}
+
+func (s *timelineDB) buildTimelineKey(userID int) string {
+	return prefixTimeline + strconv.Itoa(userID)
+}
+
+// olderThan reports whether entry comes after the cursor in newest first order.
+func olderThan(entry *entity.TimelineEntry, cursor *entity.Cursor) bool {
+	at, cursorAt := entry.CreatedAt.UnixMicro(), cursor.CreatedAt.UnixMicro()
+	return at < cursorAt || (at == cursorAt && entry.TweetID < cursor.ID)
+}
+
+func score(createdAt time.Time) float64 {
+	return float64(createdAt.UnixMicro())
+}
+
+func member(entry *entity.TimelineEntry) string {
+	return fmt.Sprintf("%d:%d", entry.ActorID, entry.TweetID)
+}
+
+func parseMember(m string) (*entity.TimelineEntry, error) {
+	actor, tweet, ok := strings.Cut(m, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid timeline member %q", m)
+	}
+	actorID, err := strconv.Atoi(actor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeline member %q: %w", m, err)
+	}
+	tweetID, err := strconv.Atoi(tweet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeline member %q: %w", m, err)
+	}
+	return &entity.TimelineEntry{
+		TweetID: tweetID,
+		ActorID: actorID,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DenyAccess revokes a single access token until it would have expired anyway.
+func (s *tokensDB) DenyAccess(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.redis.Set(ctx, s.buildDeniedAccessKey(tokenID), 1, ttl).Err()
+}
+
+// DenyUserAccess revokes every access token of userID issued before issuedBefore.
+func (s *tokensDB) DenyUserAccess(ctx context.Context, userID string, issuedBefore time.Time) error {
+	return s.redis.Set(ctx, s.buildDeniedUserAccessKey(userID), issuedBefore.Unix(), s.accessTTL).Err()
+}
+
+func (s *tokensDB) IsAccessDenied(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error) {
+	pipe := s.redis.Pipeline()
+	tokenDenied := pipe.Exists(ctx, s.buildDeniedAccessKey(tokenID))
+	userCutoff := pipe.Get(ctx, s.buildDeniedUserAccessKey(userID))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return false, fmt.Errorf("redis error: %w", err)
+	}
+
+	if tokenDenied.Val() > 0 {
+		return true, nil
+	}
+	cutoff, err := userCutoff.Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("redis error: %w", err)
+	}
+	cutoffUnix, err := strconv.ParseInt(cutoff, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("corrupted access denial of user %s: %w", userID, err)
+	}
+	return issuedAt.Unix() < cutoffUnix, nil
+}
+
+func (s *tokensDB) buildDeniedAccessKey(tokenID string) string {
+	return prefixDeniedAccess + tokenID
+}
+
+func (s *tokensDB) buildDeniedUserAccessKey(userID string) string {
+	return prefixDeniedUserAccess + userID
+}
@@ -8,64 +8,50 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-func (s *tokensDB) StoreRefresh(ctx context.Context, refreshToken, userID string, ttl time.Duration) error {
-	refreshKey := s.buildRefreshKey(refreshToken)
-	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
-		pipe.SAdd(ctx, refreshKey, refreshToken)
-		pipe.Expire(ctx, s.buildSessionKey(userID), ttl)
-		pipe.Set(ctx, refreshKey, userID, ttl)
-		return nil
-	})
-	return err
-}
-
-func (s *tokensDB) GetUserIdByRefreshToken(ctx context.Context, refreshToken string) (string, error) {
-	refreshKey := s.buildRefreshKey(refreshToken)
-	userID, err := s.redis.Get(ctx, refreshKey).Result()
+func (s *tokensDB) GetSessionIdByRefreshToken(ctx context.Context, refreshToken string) (string, error) {
+	sessionID, err := s.redis.Get(ctx, s.buildRefreshKey(refreshToken)).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return "", nil
 		}
 		return "", fmt.Errorf("redis error: %w", err)
 	}
-	return userID, nil
+	return sessionID, nil
 }
 
-func (s *tokensDB) RemoveRefresh(ctx context.Context, refreshToken string) error {
-	userID, err := s.GetUserIdByRefreshToken(ctx, refreshToken)
-	if userID == "" {
-		return nil
-	}
+// ConsumeRefresh atomically takes refreshToken out of circulation and returns its session.
+// The token is remembered as used for ttl so that a replay can be told apart from
+// an unknown token. An empty session ID means the token is not live.
+func (s *tokensDB) ConsumeRefresh(ctx context.Context, refreshToken string, ttl time.Duration) (string, error) {
+	sessionID, err := s.redis.GetDel(ctx, s.buildRefreshKey(refreshToken)).Result()
 	if err != nil {
-		return err
+		if err == redis.Nil {
+			return "", nil
+		}
+		return "", fmt.Errorf("redis error: %w", err)
 	}
-	refreshKey := s.buildRefreshKey(refreshToken)
-	pipe := s.redis.Pipeline()
-	pipe.Del(ctx, refreshKey)
-	pipe.SRem(ctx, s.buildSessionKey(userID), refreshToken)
-	_, err = pipe.Exec(ctx)
-	return err
+	if err := s.redis.Set(ctx, s.buildUsedRefreshKey(refreshToken), sessionID, ttl).Err(); err != nil {
+		return "", fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+	return sessionID, nil
 }
 
-func (s *tokensDB) CloseAllSessions(ctx context.Context, userID string) error {
-	tokens, err := s.redis.SMembers(ctx, s.buildSessionKey(userID)).Result()
+// GetSessionIdByUsedRefresh returns the session a consumed refresh token belonged to.
+func (s *tokensDB) GetSessionIdByUsedRefresh(ctx context.Context, refreshToken string) (string, error) {
+	sessionID, err := s.redis.Get(ctx, s.buildUsedRefreshKey(refreshToken)).Result()
 	if err != nil {
-		return err
-	}
-	pipe := s.redis.Pipeline()
-	for _, token := range tokens {
-		refreshKey := s.buildRefreshKey(token)
-		pipe.Del(ctx, refreshKey)
-		pipe.SRem(ctx, s.buildSessionKey(userID), token)
+		if err == redis.Nil {
+			return "", nil
+		}
+		return "", fmt.Errorf("redis error: %w", err)
 	}
-	_, err = pipe.Exec(ctx)
-	return err
+	return sessionID, nil
 }
 
 func (s *tokensDB) buildRefreshKey(refreshToken string) string {
 	return prefixRefreshToken + refreshToken
 }
 
-func (s *tokensDB) buildSessionKey(userID string) string {
-	return prefixUserSessions + userID
+func (s *tokensDB) buildUsedRefreshKey(refreshToken string) string {
+	return prefixUsedRefreshToken + refreshToken
 }
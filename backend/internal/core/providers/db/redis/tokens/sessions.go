@@ -0,0 +1,195 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+func (s *tokensDB) CreateSession(ctx context.Context, session *entity.Session, refreshToken string, ttl time.Duration) error {
+	userID := strconv.Itoa(session.UserID)
+	sessionKey := s.buildSessionKey(session.ID)
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKey, map[string]any{
+			fieldUserID:     userID,
+			fieldUserAgent:  session.Device.UserAgent,
+			fieldIP:         session.Device.IP,
+			fieldCreatedAt:  session.CreatedAt.Unix(),
+			fieldLastUsedAt: session.LastUsedAt.Unix(),
+			fieldRefresh:    refreshToken,
+		})
+		pipe.Expire(ctx, sessionKey, ttl)
+		pipe.Set(ctx, s.buildRefreshKey(refreshToken), session.ID, ttl)
+		pipe.SAdd(ctx, s.buildUserSessionsKey(userID), session.ID)
+		pipe.Expire(ctx, s.buildUserSessionsKey(userID), ttl)
+		return nil
+	})
+	return err
+}
+
+// RotateSession makes refreshToken the live token of session and records its latest use.
+func (s *tokensDB) RotateSession(ctx context.Context, session *entity.Session, refreshToken string, ttl time.Duration) error {
+	sessionKey := s.buildSessionKey(session.ID)
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKey, map[string]any{
+			fieldIP:         session.Device.IP,
+			fieldLastUsedAt: session.LastUsedAt.Unix(),
+			fieldRefresh:    refreshToken,
+		})
+		pipe.Expire(ctx, sessionKey, ttl)
+		pipe.Set(ctx, s.buildRefreshKey(refreshToken), session.ID, ttl)
+		pipe.Expire(ctx, s.buildUserSessionsKey(strconv.Itoa(session.UserID)), ttl)
+		return nil
+	})
+	return err
+}
+
+func (s *tokensDB) GetSession(ctx context.Context, sessionID string) (*entity.Session, error) {
+	fields, err := s.redis.HGetAll(ctx, s.buildSessionKey(sessionID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, errs.ErrSessionNotFound
+	}
+	return parseSession(sessionID, fields)
+}
+
+func (s *tokensDB) SessionExists(ctx context.Context, sessionID string) (bool, error) {
+	n, err := s.redis.Exists(ctx, s.buildSessionKey(sessionID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis error: %w", err)
+	}
+	return n > 0, nil
+}
+
+// GetUserSessions lists the live sessions of userID, dropping ids whose session has expired.
+func (s *tokensDB) GetUserSessions(ctx context.Context, userID string) ([]entity.Session, error) {
+	userSessionsKey := s.buildUserSessionsKey(userID)
+	ids, err := s.redis.SMembers(ctx, userSessionsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	pipe := s.redis.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, s.buildSessionKey(id))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	sessions := make([]entity.Session, 0, len(ids))
+	var expired []any
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			expired = append(expired, ids[i])
+			continue
+		}
+		session, err := parseSession(ids[i], fields)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	if len(expired) > 0 {
+		if err := s.redis.SRem(ctx, userSessionsKey, expired...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to prune expired sessions: %w", err)
+		}
+	}
+	return sessions, nil
+}
+
+func (s *tokensDB) CloseSession(ctx context.Context, sessionID string) error {
+	userID, err := s.redis.HGet(ctx, s.buildSessionKey(sessionID), fieldUserID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil
+		}
+		return fmt.Errorf("redis error: %w", err)
+	}
+	return s.closeSessions(ctx, userID, []string{sessionID})
+}
+
+// CloseOtherSessions closes every session of userID except keepSessionID.
+func (s *tokensDB) CloseOtherSessions(ctx context.Context, userID, keepSessionID string) error {
+	ids, err := s.redis.SMembers(ctx, s.buildUserSessionsKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	others := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != keepSessionID {
+			others = append(others, id)
+		}
+	}
+	return s.closeSessions(ctx, userID, others)
+}
+
+func (s *tokensDB) CloseAllSessions(ctx context.Context, userID string) error {
+	ids, err := s.redis.SMembers(ctx, s.buildUserSessionsKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	return s.closeSessions(ctx, userID, ids)
+}
+
+func (s *tokensDB) closeSessions(ctx context.Context, userID string, sessionIDs []string) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+
+	pipe := s.redis.Pipeline()
+	refreshCmds := make([]*redis.StringCmd, len(sessionIDs))
+	for i, id := range sessionIDs {
+		refreshCmds[i] = pipe.HGet(ctx, s.buildSessionKey(id), fieldRefresh)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	pipe = s.redis.TxPipeline()
+	for i, id := range sessionIDs {
+		if refreshToken, err := refreshCmds[i].Result(); err == nil {
+			pipe.Del(ctx, s.buildRefreshKey(refreshToken))
+		}
+		pipe.Del(ctx, s.buildSessionKey(id))
+		pipe.SRem(ctx, s.buildUserSessionsKey(userID), id)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func parseSession(sessionID string, fields map[string]string) (*entity.Session, error) {
+	userID, err := strconv.Atoi(fields[fieldUserID])
+	if err != nil {
+		return nil, fmt.Errorf("corrupted session %s: %w", sessionID, err)
+	}
+	createdAt, _ := strconv.ParseInt(fields[fieldCreatedAt], 10, 64)
+	lastUsedAt, _ := strconv.ParseInt(fields[fieldLastUsedAt], 10, 64)
+	return &entity.Session{
+		ID:     sessionID,
+		UserID: userID,
+		Device: entity.Device{
+			UserAgent: fields[fieldUserAgent],
+			IP:        fields[fieldIP],
+		},
+		CreatedAt:  time.Unix(createdAt, 0),
+		LastUsedAt: time.Unix(lastUsedAt, 0),
+	}, nil
+}
+
+func (s *tokensDB) buildSessionKey(sessionID string) string {
+	return prefixSession + sessionID
+}
+
+func (s *tokensDB) buildUserSessionsKey(userID string) string {
+	return prefixUserSessions + userID
+}
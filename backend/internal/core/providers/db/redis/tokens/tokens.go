@@ -1,21 +1,41 @@
 package tokens
 
 import (
+	"time"
+
 	"github.com/redis/go-redis/v9"
 )
 
 const (
-	prefixRefreshToken  = "refresh:"
-	prefixRecoveryToken = "recovery:"
-	prefixUserSessions  = "user_sessions:"
+	prefixRefreshToken      = "refresh:"
+	prefixUsedRefreshToken  = "refresh_used:"
+	prefixRecoveryToken     = "recovery:"
+	prefixVerificationToken = "verification:"
+	prefixSession           = "session:"
+	prefixUserSessions      = "user_sessions:"
+	prefixDeniedAccess      = "denied_access:"
+	prefixDeniedUserAccess  = "denied_user_access:"
+)
+
+const (
+	fieldUserID     = "user_id"
+	fieldUserAgent  = "user_agent"
+	fieldIP         = "ip"
+	fieldCreatedAt  = "created_at"
+	fieldLastUsedAt = "last_used_at"
+	fieldRefresh    = "refresh"
 )
 
 type tokensDB struct {
-	redis *redis.Client
+	redis     *redis.Client
+	accessTTL time.Duration
 }
 
-func NewTokenStorage(redis *redis.Client) *tokensDB {
+// NewTokenStorage keeps per-user access denials for accessTTL, after which every
+// token they cover has expired on its own.
+func NewTokenStorage(redis *redis.Client, accessTTL time.Duration) *tokensDB {
 	return &tokensDB{
-		redis: redis,
+		redis:     redis,
+		accessTTL: accessTTL,
 	}
 }
@@ -0,0 +1,39 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func (s *tokensDB) StoreVerification(ctx context.Context, verificationToken, userID string, ttl time.Duration) error {
+	verificationKey := s.buildVerificationKey(verificationToken)
+	return s.redis.Set(ctx, verificationKey, userID, ttl).Err()
+}
+
+func (s *tokensDB) GetUserIdByVerificationToken(ctx context.Context, verificationToken string) (string, error) {
+	verificationKey := s.buildVerificationKey(verificationToken)
+	userID, err := s.redis.Get(ctx, verificationKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", nil
+		}
+		return "", fmt.Errorf("redis error: %w", err)
+	}
+	return userID, nil
+}
+
+func (s *tokensDB) RemoveVerification(ctx context.Context, verificationToken string) error {
+	verificationKey := s.buildVerificationKey(verificationToken)
+	err := s.redis.Del(ctx, verificationKey).Err()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to remove verification token: %w", err)
+	}
+	return nil
+}
+
+func (s *tokensDB) buildVerificationKey(verificationToken string) string {
+	return prefixVerificationToken + verificationToken
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/sirupsen/logrus"
+)
+
+// fileMailer stands in for SMTP in local runs and tests: messages are appended
+// to a file, or logged when no path is configured.
+type fileMailer struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileMailer(path string) *fileMailer {
+	return &fileMailer{
+		path: path,
+	}
+}
+
+func (m *fileMailer) Send(ctx context.Context, mail *entity.Mail) error {
+	if m.path == "" {
+		logrus.WithFields(logrus.Fields{
+			"to":      mail.To,
+			"subject": mail.Subject,
+			"body":    mail.Body,
+		}).Info("mail")
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(formatMessage("", mail), "\r\n\r\n"...)); err != nil {
+		return fmt.Errorf("failed to write mail: %w", err)
+	}
+	return nil
+}
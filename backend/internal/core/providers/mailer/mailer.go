@@ -0,0 +1,20 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type Mailer interface {
+	Send(ctx context.Context, mail *entity.Mail) error
+}
+
+// NewMailer picks the transport named by cfg.Driver.
+func NewMailer(cfg *config.MailerConfig) Mailer {
+	if cfg.Driver == "smtp" {
+		return NewSMTPMailer(cfg)
+	}
+	return NewFileMailer(cfg.FilePath)
+}
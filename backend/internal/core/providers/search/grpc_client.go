@@ -3,6 +3,7 @@ package search
 import (
 	"context"
 
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	searchproto "github.com/kust1q/Zapp/backend/pkg/gen/proto/search"
 	"google.golang.org/grpc"
 )
@@ -41,3 +42,20 @@ func (s *clientSearchService) SearchUsers(ctx context.Context, query string) ([]
 	}
 	return res, nil
 }
+
+func (s *clientSearchService) GetTrends(ctx context.Context, limit int) ([]entity.Trend, error) {
+	resp, err := s.client.GetTrends(ctx, &searchproto.GetTrendsRequest{Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]entity.Trend, 0, len(resp.Trends))
+	for _, trend := range resp.Trends {
+		res = append(res, entity.Trend{
+			Term:     trend.Term,
+			Kind:     entity.TrendKind(trend.Kind),
+			Count:    int(trend.Count),
+			Velocity: trend.Velocity,
+		})
+	}
+	return res, nil
+}
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
-	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,7 +18,7 @@ const (
 type client struct {
 	hub    *hub
 	conn   *websocket.Conn
-	send   chan *entity.Notification
+	send   chan any
 	userID int
 }
 
@@ -27,7 +26,7 @@ func NewClient(hub *hub, conn *websocket.Conn, userID int) *client {
 	return &client{
 		hub:    hub,
 		conn:   conn,
-		send:   make(chan *entity.Notification, 256),
+		send:   make(chan any, 256),
 		userID: userID,
 	}
 }
@@ -65,16 +64,16 @@ func (c *client) WritePump() {
 
 	for {
 		select {
-		case notification, ok := <-c.send:
+		case frame, ok := <-c.send:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
 				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
 
-			data, err := json.Marshal(notification)
+			data, err := json.Marshal(frame)
 			if err != nil {
-				logrus.Errorf("failed to marshal notification: %v", err)
+				logrus.Errorf("failed to marshal frame: %v", err)
 				continue
 			}
 
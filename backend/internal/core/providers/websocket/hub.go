@@ -7,10 +7,16 @@ import (
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 )
 
+// delivery is a frame addressed to a single user; the payload is sent as JSON.
+type delivery struct {
+	recipientID int
+	payload     any
+}
+
 type hub struct {
 	clients    map[*client]bool
 	users      map[int]*client
-	broadcast  chan *entity.Notification
+	broadcast  chan *delivery
 	register   chan *client
 	unregister chan *client
 	mu         sync.RWMutex
@@ -20,7 +26,7 @@ func NewHub() *hub {
 	return &hub{
 		clients:    make(map[*client]bool),
 		users:      make(map[int]*client),
-		broadcast:  make(chan *entity.Notification, 256),
+		broadcast:  make(chan *delivery, 256),
 		register:   make(chan *client),
 		unregister: make(chan *client),
 	}
@@ -44,11 +50,11 @@ func (h *hub) Run() {
 			}
 			h.mu.Unlock()
 
-		case notification := <-h.broadcast:
+		case delivery := <-h.broadcast:
 			h.mu.RLock()
-			if client, ok := h.users[notification.RecipientID]; ok {
+			if client, ok := h.users[delivery.recipientID]; ok {
 				select {
-				case client.send <- notification:
+				case client.send <- delivery.payload:
 				default:
 					close(client.send)
 					delete(h.clients, client)
@@ -61,7 +67,11 @@ func (h *hub) Run() {
 }
 
 func (h *hub) SendNotification(notification *entity.Notification) {
-	h.broadcast <- notification
+	h.broadcast <- &delivery{recipientID: notification.RecipientID, payload: notification}
+}
+
+func (h *hub) SendChatFrame(frame *entity.ChatFrame) {
+	h.broadcast <- &delivery{recipientID: frame.RecipientID, payload: frame}
 }
 
 func (h *hub) HandleNewConnection(conn *websocket.Conn, userID int) {
@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+type service struct {
+	db           db
+	tokens       tokenStorage
+	tweetService tweetService
+	mediaService mediaService
+}
+
+func NewAdminService(db db, tokens tokenStorage, tweetService tweetService, mediaService mediaService) *service {
+	return &service{
+		db:           db,
+		tokens:       tokens,
+		tweetService: tweetService,
+		mediaService: mediaService,
+	}
+}
+
+// DeactivateUser blocks sign-in for the user and revokes their refresh and access tokens.
+func (s *service) DeactivateUser(ctx context.Context, adminID, userID int) error {
+	if adminID == userID {
+		return errs.ErrInvalidInput
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.db.SetUserActive(ctx, userID, false); err != nil {
+		return fmt.Errorf("failed to deactivate user: %w", err)
+	}
+	if err := s.tokens.CloseAllSessions(ctx, strconv.Itoa(userID)); err != nil {
+		return fmt.Errorf("failed to close sessions: %w", err)
+	}
+	if err := s.tokens.DenyUserAccess(ctx, strconv.Itoa(userID), time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+	return s.audit(ctx, adminID, entity.AuditDeactivateUser, entity.AuditTargetUser, userID)
+}
+
+func (s *service) ReactivateUser(ctx context.Context, adminID, userID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.db.SetUserActive(ctx, userID, true); err != nil {
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+	return s.audit(ctx, adminID, entity.AuditReactivateUser, entity.AuditTargetUser, userID)
+}
+
+func (s *service) DeleteTweet(ctx context.Context, adminID, tweetID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.tweetService.ForceDeleteTweet(ctx, tweetID); err != nil {
+		return fmt.Errorf("failed to delete tweet: %w", err)
+	}
+	return s.audit(ctx, adminID, entity.AuditDeleteTweet, entity.AuditTargetTweet, tweetID)
+}
+
+func (s *service) DeleteTweetMedia(ctx context.Context, adminID, tweetID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.mediaService.ForceDeleteTweetMedia(ctx, tweetID); err != nil {
+		return fmt.Errorf("failed to delete tweet media: %w", err)
+	}
+	return s.audit(ctx, adminID, entity.AuditDeleteTweetMedia, entity.AuditTargetTweet, tweetID)
+}
+
+func (s *service) GetRecentSignups(ctx context.Context, page *entity.Page) ([]entity.User, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	users, next, err := s.db.GetRecentUsers(ctx, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get recent users: %w", err)
+	}
+	return users, next, nil
+}
+
+func (s *service) GetAuditLog(ctx context.Context, page *entity.Page) ([]entity.AuditEntry, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	entries, next, err := s.db.GetAuditLog(ctx, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+	return entries, next, nil
+}
+
+func (s *service) audit(ctx context.Context, adminID int, action entity.AuditAction, targetType entity.AuditTarget, targetID int) error {
+	err := s.db.CreateAuditEntry(ctx, &entity.AuditEntry{
+		AdminID:    adminID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
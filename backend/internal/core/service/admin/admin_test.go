@@ -0,0 +1,178 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/core/service/admin"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDB struct {
+	mock.Mock
+}
+
+func (m *mockDB) SetUserActive(ctx context.Context, userID int, isActive bool) error {
+	args := m.Called(ctx, userID, isActive)
+	return args.Error(0)
+}
+
+func (m *mockDB) GetRecentUsers(ctx context.Context, page *entity.Page) ([]entity.User, *entity.Cursor, error) {
+	args := m.Called(ctx, page)
+	users, _ := args.Get(0).([]entity.User)
+	cursor, _ := args.Get(1).(*entity.Cursor)
+	return users, cursor, args.Error(2)
+}
+
+func (m *mockDB) CreateAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *mockDB) GetAuditLog(ctx context.Context, page *entity.Page) ([]entity.AuditEntry, *entity.Cursor, error) {
+	args := m.Called(ctx, page)
+	entries, _ := args.Get(0).([]entity.AuditEntry)
+	cursor, _ := args.Get(1).(*entity.Cursor)
+	return entries, cursor, args.Error(2)
+}
+
+type mockTokenStorage struct {
+	mock.Mock
+}
+
+func (m *mockTokenStorage) CloseAllSessions(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockTokenStorage) DenyUserAccess(ctx context.Context, userID string, issuedBefore time.Time) error {
+	args := m.Called(ctx, userID, issuedBefore)
+	return args.Error(0)
+}
+
+type mockTweetService struct {
+	mock.Mock
+}
+
+func (m *mockTweetService) ForceDeleteTweet(ctx context.Context, tweetID int) error {
+	args := m.Called(ctx, tweetID)
+	return args.Error(0)
+}
+
+type mockMediaService struct {
+	mock.Mock
+}
+
+func (m *mockMediaService) ForceDeleteTweetMedia(ctx context.Context, tweetID int) error {
+	args := m.Called(ctx, tweetID)
+	return args.Error(0)
+}
+
+func auditEntry(action entity.AuditAction, targetType entity.AuditTarget, targetID int) interface{} {
+	return mock.MatchedBy(func(entry *entity.AuditEntry) bool {
+		return entry.AdminID == 1 && entry.Action == action && entry.TargetType == targetType && entry.TargetID == targetID
+	})
+}
+
+func TestService_DeactivateUser_Success(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	service := admin.NewAdminService(mockDB, mockTokens, &mockTweetService{}, &mockMediaService{})
+
+	mockDB.On("SetUserActive", mock.Anything, 2, false).Return(nil).Once()
+	mockTokens.On("CloseAllSessions", mock.Anything, "2").Return(nil).Once()
+	mockTokens.On("DenyUserAccess", mock.Anything, "2", mock.Anything).Return(nil).Once()
+	mockDB.On("CreateAuditEntry", mock.Anything, auditEntry(entity.AuditDeactivateUser, entity.AuditTargetUser, 2)).Return(nil).Once()
+
+	err := service.DeactivateUser(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockTokens.AssertExpectations(t)
+}
+
+func TestService_DeactivateUser_Self(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	service := admin.NewAdminService(mockDB, mockTokens, &mockTweetService{}, &mockMediaService{})
+
+	err := service.DeactivateUser(context.Background(), 1, 1)
+
+	assert.ErrorIs(t, err, errs.ErrInvalidInput)
+	mockDB.AssertNotCalled(t, "SetUserActive", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_DeactivateUser_NotFound(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	service := admin.NewAdminService(mockDB, mockTokens, &mockTweetService{}, &mockMediaService{})
+
+	mockDB.On("SetUserActive", mock.Anything, 2, false).Return(errs.ErrUserNotFound).Once()
+
+	err := service.DeactivateUser(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+	mockTokens.AssertNotCalled(t, "CloseAllSessions", mock.Anything, mock.Anything)
+	mockDB.AssertNotCalled(t, "CreateAuditEntry", mock.Anything, mock.Anything)
+}
+
+func TestService_ReactivateUser_Success(t *testing.T) {
+	mockDB := &mockDB{}
+	service := admin.NewAdminService(mockDB, &mockTokenStorage{}, &mockTweetService{}, &mockMediaService{})
+
+	mockDB.On("SetUserActive", mock.Anything, 2, true).Return(nil).Once()
+	mockDB.On("CreateAuditEntry", mock.Anything, auditEntry(entity.AuditReactivateUser, entity.AuditTargetUser, 2)).Return(nil).Once()
+
+	err := service.ReactivateUser(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_DeleteTweet_Success(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTweets := &mockTweetService{}
+	service := admin.NewAdminService(mockDB, &mockTokenStorage{}, mockTweets, &mockMediaService{})
+
+	mockTweets.On("ForceDeleteTweet", mock.Anything, 10).Return(nil).Once()
+	mockDB.On("CreateAuditEntry", mock.Anything, auditEntry(entity.AuditDeleteTweet, entity.AuditTargetTweet, 10)).Return(nil).Once()
+
+	err := service.DeleteTweet(context.Background(), 1, 10)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockTweets.AssertExpectations(t)
+}
+
+func TestService_DeleteTweet_NotFound(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTweets := &mockTweetService{}
+	service := admin.NewAdminService(mockDB, &mockTokenStorage{}, mockTweets, &mockMediaService{})
+
+	mockTweets.On("ForceDeleteTweet", mock.Anything, 10).Return(errs.ErrTweetNotFound).Once()
+
+	err := service.DeleteTweet(context.Background(), 1, 10)
+
+	assert.ErrorIs(t, err, errs.ErrTweetNotFound)
+	mockDB.AssertNotCalled(t, "CreateAuditEntry", mock.Anything, mock.Anything)
+}
+
+func TestService_DeleteTweetMedia_AuditFailure(t *testing.T) {
+	mockDB := &mockDB{}
+	mockMedia := &mockMediaService{}
+	service := admin.NewAdminService(mockDB, &mockTokenStorage{}, &mockTweetService{}, mockMedia)
+
+	mockMedia.On("ForceDeleteTweetMedia", mock.Anything, 10).Return(nil).Once()
+	mockDB.On("CreateAuditEntry", mock.Anything, auditEntry(entity.AuditDeleteTweetMedia, entity.AuditTargetTweet, 10)).Return(errors.New("db error")).Once()
+
+	err := service.DeleteTweetMedia(context.Background(), 1, 10)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to write audit entry")
+	mockMedia.AssertExpectations(t)
+}
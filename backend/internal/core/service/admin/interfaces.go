@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type (
+	db interface {
+		SetUserActive(ctx context.Context, userID int, isActive bool) error
+		GetRecentUsers(ctx context.Context, page *entity.Page) ([]entity.User, *entity.Cursor, error)
+		CreateAuditEntry(ctx context.Context, entry *entity.AuditEntry) error
+		GetAuditLog(ctx context.Context, page *entity.Page) ([]entity.AuditEntry, *entity.Cursor, error)
+	}
+
+	tokenStorage interface {
+		CloseAllSessions(ctx context.Context, userID string) error
+		DenyUserAccess(ctx context.Context, userID string, issuedBefore time.Time) error
+	}
+
+	tweetService interface {
+		ForceDeleteTweet(ctx context.Context, tweetID int) error
+	}
+
+	mediaService interface {
+		ForceDeleteTweetMedia(ctx context.Context, tweetID int) error
+	}
+)
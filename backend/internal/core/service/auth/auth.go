@@ -7,20 +7,20 @@ import (
 )
 
 type service struct {
-	cfg      *config.AuthServiceConfig
-	db       db
-	tokens   tokenStorage
-	media    mediaService
-	producer eventProducer
+	cfg    *config.AuthServiceConfig
+	db     db
+	tokens tokenStorage
+	media  mediaService
+	mailer mailer
 }
 
-func NewAuthService(cfg *config.AuthServiceConfig, db db, media mediaService, tokens tokenStorage, producer eventProducer) *service {
+func NewAuthService(cfg *config.AuthServiceConfig, db db, media mediaService, tokens tokenStorage, mailer mailer) *service {
 	return &service{
-		cfg:      cfg,
-		db:       db,
-		media:    media,
-		tokens:   tokens,
-		producer: producer,
+		cfg:    cfg,
+		db:     db,
+		media:  media,
+		tokens: tokens,
+		mailer: mailer,
 	}
 }
 
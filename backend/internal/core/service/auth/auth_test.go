@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,6 +39,11 @@ func (m *mockDB) CreateUserTx(ctx context.Context, tx *sql.Tx, user *entity.User
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
+func (m *mockDB) CreateOutboxEventTx(ctx context.Context, tx *sql.Tx, topic string, event any) error {
+	args := m.Called(ctx, tx, topic, event)
+	return args.Error(0)
+}
+
 func (m *mockDB) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	args := m.Called(ctx, email)
 	user := args.Get(0)
@@ -70,6 +76,11 @@ func (m *mockDB) UpdateUserPassword(ctx context.Context, userID int, password st
 	return args.Error(0)
 }
 
+func (m *mockDB) SetUserEmailVerified(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 func (m *mockDB) DeleteUser(ctx context.Context, userID int) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
@@ -89,14 +100,41 @@ type mockTokenStorage struct {
 	mock.Mock
 }
 
-func (m *mockTokenStorage) StoreRefresh(ctx context.Context, refreshToken, userID string, ttl time.Duration) error {
-	args := m.Called(ctx, refreshToken, userID, ttl)
+func (m *mockTokenStorage) CreateSession(ctx context.Context, session *entity.Session, refreshToken string, ttl time.Duration) error {
+	args := m.Called(ctx, session, refreshToken, ttl)
 	return args.Error(0)
 }
 
-func (m *mockTokenStorage) GetUserIdByRefreshToken(ctx context.Context, refreshToken string) (string, error) {
-	args := m.Called(ctx, refreshToken)
-	return args.String(0), args.Error(1)
+func (m *mockTokenStorage) RotateSession(ctx context.Context, session *entity.Session, refreshToken string, ttl time.Duration) error {
+	args := m.Called(ctx, session, refreshToken, ttl)
+	return args.Error(0)
+}
+
+func (m *mockTokenStorage) GetSession(ctx context.Context, sessionID string) (*entity.Session, error) {
+	args := m.Called(ctx, sessionID)
+	session, _ := args.Get(0).(*entity.Session)
+	return session, args.Error(1)
+}
+
+func (m *mockTokenStorage) SessionExists(ctx context.Context, sessionID string) (bool, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockTokenStorage) GetUserSessions(ctx context.Context, userID string) ([]entity.Session, error) {
+	args := m.Called(ctx, userID)
+	sessions, _ := args.Get(0).([]entity.Session)
+	return sessions, args.Error(1)
+}
+
+func (m *mockTokenStorage) CloseSession(ctx context.Context, sessionID string) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *mockTokenStorage) CloseOtherSessions(ctx context.Context, userID, keepSessionID string) error {
+	args := m.Called(ctx, userID, keepSessionID)
+	return args.Error(0)
 }
 
 func (m *mockTokenStorage) CloseAllSessions(ctx context.Context, userID string) error {
@@ -104,9 +142,19 @@ func (m *mockTokenStorage) CloseAllSessions(ctx context.Context, userID string)
 	return args.Error(0)
 }
 
-func (m *mockTokenStorage) RemoveRefresh(ctx context.Context, refreshToken string) error {
+func (m *mockTokenStorage) GetSessionIdByRefreshToken(ctx context.Context, refreshToken string) (string, error) {
 	args := m.Called(ctx, refreshToken)
-	return args.Error(0)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockTokenStorage) ConsumeRefresh(ctx context.Context, refreshToken string, ttl time.Duration) (string, error) {
+	args := m.Called(ctx, refreshToken, ttl)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockTokenStorage) GetSessionIdByUsedRefresh(ctx context.Context, refreshToken string) (string, error) {
+	args := m.Called(ctx, refreshToken)
+	return args.String(0), args.Error(1)
 }
 
 func (m *mockTokenStorage) StoreRecovery(ctx context.Context, token, userID string, ttl time.Duration) error {
@@ -119,6 +167,50 @@ func (m *mockTokenStorage) GetUserIdByRecoveryToken(ctx context.Context, recover
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockTokenStorage) RemoveRecovery(ctx context.Context, recoveryToken string) error {
+	args := m.Called(ctx, recoveryToken)
+	return args.Error(0)
+}
+
+func (m *mockTokenStorage) StoreVerification(ctx context.Context, token, userID string, ttl time.Duration) error {
+	args := m.Called(ctx, token, userID, ttl)
+	return args.Error(0)
+}
+
+func (m *mockTokenStorage) GetUserIdByVerificationToken(ctx context.Context, verificationToken string) (string, error) {
+	args := m.Called(ctx, verificationToken)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockTokenStorage) RemoveVerification(ctx context.Context, verificationToken string) error {
+	args := m.Called(ctx, verificationToken)
+	return args.Error(0)
+}
+
+func (m *mockTokenStorage) DenyAccess(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	args := m.Called(ctx, tokenID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *mockTokenStorage) DenyUserAccess(ctx context.Context, userID string, issuedBefore time.Time) error {
+	args := m.Called(ctx, userID, issuedBefore)
+	return args.Error(0)
+}
+
+func (m *mockTokenStorage) IsAccessDenied(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error) {
+	args := m.Called(ctx, tokenID, userID, issuedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockMailer struct {
+	mock.Mock
+}
+
+func (m *mockMailer) Send(ctx context.Context, mail *entity.Mail) error {
+	args := m.Called(ctx, mail)
+	return args.Error(0)
+}
+
 type mockMediaService struct {
 	mock.Mock
 }
@@ -132,15 +224,6 @@ func (m *mockMediaService) UploadAvatarTx(ctx context.Context, userID int, file
 	return avatar.(*entity.Avatar), args.Error(1)
 }
 
-type mockEventProducer struct {
-	mock.Mock
-}
-
-func (m *mockEventProducer) Publish(ctx context.Context, topic string, event any) error {
-	args := m.Called(ctx, topic, event)
-	return args.Error(0)
-}
-
 func generateTestRSAKeys(t *testing.T) (*rsa.PrivateKey, *rsa.PublicKey) {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	require.NoError(t, err)
@@ -160,7 +243,7 @@ func TestService_GetRefreshTTL(t *testing.T) {
 		&mockDB{},
 		&mockMediaService{},
 		&mockTokenStorage{},
-		&mockEventProducer{},
+		&mockMailer{},
 	)
 
 	ttl := service.GetRefreshTTL()
@@ -179,9 +262,8 @@ func TestService_SignIn_Success(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	password := "password123"
@@ -195,22 +277,34 @@ func TestService_SignIn_Success(t *testing.T) {
 			Password: string(hashedPassword),
 		},
 		IsSuperuser: false,
+		IsActive:    true,
 	}
 
+	device := &entity.Device{UserAgent: "test-agent", IP: "127.0.0.1"}
+
+	var sessionID string
 	mockDB.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil).Once()
-	mockTokens.On("StoreRefresh", mock.Anything, mock.Anything, "1", cfg.RefreshTTL).Return(nil).Once()
+	mockTokens.On("CreateSession", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+		sessionID = s.ID
+		return s.UserID == 1 && s.Device == *device
+	}), mock.AnythingOfType("string"), cfg.RefreshTTL).Return(nil).Once()
 
 	req := &entity.Credential{
 		Email:    "test@example.com",
 		Password: password,
 	}
 
-	tokens, err := service.SignIn(ctx, req)
+	tokens, err := service.SignIn(ctx, req, device)
 	assert.NoError(t, err)
 	assert.NotNil(t, tokens)
 	assert.NotEmpty(t, tokens.Access.Access)
 	assert.NotEmpty(t, tokens.Refresh.Refresh)
 
+	claims, err := service.VerifyAccessToken(tokens.Access.Access)
+	assert.NoError(t, err)
+	assert.Equal(t, sessionID, claims.SessionID)
+	assert.NotEmpty(t, claims.TokenID)
+
 	mockDB.AssertExpectations(t)
 	mockTokens.AssertExpectations(t)
 }
@@ -225,9 +319,8 @@ func TestService_SignIn_InvalidCredentials(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	password := "password123"
@@ -249,12 +342,52 @@ func TestService_SignIn_InvalidCredentials(t *testing.T) {
 		Password: password,
 	}
 
-	tokens, err := service.SignIn(ctx, req)
+	tokens, err := service.SignIn(ctx, req, &entity.Device{})
 	assert.Error(t, err)
 	assert.Nil(t, tokens)
 	assert.Equal(t, errs.ErrInvalidCredentials, err)
 }
 
+func TestService_SignIn_InactiveUser(t *testing.T) {
+	privateKey, publicKey := generateTestRSAKeys(t)
+	cfg := &config.AuthServiceConfig{
+		PrivateKey: privateKey,
+		PublicKey:  publicKey,
+	}
+
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
+
+	ctx := context.Background()
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+	user := &entity.User{
+		ID:       1,
+		Username: "testuser",
+		Credential: &entity.Credential{
+			Email:    "test@example.com",
+			Password: string(hashedPassword),
+		},
+		IsActive: false,
+	}
+
+	mockDB.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil).Once()
+
+	req := &entity.Credential{
+		Email:    "test@example.com",
+		Password: password,
+	}
+
+	tokens, err := service.SignIn(ctx, req, &entity.Device{})
+	assert.ErrorIs(t, err, errs.ErrUserInactive)
+	assert.Nil(t, tokens)
+	mockTokens.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestService_SignUp_EmailAlreadyExists(t *testing.T) {
 	privateKey, publicKey := generateTestRSAKeys(t)
 	cfg := &config.AuthServiceConfig{
@@ -265,9 +398,8 @@ func TestService_SignUp_EmailAlreadyExists(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 
@@ -309,9 +441,8 @@ func TestService_Refresh_Success(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	refreshToken := "valid-refresh-token"
@@ -323,24 +454,32 @@ func TestService_Refresh_Success(t *testing.T) {
 			Email: "test@example.com",
 		},
 		IsSuperuser: false,
+		IsActive:    true,
 	}
 
-	mockTokens.On("GetUserIdByRefreshToken", mock.Anything, refreshToken).Return("1", nil).Once()
-	mockTokens.On("RemoveRefresh", mock.Anything, refreshToken).Return(nil).Once()
+	session := &entity.Session{ID: "session-1", UserID: 1, Device: entity.Device{UserAgent: "test-agent", IP: "10.0.0.1"}}
+
+	mockTokens.On("ConsumeRefresh", mock.Anything, refreshToken, cfg.RefreshTTL).Return("session-1", nil).Once()
+	mockTokens.On("GetSession", mock.Anything, "session-1").Return(session, nil).Once()
 	mockDB.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
-	mockTokens.On("StoreRefresh", mock.Anything, mock.Anything, "1", cfg.RefreshTTL).Return(nil).Once()
+	mockTokens.On("RotateSession", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+		return s.ID == "session-1" && s.Device.IP == "127.0.0.1"
+	}), mock.AnythingOfType("string"), cfg.RefreshTTL).Return(nil).Once()
 
 	req := &entity.Refresh{Refresh: refreshToken}
-	tokens, err := service.Refresh(ctx, req)
+	tokens, err := service.Refresh(ctx, req, &entity.Device{UserAgent: "test-agent", IP: "127.0.0.1"})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, tokens)
 	assert.NotEmpty(t, tokens.Access.Access)
 	assert.NotEmpty(t, tokens.Refresh.Refresh)
+	assert.NotEqual(t, refreshToken, tokens.Refresh.Refresh)
 
-	userID, err := service.VerifyAccessToken(tokens.Access.Access)
+	claims, err := service.VerifyAccessToken(tokens.Access.Access)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, userID)
+	assert.Equal(t, 1, claims.UserID)
+	assert.Equal(t, "session-1", claims.SessionID)
+	mockTokens.AssertExpectations(t)
 }
 
 func TestService_Refresh_InvalidToken(t *testing.T) {
@@ -353,21 +492,44 @@ func TestService_Refresh_InvalidToken(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	refreshToken := "invalid-refresh-token"
 
-	mockTokens.On("GetUserIdByRefreshToken", mock.Anything, refreshToken).Return("", errs.ErrTokenNotFound).Once()
+	mockTokens.On("ConsumeRefresh", mock.Anything, refreshToken, cfg.RefreshTTL).Return("", nil).Once()
+	mockTokens.On("GetSessionIdByUsedRefresh", mock.Anything, refreshToken).Return("", nil).Once()
 
 	req := &entity.Refresh{Refresh: refreshToken}
-	tokens, err := service.Refresh(ctx, req)
+	tokens, err := service.Refresh(ctx, req, &entity.Device{})
 
 	assert.Error(t, err)
 	assert.Nil(t, tokens)
 	assert.Equal(t, errs.ErrInvalidRefreshToken, err)
+	mockTokens.AssertNotCalled(t, "CloseSession", mock.Anything, mock.Anything)
+}
+
+func TestService_Refresh_ReusedToken(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	mockMedia := &mockMediaService{}
+
+	cfg := &config.AuthServiceConfig{RefreshTTL: 24 * time.Hour}
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
+
+	refreshToken := "rotated-refresh-token"
+
+	mockTokens.On("ConsumeRefresh", mock.Anything, refreshToken, cfg.RefreshTTL).Return("", nil).Once()
+	mockTokens.On("GetSessionIdByUsedRefresh", mock.Anything, refreshToken).Return("session-1", nil).Once()
+	mockTokens.On("CloseSession", mock.Anything, "session-1").Return(nil).Once()
+
+	tokens, err := service.Refresh(context.Background(), &entity.Refresh{Refresh: refreshToken}, &entity.Device{})
+
+	assert.ErrorIs(t, err, errs.ErrRefreshTokenReused)
+	assert.Nil(t, tokens)
+	mockTokens.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
 }
 
 func TestService_SignOut_Success(t *testing.T) {
@@ -380,22 +542,92 @@ func TestService_SignOut_Success(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	refreshToken := "refresh-token-to-delete"
 
-	mockTokens.On("RemoveRefresh", mock.Anything, refreshToken).Return(nil).Once()
+	mockTokens.On("GetSessionIdByRefreshToken", mock.Anything, refreshToken).Return("session-1", nil).Once()
+	mockTokens.On("CloseSession", mock.Anything, "session-1").Return(nil).Once()
 
 	req := &entity.Refresh{Refresh: refreshToken}
-	err := service.SignOut(ctx, req)
+	err := service.SignOut(ctx, req, nil)
 
 	assert.NoError(t, err)
 	mockTokens.AssertExpectations(t)
 }
 
+func TestService_SignOut_RevokesAccessToken(t *testing.T) {
+	mockTokens := &mockTokenStorage{}
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, &mockDB{}, &mockMediaService{}, mockTokens, &mockMailer{})
+
+	expiresAt := time.Now().Add(15 * time.Minute)
+	mockTokens.On("DenyAccess", mock.Anything, "token-1", expiresAt).Return(nil).Once()
+
+	err := service.SignOut(context.Background(), nil, &entity.Claims{UserID: 1, TokenID: "token-1", ExpiresAt: expiresAt})
+
+	assert.NoError(t, err)
+	mockTokens.AssertExpectations(t)
+	mockTokens.AssertNotCalled(t, "GetSessionIdByRefreshToken", mock.Anything, mock.Anything)
+}
+
+func TestService_GetSessions_MarksCurrent(t *testing.T) {
+	mockTokens := &mockTokenStorage{}
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, &mockDB{}, &mockMediaService{}, mockTokens, &mockMailer{})
+
+	now := time.Now()
+	mockTokens.On("GetUserSessions", mock.Anything, "1").Return([]entity.Session{
+		{ID: "old", UserID: 1, LastUsedAt: now.Add(-time.Hour)},
+		{ID: "recent", UserID: 1, LastUsedAt: now},
+	}, nil).Once()
+
+	sessions, err := service.GetSessions(context.Background(), 1, "old")
+
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "recent", sessions[0].ID)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+}
+
+func TestService_CloseSession_OtherUser(t *testing.T) {
+	mockTokens := &mockTokenStorage{}
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, &mockDB{}, &mockMediaService{}, mockTokens, &mockMailer{})
+
+	mockTokens.On("GetSession", mock.Anything, "session-2").Return(&entity.Session{ID: "session-2", UserID: 2}, nil).Once()
+
+	err := service.CloseSession(context.Background(), 1, "session-2")
+
+	assert.ErrorIs(t, err, errs.ErrSessionNotFound)
+	mockTokens.AssertNotCalled(t, "CloseSession", mock.Anything, mock.Anything)
+}
+
+func TestService_CheckAccessToken_Revoked(t *testing.T) {
+	mockTokens := &mockTokenStorage{}
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, &mockDB{}, &mockMediaService{}, mockTokens, &mockMailer{})
+
+	issuedAt := time.Now()
+	mockTokens.On("IsAccessDenied", mock.Anything, "token-1", "1", issuedAt).Return(true, nil).Once()
+
+	err := service.CheckAccessToken(context.Background(), &entity.Claims{UserID: 1, TokenID: "token-1", SessionID: "session-1", IssuedAt: issuedAt})
+
+	assert.ErrorIs(t, err, errs.ErrAccessRevoked)
+	mockTokens.AssertNotCalled(t, "SessionExists", mock.Anything, mock.Anything)
+}
+
+func TestService_CheckAccessToken_SessionClosed(t *testing.T) {
+	mockTokens := &mockTokenStorage{}
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, &mockDB{}, &mockMediaService{}, mockTokens, &mockMailer{})
+
+	mockTokens.On("IsAccessDenied", mock.Anything, mock.Anything, "1", mock.Anything).Return(false, nil).Twice()
+	mockTokens.On("SessionExists", mock.Anything, "session-1").Return(false, nil).Once()
+
+	assert.ErrorIs(t, service.CheckAccessToken(context.Background(), &entity.Claims{UserID: 1, TokenID: "token-1", SessionID: "session-1"}), errs.ErrSessionNotFound)
+	assert.NoError(t, service.CheckAccessToken(context.Background(), &entity.Claims{UserID: 1}))
+	mockTokens.AssertExpectations(t)
+}
+
 func TestService_UpdatePassword_Success(t *testing.T) {
 	privateKey, publicKey := generateTestRSAKeys(t)
 	cfg := &config.AuthServiceConfig{
@@ -406,9 +638,8 @@ func TestService_UpdatePassword_Success(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	oldPassword := "oldpassword123"
@@ -425,6 +656,7 @@ func TestService_UpdatePassword_Success(t *testing.T) {
 
 	mockDB.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
 	mockTokens.On("CloseAllSessions", mock.Anything, "1").Return(nil).Once()
+	mockTokens.On("DenyUserAccess", mock.Anything, "1", mock.Anything).Return(nil).Once()
 	mockDB.On("UpdateUserPassword", mock.Anything, 1, mock.AnythingOfType("string")).Return(nil).Once()
 
 	req := &entity.UpdatePassword{
@@ -437,6 +669,45 @@ func TestService_UpdatePassword_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestService_UpdatePassword_StoreErrorKeepsSessions(t *testing.T) {
+	privateKey, publicKey := generateTestRSAKeys(t)
+	cfg := &config.AuthServiceConfig{
+		PrivateKey: privateKey,
+		PublicKey:  publicKey,
+	}
+
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
+
+	oldPassword := "oldpassword123"
+	hashedOldPassword, _ := bcrypt.GenerateFromPassword([]byte(oldPassword), bcrypt.DefaultCost)
+
+	user := &entity.User{
+		ID: 1,
+		Credential: &entity.Credential{
+			Password: string(hashedOldPassword),
+		},
+	}
+
+	dbErr := errors.New("db error")
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
+	mockDB.On("UpdateUserPassword", mock.Anything, 1, mock.AnythingOfType("string")).Return(dbErr).Once()
+
+	err := service.UpdatePassword(context.Background(), &entity.UpdatePassword{
+		UserID:      1,
+		OldPassword: oldPassword,
+		NewPassword: "newpassword123",
+	})
+
+	assert.ErrorIs(t, err, dbErr)
+	mockDB.AssertExpectations(t)
+	mockTokens.AssertNotCalled(t, "CloseAllSessions", mock.Anything, mock.Anything)
+	mockTokens.AssertNotCalled(t, "DenyUserAccess", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestService_UpdatePassword_InvalidOldPassword(t *testing.T) {
 	privateKey, publicKey := generateTestRSAKeys(t)
 	cfg := &config.AuthServiceConfig{
@@ -447,9 +718,8 @@ func TestService_UpdatePassword_InvalidOldPassword(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	oldPassword := "oldpassword123"
@@ -484,14 +754,15 @@ func TestService_ForgotPassword_Success(t *testing.T) {
 		PrivateKey:  privateKey,
 		PublicKey:   publicKey,
 		RecoveryTTL: time.Hour,
+		ClientURL:   "http://localhost:3000",
 	}
 
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
+	mockMailer := &mockMailer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockMailer)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -503,19 +774,23 @@ func TestService_ForgotPassword_Success(t *testing.T) {
 		},
 	}
 
+	var recoveryToken string
 	mockDB.On("GetUserByEmail", mock.Anything, email).Return(user, nil).Once()
-	mockTokens.On("CloseAllSessions", mock.Anything, "1").Return(nil).Once()
-	mockTokens.On("StoreRecovery", mock.Anything, mock.AnythingOfType("string"), "1", cfg.RecoveryTTL).Return(nil).Once()
+	mockTokens.On("StoreRecovery", mock.Anything, mock.AnythingOfType("string"), "1", cfg.RecoveryTTL).
+		Run(func(args mock.Arguments) { recoveryToken = args.String(1) }).
+		Return(nil).Once()
+	mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(m *entity.Mail) bool {
+		return m.To == email && strings.Contains(m.Body, "http://localhost:3000/recovery-password?token="+recoveryToken)
+	})).Return(nil).Once()
 
 	req := &entity.ForgotPassword{Email: email}
-	recovery, err := service.ForgotPassword(ctx, req)
+	err := service.ForgotPassword(ctx, req)
 
 	assert.NoError(t, err)
-	assert.NotNil(t, recovery)
-	assert.NotEmpty(t, recovery.Recovery)
-
-	_, err = uuid.Parse(recovery.Recovery)
+	_, err = uuid.Parse(recoveryToken)
 	assert.NoError(t, err)
+	mockTokens.AssertNotCalled(t, "CloseAllSessions", mock.Anything, mock.Anything)
+	mockMailer.AssertExpectations(t)
 }
 
 func TestService_ForgotPassword_UserNotFound(t *testing.T) {
@@ -529,9 +804,8 @@ func TestService_ForgotPassword_UserNotFound(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	email := "nonexistent@example.com"
@@ -540,10 +814,9 @@ func TestService_ForgotPassword_UserNotFound(t *testing.T) {
 
 	req := &entity.ForgotPassword{Email: email}
 
-	recovery, err := service.ForgotPassword(ctx, req)
+	err := service.ForgotPassword(ctx, req)
 
 	assert.Error(t, err)
-	assert.Nil(t, recovery)
 	assert.Equal(t, errs.ErrUserNotFound, err)
 }
 
@@ -557,9 +830,8 @@ func TestService_RecoveryPassword_Success(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	recoveryToken := "valid-recovery-token"
@@ -567,6 +839,9 @@ func TestService_RecoveryPassword_Success(t *testing.T) {
 
 	mockTokens.On("GetUserIdByRecoveryToken", mock.Anything, recoveryToken).Return("1", nil).Once()
 	mockDB.On("UpdateUserPassword", mock.Anything, 1, mock.AnythingOfType("string")).Return(nil).Once()
+	mockTokens.On("RemoveRecovery", mock.Anything, recoveryToken).Return(nil).Once()
+	mockTokens.On("CloseAllSessions", mock.Anything, "1").Return(nil).Once()
+	mockTokens.On("DenyUserAccess", mock.Anything, "1", mock.Anything).Return(nil).Once()
 
 	req := &entity.RecoveryPassword{
 		RecoveryToken: recoveryToken,
@@ -575,6 +850,102 @@ func TestService_RecoveryPassword_Success(t *testing.T) {
 
 	err := service.RecoveryPassword(ctx, req)
 	assert.NoError(t, err)
+	mockTokens.AssertExpectations(t)
+}
+
+func TestService_RecoveryPassword_ExpiredToken(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, mockDB, mockMedia, mockTokens, &mockMailer{})
+
+	mockTokens.On("GetUserIdByRecoveryToken", mock.Anything, "expired-recovery-token").Return("", nil).Once()
+
+	err := service.RecoveryPassword(context.Background(), &entity.RecoveryPassword{
+		RecoveryToken: "expired-recovery-token",
+		NewPassword:   "newpassword123",
+	})
+
+	assert.ErrorIs(t, err, errs.ErrInvalidRecoveryToken)
+	mockDB.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_VerifyEmail_Success(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, mockDB, mockMedia, mockTokens, &mockMailer{})
+
+	mockTokens.On("GetUserIdByVerificationToken", mock.Anything, "verification-token").Return("1", nil).Once()
+	mockDB.On("SetUserEmailVerified", mock.Anything, 1).Return(nil).Once()
+	mockTokens.On("RemoveVerification", mock.Anything, "verification-token").Return(nil).Once()
+
+	err := service.VerifyEmail(context.Background(), &entity.VerifyEmail{VerificationToken: "verification-token"})
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockTokens.AssertExpectations(t)
+}
+
+func TestService_VerifyEmail_InvalidToken(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, mockDB, mockMedia, mockTokens, &mockMailer{})
+
+	mockTokens.On("GetUserIdByVerificationToken", mock.Anything, "unknown-token").Return("", nil).Once()
+
+	err := service.VerifyEmail(context.Background(), &entity.VerifyEmail{VerificationToken: "unknown-token"})
+
+	assert.ErrorIs(t, err, errs.ErrInvalidVerificationToken)
+	mockDB.AssertNotCalled(t, "SetUserEmailVerified", mock.Anything, mock.Anything)
+}
+
+func TestService_ResendVerification_Success(t *testing.T) {
+	cfg := &config.AuthServiceConfig{
+		VerificationTTL: 48 * time.Hour,
+		ClientURL:       "http://localhost:3000/",
+	}
+
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	mockMedia := &mockMediaService{}
+	mockMailer := &mockMailer{}
+
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockMailer)
+
+	user := &entity.User{ID: 1, IsActive: true, Credential: &entity.Credential{Email: "test@example.com"}}
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
+	mockTokens.On("StoreVerification", mock.Anything, mock.AnythingOfType("string"), "1", cfg.VerificationTTL).Return(nil).Once()
+	mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(m *entity.Mail) bool {
+		return m.To == "test@example.com" && strings.Contains(m.Body, "http://localhost:3000/verify-email?token=")
+	})).Return(nil).Once()
+
+	err := service.ResendVerification(context.Background(), 1)
+
+	assert.NoError(t, err)
+	mockTokens.AssertExpectations(t)
+	mockMailer.AssertExpectations(t)
+}
+
+func TestService_ResendVerification_AlreadyVerified(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	mockMedia := &mockMediaService{}
+	mockMailer := &mockMailer{}
+
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, mockDB, mockMedia, mockTokens, mockMailer)
+
+	user := &entity.User{ID: 1, IsActive: true, EmailVerified: true, Credential: &entity.Credential{Email: "test@example.com"}}
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
+
+	err := service.ResendVerification(context.Background(), 1)
+
+	assert.ErrorIs(t, err, errs.ErrEmailAlreadyVerified)
+	mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
 }
 
 func TestService_RecoveryPassword_InvalidToken(t *testing.T) {
@@ -587,9 +958,8 @@ func TestService_RecoveryPassword_InvalidToken(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	ctx := context.Background()
 	recoveryToken := "invalid-recovery-token"
@@ -618,9 +988,8 @@ func TestService_VerifyAccessToken_Success(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	claims := auth.AccessClaims{
 		UserID: 1,
@@ -635,9 +1004,65 @@ func TestService_VerifyAccessToken_Success(t *testing.T) {
 	tokenString, err := token.SignedString(privateKey)
 	require.NoError(t, err)
 
-	userID, err := service.VerifyAccessToken(tokenString)
+	verified, err := service.VerifyAccessToken(tokenString)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, userID)
+	assert.Equal(t, 1, verified.UserID)
+	assert.Equal(t, "user", verified.Role)
+}
+
+func TestService_VerifyAccessToken_PreviousKey(t *testing.T) {
+	privateKey, publicKey := generateTestRSAKeys(t)
+	oldPrivateKey, oldPublicKey := generateTestRSAKeys(t)
+	cfg := &config.AuthServiceConfig{
+		KeyID:              "2024-02",
+		PrivateKey:         privateKey,
+		PublicKey:          publicKey,
+		PreviousPublicKeys: map[string]*rsa.PublicKey{"2024-01": oldPublicKey},
+		AccessTTL:          time.Hour,
+	}
+
+	service := auth.NewAuthService(cfg, &mockDB{}, &mockMediaService{}, &mockTokenStorage{}, &mockMailer{})
+
+	claims := auth.AccessClaims{
+		UserID: 1,
+		Role:   "user",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "token-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "2024-01"
+	tokenString, err := token.SignedString(oldPrivateKey)
+	require.NoError(t, err)
+
+	verified, err := service.VerifyAccessToken(tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, 1, verified.UserID)
+	assert.Equal(t, "token-1", verified.TokenID)
+
+	token.Header["kid"] = "2023-12"
+	tokenString, err = token.SignedString(oldPrivateKey)
+	require.NoError(t, err)
+
+	verified, err = service.VerifyAccessToken(tokenString)
+	assert.Error(t, err)
+	assert.Nil(t, verified)
+}
+
+func TestService_CheckUserActive_Inactive(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTokens := &mockTokenStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := auth.NewAuthService(&config.AuthServiceConfig{}, mockDB, mockMedia, mockTokens, &mockMailer{})
+
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, IsActive: false}, nil).Once()
+
+	err := service.CheckUserActive(context.Background(), 1)
+	assert.ErrorIs(t, err, errs.ErrUserInactive)
+	mockDB.AssertExpectations(t)
 }
 
 func TestService_VerifyAccessToken_Expired(t *testing.T) {
@@ -651,9 +1076,8 @@ func TestService_VerifyAccessToken_Expired(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	claims := auth.AccessClaims{
 		UserID: 1,
@@ -668,9 +1092,9 @@ func TestService_VerifyAccessToken_Expired(t *testing.T) {
 	tokenString, err := token.SignedString(privateKey)
 	require.NoError(t, err)
 
-	userID, err := service.VerifyAccessToken(tokenString)
+	verified, err := service.VerifyAccessToken(tokenString)
 	assert.Error(t, err)
-	assert.Equal(t, 0, userID)
+	assert.Nil(t, verified)
 }
 
 func TestService_VerifyAccessToken_InvalidSignature(t *testing.T) {
@@ -684,9 +1108,8 @@ func TestService_VerifyAccessToken_InvalidSignature(t *testing.T) {
 	mockDB := &mockDB{}
 	mockTokens := &mockTokenStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, mockProducer)
+	service := auth.NewAuthService(cfg, mockDB, mockMedia, mockTokens, &mockMailer{})
 
 	wrongPrivateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
 	claims := auth.AccessClaims{
@@ -702,8 +1125,8 @@ func TestService_VerifyAccessToken_InvalidSignature(t *testing.T) {
 	tokenString, err := token.SignedString(wrongPrivateKey)
 	require.NoError(t, err)
 
-	userID, err := service.VerifyAccessToken(tokenString)
+	verified, err := service.VerifyAccessToken(tokenString)
 	assert.Error(t, err)
-	assert.Equal(t, 0, userID)
+	assert.Nil(t, verified)
 	assert.Contains(t, err.Error(), "token validation failed")
 }
@@ -13,7 +13,9 @@ import (
 	"github.com/kust1q/Zapp/backend/internal/errs"
 )
 
-func (s *service) ForgotPassword(ctx context.Context, req *entity.ForgotPassword) (*entity.Recovery, error) {
+// ForgotPassword mails a recovery link to the account owner; the token itself is never
+// returned to the caller.
+func (s *service) ForgotPassword(ctx context.Context, req *entity.ForgotPassword) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -21,23 +23,20 @@ func (s *service) ForgotPassword(ctx context.Context, req *entity.ForgotPassword
 
 	user, err := s.db.GetUserByEmail(ctx, req.Email)
 	if err != nil && !errors.Is(err, errs.ErrUserNotFound) {
-		return nil, fmt.Errorf("failed to find user: %w", err)
+		return fmt.Errorf("failed to find user: %w", err)
 	} else if errors.Is(err, errs.ErrUserNotFound) {
-		return nil, err
-	}
-
-	if err := s.tokens.CloseAllSessions(ctx, strconv.Itoa(user.ID)); err != nil {
-		return nil, fmt.Errorf("failed to close sessions: %w", err)
+		return err
 	}
 
 	recoveryToken := s.generateRecoveryToken()
 	if err = s.tokens.StoreRecovery(ctx, recoveryToken, strconv.Itoa(user.ID), s.cfg.RecoveryTTL); err != nil {
-		return nil, fmt.Errorf("failed to store recovery token: %w", err)
+		return fmt.Errorf("failed to store recovery token: %w", err)
 	}
 
-	return &entity.Recovery{
-		Recovery: recoveryToken,
-	}, nil
+	if err := s.mailer.Send(ctx, s.recoveryMail(user.Credential.Email, recoveryToken)); err != nil {
+		return fmt.Errorf("failed to send recovery mail: %w", err)
+	}
+	return nil
 }
 
 func (s *service) generateRecoveryToken() string {
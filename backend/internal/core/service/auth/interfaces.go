@@ -13,30 +13,49 @@ type (
 	db interface {
 		BeginTx(ctx context.Context) (*sql.Tx, error)
 		CreateUserTx(ctx context.Context, tx *sql.Tx, user *entity.User) (*entity.User, error)
+		CreateOutboxEventTx(ctx context.Context, tx *sql.Tx, topic string, event any) error
 		GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
 		GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
 		GetUserByID(ctx context.Context, userID int) (*entity.User, error)
 		UpdateUserPassword(ctx context.Context, userID int, password string) error
+		SetUserEmailVerified(ctx context.Context, userID int) error
 		DeleteUser(ctx context.Context, userID int) error
 		UserExistsByUsername(ctx context.Context, username string) (bool, error)
 		UserExistsByEmail(ctx context.Context, email string) (bool, error)
 	}
 
 	tokenStorage interface {
-		StoreRefresh(ctx context.Context, refreshToken, userID string, ttl time.Duration) error
-		GetUserIdByRefreshToken(ctx context.Context, refreshToken string) (string, error)
+		CreateSession(ctx context.Context, session *entity.Session, refreshToken string, ttl time.Duration) error
+		RotateSession(ctx context.Context, session *entity.Session, refreshToken string, ttl time.Duration) error
+		GetSession(ctx context.Context, sessionID string) (*entity.Session, error)
+		SessionExists(ctx context.Context, sessionID string) (bool, error)
+		GetUserSessions(ctx context.Context, userID string) ([]entity.Session, error)
+		CloseSession(ctx context.Context, sessionID string) error
+		CloseOtherSessions(ctx context.Context, userID, keepSessionID string) error
 		CloseAllSessions(ctx context.Context, userID string) error
-		RemoveRefresh(ctx context.Context, refreshToken string) error
+
+		GetSessionIdByRefreshToken(ctx context.Context, refreshToken string) (string, error)
+		ConsumeRefresh(ctx context.Context, refreshToken string, ttl time.Duration) (string, error)
+		GetSessionIdByUsedRefresh(ctx context.Context, refreshToken string) (string, error)
+
+		DenyAccess(ctx context.Context, tokenID string, expiresAt time.Time) error
+		DenyUserAccess(ctx context.Context, userID string, issuedBefore time.Time) error
+		IsAccessDenied(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error)
 
 		StoreRecovery(ctx context.Context, token, userID string, ttl time.Duration) error
 		GetUserIdByRecoveryToken(ctx context.Context, recoveryToken string) (string, error)
+		RemoveRecovery(ctx context.Context, recoveryToken string) error
+
+		StoreVerification(ctx context.Context, token, userID string, ttl time.Duration) error
+		GetUserIdByVerificationToken(ctx context.Context, verificationToken string) (string, error)
+		RemoveVerification(ctx context.Context, verificationToken string) error
 	}
 
-	mediaService interface {
-		UploadAvatarTx(ctx context.Context, userID int, file io.Reader, filename string, tx *sql.Tx) (*entity.Avatar, error)
+	mailer interface {
+		Send(ctx context.Context, mail *entity.Mail) error
 	}
 
-	eventProducer interface {
-		Publish(ctx context.Context, topic string, event any) error
+	mediaService interface {
+		UploadAvatarTx(ctx context.Context, userID int, file io.Reader, filename string, tx *sql.Tx) (*entity.Avatar, error)
 	}
 )
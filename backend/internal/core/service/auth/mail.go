@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+func (s *service) recoveryMail(email, token string) *entity.Mail {
+	return &entity.Mail{
+		To:      email,
+		Subject: "Reset your Zapp password",
+		Body: fmt.Sprintf(
+			"Someone asked to reset the password of your Zapp account.\n\n"+
+				"Follow this link to choose a new one:\n%s\n\n"+
+				"The link expires in %s. If it wasn't you, ignore this message.\n",
+			s.clientLink("/recovery-password", token), s.cfg.RecoveryTTL),
+	}
+}
+
+func (s *service) verificationMail(email, token string) *entity.Mail {
+	return &entity.Mail{
+		To:      email,
+		Subject: "Confirm your Zapp email",
+		Body: fmt.Sprintf(
+			"Welcome to Zapp!\n\n"+
+				"Follow this link to confirm your email address:\n%s\n\n"+
+				"The link expires in %s.\n",
+			s.clientLink("/verify-email", token), s.cfg.VerificationTTL),
+	}
+}
+
+func (s *service) clientLink(path, token string) string {
+	return strings.TrimRight(s.cfg.ClientURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
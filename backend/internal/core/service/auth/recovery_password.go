@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -22,6 +24,9 @@ func (s *service) RecoveryPassword(ctx context.Context, req *entity.RecoveryPass
 	if err != nil {
 		return fmt.Errorf("failed to get userID: %w", err)
 	}
+	if userIDstr == "" {
+		return errs.ErrInvalidRecoveryToken
+	}
 
 	userID, err := strconv.Atoi(userIDstr)
 	if err != nil {
@@ -33,5 +38,12 @@ func (s *service) RecoveryPassword(ctx context.Context, req *entity.RecoveryPass
 		return fmt.Errorf("password hashing failed: %w", err)
 	}
 
-	return s.db.UpdateUserPassword(ctx, userID, string(newHashPassword))
+	if err := s.db.UpdateUserPassword(ctx, userID, string(newHashPassword)); err != nil {
+		return err
+	}
+
+	if err := s.tokens.RemoveRecovery(ctx, req.RecoveryToken); err != nil {
+		logrus.Warnf("failed to delete recovery token: %v", err)
+	}
+	return s.revokeUserAccess(ctx, userID)
 }
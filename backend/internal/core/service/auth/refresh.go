@@ -2,10 +2,8 @@ package auth
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -14,49 +12,55 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func (s *service) Refresh(ctx context.Context, req *entity.Refresh) (*entity.Tokens, error) {
+// Refresh rotates the refresh token of a session. Presenting a token that was already
+// rotated out means it was copied, so the whole session is closed.
+func (s *service) Refresh(ctx context.Context, req *entity.Refresh, device *entity.Device) (*entity.Tokens, error) {
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
 	req.Refresh = strings.TrimSpace(req.Refresh)
 
-	userID, err := s.tokens.GetUserIdByRefreshToken(ctx, req.Refresh)
+	sessionID, err := s.tokens.ConsumeRefresh(ctx, req.Refresh, s.cfg.RefreshTTL)
 	if err != nil {
-		if errors.Is(err, errs.ErrTokenNotFound) {
-			return nil, errs.ErrInvalidRefreshToken
-		}
 		return nil, fmt.Errorf("failed to get refresh token: %w", err)
 	}
-
-	if err := s.tokens.RemoveRefresh(ctx, req.Refresh); err != nil {
-		logrus.Warnf("failed to delete refresh token: %v", err)
+	if sessionID == "" {
+		return nil, s.checkRefreshReuse(ctx, req.Refresh)
 	}
 
-	userIntID, err := strconv.Atoi(userID)
+	session, err := s.tokens.GetSession(ctx, sessionID)
 	if err != nil {
-		return nil, errs.ErrInvalidRefreshToken
+		if errors.Is(err, errs.ErrSessionNotFound) {
+			return nil, errs.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
-	user, err := s.db.GetUserByID(ctx, userIntID)
+	user, err := s.db.GetUserByID(ctx, session.UserID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, errs.ErrUserNotFound) {
 			return nil, errs.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	role := "user"
-	if user.IsSuperuser {
-		role = "admin"
+	if !user.IsActive {
+		return nil, errs.ErrUserInactive
 	}
-	accessToken, err := s.generateAccessToken(user.ID, user.Credential.Email, role)
+
+	refreshToken, err := s.generateRefreshToken()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	session.Device.IP = device.IP
+	session.LastUsedAt = time.Now()
+	if err := s.tokens.RotateSession(ctx, session, refreshToken, s.cfg.RefreshTTL); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
-	refreshToken, err := s.generateRefreshToken(ctx, strconv.Itoa(user.ID))
+	accessToken, err := s.generateAccessToken(user.ID, user.Credential.Email, roleOf(user), session.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
 	return &entity.Tokens{
@@ -68,3 +72,19 @@ func (s *service) Refresh(ctx context.Context, req *entity.Refresh) (*entity.Tok
 		},
 	}, nil
 }
+
+func (s *service) checkRefreshReuse(ctx context.Context, refreshToken string) error {
+	sessionID, err := s.tokens.GetSessionIdByUsedRefresh(ctx, refreshToken)
+	if err != nil {
+		return fmt.Errorf("failed to check refresh token reuse: %w", err)
+	}
+	if sessionID == "" {
+		return errs.ErrInvalidRefreshToken
+	}
+
+	logrus.WithField("session_id", sessionID).Warn("refresh token reused, closing session")
+	if err := s.tokens.CloseSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to close session: %w", err)
+	}
+	return errs.ErrRefreshTokenReused
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+func (s *service) openSession(ctx context.Context, userID int, device *entity.Device) (*entity.Session, string, error) {
+	refreshToken, err := s.generateRefreshToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	now := time.Now()
+	session := &entity.Session{
+		ID:         uuid.New().String(),
+		UserID:     userID,
+		Device:     *device,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+	if err := s.tokens.CreateSession(ctx, session, refreshToken, s.cfg.RefreshTTL); err != nil {
+		return nil, "", fmt.Errorf("failed to store session: %w", err)
+	}
+	return session, refreshToken, nil
+}
+
+// GetSessions lists the active sessions of userID, most recently used first.
+func (s *service) GetSessions(ctx context.Context, userID int, currentSessionID string) ([]entity.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	sessions, err := s.tokens.GetUserSessions(ctx, strconv.Itoa(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+func (s *service) CloseSession(ctx context.Context, userID int, sessionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	session, err := s.tokens.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrSessionNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session.UserID != userID {
+		return errs.ErrSessionNotFound
+	}
+
+	if err := s.tokens.CloseSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to close session: %w", err)
+	}
+	return nil
+}
+
+func (s *service) CloseOtherSessions(ctx context.Context, userID int, currentSessionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.tokens.CloseOtherSessions(ctx, strconv.Itoa(userID), currentSessionID); err != nil {
+		return fmt.Errorf("failed to close sessions: %w", err)
+	}
+	return nil
+}
+
+// CheckAccessToken rejects access tokens that were revoked or whose session was closed.
+// Tokens issued before sessions were tracked carry no session and are only checked
+// against the denylist.
+func (s *service) CheckAccessToken(ctx context.Context, claims *entity.Claims) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	denied, err := s.tokens.IsAccessDenied(ctx, claims.TokenID, strconv.Itoa(claims.UserID), claims.IssuedAt)
+	if err != nil {
+		return fmt.Errorf("failed to check access denylist: %w", err)
+	}
+	if denied {
+		return errs.ErrAccessRevoked
+	}
+
+	if claims.SessionID == "" {
+		return nil
+	}
+	exists, err := s.tokens.SessionExists(ctx, claims.SessionID)
+	if err != nil {
+		return fmt.Errorf("failed to check session: %w", err)
+	}
+	if !exists {
+		return errs.ErrSessionNotFound
+	}
+	return nil
+}
+
+// revokeUserAccess signs userID out everywhere: refresh sessions are closed and access
+// tokens issued so far are denied.
+func (s *service) revokeUserAccess(ctx context.Context, userID int) error {
+	if err := s.tokens.CloseAllSessions(ctx, strconv.Itoa(userID)); err != nil {
+		return fmt.Errorf("failed to close sessions: %w", err)
+	}
+	if err := s.tokens.DenyUserAccess(ctx, strconv.Itoa(userID), time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+	return nil
+}
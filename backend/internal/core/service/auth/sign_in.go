@@ -3,27 +3,29 @@ package auth
 import (
 	"context"
 	"crypto/rand"
+	"crypto/rsa"
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/kust1q/Zapp/backend/internal/errs"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AccessClaims struct {
-	UserID int    `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    int    `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func (s *service) SignIn(ctx context.Context, req *entity.Credential) (*entity.Tokens, error) {
+func (s *service) SignIn(ctx context.Context, req *entity.Credential, device *entity.Device) (*entity.Tokens, error) {
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
@@ -41,20 +43,18 @@ func (s *service) SignIn(ctx context.Context, req *entity.Credential) (*entity.T
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Credential.Password), []byte(req.Password)); err != nil {
 		return nil, errs.ErrInvalidCredentials
 	}
-
-	role := "user"
-	if user.IsSuperuser {
-		role = "admin"
+	if !user.IsActive {
+		return nil, errs.ErrUserInactive
 	}
 
-	accessToken, err := s.generateAccessToken(user.ID, user.Credential.Email, role)
+	session, refreshToken, err := s.openSession(ctx, user.ID, device)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, err
 	}
 
-	refreshToken, err := s.generateRefreshToken(ctx, strconv.Itoa(user.ID))
+	accessToken, err := s.generateAccessToken(user.ID, user.Credential.Email, roleOf(user), session.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
 	return &entity.Tokens{
@@ -67,35 +67,42 @@ func (s *service) SignIn(ctx context.Context, req *entity.Credential) (*entity.T
 	}, nil
 }
 
-func (s *service) generateAccessToken(userID int, email, role string) (string, error) {
+func (s *service) generateAccessToken(userID int, email, role, sessionID string) (string, error) {
 	claims := AccessClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.cfg.AccessTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "zapp",
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if s.cfg.KeyID != "" {
+		token.Header["kid"] = s.cfg.KeyID
+	}
 	return token.SignedString(s.cfg.PrivateKey)
 }
 
-func (s *service) generateRefreshToken(ctx context.Context, userID string) (string, error) {
+func (s *service) generateRefreshToken() (string, error) {
 	tokenBytes := make([]byte, 32)
 	if _, err := rand.Read(tokenBytes); err != nil {
 		return "", fmt.Errorf("failed to generate token: %w", err)
 	}
-	refreshToken := base64.RawURLEncoding.EncodeToString(tokenBytes)
-	if err := s.tokens.StoreRefresh(ctx, refreshToken, userID, s.cfg.RefreshTTL); err != nil {
-		return "", fmt.Errorf("failed to store refresh token: %w", err)
-	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
 
-	return refreshToken, nil
+func roleOf(user *entity.User) string {
+	if user.IsSuperuser {
+		return entity.RoleAdmin
+	}
+	return entity.RoleUser
 }
 
-func (s *service) VerifyAccessToken(tokenString string) (int, error) {
+func (s *service) VerifyAccessToken(tokenString string) (*entity.Claims, error) {
 	claims := &AccessClaims{}
 
 	token, err := jwt.ParseWithClaims(
@@ -105,18 +112,57 @@ func (s *service) VerifyAccessToken(tokenString string) (int, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return s.cfg.PublicKey, nil
+			kid, _ := token.Header["kid"].(string)
+			return s.verificationKey(kid)
 		},
 		jwt.WithLeeway(10*time.Second),
 	)
 
 	if err != nil {
-		return 0, fmt.Errorf("token validation failed: %w", err)
+		return nil, fmt.Errorf("token validation failed: %w", err)
 	}
 
 	if !token.Valid {
-		return 0, errors.New("invalid token")
+		return nil, errors.New("invalid token")
+	}
+
+	result := &entity.Claims{
+		UserID:    claims.UserID,
+		Role:      claims.Role,
+		SessionID: claims.SessionID,
+		TokenID:   claims.ID,
 	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return result, nil
+}
 
-	return claims.UserID, nil
+// verificationKey picks the public key for the kid header. Tokens without a kid
+// predate key rotation and were signed with the current key.
+func (s *service) verificationKey(kid string) (*rsa.PublicKey, error) {
+	if kid == "" || kid == s.cfg.KeyID {
+		return s.cfg.PublicKey, nil
+	}
+	if key, ok := s.cfg.PreviousPublicKeys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// CheckUserActive rejects access tokens of users deactivated or deleted after the token was issued.
+func (s *service) CheckUserActive(ctx context.Context, userID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	user, err := s.db.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive {
+		return errs.ErrUserInactive
+	}
+	return nil
 }
@@ -2,29 +2,44 @@ package auth
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
-	"github.com/kust1q/Zapp/backend/internal/errs"
 	"github.com/sirupsen/logrus"
 )
 
-func (s *service) SignOut(ctx context.Context, req *entity.Refresh) error {
+// SignOut closes the session of the refresh token and revokes the access token the
+// request was made with. Either may be missing.
+func (s *service) SignOut(ctx context.Context, req *entity.Refresh, access *entity.Claims) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	if access != nil && access.TokenID != "" {
+		if err := s.tokens.DenyAccess(ctx, access.TokenID, access.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to revoke access token: %w", err)
+		}
+	}
+
+	if req == nil {
+		return nil
+	}
 	req.Refresh = strings.TrimSpace(req.Refresh)
 
-	if err := s.tokens.RemoveRefresh(ctx, req.Refresh); err != nil {
-		if errors.Is(err, errs.ErrTokenNotFound) {
-			logrus.Warn("refresh token already deleted or expired")
-		}
-		return fmt.Errorf("failed to delete refresh token: %w", err)
+	sessionID, err := s.tokens.GetSessionIdByRefreshToken(ctx, req.Refresh)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if sessionID == "" {
+		logrus.Warn("refresh token already deleted or expired")
+		return nil
+	}
+
+	if err := s.tokens.CloseSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to close session: %w", err)
 	}
 
-	logrus.Info("refresh token successfully removed")
+	logrus.WithField("session_id", sessionID).Info("session closed")
 	return nil
 }
@@ -64,32 +64,33 @@ func (s *service) SignUp(ctx context.Context, req *entity.User) (*entity.User, e
 		return nil, fmt.Errorf("failed to generate or upload avatar: %w", err)
 	}
 
+	event := events.UserEvent{
+		EventType: events.UserCreateEvent,
+		ID:        createdUser.ID,
+		Username:  createdUser.Username,
+		Bio:       createdUser.Bio,
+	}
+	if err := s.db.CreateOutboxEventTx(ctx, tx, events.TopicUser, event); err != nil {
+		return nil, fmt.Errorf("failed to save user.created event: %w", err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("commit transaction failed: %w", err)
 	}
 
-	go func() {
-		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		event := events.UserEvent{
-			EventType: events.UserCreateEvent,
-			ID:        createdUser.ID,
-			Username:  createdUser.Username,
-			Bio:       createdUser.Bio,
-		}
-
-		if err := s.producer.Publish(cntx, events.TopicUser, event); err != nil {
-			logrus.WithError(err).Error("failed to publish user.created")
-		}
-	}()
+	// The account is usable without the mail; the user can ask for another link.
+	if err := s.sendVerification(ctx, createdUser.ID, createdUser.Credential.Email); err != nil {
+		logrus.WithError(err).WithField("user_id", createdUser.ID).Warn("failed to send verification mail")
+	}
 
 	return &entity.User{
-		ID:        createdUser.ID,
-		Username:  createdUser.Username,
-		Bio:       createdUser.Bio,
-		Gen:       createdUser.Gen,
-		AvatarUrl: avatar.Path,
-		CreatedAt: createdUser.CreatedAt,
+		ID:            createdUser.ID,
+		Username:      createdUser.Username,
+		Bio:           createdUser.Bio,
+		Gen:           createdUser.Gen,
+		AvatarUrl:     avatar.Path,
+		CreatedAt:     createdUser.CreatedAt,
+		EmailVerified: createdUser.EmailVerified,
 		Credential: &entity.Credential{
 			Email: createdUser.Credential.Email,
 		},
@@ -3,7 +3,6 @@ package auth
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -27,12 +26,12 @@ func (s *service) UpdatePassword(ctx context.Context, req *entity.UpdatePassword
 		return fmt.Errorf("invalid password")
 	}
 
-	if err := s.tokens.CloseAllSessions(ctx, strconv.Itoa(req.UserID)); err != nil {
-		return fmt.Errorf("failed to close sessions: %w", err)
-	}
 	newHashPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
 		return fmt.Errorf("password hashing failed: %w", err)
 	}
-	return s.db.UpdateUserPassword(ctx, req.UserID, string(newHashPassword))
+	if err := s.db.UpdateUserPassword(ctx, req.UserID, string(newHashPassword)); err != nil {
+		return err
+	}
+	return s.revokeUserAccess(ctx, req.UserID)
 }
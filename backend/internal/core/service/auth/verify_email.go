@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *service) VerifyEmail(ctx context.Context, req *entity.VerifyEmail) error {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	req.VerificationToken = strings.TrimSpace(req.VerificationToken)
+
+	userIDstr, err := s.tokens.GetUserIdByVerificationToken(ctx, req.VerificationToken)
+	if err != nil {
+		return fmt.Errorf("failed to get userID: %w", err)
+	}
+	if userIDstr == "" {
+		return errs.ErrInvalidVerificationToken
+	}
+
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		return errs.ErrInvalidVerificationToken
+	}
+
+	if err := s.db.SetUserEmailVerified(ctx, userID); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	if err := s.tokens.RemoveVerification(ctx, req.VerificationToken); err != nil {
+		logrus.Warnf("failed to delete verification token: %v", err)
+	}
+	return nil
+}
+
+func (s *service) ResendVerification(ctx context.Context, userID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	user, err := s.db.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.EmailVerified {
+		return errs.ErrEmailAlreadyVerified
+	}
+	return s.sendVerification(ctx, user.ID, user.Credential.Email)
+}
+
+func (s *service) sendVerification(ctx context.Context, userID int, email string) error {
+	token := uuid.New().String()
+	if err := s.tokens.StoreVerification(ctx, token, strconv.Itoa(userID), s.cfg.VerificationTTL); err != nil {
+		return fmt.Errorf("failed to store verification token: %w", err)
+	}
+
+	if err := s.mailer.Send(ctx, s.verificationMail(email, token)); err != nil {
+		return fmt.Errorf("failed to send verification mail: %w", err)
+	}
+	return nil
+}
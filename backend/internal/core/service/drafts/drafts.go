@@ -0,0 +1,190 @@
+package drafts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+type service struct {
+	db            db
+	media         mediaService
+	tweets        tweetService
+	notifications notificationService
+	interval      time.Duration
+	batchSize     int
+	lease         time.Duration
+	maxAttempts   int
+}
+
+func NewDraftService(cfg *config.DraftsConfig, db db, media mediaService, tweets tweetService, notifications notificationService) *service {
+	return &service{
+		db:            db,
+		media:         media,
+		tweets:        tweets,
+		notifications: notifications,
+		interval:      cfg.PollInterval,
+		batchSize:     cfg.BatchSize,
+		lease:         cfg.Lease,
+		maxAttempts:   cfg.MaxAttempts,
+	}
+}
+
+// CreateDraft stores a draft with its media, scheduling it right away when ScheduledAt is set.
+func (s *service) CreateDraft(ctx context.Context, draft *entity.Draft) (*entity.Draft, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if strings.TrimSpace(draft.Content) == "" && draft.File == nil {
+		return nil, errs.ErrEmptyDraft
+	}
+	if draft.ParentTweetID != nil && draft.QuotedTweetID != nil {
+		return nil, errs.ErrInvalidInput
+	}
+	if draft.ScheduledAt != nil && !draft.ScheduledAt.After(time.Now()) {
+		return nil, errs.ErrInvalidSchedule
+	}
+
+	if draft.File != nil {
+		media, err := s.media.UploadMedia(ctx, draft.File.File, draft.File.Header.Filename)
+		if err != nil {
+			return nil, err
+		}
+		draft.Media = media
+	}
+
+	now := time.Now()
+	draft.CreatedAt = now
+	draft.UpdatedAt = now
+	created, err := s.db.CreateDraft(ctx, draft)
+	if err != nil {
+		if draft.Media != nil {
+			s.media.DeleteUploadedMedia(draft.Media.Path)
+		}
+		return nil, fmt.Errorf("failed to create draft: %w", err)
+	}
+	return s.withMediaUrl(ctx, created)
+}
+
+func (s *service) GetDraft(ctx context.Context, userID, draftID int) (*entity.Draft, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	draft, err := s.db.GetDraftByID(ctx, userID, draftID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withMediaUrl(ctx, draft)
+}
+
+// GetDrafts lists the drafts of userID, newest first.
+func (s *service) GetDrafts(ctx context.Context, userID int, page *entity.Page) ([]entity.Draft, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	drafts, next, err := s.db.GetDrafts(ctx, userID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get drafts: %w", err)
+	}
+	for i := range drafts {
+		if _, err := s.withMediaUrl(ctx, &drafts[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return drafts, next, nil
+}
+
+// UpdateDraft replaces the content of a draft, and its media when a new file is sent.
+// The reply or quote target of a draft is fixed when it is created.
+func (s *service) UpdateDraft(ctx context.Context, req *entity.Draft) (*entity.Draft, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	draft, err := s.db.GetDraftByID(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Content) == "" && req.File == nil && draft.Media == nil {
+		return nil, errs.ErrEmptyDraft
+	}
+
+	oldMedia := draft.Media
+	if req.File != nil {
+		media, err := s.media.UploadMedia(ctx, req.File.File, req.File.Header.Filename)
+		if err != nil {
+			return nil, err
+		}
+		draft.Media = media
+	}
+	draft.Content = req.Content
+	draft.UpdatedAt = time.Now()
+
+	updated, err := s.db.UpdateDraft(ctx, draft)
+	if err != nil {
+		if req.File != nil {
+			s.media.DeleteUploadedMedia(draft.Media.Path)
+		}
+		return nil, err
+	}
+	if req.File != nil && oldMedia != nil {
+		s.media.DeleteUploadedMedia(oldMedia.Path)
+	}
+	return s.withMediaUrl(ctx, updated)
+}
+
+// DeleteDraftMedia detaches the media of a draft. A draft without content must keep its media.
+func (s *service) DeleteDraftMedia(ctx context.Context, userID, draftID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	draft, err := s.db.GetDraftByID(ctx, userID, draftID)
+	if err != nil {
+		return err
+	}
+	if draft.Media == nil {
+		return errs.ErrTweetMediaNotFound
+	}
+	if strings.TrimSpace(draft.Content) == "" {
+		return errs.ErrEmptyDraft
+	}
+
+	media := draft.Media
+	draft.Media = nil
+	draft.UpdatedAt = time.Now()
+	if _, err := s.db.UpdateDraft(ctx, draft); err != nil {
+		return err
+	}
+	s.media.DeleteUploadedMedia(media.Path)
+	return nil
+}
+
+func (s *service) DeleteDraft(ctx context.Context, userID, draftID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	draft, err := s.db.DeleteDraft(ctx, userID, draftID)
+	if err != nil {
+		return err
+	}
+	if draft.Media != nil {
+		s.media.DeleteUploadedMedia(draft.Media.Path)
+	}
+	return nil
+}
+
+func (s *service) withMediaUrl(ctx context.Context, draft *entity.Draft) (*entity.Draft, error) {
+	if draft.Media == nil {
+		return draft, nil
+	}
+	url, err := s.media.GetPresignedURL(ctx, draft.Media.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get draft media url: %w", err)
+	}
+	draft.MediaUrl = url
+	return draft, nil
+}
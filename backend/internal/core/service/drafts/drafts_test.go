@@ -0,0 +1,223 @@
+package drafts_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/core/service/drafts"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDraftStorage struct {
+	mock.Mock
+}
+
+func (m *mockDraftStorage) CreateDraft(ctx context.Context, draft *entity.Draft) (*entity.Draft, error) {
+	args := m.Called(ctx, draft)
+	d, _ := args.Get(0).(*entity.Draft)
+	return d, args.Error(1)
+}
+
+func (m *mockDraftStorage) GetDraftByID(ctx context.Context, userID, draftID int) (*entity.Draft, error) {
+	args := m.Called(ctx, userID, draftID)
+	d, _ := args.Get(0).(*entity.Draft)
+	return d, args.Error(1)
+}
+
+func (m *mockDraftStorage) GetDrafts(ctx context.Context, userID int, page *entity.Page) ([]entity.Draft, *entity.Cursor, error) {
+	args := m.Called(ctx, userID, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.Draft), next, args.Error(2)
+}
+
+func (m *mockDraftStorage) UpdateDraft(ctx context.Context, draft *entity.Draft) (*entity.Draft, error) {
+	args := m.Called(ctx, draft)
+	d, _ := args.Get(0).(*entity.Draft)
+	return d, args.Error(1)
+}
+
+func (m *mockDraftStorage) ScheduleDraft(ctx context.Context, userID, draftID int, scheduledAt *time.Time) (*entity.Draft, error) {
+	args := m.Called(ctx, userID, draftID, scheduledAt)
+	d, _ := args.Get(0).(*entity.Draft)
+	return d, args.Error(1)
+}
+
+func (m *mockDraftStorage) DeleteDraft(ctx context.Context, userID, draftID int) (*entity.Draft, error) {
+	args := m.Called(ctx, userID, draftID)
+	d, _ := args.Get(0).(*entity.Draft)
+	return d, args.Error(1)
+}
+
+func (m *mockDraftStorage) ClaimDueDrafts(ctx context.Context, now, lockedUntil time.Time, limit int) ([]entity.Draft, error) {
+	args := m.Called(ctx, now, lockedUntil, limit)
+	return args.Get(0).([]entity.Draft), args.Error(1)
+}
+
+func (m *mockDraftStorage) FailDraftPublish(ctx context.Context, draftID int, reason string, unschedule bool) error {
+	args := m.Called(ctx, draftID, reason, unschedule)
+	return args.Error(0)
+}
+
+func (m *mockDraftStorage) GetUserByID(ctx context.Context, userID int) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+	u, _ := args.Get(0).(*entity.User)
+	return u, args.Error(1)
+}
+
+type mockMediaService struct {
+	mock.Mock
+}
+
+func (m *mockMediaService) UploadMedia(ctx context.Context, file io.Reader, filename string) (*entity.UploadedMedia, error) {
+	args := m.Called(ctx, file, filename)
+	media, _ := args.Get(0).(*entity.UploadedMedia)
+	return media, args.Error(1)
+}
+
+func (m *mockMediaService) DeleteUploadedMedia(path string) {
+	m.Called(path)
+}
+
+func (m *mockMediaService) GetPresignedURL(ctx context.Context, path string) (string, error) {
+	args := m.Called(ctx, path)
+	return args.String(0), args.Error(1)
+}
+
+type mockTweetService struct {
+	mock.Mock
+}
+
+func (m *mockTweetService) CreateTweet(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error) {
+	args := m.Called(ctx, tweet)
+	t, _ := args.Get(0).(*entity.Tweet)
+	return t, args.Error(1)
+}
+
+type mockNotificationService struct {
+	mock.Mock
+}
+
+func (m *mockNotificationService) NotifyReply(ctx context.Context, actorID, tweetID int) error {
+	return m.Called(ctx, actorID, tweetID).Error(0)
+}
+
+func (m *mockNotificationService) NotifyQuote(ctx context.Context, actorID, tweetID int) error {
+	return m.Called(ctx, actorID, tweetID).Error(0)
+}
+
+func (m *mockNotificationService) NotifyMention(ctx context.Context, actorID, tweetID, mentionedID int) error {
+	return m.Called(ctx, actorID, tweetID, mentionedID).Error(0)
+}
+
+var testConfig = &config.DraftsConfig{
+	PollInterval: 10 * time.Millisecond,
+	BatchSize:    10,
+	Lease:        time.Minute,
+	MaxAttempts:  3,
+}
+
+func TestService_CreateDraft_Empty(t *testing.T) {
+	mockDB := &mockDraftStorage{}
+	service := drafts.NewDraftService(testConfig, mockDB, &mockMediaService{}, &mockTweetService{}, &mockNotificationService{})
+
+	_, err := service.CreateDraft(context.Background(), &entity.Draft{UserID: 1, Content: "   "})
+
+	assert.ErrorIs(t, err, errs.ErrEmptyDraft)
+	mockDB.AssertNotCalled(t, "CreateDraft", mock.Anything, mock.Anything)
+}
+
+func TestService_CreateDraft_ScheduledInPast(t *testing.T) {
+	mockDB := &mockDraftStorage{}
+	service := drafts.NewDraftService(testConfig, mockDB, &mockMediaService{}, &mockTweetService{}, &mockNotificationService{})
+
+	past := time.Now().Add(-time.Minute)
+	_, err := service.CreateDraft(context.Background(), &entity.Draft{UserID: 1, Content: "hello", ScheduledAt: &past})
+
+	assert.ErrorIs(t, err, errs.ErrInvalidSchedule)
+	mockDB.AssertNotCalled(t, "CreateDraft", mock.Anything, mock.Anything)
+}
+
+func TestService_PublishDraft_Success(t *testing.T) {
+	mockDB := &mockDraftStorage{}
+	mockTweets := &mockTweetService{}
+	service := drafts.NewDraftService(testConfig, mockDB, &mockMediaService{}, mockTweets, &mockNotificationService{})
+
+	media := &entity.UploadedMedia{Path: "media/1.png", MimeType: "image/png", SizeBytes: 10}
+	mockDB.On("GetDraftByID", mock.Anything, 1, 7).Return(&entity.Draft{ID: 7, UserID: 1, Content: "hello", Media: media}, nil)
+	mockTweets.On("CreateTweet", mock.Anything, mock.MatchedBy(func(tweet *entity.Tweet) bool {
+		return tweet.DraftID == 7 && tweet.Author.ID == 1 && tweet.Content == "hello" && tweet.Uploaded == media
+	})).Return(&entity.Tweet{ID: 42, Content: "hello"}, nil)
+
+	tweet, err := service.PublishDraft(context.Background(), 1, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, tweet.ID)
+	mockTweets.AssertExpectations(t)
+}
+
+func TestService_Run_PublishesDueDrafts(t *testing.T) {
+	mockDB := &mockDraftStorage{}
+	mockTweets := &mockTweetService{}
+	service := drafts.NewDraftService(testConfig, mockDB, &mockMediaService{}, mockTweets, &mockNotificationService{})
+
+	mockDB.On("ClaimDueDrafts", mock.Anything, mock.Anything, mock.Anything, 10).
+		Return([]entity.Draft{{ID: 7, UserID: 1, Content: "scheduled", Attempts: 1}}, nil).Once()
+	mockDB.On("ClaimDueDrafts", mock.Anything, mock.Anything, mock.Anything, 10).Return([]entity.Draft{}, nil)
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, IsActive: true}, nil)
+	mockTweets.On("CreateTweet", mock.Anything, mock.MatchedBy(func(tweet *entity.Tweet) bool {
+		return tweet.DraftID == 7
+	})).Return(&entity.Tweet{ID: 42}, nil).Once()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	service.Run(ctx)
+
+	mockTweets.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "FailDraftPublish", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_Run_PermanentFailureUnschedules(t *testing.T) {
+	mockDB := &mockDraftStorage{}
+	mockTweets := &mockTweetService{}
+	service := drafts.NewDraftService(testConfig, mockDB, &mockMediaService{}, mockTweets, &mockNotificationService{})
+
+	parentID := 5
+	mockDB.On("ClaimDueDrafts", mock.Anything, mock.Anything, mock.Anything, 10).
+		Return([]entity.Draft{{ID: 7, UserID: 1, Content: "reply", ParentTweetID: &parentID, Attempts: 1}}, nil).Once()
+	mockDB.On("ClaimDueDrafts", mock.Anything, mock.Anything, mock.Anything, 10).Return([]entity.Draft{}, nil)
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, IsActive: true}, nil)
+	mockTweets.On("CreateTweet", mock.Anything, mock.Anything).Return(nil, errs.ErrTweetNotFound).Once()
+	mockDB.On("FailDraftPublish", mock.Anything, 7, mock.Anything, true).Return(nil).Once()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	service.Run(ctx)
+
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_Run_TransientFailureRetries(t *testing.T) {
+	mockDB := &mockDraftStorage{}
+	mockTweets := &mockTweetService{}
+	service := drafts.NewDraftService(testConfig, mockDB, &mockMediaService{}, mockTweets, &mockNotificationService{})
+
+	mockDB.On("ClaimDueDrafts", mock.Anything, mock.Anything, mock.Anything, 10).
+		Return([]entity.Draft{{ID: 7, UserID: 1, Content: "hello", Attempts: 1}}, nil).Once()
+	mockDB.On("ClaimDueDrafts", mock.Anything, mock.Anything, mock.Anything, 10).Return([]entity.Draft{}, nil)
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, IsActive: true}, nil)
+	mockTweets.On("CreateTweet", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+	mockDB.On("FailDraftPublish", mock.Anything, 7, mock.Anything, false).Return(nil).Once()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	service.Run(ctx)
+
+	mockDB.AssertExpectations(t)
+}
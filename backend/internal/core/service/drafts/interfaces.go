@@ -0,0 +1,40 @@
+package drafts
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type (
+	db interface {
+		CreateDraft(ctx context.Context, draft *entity.Draft) (*entity.Draft, error)
+		GetDraftByID(ctx context.Context, userID, draftID int) (*entity.Draft, error)
+		GetDrafts(ctx context.Context, userID int, page *entity.Page) ([]entity.Draft, *entity.Cursor, error)
+		UpdateDraft(ctx context.Context, draft *entity.Draft) (*entity.Draft, error)
+		ScheduleDraft(ctx context.Context, userID, draftID int, scheduledAt *time.Time) (*entity.Draft, error)
+		DeleteDraft(ctx context.Context, userID, draftID int) (*entity.Draft, error)
+		ClaimDueDrafts(ctx context.Context, now, lockedUntil time.Time, limit int) ([]entity.Draft, error)
+		FailDraftPublish(ctx context.Context, draftID int, reason string, unschedule bool) error
+
+		GetUserByID(ctx context.Context, userID int) (*entity.User, error)
+	}
+
+	mediaService interface {
+		UploadMedia(ctx context.Context, file io.Reader, filename string) (*entity.UploadedMedia, error)
+		DeleteUploadedMedia(path string)
+		GetPresignedURL(ctx context.Context, path string) (string, error)
+	}
+
+	tweetService interface {
+		CreateTweet(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error)
+	}
+
+	notificationService interface {
+		NotifyReply(ctx context.Context, actorID, tweetID int) error
+		NotifyQuote(ctx context.Context, actorID, tweetID int) error
+		NotifyMention(ctx context.Context, actorID, tweetID, mentionedID int) error
+	}
+)
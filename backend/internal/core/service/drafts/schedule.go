@@ -0,0 +1,205 @@
+package drafts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// ScheduleDraft publishes a draft at scheduledAt, which must be in the future.
+func (s *service) ScheduleDraft(ctx context.Context, userID, draftID int, scheduledAt time.Time) (*entity.Draft, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if !scheduledAt.After(time.Now()) {
+		return nil, errs.ErrInvalidSchedule
+	}
+	draft, err := s.db.ScheduleDraft(ctx, userID, draftID, &scheduledAt)
+	if err != nil {
+		return nil, err
+	}
+	return s.withMediaUrl(ctx, draft)
+}
+
+func (s *service) UnscheduleDraft(ctx context.Context, userID, draftID int) (*entity.Draft, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	draft, err := s.db.ScheduleDraft(ctx, userID, draftID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.withMediaUrl(ctx, draft)
+}
+
+// PublishDraft publishes a draft right away, whether it is scheduled or not.
+func (s *service) PublishDraft(ctx context.Context, userID, draftID int) (*entity.Tweet, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	draft, err := s.db.GetDraftByID(ctx, userID, draftID)
+	if err != nil {
+		return nil, err
+	}
+	return s.publish(ctx, draft)
+}
+
+// Run publishes scheduled drafts as they come due until ctx is cancelled. Every replica
+// may run it: due drafts are leased to one replica at a time, and publishing consumes
+// the draft in the tweet transaction, so a draft is never published twice.
+func (s *service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				claimed, err := s.publishDue(ctx)
+				if err != nil {
+					logrus.WithError(err).Warn("publishing scheduled drafts failed")
+					break
+				}
+				if claimed < s.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (s *service) publishDue(ctx context.Context) (int, error) {
+	claimCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	due, err := s.db.ClaimDueDrafts(claimCtx, now, now.Add(s.lease), s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due drafts: %w", err)
+	}
+	for i := range due {
+		s.publishScheduled(ctx, &due[i])
+	}
+	return len(due), nil
+}
+
+// publishScheduled publishes a claimed draft. Failures that retrying cannot fix, and
+// failures past the attempt limit, unschedule the draft and leave the reason on it.
+func (s *service) publishScheduled(ctx context.Context, draft *entity.Draft) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	log := logrus.WithFields(logrus.Fields{
+		"draft_id": draft.ID,
+		"user_id":  draft.UserID,
+	})
+
+	user, err := s.db.GetUserByID(ctx, draft.UserID)
+	if err == nil && !user.IsActive {
+		err = errs.ErrUserInactive
+	}
+	var tweet *entity.Tweet
+	if err == nil {
+		tweet, err = s.publish(ctx, draft)
+	}
+	if err == nil {
+		log.WithField("tweet_id", tweet.ID).Info("scheduled draft published")
+		return
+	}
+	if errors.Is(err, errs.ErrDraftNotFound) {
+		// Deleted or published by its author in the meantime.
+		return
+	}
+
+	unschedule := isPermanent(err) || draft.Attempts >= s.maxAttempts
+	log.WithError(err).WithField("attempts", draft.Attempts).Warn("failed to publish scheduled draft")
+	if err := s.db.FailDraftPublish(ctx, draft.ID, failureReason(err, !unschedule), unschedule); err != nil {
+		log.WithError(err).Error("failed to record draft publish failure")
+	}
+}
+
+func (s *service) publish(ctx context.Context, draft *entity.Draft) (*entity.Tweet, error) {
+	now := time.Now()
+	tweet, err := s.tweets.CreateTweet(entity.WithViewer(ctx, draft.UserID), &entity.Tweet{
+		ParentTweetID: draft.ParentTweetID,
+		QuotedTweetID: draft.QuotedTweetID,
+		Content:       draft.Content,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Author:        &entity.SmallUser{ID: draft.UserID},
+		Uploaded:      draft.Media,
+		DraftID:       draft.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notify(draft.UserID, tweet)
+	return tweet, nil
+}
+
+// notify sends the notifications the tweet endpoints send for a new tweet.
+func (s *service) notify(actorID int, tweet *entity.Tweet) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if tweet.ParentTweetID != nil {
+			if err := s.notifications.NotifyReply(ctx, actorID, *tweet.ParentTweetID); err != nil {
+				logrus.WithError(err).Warn("failed to notify reply")
+			}
+		}
+		if tweet.QuotedTweetID != nil {
+			if err := s.notifications.NotifyQuote(ctx, actorID, *tweet.QuotedTweetID); err != nil {
+				logrus.WithError(err).Warn("failed to notify quote")
+			}
+		}
+		for _, mentioned := range tweet.Mentions {
+			if err := s.notifications.NotifyMention(ctx, actorID, tweet.ID, mentioned.ID); err != nil {
+				logrus.WithError(err).Warn("failed to notify mention")
+			}
+		}
+	}()
+}
+
+// permanentErrors fail a publish no matter how often it is retried.
+var permanentErrors = []error{
+	errs.ErrTweetNotFound,
+	errs.ErrBlocked,
+	errs.ErrUserNotFound,
+	errs.ErrUserInactive,
+	errs.ErrInvalidmediaType,
+}
+
+func isPermanent(err error) bool {
+	for _, target := range permanentErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// failureReason is what the author is shown; internal errors stay in the logs.
+func failureReason(err error, retried bool) string {
+	switch {
+	case errors.Is(err, errs.ErrTweetNotFound):
+		return "the replied or quoted tweet is no longer available"
+	case errors.Is(err, errs.ErrBlocked):
+		return "the author of the replied or quoted tweet is blocked"
+	case errors.Is(err, errs.ErrUserNotFound), errors.Is(err, errs.ErrUserInactive):
+		return "the account is deactivated"
+	case errors.Is(err, errs.ErrInvalidmediaType):
+		return "the media type is not supported"
+	case retried:
+		return "publishing failed, it will be retried"
+	default:
+		return "publishing failed repeatedly"
+	}
+}
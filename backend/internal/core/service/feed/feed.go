@@ -9,68 +9,94 @@ import (
 )
 
 type service struct {
-	db           db
-	tweetService tweetService
+	db              db
+	tweetService    tweetService
+	timelineService timelineService
 }
 
-func NewFeedService(db db, tweetService tweetService) *service {
+func NewFeedService(db db, tweetService tweetService, timelineService timelineService) *service {
 	return &service{
-		db:           db,
-		tweetService: tweetService,
+		db:              db,
+		tweetService:    tweetService,
+		timelineService: timelineService,
 	}
 }
 
-func (s *service) GetUserFeedByUserId(ctx context.Context, userID, limit, offset int) ([]entity.Tweet, error) {
+func (s *service) GetUserFeedByUserId(ctx context.Context, userID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	user, err := s.db.GetUserByID(ctx, userID)
+	entries, next, err := s.timelineService.GetHomeTimeline(ctx, userID, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by id: %w", err)
+		return nil, nil, fmt.Errorf("failed to get home timeline: %w", err)
 	}
 
-	followings, err := s.db.GetFollowingsIds(ctx, user.Username, limit, offset)
+	muted, err := s.mutedBy(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user followings by username: %w", err)
+		return nil, nil, err
 	}
 
-	feed, err := s.db.GetFeedByAuthorsIds(ctx, followings, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get feed by ids: %w", err)
+	// Retweets by muted users are dropped along with their own tweets.
+	ids := make([]int, 0, len(entries))
+	for _, e := range entries {
+		if _, ok := muted[e.ActorID]; ok {
+			continue
+		}
+		ids = append(ids, e.TweetID)
 	}
 
-	dflt, err := s.db.GetAllTweets(ctx, limit, offset)
+	// Entries of deleted tweets may still linger in timelines; they are skipped here.
+	res, err := s.tweetService.GetTweetsByIds(ctx, ids)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get default feed: %w", err)
+		return nil, nil, fmt.Errorf("failed to get tweets by ids: %w", err)
 	}
 
-	feed = append(feed, dflt...)
-	res := make([]entity.Tweet, 0, len(feed))
-	for _, t := range feed {
-		tr, err := s.tweetService.BuildEntityTweetToResponse(ctx, &t)
-		if err != nil {
-			return nil, fmt.Errorf("failed to change tweet entity to response: %w", err)
-		}
-		res = append(res, *tr)
-	}
-
-	return res, nil
+	return withoutMuted(res, muted), next, nil
 }
 
-func (s *service) GetDeafultFeed(ctx context.Context, limit, offset int) ([]entity.Tweet, error) {
+func (s *service) GetDeafultFeed(ctx context.Context, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	feed, err := s.db.GetAllTweets(ctx, limit, offset)
+	feed, next, err := s.db.GetAllTweets(ctx, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get default feed: %w", err)
+		return nil, nil, fmt.Errorf("failed to get default feed: %w", err)
 	}
-	res := make([]entity.Tweet, 0, len(feed))
-	for _, t := range feed {
-		tr, err := s.tweetService.BuildEntityTweetToResponse(ctx, &t)
+	if viewerID, ok := entity.ViewerFromContext(ctx); ok {
+		muted, err := s.mutedBy(ctx, viewerID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to change tweet entity to response: %w", err)
+			return nil, nil, err
+		}
+		feed = withoutMuted(feed, muted)
+	}
+	res, err := s.tweetService.BuildEntityTweetsToResponse(ctx, feed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to change tweet entity to response: %w", err)
+	}
+	return res, next, nil
+}
+
+// mutedBy returns the set of users muted by userID. Mutes only ever shape the muter's own feed.
+func (s *service) mutedBy(ctx context.Context, userID int) (map[int]struct{}, error) {
+	ids, err := s.db.GetMutedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get muted users: %w", err)
+	}
+	muted := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		muted[id] = struct{}{}
+	}
+	return muted, nil
+}
+
+func withoutMuted(tweets []entity.Tweet, muted map[int]struct{}) []entity.Tweet {
+	if len(muted) == 0 {
+		return tweets
+	}
+	res := make([]entity.Tweet, 0, len(tweets))
+	for i := range tweets {
+		if _, ok := muted[tweets[i].Author.ID]; !ok {
+			res = append(res, tweets[i])
 		}
-		res = append(res, *tr)
 	}
-	return res, nil
+	return res
 }
@@ -0,0 +1,147 @@
+package feed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/core/service/feed"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDB struct {
+	mock.Mock
+}
+
+func (m *mockDB) GetAllTweets(ctx context.Context, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	args := m.Called(ctx, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.Tweet), next, args.Error(2)
+}
+
+func (m *mockDB) GetMutedUserIDs(ctx context.Context, userID int) ([]int, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+type mockTweetService struct {
+	mock.Mock
+}
+
+func (m *mockTweetService) GetTweetsByIds(ctx context.Context, ids []int) ([]entity.Tweet, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]entity.Tweet), args.Error(1)
+}
+
+func (m *mockTweetService) BuildEntityTweetsToResponse(ctx context.Context, tweets []entity.Tweet) ([]entity.Tweet, error) {
+	args := m.Called(ctx, tweets)
+	return args.Get(0).([]entity.Tweet), args.Error(1)
+}
+
+type mockTimelineService struct {
+	mock.Mock
+}
+
+func (m *mockTimelineService) GetHomeTimeline(ctx context.Context, userID int, page *entity.Page) ([]entity.TimelineEntry, *entity.Cursor, error) {
+	args := m.Called(ctx, userID, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.TimelineEntry), next, args.Error(2)
+}
+
+func tweet(id, authorID int) entity.Tweet {
+	return entity.Tweet{ID: id, Author: &entity.SmallUser{ID: authorID}}
+}
+
+func TestService_GetUserFeedByUserId_SkipsMuted(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTweets := &mockTweetService{}
+	mockTimeline := &mockTimelineService{}
+
+	service := feed.NewFeedService(mockDB, mockTweets, mockTimeline)
+
+	page := &entity.Page{Limit: 3}
+	next := &entity.Cursor{CreatedAt: time.Now(), ID: 1}
+	// Tweet 2 is retweeted by muted user 3, tweet 1 is written by muted user 3 and retweeted by user 4.
+	entries := []entity.TimelineEntry{{TweetID: 3, ActorID: 2}, {TweetID: 2, ActorID: 3}, {TweetID: 1, ActorID: 4}}
+
+	mockTimeline.On("GetHomeTimeline", mock.Anything, 1, page).Return(entries, next, nil).Once()
+	mockDB.On("GetMutedUserIDs", mock.Anything, 1).Return([]int{3}, nil).Once()
+	mockTweets.On("GetTweetsByIds", mock.Anything, []int{3, 1}).Return([]entity.Tweet{tweet(3, 2), tweet(1, 3)}, nil).Once()
+
+	res, cursor, err := service.GetUserFeedByUserId(context.Background(), 1, page)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Tweet{tweet(3, 2)}, res)
+	assert.Equal(t, next, cursor)
+	mockDB.AssertExpectations(t)
+	mockTweets.AssertExpectations(t)
+	mockTimeline.AssertExpectations(t)
+}
+
+func TestService_GetUserFeedByUserId_TimelineError(t *testing.T) {
+	mockDB := &mockDB{}
+	mockTweets := &mockTweetService{}
+	mockTimeline := &mockTimelineService{}
+
+	service := feed.NewFeedService(mockDB, mockTweets, mockTimeline)
+
+	timelineErr := errors.New("redis down")
+	mockTimeline.On("GetHomeTimeline", mock.Anything, 1, mock.Anything).Return([]entity.TimelineEntry(nil), nil, timelineErr).Once()
+
+	res, cursor, err := service.GetUserFeedByUserId(context.Background(), 1, &entity.Page{Limit: 20})
+
+	assert.ErrorIs(t, err, timelineErr)
+	assert.Nil(t, res)
+	assert.Nil(t, cursor)
+	mockDB.AssertNotCalled(t, "GetMutedUserIDs", mock.Anything, mock.Anything)
+	mockTweets.AssertNotCalled(t, "GetTweetsByIds", mock.Anything, mock.Anything)
+}
+
+func TestService_GetDeafultFeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		muted    []int
+		expected []entity.Tweet
+	}{
+		{
+			name:     "anonymous",
+			ctx:      context.Background(),
+			expected: []entity.Tweet{tweet(2, 5), tweet(1, 6)},
+		},
+		{
+			name:     "viewer with mutes",
+			ctx:      entity.WithViewer(context.Background(), 1),
+			muted:    []int{6},
+			expected: []entity.Tweet{tweet(2, 5)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &mockDB{}
+			mockTweets := &mockTweetService{}
+			mockTimeline := &mockTimelineService{}
+
+			service := feed.NewFeedService(mockDB, mockTweets, mockTimeline)
+
+			page := &entity.Page{Limit: 2}
+			mockDB.On("GetAllTweets", mock.Anything, page).Return([]entity.Tweet{tweet(2, 5), tweet(1, 6)}, nil, nil).Once()
+			if tt.muted != nil {
+				mockDB.On("GetMutedUserIDs", mock.Anything, 1).Return(tt.muted, nil).Once()
+			}
+			mockTweets.On("BuildEntityTweetsToResponse", mock.Anything, tt.expected).Return(tt.expected, nil).Once()
+
+			res, next, err := service.GetDeafultFeed(tt.ctx, page)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+			assert.Nil(t, next)
+			mockDB.AssertExpectations(t)
+			mockTweets.AssertExpectations(t)
+		})
+	}
+}
@@ -8,14 +8,16 @@ import (
 
 type (
 	db interface {
-		GetUserByID(ctx context.Context, userID int) (*entity.User, error)
-		GetFollowingsIds(ctx context.Context, username string, limit, offset int) ([]int, error)
-
-		GetFeedByAuthorsIds(ctx context.Context, userIDs []int, limit, offset int) ([]entity.Tweet, error)
-		GetAllTweets(ctx context.Context, limit, offset int) ([]entity.Tweet, error)
+		GetAllTweets(ctx context.Context, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetMutedUserIDs(ctx context.Context, userID int) ([]int, error)
 	}
 
 	tweetService interface {
-		BuildEntityTweetToResponse(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error)
+		GetTweetsByIds(ctx context.Context, ids []int) ([]entity.Tweet, error)
+		BuildEntityTweetsToResponse(ctx context.Context, tweets []entity.Tweet) ([]entity.Tweet, error)
+	}
+
+	timelineService interface {
+		GetHomeTimeline(ctx context.Context, userID int, page *entity.Page) ([]entity.TimelineEntry, *entity.Cursor, error)
 	}
 )
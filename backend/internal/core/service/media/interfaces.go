@@ -4,28 +4,54 @@ import (
 	"context"
 	"database/sql"
 	"io"
+	"time"
 
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 )
 
 type (
 	db interface {
-		UpsertByTweetIdTx(ctx context.Context, tx *sql.Tx, media *entity.TweetMedia) (*entity.TweetMedia, error)
-		GetMediaPathByTweetID(ctx context.Context, tweetID int) (string, error)
-		GetMediaDataByTweetID(ctx context.Context, tweetID int) (*entity.TweetMedia, error)
-		DeleteMediaByTweetID(ctx context.Context, tweetID, userID int) error
+		InsertTweetMediaTx(ctx context.Context, tx *sql.Tx, media *entity.TweetMedia) (*entity.TweetMedia, error)
+		GetMediaByTweetID(ctx context.Context, tweetID int) ([]entity.TweetMedia, error)
+		DeleteMediaByTweetID(ctx context.Context, tweetID, userID int) ([]string, error)
+		DeleteMediaByTweetIDTx(ctx context.Context, tx *sql.Tx, tweetID int) ([]string, error)
+		DeleteMediaItem(ctx context.Context, tweetID, mediaID, userID int) (string, error)
+		ForceDeleteMediaByTweetID(ctx context.Context, tweetID int) ([]string, error)
 
 		UploadAvatarTx(ctx context.Context, tx *sql.Tx, avatar *entity.Avatar) (*entity.Avatar, error)
 		GetAvatarPathByUserID(ctx context.Context, userID int) (string, error)
 		GetAvatarDataByUserID(ctx context.Context, userID int) (*entity.Avatar, error)
 		DeleteAvatarByUserID(ctx context.Context, userID int) error
 
+		UpsertByMessageIdTx(ctx context.Context, tx *sql.Tx, media *entity.MessageMedia) (*entity.MessageMedia, error)
+
 		GetMediaUrlsByUserID(ctx context.Context, userID int) ([]string, error)
+
+		GetMediaByTweetIDs(ctx context.Context, tweetIDs []int) (map[int][]entity.TweetMedia, error)
+		GetAvatarPathsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error)
+		GetMediaPathsByMessageIDs(ctx context.Context, messageIDs []int) (map[int]string, error)
+
+		CreateUpload(ctx context.Context, upload *entity.Upload) (*entity.Upload, error)
+		GetUploadByID(ctx context.Context, userID, uploadID int) (*entity.Upload, error)
+		ClaimUpload(ctx context.Context, userID, uploadID int, offset int64, lockedUntil time.Time) (*entity.Upload, error)
+		StartUpload(ctx context.Context, uploadID int, path, mimeType, storageUploadID string) error
+		AdvanceUpload(ctx context.Context, uploadID int, offset int64) error
+		ReleaseUpload(ctx context.Context, uploadID int) error
+		CompleteUpload(ctx context.Context, uploadID int, expiresAt time.Time) (*entity.Upload, error)
+		DeleteUpload(ctx context.Context, userID, uploadID int) (*entity.Upload, error)
+		ConsumeUploadTx(ctx context.Context, tx *sql.Tx, userID, uploadID int) (*entity.Upload, error)
+		DeleteExpiredUploads(ctx context.Context, now time.Time, limit int) ([]entity.Upload, error)
 	}
 
 	objectStorage interface {
 		Upload(ctx context.Context, file io.Reader, mediaType entity.MediaType, filename string) (path string, mimeType string, err error)
 		Remove(ctx context.Context, objectPath string) error
 		GetPresignedURL(ctx context.Context, objectPath string) (string, error)
+
+		CheckUpload(mediaType entity.MediaType, filename string, size int64) error
+		StartMultipartUpload(ctx context.Context, mediaType entity.MediaType, filename string, head []byte) (path string, mimeType string, uploadID string, err error)
+		UploadPart(ctx context.Context, path, uploadID string, partNumber int, data io.Reader, size int64) error
+		CompleteMultipartUpload(ctx context.Context, path, uploadID string) error
+		AbortMultipartUpload(ctx context.Context, path, uploadID string) error
 	}
 )
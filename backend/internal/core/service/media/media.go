@@ -4,110 +4,302 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
+	"github.com/kust1q/Zapp/backend/internal/config"
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	maxTweetAttachments = 4
+	maxAltTextLength    = 1000
+)
+
+// attachmentLimits caps the number of attachments of a tweet by their media type.
+var attachmentLimits = map[entity.MediaType]int{
+	entity.MediaTypeImage: 4,
+	entity.MediaTypeGIF:   1,
+	entity.MediaTypeVideo: 1,
+	entity.MediaTypeAudio: 1,
+}
+
 type service struct {
-	db     db
-	object objectStorage
+	db              db
+	object          objectStorage
+	chunkSize       int64
+	uploadTTL       time.Duration
+	cleanupInterval time.Duration
 }
 
-func NewMediaService(db db, object objectStorage) *service {
-	return &service{db: db, object: object}
+func NewMediaService(cfg *config.UploadsConfig, db db, object objectStorage) *service {
+	return &service{
+		db:              db,
+		object:          object,
+		chunkSize:       cfg.ChunkSize,
+		uploadTTL:       cfg.TTL,
+		cleanupInterval: cfg.CleanupInterval,
+	}
 }
 
-func (s *service) UploadAndAttachTweetMediaTx(ctx context.Context, tweetID int, file io.Reader, filename string, tx *sql.Tx) (string, error) {
+// UploadAndAttachTweetMediaTx uploads the attachments of a tweet of userID and attaches
+// them in the given order. Attachments referring to a completed upload of userID take
+// its object over within tx. Objects uploaded here are removed again when any of them fails.
+func (s *service) UploadAndAttachTweetMediaTx(ctx context.Context, tweetID, userID int, attachments []entity.Attachment, tx *sql.Tx) ([]entity.TweetMedia, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	types, err := s.attachmentTypes(ctx, userID, attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(attachments))
+	cleanup := func() {
+		for _, path := range paths {
+			s.asyncCleanup(path)
+		}
+	}
+
+	attached := make([]entity.TweetMedia, 0, len(attachments))
+	for i, attachment := range attachments {
+		var media *entity.UploadedMedia
+		if attachment.UploadID != 0 {
+			upload, err := s.db.ConsumeUploadTx(ctx, tx, userID, attachment.UploadID)
+			if err != nil {
+				cleanup()
+				return nil, err
+			}
+			media = &entity.UploadedMedia{Path: upload.Path, MimeType: upload.MimeType, SizeBytes: upload.Size}
+		} else {
+			var buf bytes.Buffer
+			size, err := io.Copy(&buf, attachment.File.File)
+			if err != nil {
+				cleanup()
+				return nil, fmt.Errorf("failed to read file for size calculation: %w", err)
+			}
+
+			path, mime, err := s.object.Upload(ctx, bytes.NewReader(buf.Bytes()), types[i], attachment.File.Header.Filename)
+			if err != nil {
+				cleanup()
+				return nil, err
+			}
+			paths = append(paths, path)
+			media = &entity.UploadedMedia{Path: path, MimeType: mime, SizeBytes: size}
+		}
+
+		tweetMedia, err := s.db.InsertTweetMediaTx(ctx, tx, &entity.TweetMedia{
+			TweetID:   tweetID,
+			Position:  i,
+			Path:      media.Path,
+			MimeType:  media.MimeType,
+			SizeBytes: media.SizeBytes,
+			AltText:   attachment.AltText,
+		})
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("insert media failed: %w", err)
+		}
+		attached = append(attached, *tweetMedia)
+	}
+
+	if err := s.presignTweetMedia(ctx, attached); err != nil {
+		cleanup()
+		return nil, err
+	}
+	return attached, nil
+}
+
+// UploadMedia stores a file that is attached to a tweet later, such as the media of a draft.
+func (s *service) UploadMedia(ctx context.Context, file io.Reader, filename string) (*entity.UploadedMedia, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	mt, err := s.detectMediaType(filename)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var buf bytes.Buffer
 	size, err := io.Copy(&buf, file)
 	if err != nil {
-		return "", fmt.Errorf("failed to read file for size calculation: %w", err)
+		return nil, fmt.Errorf("failed to read file for size calculation: %w", err)
 	}
 
-	bufReader := bytes.NewReader(buf.Bytes())
-
-	path, mime, err := s.object.Upload(ctx, bufReader, mt, filename)
+	path, mime, err := s.object.Upload(ctx, bytes.NewReader(buf.Bytes()), mt, filename)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	tweetMedia, err := s.db.UpsertByTweetIdTx(ctx, tx, &entity.TweetMedia{
-		TweetID:   tweetID,
+	return &entity.UploadedMedia{
 		Path:      path,
 		MimeType:  mime,
 		SizeBytes: size,
+	}, nil
+}
+
+// AttachTweetMediaTx attaches media stored by UploadMedia to a tweet. The object is left
+// in place when attaching fails, since it still belongs to whatever uploaded it.
+func (s *service) AttachTweetMediaTx(ctx context.Context, tweetID int, media *entity.UploadedMedia, tx *sql.Tx) ([]entity.TweetMedia, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tweetMedia, err := s.db.InsertTweetMediaTx(ctx, tx, &entity.TweetMedia{
+		TweetID:   tweetID,
+		Path:      media.Path,
+		MimeType:  media.MimeType,
+		SizeBytes: media.SizeBytes,
 	})
 	if err != nil {
-		go s.asyncCleanup(path)
-		return "", fmt.Errorf("upsert media failed: %w", err)
+		return nil, fmt.Errorf("insert media failed: %w", err)
 	}
-	return s.object.GetPresignedURL(ctx, tweetMedia.Path)
+	attached := []entity.TweetMedia{*tweetMedia}
+	if err := s.presignTweetMedia(ctx, attached); err != nil {
+		return nil, err
+	}
+	return attached, nil
 }
 
-func (s *service) GetMediaUrlByTweetID(ctx context.Context, tweetID int) (string, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+// DeleteUploadedMedia removes media stored by UploadMedia that was never attached.
+func (s *service) DeleteUploadedMedia(path string) {
+	s.asyncCleanup(path)
+}
+
+// GetMediaByTweetIDs presigns media of several tweets at once; tweets without media are absent.
+func (s *service) GetMediaByTweetIDs(ctx context.Context, tweetIDs []int) (map[int][]entity.TweetMedia, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	mediaPath, err := s.db.GetMediaPathByTweetID(ctx, tweetID)
+	media, err := s.db.GetMediaByTweetIDs(ctx, tweetIDs)
 	if err != nil {
-		return "", fmt.Errorf("failed to get media url: %w", err)
+		return nil, fmt.Errorf("failed to get tweet media: %w", err)
 	}
-
-	return s.object.GetPresignedURL(ctx, mediaPath)
+	for tweetID := range media {
+		if err := s.presignTweetMedia(ctx, media[tweetID]); err != nil {
+			return nil, err
+		}
+	}
+	return media, nil
 }
 
-func (s *service) GetMediaDataByTweetID(ctx context.Context, tweetID int) (*entity.TweetMedia, error) {
+func (s *service) GetTweetMedia(ctx context.Context, tweetID int) ([]entity.TweetMedia, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	tweetMedia, err := s.db.GetMediaDataByTweetID(ctx, tweetID)
-	if err != nil && err != sql.ErrNoRows {
+	media, err := s.db.GetMediaByTweetID(ctx, tweetID)
+	if err != nil {
 		return nil, fmt.Errorf("failed to get tweet media data: %w", err)
-	} else if err == sql.ErrNoRows {
-		return nil, nil
 	}
+	if err := s.presignTweetMedia(ctx, media); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (s *service) DeleteTweetMedia(ctx context.Context, tweetID, userID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	paths, err := s.db.DeleteMediaByTweetID(ctx, tweetID, userID)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		s.asyncCleanup(path)
+	}
+	return nil
+}
 
-	mediaURL, err := s.object.GetPresignedURL(ctx, tweetMedia.Path)
+// DetachTweetMediaTx removes every attachment of a tweet within tx and returns their object
+// paths. The objects stay in place until RemoveDetachedMedia is called after tx commits,
+// so that rolling tx back leaves the tweet with its media.
+func (s *service) DetachTweetMediaTx(ctx context.Context, tweetID int, tx *sql.Tx) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	paths, err := s.db.DeleteMediaByTweetIDTx(ctx, tx, tweetID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tweet media url: %w", err)
+		return nil, fmt.Errorf("failed to detach tweet media: %w", err)
 	}
+	return paths, nil
+}
 
-	return &entity.TweetMedia{
-		ID:        tweetMedia.ID,
-		TweetID:   tweetMedia.TweetID,
-		Path:      mediaURL,
-		MimeType:  tweetMedia.MimeType,
-		SizeBytes: tweetMedia.SizeBytes,
-	}, nil
+// RemoveDetachedMedia removes the objects of media detached by DetachTweetMediaTx.
+func (s *service) RemoveDetachedMedia(paths []string) {
+	for _, path := range paths {
+		s.asyncCleanup(path)
+	}
 }
 
-func (s *service) DeleteTweetMedia(ctx context.Context, tweetID, userID int) error {
+// DeleteTweetMediaItem removes a single attachment of a tweet; the remaining ones keep their order.
+func (s *service) DeleteTweetMediaItem(ctx context.Context, tweetID, mediaID, userID int) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	media, err := s.db.GetMediaDataByTweetID(ctx, tweetID)
+	path, err := s.db.DeleteMediaItem(ctx, tweetID, mediaID, userID)
 	if err != nil {
 		return err
 	}
-	if err := s.db.DeleteMediaByTweetID(ctx, tweetID, userID); err != nil {
+	s.asyncCleanup(path)
+	return nil
+}
+
+// ForceDeleteTweetMedia removes tweet media regardless of the tweet author, for moderation.
+func (s *service) ForceDeleteTweetMedia(ctx context.Context, tweetID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	paths, err := s.db.ForceDeleteMediaByTweetID(ctx, tweetID)
+	if err != nil {
 		return err
 	}
-	if media.Path != "" {
-		go s.asyncCleanup(media.Path)
+	for _, path := range paths {
+		s.asyncCleanup(path)
 	}
 	return nil
 }
 
+func (s *service) UploadAndAttachMessageMediaTx(ctx context.Context, messageID int, file io.Reader, filename string, tx *sql.Tx) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	mt, err := s.detectMediaType(filename)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	size, err := io.Copy(&buf, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file for size calculation: %w", err)
+	}
+
+	path, mime, err := s.object.Upload(ctx, bytes.NewReader(buf.Bytes()), mt, filename)
+	if err != nil {
+		return "", err
+	}
+	messageMedia, err := s.db.UpsertByMessageIdTx(ctx, tx, &entity.MessageMedia{
+		MessageID: messageID,
+		Path:      path,
+		MimeType:  mime,
+		SizeBytes: size,
+	})
+	if err != nil {
+		go s.asyncCleanup(path)
+		return "", fmt.Errorf("upsert message media failed: %w", err)
+	}
+	return s.object.GetPresignedURL(ctx, messageMedia.Path)
+}
+
+// GetMediaUrlsByMessageIDs presigns media of several messages at once; messages without media are absent.
+func (s *service) GetMediaUrlsByMessageIDs(ctx context.Context, messageIDs []int) (map[int]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	paths, err := s.db.GetMediaPathsByMessageIDs(ctx, messageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message media paths: %w", err)
+	}
+	return s.presignAll(ctx, paths)
+}
+
 func (s *service) UploadAvatarTx(ctx context.Context, userID int, file io.Reader, filename string, tx *sql.Tx) (*entity.Avatar, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -163,6 +355,17 @@ func (s *service) GetAvatarUrlByUserID(ctx context.Context, userID int) (string,
 	return s.object.GetPresignedURL(ctx, avatarPath)
 }
 
+// GetAvatarUrlsByUserIDs presigns avatars of several users at once; users without an avatar are absent.
+func (s *service) GetAvatarUrlsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	paths, err := s.db.GetAvatarPathsByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get avatar paths: %w", err)
+	}
+	return s.presignAll(ctx, paths)
+}
+
 func (s *service) GetAvatarDataByUserID(ctx context.Context, userID int) (*entity.Avatar, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -248,6 +451,87 @@ func (s *service) GetPresignedURL(ctx context.Context, path string) (string, err
 	return s.object.GetPresignedURL(ctx, path)
 }
 
+func (s *service) presignAll(ctx context.Context, paths map[int]string) (map[int]string, error) {
+	urls := make(map[int]string, len(paths))
+	for id, path := range paths {
+		if path == "" {
+			continue
+		}
+		url, err := s.object.GetPresignedURL(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to presign %s: %w", path, err)
+		}
+		urls[id] = url
+	}
+	return urls, nil
+}
+
+// presignTweetMedia replaces the object paths of the media with presigned urls.
+func (s *service) presignTweetMedia(ctx context.Context, media []entity.TweetMedia) error {
+	for i := range media {
+		url, err := s.object.GetPresignedURL(ctx, media[i].Path)
+		if err != nil {
+			return fmt.Errorf("failed to presign %s: %w", media[i].Path, err)
+		}
+		media[i].Path = url
+	}
+	return nil
+}
+
+// ValidateTweetAttachments checks attachments of a tweet of userID against the rules of
+// a tweet without uploading them.
+func (s *service) ValidateTweetAttachments(ctx context.Context, userID int, attachments []entity.Attachment) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := s.attachmentTypes(ctx, userID, attachments)
+	return err
+}
+
+// attachmentTypes detects the media type of every attachment and enforces the attachment
+// rules of a tweet: every attachment has the same media type, there are no more of them
+// than that type allows, and alt texts fit their limit. Uploads of userID that attachments
+// refer to must be completed.
+func (s *service) attachmentTypes(ctx context.Context, userID int, attachments []entity.Attachment) ([]entity.MediaType, error) {
+	if len(attachments) > maxTweetAttachments {
+		return nil, errs.ErrTooManyAttachments
+	}
+	types := make([]entity.MediaType, 0, len(attachments))
+	for _, attachment := range attachments {
+		mt, err := s.attachmentType(ctx, userID, attachment)
+		if err != nil {
+			return nil, err
+		}
+		if len(types) > 0 && mt != types[0] {
+			return nil, errs.ErrMixedAttachments
+		}
+		if utf8.RuneCountInString(attachment.AltText) > maxAltTextLength {
+			return nil, errs.ErrAltTextTooLong
+		}
+		types = append(types, mt)
+	}
+	if len(types) > 0 && len(types) > attachmentLimits[types[0]] {
+		return nil, errs.ErrTooManyAttachments
+	}
+	return types, nil
+}
+
+func (s *service) attachmentType(ctx context.Context, userID int, attachment entity.Attachment) (entity.MediaType, error) {
+	if attachment.UploadID == 0 {
+		return s.detectMediaType(attachment.File.Header.Filename)
+	}
+	upload, err := s.db.GetUploadByID(ctx, userID, attachment.UploadID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUploadNotFound) {
+			return "", err
+		}
+		return "", fmt.Errorf("failed to get upload: %w", err)
+	}
+	if !upload.Completed() {
+		return "", errs.ErrUploadIncomplete
+	}
+	return upload.MediaType, nil
+}
+
 func (s *service) detectMediaType(filename string) (entity.MediaType, error) {
 	ext := strings.ToLower(filepath.Ext(filename))
 	switch ext {
@@ -260,6 +544,6 @@ func (s *service) detectMediaType(filename string) (entity.MediaType, error) {
 	case ".mp3", ".wav", ".ogg", ".flac", ".aac", ".m4a", ".webm":
 		return entity.MediaTypeAudio, nil
 	default:
-		return "", fmt.Errorf("invalid media type")
+		return "", errs.ErrInvalidmediaType
 	}
 }
@@ -0,0 +1,451 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/core/service/media"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockMediaStorage struct {
+	mock.Mock
+}
+
+func (m *mockMediaStorage) InsertTweetMediaTx(ctx context.Context, tx *sql.Tx, media *entity.TweetMedia) (*entity.TweetMedia, error) {
+	args := m.Called(ctx, tx, media)
+	inserted, _ := args.Get(0).(*entity.TweetMedia)
+	return inserted, args.Error(1)
+}
+
+func (m *mockMediaStorage) GetMediaByTweetID(ctx context.Context, tweetID int) ([]entity.TweetMedia, error) {
+	args := m.Called(ctx, tweetID)
+	media, _ := args.Get(0).([]entity.TweetMedia)
+	return media, args.Error(1)
+}
+
+func (m *mockMediaStorage) DeleteMediaByTweetID(ctx context.Context, tweetID, userID int) ([]string, error) {
+	args := m.Called(ctx, tweetID, userID)
+	paths, _ := args.Get(0).([]string)
+	return paths, args.Error(1)
+}
+
+func (m *mockMediaStorage) DeleteMediaByTweetIDTx(ctx context.Context, tx *sql.Tx, tweetID int) ([]string, error) {
+	args := m.Called(ctx, tx, tweetID)
+	paths, _ := args.Get(0).([]string)
+	return paths, args.Error(1)
+}
+
+func (m *mockMediaStorage) DeleteMediaItem(ctx context.Context, tweetID, mediaID, userID int) (string, error) {
+	args := m.Called(ctx, tweetID, mediaID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMediaStorage) ForceDeleteMediaByTweetID(ctx context.Context, tweetID int) ([]string, error) {
+	args := m.Called(ctx, tweetID)
+	paths, _ := args.Get(0).([]string)
+	return paths, args.Error(1)
+}
+
+func (m *mockMediaStorage) UploadAvatarTx(ctx context.Context, tx *sql.Tx, avatar *entity.Avatar) (*entity.Avatar, error) {
+	args := m.Called(ctx, tx, avatar)
+	a, _ := args.Get(0).(*entity.Avatar)
+	return a, args.Error(1)
+}
+
+func (m *mockMediaStorage) GetAvatarPathByUserID(ctx context.Context, userID int) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMediaStorage) GetAvatarDataByUserID(ctx context.Context, userID int) (*entity.Avatar, error) {
+	args := m.Called(ctx, userID)
+	a, _ := args.Get(0).(*entity.Avatar)
+	return a, args.Error(1)
+}
+
+func (m *mockMediaStorage) DeleteAvatarByUserID(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockMediaStorage) UpsertByMessageIdTx(ctx context.Context, tx *sql.Tx, media *entity.MessageMedia) (*entity.MessageMedia, error) {
+	args := m.Called(ctx, tx, media)
+	mm, _ := args.Get(0).(*entity.MessageMedia)
+	return mm, args.Error(1)
+}
+
+func (m *mockMediaStorage) GetMediaUrlsByUserID(ctx context.Context, userID int) ([]string, error) {
+	args := m.Called(ctx, userID)
+	urls, _ := args.Get(0).([]string)
+	return urls, args.Error(1)
+}
+
+func (m *mockMediaStorage) GetMediaByTweetIDs(ctx context.Context, tweetIDs []int) (map[int][]entity.TweetMedia, error) {
+	args := m.Called(ctx, tweetIDs)
+	media, _ := args.Get(0).(map[int][]entity.TweetMedia)
+	return media, args.Error(1)
+}
+
+func (m *mockMediaStorage) GetAvatarPathsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error) {
+	args := m.Called(ctx, userIDs)
+	paths, _ := args.Get(0).(map[int]string)
+	return paths, args.Error(1)
+}
+
+func (m *mockMediaStorage) GetMediaPathsByMessageIDs(ctx context.Context, messageIDs []int) (map[int]string, error) {
+	args := m.Called(ctx, messageIDs)
+	paths, _ := args.Get(0).(map[int]string)
+	return paths, args.Error(1)
+}
+
+func (m *mockMediaStorage) CreateUpload(ctx context.Context, upload *entity.Upload) (*entity.Upload, error) {
+	args := m.Called(ctx, upload)
+	u, _ := args.Get(0).(*entity.Upload)
+	return u, args.Error(1)
+}
+
+func (m *mockMediaStorage) GetUploadByID(ctx context.Context, userID, uploadID int) (*entity.Upload, error) {
+	args := m.Called(ctx, userID, uploadID)
+	u, _ := args.Get(0).(*entity.Upload)
+	return u, args.Error(1)
+}
+
+func (m *mockMediaStorage) ClaimUpload(ctx context.Context, userID, uploadID int, offset int64, lockedUntil time.Time) (*entity.Upload, error) {
+	args := m.Called(ctx, userID, uploadID, offset, lockedUntil)
+	u, _ := args.Get(0).(*entity.Upload)
+	return u, args.Error(1)
+}
+
+func (m *mockMediaStorage) StartUpload(ctx context.Context, uploadID int, path, mimeType, storageUploadID string) error {
+	args := m.Called(ctx, uploadID, path, mimeType, storageUploadID)
+	return args.Error(0)
+}
+
+func (m *mockMediaStorage) AdvanceUpload(ctx context.Context, uploadID int, offset int64) error {
+	args := m.Called(ctx, uploadID, offset)
+	return args.Error(0)
+}
+
+func (m *mockMediaStorage) ReleaseUpload(ctx context.Context, uploadID int) error {
+	args := m.Called(ctx, uploadID)
+	return args.Error(0)
+}
+
+func (m *mockMediaStorage) CompleteUpload(ctx context.Context, uploadID int, expiresAt time.Time) (*entity.Upload, error) {
+	args := m.Called(ctx, uploadID, expiresAt)
+	u, _ := args.Get(0).(*entity.Upload)
+	return u, args.Error(1)
+}
+
+func (m *mockMediaStorage) DeleteUpload(ctx context.Context, userID, uploadID int) (*entity.Upload, error) {
+	args := m.Called(ctx, userID, uploadID)
+	u, _ := args.Get(0).(*entity.Upload)
+	return u, args.Error(1)
+}
+
+func (m *mockMediaStorage) ConsumeUploadTx(ctx context.Context, tx *sql.Tx, userID, uploadID int) (*entity.Upload, error) {
+	args := m.Called(ctx, tx, userID, uploadID)
+	u, _ := args.Get(0).(*entity.Upload)
+	return u, args.Error(1)
+}
+
+func (m *mockMediaStorage) DeleteExpiredUploads(ctx context.Context, now time.Time, limit int) ([]entity.Upload, error) {
+	args := m.Called(ctx, now, limit)
+	uploads, _ := args.Get(0).([]entity.Upload)
+	return uploads, args.Error(1)
+}
+
+type mockObjectStorage struct {
+	mock.Mock
+}
+
+func (m *mockObjectStorage) Upload(ctx context.Context, file io.Reader, mediaType entity.MediaType, filename string) (string, string, error) {
+	args := m.Called(ctx, file, mediaType, filename)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *mockObjectStorage) Remove(ctx context.Context, objectPath string) error {
+	args := m.Called(ctx, objectPath)
+	return args.Error(0)
+}
+
+func (m *mockObjectStorage) GetPresignedURL(ctx context.Context, objectPath string) (string, error) {
+	args := m.Called(ctx, objectPath)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockObjectStorage) CheckUpload(mediaType entity.MediaType, filename string, size int64) error {
+	args := m.Called(mediaType, filename, size)
+	return args.Error(0)
+}
+
+func (m *mockObjectStorage) StartMultipartUpload(ctx context.Context, mediaType entity.MediaType, filename string, head []byte) (string, string, string, error) {
+	args := m.Called(ctx, mediaType, filename, head)
+	return args.String(0), args.String(1), args.String(2), args.Error(3)
+}
+
+func (m *mockObjectStorage) UploadPart(ctx context.Context, path, uploadID string, partNumber int, data io.Reader, size int64) error {
+	args := m.Called(ctx, path, uploadID, partNumber, data, size)
+	return args.Error(0)
+}
+
+func (m *mockObjectStorage) CompleteMultipartUpload(ctx context.Context, path, uploadID string) error {
+	args := m.Called(ctx, path, uploadID)
+	return args.Error(0)
+}
+
+func (m *mockObjectStorage) AbortMultipartUpload(ctx context.Context, path, uploadID string) error {
+	args := m.Called(ctx, path, uploadID)
+	return args.Error(0)
+}
+
+// uploadsConfig uses 8-byte chunks to keep test chunks small.
+var uploadsConfig = &config.UploadsConfig{ChunkSize: 8, TTL: time.Hour, CleanupInterval: time.Minute}
+
+type testFile struct {
+	*bytes.Reader
+}
+
+func (testFile) Close() error { return nil }
+
+func attachment(filename, altText string) entity.Attachment {
+	return entity.Attachment{
+		File: &entity.File{
+			File:   testFile{bytes.NewReader([]byte("data"))},
+			Header: &multipart.FileHeader{Filename: filename},
+		},
+		AltText: altText,
+	}
+}
+
+func TestService_UploadAndAttachTweetMediaTx_Ordered(t *testing.T) {
+	mockDB := &mockMediaStorage{}
+	mockObject := &mockObjectStorage{}
+	service := media.NewMediaService(uploadsConfig, mockDB, mockObject)
+
+	mockObject.On("Upload", mock.Anything, mock.Anything, entity.MediaTypeImage, "a.png").Return("tweets/a.png", "image/png", nil).Once()
+	mockObject.On("Upload", mock.Anything, mock.Anything, entity.MediaTypeImage, "b.jpg").Return("tweets/b.jpg", "image/jpeg", nil).Once()
+	mockDB.On("InsertTweetMediaTx", mock.Anything, mock.Anything, mock.MatchedBy(func(m *entity.TweetMedia) bool {
+		return m.Position == 0 && m.Path == "tweets/a.png" && m.AltText == "first"
+	})).Return(&entity.TweetMedia{ID: 1, TweetID: 9, Position: 0, Path: "tweets/a.png", AltText: "first"}, nil).Once()
+	mockDB.On("InsertTweetMediaTx", mock.Anything, mock.Anything, mock.MatchedBy(func(m *entity.TweetMedia) bool {
+		return m.Position == 1 && m.Path == "tweets/b.jpg" && m.AltText == ""
+	})).Return(&entity.TweetMedia{ID: 2, TweetID: 9, Position: 1, Path: "tweets/b.jpg"}, nil).Once()
+	mockObject.On("GetPresignedURL", mock.Anything, "tweets/a.png").Return("https://cdn/a.png", nil).Once()
+	mockObject.On("GetPresignedURL", mock.Anything, "tweets/b.jpg").Return("https://cdn/b.jpg", nil).Once()
+
+	attached, err := service.UploadAndAttachTweetMediaTx(context.Background(), 9, 1, []entity.Attachment{
+		attachment("a.png", "first"),
+		attachment("b.jpg", ""),
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, attached, 2)
+	assert.Equal(t, "https://cdn/a.png", attached[0].Path)
+	assert.Equal(t, "first", attached[0].AltText)
+	assert.Equal(t, 1, attached[1].Position)
+	mockDB.AssertExpectations(t)
+	mockObject.AssertExpectations(t)
+}
+
+func TestService_UploadAndAttachTweetMediaTx_PresignErrorRemovesUploads(t *testing.T) {
+	mockDB := &mockMediaStorage{}
+	mockObject := &mockObjectStorage{}
+	service := media.NewMediaService(uploadsConfig, mockDB, mockObject)
+
+	removed := make(chan string, 1)
+	mockObject.On("Upload", mock.Anything, mock.Anything, entity.MediaTypeImage, "a.png").Return("tweets/a.png", "image/png", nil).Once()
+	mockDB.On("InsertTweetMediaTx", mock.Anything, mock.Anything, mock.Anything).Return(&entity.TweetMedia{ID: 1, TweetID: 9, Path: "tweets/a.png"}, nil).Once()
+	mockObject.On("GetPresignedURL", mock.Anything, "tweets/a.png").Return("", errors.New("storage down")).Once()
+	mockObject.On("Remove", mock.Anything, "tweets/a.png").Run(func(args mock.Arguments) {
+		removed <- args.String(1)
+	}).Return(nil).Once()
+
+	_, err := service.UploadAndAttachTweetMediaTx(context.Background(), 9, 1, []entity.Attachment{attachment("a.png", "")}, nil)
+
+	assert.Error(t, err)
+	select {
+	case path := <-removed:
+		assert.Equal(t, "tweets/a.png", path)
+	case <-time.After(time.Second):
+		t.Fatal("uploaded object was not removed")
+	}
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_UploadAndAttachTweetMediaTx_Rules(t *testing.T) {
+	tests := []struct {
+		name        string
+		attachments []entity.Attachment
+		err         error
+	}{
+		{
+			name: "five images",
+			attachments: []entity.Attachment{
+				attachment("1.png", ""), attachment("2.png", ""), attachment("3.png", ""),
+				attachment("4.png", ""), attachment("5.png", ""),
+			},
+			err: errs.ErrTooManyAttachments,
+		},
+		{
+			name:        "two videos",
+			attachments: []entity.Attachment{attachment("1.mp4", ""), attachment("2.mp4", "")},
+			err:         errs.ErrTooManyAttachments,
+		},
+		{
+			name:        "image and video",
+			attachments: []entity.Attachment{attachment("1.png", ""), attachment("2.mp4", "")},
+			err:         errs.ErrMixedAttachments,
+		},
+		{
+			name:        "unknown type",
+			attachments: []entity.Attachment{attachment("1.exe", "")},
+			err:         errs.ErrInvalidmediaType,
+		},
+		{
+			name:        "alt text too long",
+			attachments: []entity.Attachment{attachment("1.png", strings.Repeat("a", 1001))},
+			err:         errs.ErrAltTextTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &mockMediaStorage{}
+			mockObject := &mockObjectStorage{}
+			service := media.NewMediaService(uploadsConfig, mockDB, mockObject)
+
+			_, err := service.UploadAndAttachTweetMediaTx(context.Background(), 9, 1, tt.attachments, nil)
+
+			assert.ErrorIs(t, err, tt.err)
+			mockObject.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_DeleteTweetMediaItem_NotFound(t *testing.T) {
+	mockDB := &mockMediaStorage{}
+	mockObject := &mockObjectStorage{}
+	service := media.NewMediaService(uploadsConfig, mockDB, mockObject)
+
+	mockDB.On("DeleteMediaItem", mock.Anything, 9, 3, 1).Return("", errs.ErrTweetMediaNotFound).Once()
+
+	err := service.DeleteTweetMediaItem(context.Background(), 9, 3, 1)
+
+	assert.ErrorIs(t, err, errs.ErrTweetMediaNotFound)
+	mockObject.AssertNotCalled(t, "Remove", mock.Anything, mock.Anything)
+}
+
+func TestService_UploadAndAttachTweetMediaTx_Upload(t *testing.T) {
+	mockDB := &mockMediaStorage{}
+	mockObject := &mockObjectStorage{}
+	service := media.NewMediaService(uploadsConfig, mockDB, mockObject)
+
+	completedAt := time.Now()
+	mockDB.On("GetUploadByID", mock.Anything, 1, 5).Return(&entity.Upload{ID: 5, MediaType: entity.MediaTypeVideo, CompletedAt: &completedAt}, nil).Once()
+	mockDB.On("ConsumeUploadTx", mock.Anything, mock.Anything, 1, 5).Return(&entity.Upload{ID: 5, Path: "video/x/clip.mp4", MimeType: "video/mp4", Size: 20}, nil).Once()
+	mockDB.On("InsertTweetMediaTx", mock.Anything, mock.Anything, mock.MatchedBy(func(m *entity.TweetMedia) bool {
+		return m.Path == "video/x/clip.mp4" && m.SizeBytes == 20 && m.AltText == "clip"
+	})).Return(&entity.TweetMedia{ID: 1, TweetID: 9, Path: "video/x/clip.mp4", AltText: "clip"}, nil).Once()
+	mockObject.On("GetPresignedURL", mock.Anything, "video/x/clip.mp4").Return("https://cdn/clip.mp4", nil).Once()
+
+	attached, err := service.UploadAndAttachTweetMediaTx(context.Background(), 9, 1, []entity.Attachment{{UploadID: 5, AltText: "clip"}}, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, attached, 1)
+	assert.Equal(t, "https://cdn/clip.mp4", attached[0].Path)
+	mockDB.AssertExpectations(t)
+	mockObject.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_ValidateTweetAttachments_UploadIncomplete(t *testing.T) {
+	mockDB := &mockMediaStorage{}
+	mockObject := &mockObjectStorage{}
+	service := media.NewMediaService(uploadsConfig, mockDB, mockObject)
+
+	mockDB.On("GetUploadByID", mock.Anything, 1, 5).Return(&entity.Upload{ID: 5, MediaType: entity.MediaTypeVideo, Size: 20, Offset: 8}, nil).Once()
+
+	err := service.ValidateTweetAttachments(context.Background(), 1, []entity.Attachment{{UploadID: 5}})
+
+	assert.ErrorIs(t, err, errs.ErrUploadIncomplete)
+}
+
+func TestService_WriteUploadChunk_FirstChunk(t *testing.T) {
+	mockDB := &mockMediaStorage{}
+	mockObject := &mockObjectStorage{}
+	service := media.NewMediaService(uploadsConfig, mockDB, mockObject)
+
+	upload := &entity.Upload{ID: 5, UserID: 1, Filename: "clip.mp4", MediaType: entity.MediaTypeVideo, Size: 20}
+	chunk := []byte("ftypmp42")
+	mockDB.On("GetUploadByID", mock.Anything, 1, 5).Return(upload, nil).Once()
+	mockDB.On("ClaimUpload", mock.Anything, 1, 5, int64(0), mock.Anything).Return(upload, nil).Once()
+	mockObject.On("StartMultipartUpload", mock.Anything, entity.MediaTypeVideo, "clip.mp4", chunk).Return("video/x/clip.mp4", "video/mp4", "s3-upload", nil).Once()
+	mockDB.On("StartUpload", mock.Anything, 5, "video/x/clip.mp4", "video/mp4", "s3-upload").Return(nil).Once()
+	mockObject.On("UploadPart", mock.Anything, "video/x/clip.mp4", "s3-upload", 1, mock.MatchedBy(func(r io.Reader) bool {
+		data, _ := io.ReadAll(r)
+		return bytes.Equal(data, chunk)
+	}), int64(8)).Return(nil).Once()
+	mockDB.On("AdvanceUpload", mock.Anything, 5, int64(8)).Return(nil).Once()
+
+	result, err := service.WriteUploadChunk(context.Background(), 1, 5, 0, bytes.NewReader(chunk), 8)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), result.Offset)
+	assert.Equal(t, int64(8), result.ChunkSize)
+	mockDB.AssertExpectations(t)
+	mockObject.AssertExpectations(t)
+}
+
+func TestService_WriteUploadChunk_Rejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int64
+		size   int64
+		err    error
+	}{
+		{name: "offset behind", offset: 0, size: 8, err: errs.ErrUploadOffsetMismatch},
+		{name: "offset ahead", offset: 16, size: 4, err: errs.ErrUploadOffsetMismatch},
+		{name: "short chunk", offset: 8, size: 4, err: errs.ErrInvalidChunk},
+		{name: "long chunk", offset: 8, size: 9, err: errs.ErrInvalidChunk},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &mockMediaStorage{}
+			mockObject := &mockObjectStorage{}
+			service := media.NewMediaService(uploadsConfig, mockDB, mockObject)
+
+			mockDB.On("GetUploadByID", mock.Anything, 1, 5).Return(&entity.Upload{ID: 5, Size: 20, Offset: 8, StorageUploadID: "s3-upload"}, nil).Once()
+
+			_, err := service.WriteUploadChunk(context.Background(), 1, 5, tt.offset, bytes.NewReader(make([]byte, tt.size)), tt.size)
+
+			assert.ErrorIs(t, err, tt.err)
+			mockDB.AssertNotCalled(t, "ClaimUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_CompleteUpload_Incomplete(t *testing.T) {
+	mockDB := &mockMediaStorage{}
+	mockObject := &mockObjectStorage{}
+	service := media.NewMediaService(uploadsConfig, mockDB, mockObject)
+
+	mockDB.On("GetUploadByID", mock.Anything, 1, 5).Return(&entity.Upload{ID: 5, Size: 20, Offset: 16}, nil).Once()
+
+	_, err := service.CompleteUpload(context.Background(), 1, 5)
+
+	assert.ErrorIs(t, err, errs.ErrUploadIncomplete)
+	mockObject.AssertNotCalled(t, "CompleteMultipartUpload", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,247 @@
+package media
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// chunkTimeout bounds writing one chunk; the upload stays leased to its writer that long.
+	chunkTimeout = 10 * time.Minute
+	// sniffLen is how much of the first chunk decides the mime type of an upload.
+	sniffLen            = 512
+	expiredUploadsBatch = 100
+)
+
+// CreateUpload starts a resumable upload of a file of size bytes. Chunks are then written
+// with WriteUploadChunk and the upload is finished with CompleteUpload.
+func (s *service) CreateUpload(ctx context.Context, userID int, filename string, size int64) (*entity.Upload, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if size <= 0 {
+		return nil, errs.ErrInvalidInput
+	}
+	filename = filepath.Base(filename)
+	mt, err := s.detectMediaType(filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.object.CheckUpload(mt, filename, size); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	upload, err := s.db.CreateUpload(ctx, &entity.Upload{
+		UserID:    userID,
+		Filename:  filename,
+		MediaType: mt,
+		Size:      size,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.uploadTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload: %w", err)
+	}
+	upload.ChunkSize = s.chunkSize
+	return upload, nil
+}
+
+func (s *service) GetUpload(ctx context.Context, userID, uploadID int) (*entity.Upload, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	upload, err := s.db.GetUploadByID(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	upload.ChunkSize = s.chunkSize
+	return upload, nil
+}
+
+// WriteUploadChunk streams the chunk of size bytes at offset to object storage. The offset
+// must be the current offset of the upload, and every chunk but the last one must be
+// exactly the chunk size, so that a chunk that failed can be written again at the same offset.
+func (s *service) WriteUploadChunk(ctx context.Context, userID, uploadID int, offset int64, chunk io.Reader, size int64) (*entity.Upload, error) {
+	ctx, cancel := context.WithTimeout(ctx, chunkTimeout)
+	defer cancel()
+
+	upload, err := s.db.GetUploadByID(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Completed() {
+		return nil, errs.ErrUploadCompleted
+	}
+	if offset != upload.Offset {
+		return nil, errs.ErrUploadOffsetMismatch
+	}
+	if upload.Offset == upload.Size || size != min(s.chunkSize, upload.Size-upload.Offset) {
+		return nil, errs.ErrInvalidChunk
+	}
+
+	upload, err = s.db.ClaimUpload(ctx, userID, uploadID, offset, time.Now().Add(chunkTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.writeChunk(ctx, upload, chunk, size); err != nil {
+		s.releaseUpload(upload.ID)
+		return nil, err
+	}
+
+	upload.Offset += size
+	if err := s.db.AdvanceUpload(ctx, upload.ID, upload.Offset); err != nil {
+		return nil, fmt.Errorf("failed to advance upload: %w", err)
+	}
+	upload.ChunkSize = s.chunkSize
+	return upload, nil
+}
+
+// writeChunk uploads the chunk as the next part of a claimed upload. The first chunk
+// starts the multipart upload, typed by its leading bytes.
+func (s *service) writeChunk(ctx context.Context, upload *entity.Upload, chunk io.Reader, size int64) error {
+	if upload.StorageUploadID == "" {
+		buffered := bufio.NewReaderSize(chunk, sniffLen)
+		head, err := buffered.Peek(int(min(sniffLen, size)))
+		if err != nil {
+			return fmt.Errorf("failed to read chunk: %w", err)
+		}
+		path, mime, storageUploadID, err := s.object.StartMultipartUpload(ctx, upload.MediaType, upload.Filename, head)
+		if err != nil {
+			return err
+		}
+		if err := s.db.StartUpload(ctx, upload.ID, path, mime, storageUploadID); err != nil {
+			s.abortMultipartUpload(path, storageUploadID)
+			return fmt.Errorf("failed to start upload: %w", err)
+		}
+		upload.Path, upload.MimeType, upload.StorageUploadID = path, mime, storageUploadID
+		chunk = buffered
+	}
+
+	partNumber := int(upload.Offset/s.chunkSize) + 1
+	if err := s.object.UploadPart(ctx, upload.Path, upload.StorageUploadID, partNumber, chunk, size); err != nil {
+		return fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+	}
+	return nil
+}
+
+// CompleteUpload assembles an upload whose chunks are all written. The completed upload
+// can be attached to a tweet until it expires.
+func (s *service) CompleteUpload(ctx context.Context, userID, uploadID int) (*entity.Upload, error) {
+	ctx, cancel := context.WithTimeout(ctx, chunkTimeout)
+	defer cancel()
+
+	upload, err := s.db.GetUploadByID(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Completed() {
+		return nil, errs.ErrUploadCompleted
+	}
+	if upload.Offset != upload.Size {
+		return nil, errs.ErrUploadIncomplete
+	}
+
+	upload, err = s.db.ClaimUpload(ctx, userID, uploadID, upload.Size, time.Now().Add(chunkTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.object.CompleteMultipartUpload(ctx, upload.Path, upload.StorageUploadID); err != nil {
+		s.releaseUpload(upload.ID)
+		return nil, fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+
+	completed, err := s.db.CompleteUpload(ctx, upload.ID, time.Now().Add(s.uploadTTL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete upload: %w", err)
+	}
+	completed.ChunkSize = s.chunkSize
+	return completed, nil
+}
+
+// AbortUpload drops an upload that was not attached together with whatever of it was stored.
+func (s *service) AbortUpload(ctx context.Context, userID, uploadID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	upload, err := s.db.DeleteUpload(ctx, userID, uploadID)
+	if err != nil {
+		return err
+	}
+	s.discardUpload(upload)
+	return nil
+}
+
+// Run removes expired uploads every cleanup interval until ctx is cancelled.
+func (s *service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				removed, err := s.removeExpiredUploads(ctx)
+				if err != nil {
+					logrus.WithError(err).Warn("removing expired uploads failed")
+					break
+				}
+				if removed < expiredUploadsBatch {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (s *service) removeExpiredUploads(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	expired, err := s.db.DeleteExpiredUploads(ctx, time.Now(), expiredUploadsBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired uploads: %w", err)
+	}
+	for i := range expired {
+		s.discardUpload(&expired[i])
+	}
+	return len(expired), nil
+}
+
+// discardUpload removes the stored data of a deleted upload in the background.
+func (s *service) discardUpload(upload *entity.Upload) {
+	switch {
+	case upload.Completed():
+		s.asyncCleanup(upload.Path)
+	case upload.StorageUploadID != "":
+		s.abortMultipartUpload(upload.Path, upload.StorageUploadID)
+	}
+}
+
+func (s *service) abortMultipartUpload(path, storageUploadID string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.object.AbortMultipartUpload(ctx, path, storageUploadID); err != nil {
+			logrus.WithField("media_url", path).Warnf("failed to abort multipart upload: %s", err)
+		}
+	}()
+}
+
+// releaseUpload gives up the lease of a claimed upload, so that the failed chunk can be
+// written again without waiting for the lease to run out.
+func (s *service) releaseUpload(uploadID int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.db.ReleaseUpload(ctx, uploadID); err != nil {
+		logrus.WithField("upload_id", uploadID).Warnf("failed to release upload: %s", err)
+	}
+}
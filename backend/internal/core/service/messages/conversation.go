@@ -0,0 +1,95 @@
+package messages
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+// CreateConversation starts a conversation of creatorID with participantIDs. Every
+// participant must follow the creator and be followed back, and nobody may be blocked
+// by or have blocked the creator. A one-to-one conversation is created once per pair;
+// asking again returns the existing one.
+func (s *service) CreateConversation(ctx context.Context, creatorID int, participantIDs []int) (*entity.Conversation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	others := make([]int, 0, len(participantIDs))
+	seen := map[int]struct{}{creatorID: {}}
+	for _, id := range participantIDs {
+		if _, ok := seen[id]; ok || id <= 0 {
+			continue
+		}
+		seen[id] = struct{}{}
+		others = append(others, id)
+	}
+	if len(others) == 0 {
+		return nil, errs.ErrInvalidInput
+	}
+	if len(others)+1 > entity.MaxConversationParticipants {
+		return nil, errs.ErrTooManyParticipants
+	}
+
+	mutual, err := s.db.AreMutualFollowers(ctx, creatorID, others)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check follows: %w", err)
+	}
+	if !mutual {
+		return nil, errs.ErrNotMutualFollow
+	}
+	if err := s.checkNotBlocked(ctx, creatorID, others); err != nil {
+		return nil, err
+	}
+
+	var conversation *entity.Conversation
+	if len(others) > 1 {
+		conversation, err = s.db.CreateGroupConversation(ctx, creatorID, others, time.Now())
+	} else {
+		conversation, err = s.db.GetOrCreateDirectConversation(ctx, creatorID, others[0], time.Now())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	members, err := s.participants(ctx, []int{conversation.ID})
+	if err != nil {
+		return nil, err
+	}
+	conversation.Participants = members[conversation.ID]
+	return conversation, nil
+}
+
+// GetConversations lists the conversations of userID, most recently active first.
+func (s *service) GetConversations(ctx context.Context, userID int, page *entity.Page) ([]entity.Conversation, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	conversations, next, err := s.db.GetConversationsByUserID(ctx, userID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get conversations: %w", err)
+	}
+	if len(conversations) == 0 {
+		return conversations, next, nil
+	}
+
+	ids := make([]int, 0, len(conversations))
+	for i := range conversations {
+		ids = append(ids, conversations[i].ID)
+	}
+	members, err := s.participants(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range conversations {
+		conversations[i].Participants = members[conversations[i].ID]
+		if conversations[i].LastMessage != nil {
+			last := []entity.DirectMessage{*conversations[i].LastMessage}
+			fillSenders(last, conversations[i].Participants)
+			conversations[i].LastMessage = &last[0]
+		}
+	}
+	return conversations, next, nil
+}
@@ -0,0 +1,38 @@
+package messages
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type (
+	db interface {
+		BeginTx(ctx context.Context) (*sql.Tx, error)
+
+		AreMutualFollowers(ctx context.Context, userID int, otherIDs []int) (bool, error)
+		IsBlockedBetween(ctx context.Context, userID, otherID int) (bool, error)
+		GetOrCreateDirectConversation(ctx context.Context, userID, otherID int, createdAt time.Time) (*entity.Conversation, error)
+		CreateGroupConversation(ctx context.Context, creatorID int, participantIDs []int, createdAt time.Time) (*entity.Conversation, error)
+		GetConversationForUser(ctx context.Context, conversationID, userID int) (*entity.Conversation, error)
+		GetConversationsByUserID(ctx context.Context, userID int, page *entity.Page) ([]entity.Conversation, *entity.Cursor, error)
+		GetParticipantsByConversationIDs(ctx context.Context, conversationIDs []int) (map[int][]entity.Participant, error)
+
+		CreateMessageTx(ctx context.Context, tx *sql.Tx, message *entity.DirectMessage) (*entity.DirectMessage, error)
+		GetMessages(ctx context.Context, conversationID int, page *entity.Page) ([]entity.DirectMessage, *entity.Cursor, error)
+		MarkConversationRead(ctx context.Context, conversationID, userID, messageID int, readAt time.Time) (*entity.ReadReceipt, error)
+	}
+
+	mediaService interface {
+		UploadAndAttachMessageMediaTx(ctx context.Context, messageID int, file io.Reader, filename string, tx *sql.Tx) (string, error)
+		GetMediaUrlsByMessageIDs(ctx context.Context, messageIDs []int) (map[int]string, error)
+		GetAvatarUrlsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error)
+	}
+
+	hubProvider interface {
+		SendChatFrame(frame *entity.ChatFrame)
+	}
+)
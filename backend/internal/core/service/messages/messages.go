@@ -0,0 +1,93 @@
+package messages
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+type service struct {
+	db    db
+	media mediaService
+	hub   hubProvider
+}
+
+func NewMessageService(db db, media mediaService, hub hubProvider) *service {
+	return &service{
+		db:    db,
+		media: media,
+		hub:   hub,
+	}
+}
+
+// participants returns the members of a conversation with their avatars.
+func (s *service) participants(ctx context.Context, conversationIDs []int) (map[int][]entity.Participant, error) {
+	byConversation, err := s.db.GetParticipantsByConversationIDs(ctx, conversationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	userIDs := make([]int, 0, len(byConversation))
+	seen := make(map[int]struct{})
+	for _, members := range byConversation {
+		for _, member := range members {
+			if _, ok := seen[member.User.ID]; !ok {
+				seen[member.User.ID] = struct{}{}
+				userIDs = append(userIDs, member.User.ID)
+			}
+		}
+	}
+
+	avatarUrls, err := s.media.GetAvatarUrlsByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user avatars: %w", err)
+	}
+	for _, members := range byConversation {
+		for i := range members {
+			members[i].User.AvatarUrl = avatarUrls[members[i].User.ID]
+		}
+	}
+	return byConversation, nil
+}
+
+// checkNotBlocked fails with errs.ErrBlocked when userID and any of otherIDs are on
+// either side of a block.
+func (s *service) checkNotBlocked(ctx context.Context, userID int, otherIDs []int) error {
+	for _, otherID := range otherIDs {
+		blocked, err := s.db.IsBlockedBetween(ctx, userID, otherID)
+		if err != nil {
+			return fmt.Errorf("failed to check block: %w", err)
+		}
+		if blocked {
+			return errs.ErrBlocked
+		}
+	}
+	return nil
+}
+
+// fillSenders replaces the bare sender ids of messages with the participants' profiles.
+func fillSenders(messages []entity.DirectMessage, members []entity.Participant) {
+	users := make(map[int]entity.SmallUser, len(members))
+	for _, member := range members {
+		users[member.User.ID] = member.User
+	}
+	for i := range messages {
+		if user, ok := users[messages[i].Sender.ID]; ok {
+			messages[i].Sender = &user
+		}
+	}
+}
+
+// broadcast pushes a frame to every participant except the one who caused it.
+func (s *service) broadcast(members []entity.Participant, exceptID int, frame entity.ChatFrame) {
+	for _, member := range members {
+		if member.User.ID == exceptID {
+			continue
+		}
+		f := frame
+		f.RecipientID = member.User.ID
+		s.hub.SendChatFrame(&f)
+	}
+}
@@ -0,0 +1,326 @@
+package messages_test
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/core/service/messages"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/kust1q/Zapp/backend/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockStorage struct {
+	mock.Mock
+}
+
+func (m *mockStorage) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	args := m.Called(ctx)
+	tx, _ := args.Get(0).(*sql.Tx)
+	return tx, args.Error(1)
+}
+
+func (m *mockStorage) AreMutualFollowers(ctx context.Context, userID int, otherIDs []int) (bool, error) {
+	args := m.Called(ctx, userID, otherIDs)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockStorage) IsBlockedBetween(ctx context.Context, userID, otherID int) (bool, error) {
+	args := m.Called(ctx, userID, otherID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockStorage) GetOrCreateDirectConversation(ctx context.Context, userID, otherID int, createdAt time.Time) (*entity.Conversation, error) {
+	args := m.Called(ctx, userID, otherID, createdAt)
+	conversation, _ := args.Get(0).(*entity.Conversation)
+	return conversation, args.Error(1)
+}
+
+func (m *mockStorage) CreateGroupConversation(ctx context.Context, creatorID int, participantIDs []int, createdAt time.Time) (*entity.Conversation, error) {
+	args := m.Called(ctx, creatorID, participantIDs, createdAt)
+	conversation, _ := args.Get(0).(*entity.Conversation)
+	return conversation, args.Error(1)
+}
+
+func (m *mockStorage) GetConversationForUser(ctx context.Context, conversationID, userID int) (*entity.Conversation, error) {
+	args := m.Called(ctx, conversationID, userID)
+	conversation, _ := args.Get(0).(*entity.Conversation)
+	return conversation, args.Error(1)
+}
+
+func (m *mockStorage) GetConversationsByUserID(ctx context.Context, userID int, page *entity.Page) ([]entity.Conversation, *entity.Cursor, error) {
+	args := m.Called(ctx, userID, page)
+	conversations, _ := args.Get(0).([]entity.Conversation)
+	cursor, _ := args.Get(1).(*entity.Cursor)
+	return conversations, cursor, args.Error(2)
+}
+
+func (m *mockStorage) GetParticipantsByConversationIDs(ctx context.Context, conversationIDs []int) (map[int][]entity.Participant, error) {
+	args := m.Called(ctx, conversationIDs)
+	participants, _ := args.Get(0).(map[int][]entity.Participant)
+	return participants, args.Error(1)
+}
+
+func (m *mockStorage) CreateMessageTx(ctx context.Context, tx *sql.Tx, message *entity.DirectMessage) (*entity.DirectMessage, error) {
+	args := m.Called(ctx, tx, message)
+	created, _ := args.Get(0).(*entity.DirectMessage)
+	return created, args.Error(1)
+}
+
+func (m *mockStorage) GetMessages(ctx context.Context, conversationID int, page *entity.Page) ([]entity.DirectMessage, *entity.Cursor, error) {
+	args := m.Called(ctx, conversationID, page)
+	list, _ := args.Get(0).([]entity.DirectMessage)
+	cursor, _ := args.Get(1).(*entity.Cursor)
+	return list, cursor, args.Error(2)
+}
+
+func (m *mockStorage) MarkConversationRead(ctx context.Context, conversationID, userID, messageID int, readAt time.Time) (*entity.ReadReceipt, error) {
+	args := m.Called(ctx, conversationID, userID, messageID, readAt)
+	receipt, _ := args.Get(0).(*entity.ReadReceipt)
+	return receipt, args.Error(1)
+}
+
+type mockMediaService struct {
+	mock.Mock
+}
+
+func (m *mockMediaService) UploadAndAttachMessageMediaTx(ctx context.Context, messageID int, file io.Reader, filename string, tx *sql.Tx) (string, error) {
+	args := m.Called(ctx, messageID, file, filename, tx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMediaService) GetMediaUrlsByMessageIDs(ctx context.Context, messageIDs []int) (map[int]string, error) {
+	args := m.Called(ctx, messageIDs)
+	urls, _ := args.Get(0).(map[int]string)
+	return urls, args.Error(1)
+}
+
+func (m *mockMediaService) GetAvatarUrlsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error) {
+	args := m.Called(ctx, userIDs)
+	urls, _ := args.Get(0).(map[int]string)
+	return urls, args.Error(1)
+}
+
+type mockHub struct {
+	frames []entity.ChatFrame
+}
+
+func (m *mockHub) SendChatFrame(frame *entity.ChatFrame) {
+	m.frames = append(m.frames, *frame)
+}
+
+func participants(ids ...int) []entity.Participant {
+	res := make([]entity.Participant, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, entity.Participant{User: entity.SmallUser{ID: id, Username: "user"}})
+	}
+	return res
+}
+
+func TestService_CreateConversation_RequiresMutualFollow(t *testing.T) {
+	mockDB := &mockStorage{}
+	service := messages.NewMessageService(mockDB, &mockMediaService{}, &mockHub{})
+
+	mockDB.On("AreMutualFollowers", mock.Anything, 1, []int{2}).Return(false, nil).Once()
+
+	conversation, err := service.CreateConversation(context.Background(), 1, []int{2, 1, 2})
+
+	assert.ErrorIs(t, err, errs.ErrNotMutualFollow)
+	assert.Nil(t, conversation)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_CreateConversation_Blocked(t *testing.T) {
+	mockDB := &mockStorage{}
+	service := messages.NewMessageService(mockDB, &mockMediaService{}, &mockHub{})
+
+	mockDB.On("AreMutualFollowers", mock.Anything, 1, []int{2, 3}).Return(true, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(false, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 3).Return(true, nil).Once()
+
+	conversation, err := service.CreateConversation(context.Background(), 1, []int{2, 3})
+
+	assert.ErrorIs(t, err, errs.ErrBlocked)
+	assert.Nil(t, conversation)
+	mockDB.AssertNotCalled(t, "CreateGroupConversation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_CreateConversation_OnlySelf(t *testing.T) {
+	service := messages.NewMessageService(&mockStorage{}, &mockMediaService{}, &mockHub{})
+
+	_, err := service.CreateConversation(context.Background(), 1, []int{1})
+
+	assert.ErrorIs(t, err, errs.ErrInvalidInput)
+}
+
+func TestService_CreateConversation_TooManyParticipants(t *testing.T) {
+	service := messages.NewMessageService(&mockStorage{}, &mockMediaService{}, &mockHub{})
+
+	ids := make([]int, 0, entity.MaxConversationParticipants)
+	for id := 2; id <= entity.MaxConversationParticipants+1; id++ {
+		ids = append(ids, id)
+	}
+	_, err := service.CreateConversation(context.Background(), 1, ids)
+
+	assert.ErrorIs(t, err, errs.ErrTooManyParticipants)
+}
+
+func TestService_CreateConversation_ReusesDirectConversation(t *testing.T) {
+	mockDB := &mockStorage{}
+	mockMedia := &mockMediaService{}
+	service := messages.NewMessageService(mockDB, mockMedia, &mockHub{})
+
+	existing := &entity.Conversation{ID: 7}
+	mockDB.On("AreMutualFollowers", mock.Anything, 1, []int{2}).Return(true, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(false, nil).Once()
+	mockDB.On("GetOrCreateDirectConversation", mock.Anything, 1, 2, mock.AnythingOfType("time.Time")).Return(existing, nil).Once()
+	mockDB.On("GetParticipantsByConversationIDs", mock.Anything, []int{7}).Return(map[int][]entity.Participant{7: participants(1, 2)}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, mock.Anything).Return(map[int]string{2: "avatar"}, nil).Once()
+
+	conversation, err := service.CreateConversation(context.Background(), 1, []int{2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, conversation.ID)
+	assert.Len(t, conversation.Participants, 2)
+	assert.Equal(t, "avatar", conversation.Participants[1].User.AvatarUrl)
+	mockDB.AssertNotCalled(t, "CreateGroupConversation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_CreateConversation_Group(t *testing.T) {
+	mockDB := &mockStorage{}
+	mockMedia := &mockMediaService{}
+	service := messages.NewMessageService(mockDB, mockMedia, &mockHub{})
+
+	mockDB.On("AreMutualFollowers", mock.Anything, 1, []int{2, 3}).Return(true, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(false, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 3).Return(false, nil).Once()
+	mockDB.On("CreateGroupConversation", mock.Anything, 1, []int{2, 3}, mock.AnythingOfType("time.Time")).Return(&entity.Conversation{ID: 9, IsGroup: true}, nil).Once()
+	mockDB.On("GetParticipantsByConversationIDs", mock.Anything, []int{9}).Return(map[int][]entity.Participant{9: participants(1, 2, 3)}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, mock.Anything).Return(map[int]string{}, nil).Once()
+
+	conversation, err := service.CreateConversation(context.Background(), 1, []int{2, 3})
+
+	assert.NoError(t, err)
+	assert.True(t, conversation.IsGroup)
+	assert.Len(t, conversation.Participants, 3)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_SendMessage_DeliversToOtherParticipants(t *testing.T) {
+	mockDB := &mockStorage{}
+	mockMedia := &mockMediaService{}
+	hub := &mockHub{}
+	service := messages.NewMessageService(mockDB, mockMedia, hub)
+
+	tx := mocks.NewTestTx(t)
+	mockDB.On("GetConversationForUser", mock.Anything, 5, 1).Return(&entity.Conversation{ID: 5}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(false, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 3).Return(false, nil).Once()
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("CreateMessageTx", mock.Anything, tx, mock.Anything).Return(&entity.DirectMessage{
+		ID:             11,
+		ConversationID: 5,
+		Sender:         &entity.SmallUser{ID: 1},
+		Content:        "hi",
+	}, nil).Once()
+	mockDB.On("GetParticipantsByConversationIDs", mock.Anything, []int{5}).Return(map[int][]entity.Participant{5: participants(1, 2, 3)}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, mock.Anything).Return(map[int]string{}, nil).Once()
+
+	message, err := service.SendMessage(context.Background(), &entity.DirectMessage{
+		ConversationID: 5,
+		Sender:         &entity.SmallUser{ID: 1},
+		Content:        "  hi ",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user", message.Sender.Username)
+	if assert.Len(t, hub.frames, 2) {
+		assert.Equal(t, entity.FrameMessage, hub.frames[0].Type)
+		assert.Equal(t, 2, hub.frames[0].RecipientID)
+		assert.Equal(t, 3, hub.frames[1].RecipientID)
+		assert.Equal(t, 11, hub.frames[1].MessageID)
+	}
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_SendMessage_Blocked(t *testing.T) {
+	mockDB := &mockStorage{}
+	mockMedia := &mockMediaService{}
+	hub := &mockHub{}
+	service := messages.NewMessageService(mockDB, mockMedia, hub)
+
+	mockDB.On("GetConversationForUser", mock.Anything, 5, 1).Return(&entity.Conversation{ID: 5}, nil).Once()
+	mockDB.On("GetParticipantsByConversationIDs", mock.Anything, []int{5}).Return(map[int][]entity.Participant{5: participants(1, 2)}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, mock.Anything).Return(map[int]string{}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(true, nil).Once()
+
+	message, err := service.SendMessage(context.Background(), &entity.DirectMessage{
+		ConversationID: 5,
+		Sender:         &entity.SmallUser{ID: 1},
+		Content:        "hi",
+	})
+
+	assert.ErrorIs(t, err, errs.ErrBlocked)
+	assert.Nil(t, message)
+	assert.Empty(t, hub.frames)
+	mockDB.AssertNotCalled(t, "BeginTx", mock.Anything)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_SendMessage_Empty(t *testing.T) {
+	service := messages.NewMessageService(&mockStorage{}, &mockMediaService{}, &mockHub{})
+
+	_, err := service.SendMessage(context.Background(), &entity.DirectMessage{
+		ConversationID: 5,
+		Sender:         &entity.SmallUser{ID: 1},
+		Content:        "   ",
+	})
+
+	assert.ErrorIs(t, err, errs.ErrEmptyMessage)
+}
+
+func TestService_GetMessages_NotParticipant(t *testing.T) {
+	mockDB := &mockStorage{}
+	service := messages.NewMessageService(mockDB, &mockMediaService{}, &mockHub{})
+
+	mockDB.On("GetConversationForUser", mock.Anything, 5, 4).Return(nil, errs.ErrConversationNotFound).Once()
+
+	_, _, err := service.GetMessages(context.Background(), 4, 5, &entity.Page{Limit: 10})
+
+	assert.ErrorIs(t, err, errs.ErrConversationNotFound)
+	mockDB.AssertNotCalled(t, "GetMessages", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_MarkAsRead_SendsReceipt(t *testing.T) {
+	mockDB := &mockStorage{}
+	hub := &mockHub{}
+	service := messages.NewMessageService(mockDB, &mockMediaService{}, hub)
+
+	readAt := time.Now()
+	mockDB.On("GetConversationForUser", mock.Anything, 5, 2).Return(&entity.Conversation{ID: 5}, nil).Once()
+	mockDB.On("MarkConversationRead", mock.Anything, 5, 2, 0, mock.AnythingOfType("time.Time")).Return(&entity.ReadReceipt{
+		ConversationID: 5,
+		UserID:         2,
+		MessageID:      11,
+		ReadAt:         readAt,
+	}, nil).Once()
+	mockDB.On("GetParticipantsByConversationIDs", mock.Anything, []int{5}).Return(map[int][]entity.Participant{5: participants(1, 2)}, nil).Once()
+
+	receipt, err := service.MarkAsRead(context.Background(), 2, 5, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 11, receipt.MessageID)
+	if assert.Len(t, hub.frames, 1) {
+		assert.Equal(t, entity.FrameReadReceipt, hub.frames[0].Type)
+		assert.Equal(t, 1, hub.frames[0].RecipientID)
+		assert.Equal(t, 2, hub.frames[0].ReaderID)
+	}
+	mockDB.AssertExpectations(t)
+}
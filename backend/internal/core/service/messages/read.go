@@ -0,0 +1,38 @@
+package messages
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// MarkAsRead moves the read receipt of userID up to messageID, or to the latest
+// message when messageID is zero, and tells the other participants about it.
+func (s *service) MarkAsRead(ctx context.Context, userID, conversationID, messageID int) (*entity.ReadReceipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := s.db.GetConversationForUser(ctx, conversationID, userID); err != nil {
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	receipt, err := s.db.MarkConversationRead(ctx, conversationID, userID, messageID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark conversation as read: %w", err)
+	}
+
+	members, err := s.db.GetParticipantsByConversationIDs(ctx, []int{conversationID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	s.broadcast(members[conversationID], userID, entity.ChatFrame{
+		Type:           entity.FrameReadReceipt,
+		ConversationID: receipt.ConversationID,
+		MessageID:      receipt.MessageID,
+		ReaderID:       receipt.UserID,
+		Timestamp:      receipt.ReadAt,
+	})
+	return receipt, nil
+}
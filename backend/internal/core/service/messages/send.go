@@ -0,0 +1,120 @@
+package messages
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+// SendMessage stores a message from message.Sender and pushes it to the other participants.
+// Nothing is sent while the sender and another participant are on either side of a block.
+func (s *service) SendMessage(ctx context.Context, message *entity.DirectMessage) (*entity.DirectMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	message.Content = strings.TrimSpace(message.Content)
+	if message.Content == "" && message.File == nil {
+		return nil, errs.ErrEmptyMessage
+	}
+	if utf8.RuneCountInString(message.Content) > entity.MaxMessageLength {
+		return nil, errs.ErrInvalidInput
+	}
+
+	if _, err := s.db.GetConversationForUser(ctx, message.ConversationID, message.Sender.ID); err != nil {
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+	members, err := s.participants(ctx, []int{message.ConversationID})
+	if err != nil {
+		return nil, err
+	}
+	others := make([]int, 0, len(members[message.ConversationID]))
+	for _, member := range members[message.ConversationID] {
+		if member.User.ID != message.Sender.ID {
+			others = append(others, member.User.ID)
+		}
+	}
+	if err := s.checkNotBlocked(ctx, message.Sender.ID, others); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	message.CreatedAt = time.Now()
+	created, err := s.db.CreateMessageTx(ctx, tx, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	if message.File != nil {
+		created.MediaUrl, err = s.media.UploadAndAttachMessageMediaTx(ctx, created.ID, message.File.File, message.File.Header.Filename, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	sent := []entity.DirectMessage{*created}
+	fillSenders(sent, members[created.ConversationID])
+	created = &sent[0]
+
+	s.broadcast(members[created.ConversationID], created.Sender.ID, entity.ChatFrame{
+		Type:           entity.FrameMessage,
+		ConversationID: created.ConversationID,
+		MessageID:      created.ID,
+		SenderID:       created.Sender.ID,
+		SenderName:     created.Sender.Username,
+		Content:        created.Content,
+		MediaUrl:       created.MediaUrl,
+		Timestamp:      created.CreatedAt,
+	})
+	return created, nil
+}
+
+// GetMessages lists the history of a conversation newest first; only participants may read it.
+func (s *service) GetMessages(ctx context.Context, userID, conversationID int, page *entity.Page) ([]entity.DirectMessage, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := s.db.GetConversationForUser(ctx, conversationID, userID); err != nil {
+		return nil, nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	messages, next, err := s.db.GetMessages(ctx, conversationID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+	if len(messages) == 0 {
+		return messages, next, nil
+	}
+
+	ids := make([]int, 0, len(messages))
+	for i := range messages {
+		ids = append(ids, messages[i].ID)
+	}
+	mediaUrls, err := s.media.GetMediaUrlsByMessageIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get message media urls: %w", err)
+	}
+	members, err := s.participants(ctx, []int{conversationID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fillSenders(messages, members[conversationID])
+	for i := range messages {
+		messages[i].MediaUrl = mediaUrls[messages[i].ID]
+	}
+	return messages, next, nil
+}
@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// GetNotifications lists the notifications of userID newest first, with actor avatars.
+func (s *service) GetNotifications(ctx context.Context, userID int, page *entity.Page) ([]entity.Notification, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	notifications, next, err := s.db.GetNotificationsByRecipientID(ctx, userID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	if len(notifications) == 0 {
+		return notifications, next, nil
+	}
+
+	actorIDs := make([]int, 0, len(notifications))
+	seen := make(map[int]struct{}, len(notifications))
+	for i := range notifications {
+		if _, ok := seen[notifications[i].ActorID]; !ok {
+			seen[notifications[i].ActorID] = struct{}{}
+			actorIDs = append(actorIDs, notifications[i].ActorID)
+		}
+	}
+	avatarUrls, err := s.media.GetAvatarUrlsByUserIDs(ctx, actorIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user avatars: %w", err)
+	}
+	for i := range notifications {
+		notifications[i].ActorAvatar = avatarUrls[notifications[i].ActorID]
+	}
+	return notifications, next, nil
+}
+
+func (s *service) GetUnreadCount(ctx context.Context, userID int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	count, err := s.db.GetUnreadNotificationsCount(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unread notifications count: %w", err)
+	}
+	return count, nil
+}
+
+func (s *service) MarkAsRead(ctx context.Context, userID int, notificationID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.db.MarkNotificationAsRead(ctx, userID, notificationID); err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+func (s *service) MarkAllAsRead(ctx context.Context, userID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.db.MarkAllNotificationsAsRead(ctx, userID); err != nil {
+		return fmt.Errorf("failed to mark all notifications as read: %w", err)
+	}
+	return nil
+}
+
+func (s *service) DeleteNotification(ctx context.Context, userID int, notificationID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.db.DeleteNotification(ctx, userID, notificationID); err != nil {
+		return fmt.Errorf("failed to delete notification: %w", err)
+	}
+	return nil
+}
@@ -14,5 +14,20 @@ type (
 	db interface {
 		GetTweetById(ctx context.Context, tweetID int) (*entity.Tweet, error)
 		GetUserByID(ctx context.Context, userID int) (*entity.User, error)
+		IsBlockedBetween(ctx context.Context, userID, otherID int) (bool, error)
+		IsMuted(ctx context.Context, muterID, mutedID int) (bool, error)
+		IsFollowing(ctx context.Context, followerID, followingID int) (bool, error)
+
+		CreateNotification(ctx context.Context, notification *entity.Notification) error
+		NotificationExists(ctx context.Context, recipientID int, notificationType entity.NotificationType, tweetID int) (bool, error)
+		GetNotificationsByRecipientID(ctx context.Context, recipientID int, page *entity.Page) ([]entity.Notification, *entity.Cursor, error)
+		GetUnreadNotificationsCount(ctx context.Context, recipientID int) (int, error)
+		MarkNotificationAsRead(ctx context.Context, recipientID int, notificationID string) error
+		MarkAllNotificationsAsRead(ctx context.Context, recipientID int) error
+		DeleteNotification(ctx context.Context, recipientID int, notificationID string) error
+	}
+
+	mediaService interface {
+		GetAvatarUrlsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error)
 	}
 )
@@ -7,17 +7,20 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/sirupsen/logrus"
 )
 
 type service struct {
-	hub hubProvider
-	db  db
+	hub   hubProvider
+	db    db
+	media mediaService
 }
 
-func NewNotificationService(hub hubProvider, db db) *service {
+func NewNotificationService(hub hubProvider, db db, media mediaService) *service {
 	return &service{
-		hub: hub,
-		db:  db,
+		hub:   hub,
+		db:    db,
+		media: media,
 	}
 }
 
@@ -45,15 +48,13 @@ func (s *service) NotifyLike(ctx context.Context, actorID, tweetID int) error {
 		RecipientID: tweet.Author.ID,
 		ActorID:     actorID,
 		ActorName:   actor.Username,
-		ActorAvatar: actor.AvatarUrl,
 		TweetID:     &tweetID,
 		TweetText:   &tweetText,
 		Timestamp:   time.Now(),
 		Read:        false,
 	}
 
-	s.hub.SendNotification(notification)
-	return nil
+	return s.deliver(ctx, notification)
 }
 
 func (s *service) NotifyRetweet(ctx context.Context, actorID, tweetID int) error {
@@ -81,15 +82,13 @@ func (s *service) NotifyRetweet(ctx context.Context, actorID, tweetID int) error
 		RecipientID: tweet.Author.ID,
 		ActorID:     actorID,
 		ActorName:   actor.Username,
-		ActorAvatar: actor.AvatarUrl,
 		TweetID:     &tweetID,
 		TweetText:   &tweetText,
 		Timestamp:   time.Now(),
 		Read:        false,
 	}
 
-	s.hub.SendNotification(notification)
-	return nil
+	return s.deliver(ctx, notification)
 }
 
 func (s *service) NotifyReply(ctx context.Context, actorID, tweetID int) error {
@@ -117,37 +116,203 @@ func (s *service) NotifyReply(ctx context.Context, actorID, tweetID int) error {
 		RecipientID: tweet.Author.ID,
 		ActorID:     actorID,
 		ActorName:   actor.Username,
-		ActorAvatar: actor.AvatarUrl,
 		TweetID:     &tweetID,
 		TweetText:   &tweetText,
 		Timestamp:   time.Now(),
 		Read:        false,
 	}
 
-	s.hub.SendNotification(notification)
-	return nil
+	return s.deliver(ctx, notification)
+}
+
+func (s *service) NotifyQuote(ctx context.Context, actorID, tweetID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tweet, err := s.db.GetTweetById(ctx, tweetID)
+	if err != nil {
+		return fmt.Errorf("failed to get tweet by id: %w", err)
+	}
+
+	if tweet.Author.ID == actorID {
+		return nil
+	}
+
+	actor, err := s.db.GetUserByID(ctx, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	tweetText := tweet.Content
+	notification := &entity.Notification{
+		ID:          uuid.New().String(),
+		Type:        entity.NotificationQuote,
+		RecipientID: tweet.Author.ID,
+		ActorID:     actorID,
+		ActorName:   actor.Username,
+		TweetID:     &tweetID,
+		TweetText:   &tweetText,
+		Timestamp:   time.Now(),
+		Read:        false,
+	}
+
+	return s.deliver(ctx, notification)
+}
+
+// NotifyMention tells mentionedID about a tweet that mentions them. A user is notified
+// once per tweet, so editing a tweet only reaches the users it newly mentions. Tweets of
+// a private account only notify its followers.
+func (s *service) NotifyMention(ctx context.Context, actorID, tweetID, mentionedID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if mentionedID == actorID {
+		return nil
+	}
+
+	exists, err := s.db.NotificationExists(ctx, mentionedID, entity.NotificationMention, tweetID)
+	if err != nil {
+		return fmt.Errorf("failed to check mention notification: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	tweet, err := s.db.GetTweetById(ctx, tweetID)
+	if err != nil {
+		return fmt.Errorf("failed to get tweet by id: %w", err)
+	}
+
+	actor, err := s.db.GetUserByID(ctx, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
+	if actor.IsPrivate {
+		following, err := s.db.IsFollowing(ctx, mentionedID, actorID)
+		if err != nil {
+			return fmt.Errorf("failed to check follow: %w", err)
+		}
+		if !following {
+			return nil
+		}
+	}
+
+	tweetText := tweet.Content
+	notification := &entity.Notification{
+		ID:          uuid.New().String(),
+		Type:        entity.NotificationMention,
+		RecipientID: mentionedID,
+		ActorID:     actorID,
+		ActorName:   actor.Username,
+		TweetID:     &tweetID,
+		TweetText:   &tweetText,
+		Timestamp:   time.Now(),
+		Read:        false,
+	}
+
+	return s.deliver(ctx, notification)
 }
 
 func (s *service) NotifyFollow(ctx context.Context, followerID, followingID int) error {
+	return s.notifyUser(ctx, entity.NotificationFollow, followerID, followingID)
+}
+
+// NotifyFollowRequest tells the owner of a private account that followerID asks to follow them.
+func (s *service) NotifyFollowRequest(ctx context.Context, followerID, followingID int) error {
+	return s.notifyUser(ctx, entity.NotificationFollowRequest, followerID, followingID)
+}
+
+// NotifyFollowAccept tells followerID that followingID approved their follow request.
+func (s *service) NotifyFollowAccept(ctx context.Context, followingID, followerID int) error {
+	return s.notifyUser(ctx, entity.NotificationFollowAccept, followingID, followerID)
+}
+
+// NotifyReportResolved tells the reporter how the moderator who claimed the report resolved it.
+// Moderation outcomes bypass blocks and mutes so the reporter always learns the result.
+func (s *service) NotifyReportResolved(ctx context.Context, report *entity.Report) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	follower, err := s.db.GetUserByID(ctx, followerID)
+	actor, err := s.db.GetUserByID(ctx, report.ClaimedBy)
 	if err != nil {
 		return fmt.Errorf("failed to get user by id: %w", err)
 	}
 
+	reportID := report.ID
+	resolution := string(report.Resolution)
 	notification := &entity.Notification{
 		ID:          uuid.New().String(),
-		Type:        entity.NotificationFollow,
-		RecipientID: followingID,
-		ActorID:     followerID,
-		ActorName:   follower.Username,
-		ActorAvatar: follower.AvatarUrl,
+		Type:        entity.NotificationReportResolved,
+		RecipientID: report.ReporterID,
+		ActorID:     actor.ID,
+		ActorName:   actor.Username,
+		ReportID:    &reportID,
+		Resolution:  &resolution,
 		Timestamp:   time.Now(),
 		Read:        false,
 	}
 
-	s.hub.SendNotification(notification)
+	if err := s.db.CreateNotification(ctx, notification); err != nil {
+		return fmt.Errorf("failed to save notification: %w", err)
+	}
+	s.push(ctx, notification)
+	return nil
+}
+
+// notifyUser delivers a notification that is about actorID rather than a tweet.
+func (s *service) notifyUser(ctx context.Context, notificationType entity.NotificationType, actorID, recipientID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	actor, err := s.db.GetUserByID(ctx, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	notification := &entity.Notification{
+		ID:          uuid.New().String(),
+		Type:        notificationType,
+		RecipientID: recipientID,
+		ActorID:     actorID,
+		ActorName:   actor.Username,
+		Timestamp:   time.Now(),
+		Read:        false,
+	}
+
+	return s.deliver(ctx, notification)
+}
+
+// deliver drops notifications between blocked users and from actors the recipient muted.
+func (s *service) deliver(ctx context.Context, notification *entity.Notification) error {
+	blocked, err := s.db.IsBlockedBetween(ctx, notification.RecipientID, notification.ActorID)
+	if err != nil {
+		return fmt.Errorf("failed to check block: %w", err)
+	}
+	if blocked {
+		return nil
+	}
+	muted, err := s.db.IsMuted(ctx, notification.RecipientID, notification.ActorID)
+	if err != nil {
+		return fmt.Errorf("failed to check mute: %w", err)
+	}
+	if muted {
+		return nil
+	}
+
+	if err := s.db.CreateNotification(ctx, notification); err != nil {
+		return fmt.Errorf("failed to save notification: %w", err)
+	}
+	s.push(ctx, notification)
 	return nil
 }
+
+// push sends a saved notification to the recipient's open connections. Avatar urls expire,
+// so the actor avatar is looked up here and when notifications are listed, never stored.
+func (s *service) push(ctx context.Context, notification *entity.Notification) {
+	avatarUrls, err := s.media.GetAvatarUrlsByUserIDs(ctx, []int{notification.ActorID})
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", notification.ActorID).Warn("failed to get actor avatar")
+	}
+	notification.ActorAvatar = avatarUrls[notification.ActorID]
+	s.hub.SendNotification(notification)
+}
@@ -0,0 +1,301 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/core/service/notification"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockStorage struct {
+	mock.Mock
+}
+
+func (m *mockStorage) GetTweetById(ctx context.Context, tweetID int) (*entity.Tweet, error) {
+	args := m.Called(ctx, tweetID)
+	tweet, _ := args.Get(0).(*entity.Tweet)
+	return tweet, args.Error(1)
+}
+
+func (m *mockStorage) GetUserByID(ctx context.Context, userID int) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+	user, _ := args.Get(0).(*entity.User)
+	return user, args.Error(1)
+}
+
+func (m *mockStorage) IsBlockedBetween(ctx context.Context, userID, otherID int) (bool, error) {
+	args := m.Called(ctx, userID, otherID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockStorage) IsMuted(ctx context.Context, muterID, mutedID int) (bool, error) {
+	args := m.Called(ctx, muterID, mutedID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockStorage) IsFollowing(ctx context.Context, followerID, followingID int) (bool, error) {
+	args := m.Called(ctx, followerID, followingID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockStorage) CreateNotification(ctx context.Context, n *entity.Notification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+func (m *mockStorage) NotificationExists(ctx context.Context, recipientID int, notificationType entity.NotificationType, tweetID int) (bool, error) {
+	args := m.Called(ctx, recipientID, notificationType, tweetID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockStorage) GetNotificationsByRecipientID(ctx context.Context, recipientID int, page *entity.Page) ([]entity.Notification, *entity.Cursor, error) {
+	args := m.Called(ctx, recipientID, page)
+	list, _ := args.Get(0).([]entity.Notification)
+	cursor, _ := args.Get(1).(*entity.Cursor)
+	return list, cursor, args.Error(2)
+}
+
+func (m *mockStorage) GetUnreadNotificationsCount(ctx context.Context, recipientID int) (int, error) {
+	args := m.Called(ctx, recipientID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockStorage) MarkNotificationAsRead(ctx context.Context, recipientID int, notificationID string) error {
+	args := m.Called(ctx, recipientID, notificationID)
+	return args.Error(0)
+}
+
+func (m *mockStorage) MarkAllNotificationsAsRead(ctx context.Context, recipientID int) error {
+	args := m.Called(ctx, recipientID)
+	return args.Error(0)
+}
+
+func (m *mockStorage) DeleteNotification(ctx context.Context, recipientID int, notificationID string) error {
+	args := m.Called(ctx, recipientID, notificationID)
+	return args.Error(0)
+}
+
+type mockMediaService struct {
+	mock.Mock
+}
+
+func (m *mockMediaService) GetAvatarUrlsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error) {
+	args := m.Called(ctx, userIDs)
+	urls, _ := args.Get(0).(map[int]string)
+	return urls, args.Error(1)
+}
+
+type mockHub struct {
+	sent []entity.Notification
+}
+
+func (m *mockHub) SendNotification(n *entity.Notification) {
+	m.sent = append(m.sent, *n)
+}
+
+func TestService_NotifyLike_SavesAndPushes(t *testing.T) {
+	mockDB := &mockStorage{}
+	mockMedia := &mockMediaService{}
+	hub := &mockHub{}
+	service := notification.NewNotificationService(hub, mockDB, mockMedia)
+
+	mockDB.On("GetTweetById", mock.Anything, 10).Return(&entity.Tweet{ID: 10, Content: "hello", Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, Username: "actor"}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 2, 1).Return(false, nil).Once()
+	mockDB.On("IsMuted", mock.Anything, 2, 1).Return(false, nil).Once()
+	mockDB.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n *entity.Notification) bool {
+		return n.Type == entity.NotificationLike && n.RecipientID == 2 && n.ActorID == 1 && *n.TweetID == 10
+	})).Return(nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1}).Return(map[int]string{1: "/avatars/1.jpg"}, nil).Once()
+
+	err := service.NotifyLike(context.Background(), 1, 10)
+
+	assert.NoError(t, err)
+	if assert.Len(t, hub.sent, 1) {
+		assert.Equal(t, "actor", hub.sent[0].ActorName)
+		assert.Equal(t, "/avatars/1.jpg", hub.sent[0].ActorAvatar)
+		assert.Equal(t, "hello", *hub.sent[0].TweetText)
+	}
+	mockDB.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+}
+
+func TestService_NotifyLike_OwnTweet(t *testing.T) {
+	mockDB := &mockStorage{}
+	hub := &mockHub{}
+	service := notification.NewNotificationService(hub, mockDB, &mockMediaService{})
+
+	mockDB.On("GetTweetById", mock.Anything, 10).Return(&entity.Tweet{ID: 10, Author: &entity.SmallUser{ID: 1}}, nil).Once()
+
+	err := service.NotifyLike(context.Background(), 1, 10)
+
+	assert.NoError(t, err)
+	assert.Empty(t, hub.sent)
+	mockDB.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_NotifyRetweet_Blocked(t *testing.T) {
+	mockDB := &mockStorage{}
+	hub := &mockHub{}
+	service := notification.NewNotificationService(hub, mockDB, &mockMediaService{})
+
+	mockDB.On("GetTweetById", mock.Anything, 10).Return(&entity.Tweet{ID: 10, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, Username: "actor"}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 2, 1).Return(true, nil).Once()
+
+	err := service.NotifyRetweet(context.Background(), 1, 10)
+
+	assert.NoError(t, err)
+	assert.Empty(t, hub.sent)
+	mockDB.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_NotifyReply_Muted(t *testing.T) {
+	mockDB := &mockStorage{}
+	hub := &mockHub{}
+	service := notification.NewNotificationService(hub, mockDB, &mockMediaService{})
+
+	mockDB.On("GetTweetById", mock.Anything, 10).Return(&entity.Tweet{ID: 10, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, Username: "actor"}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 2, 1).Return(false, nil).Once()
+	mockDB.On("IsMuted", mock.Anything, 2, 1).Return(true, nil).Once()
+
+	err := service.NotifyReply(context.Background(), 1, 10)
+
+	assert.NoError(t, err)
+	assert.Empty(t, hub.sent)
+	mockDB.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_NotifyMention_OncePerTweet(t *testing.T) {
+	mockDB := &mockStorage{}
+	hub := &mockHub{}
+	service := notification.NewNotificationService(hub, mockDB, &mockMediaService{})
+
+	mockDB.On("NotificationExists", mock.Anything, 3, entity.NotificationMention, 10).Return(true, nil).Once()
+
+	err := service.NotifyMention(context.Background(), 1, 10, 3)
+
+	assert.NoError(t, err)
+	assert.Empty(t, hub.sent)
+	mockDB.AssertNotCalled(t, "GetTweetById", mock.Anything, mock.Anything)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_NotifyMention_PrivateActorSkipsNonFollower(t *testing.T) {
+	mockDB := &mockStorage{}
+	hub := &mockHub{}
+	service := notification.NewNotificationService(hub, mockDB, &mockMediaService{})
+
+	mockDB.On("NotificationExists", mock.Anything, 3, entity.NotificationMention, 10).Return(false, nil).Once()
+	mockDB.On("GetTweetById", mock.Anything, 10).Return(&entity.Tweet{ID: 10, Author: &entity.SmallUser{ID: 1}}, nil).Once()
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, Username: "actor", IsPrivate: true}, nil).Once()
+	mockDB.On("IsFollowing", mock.Anything, 3, 1).Return(false, nil).Once()
+
+	err := service.NotifyMention(context.Background(), 1, 10, 3)
+
+	assert.NoError(t, err)
+	assert.Empty(t, hub.sent)
+	mockDB.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_NotifyFollow_SavesAndPushes(t *testing.T) {
+	mockDB := &mockStorage{}
+	mockMedia := &mockMediaService{}
+	hub := &mockHub{}
+	service := notification.NewNotificationService(hub, mockDB, mockMedia)
+
+	mockDB.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, Username: "follower"}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 2, 1).Return(false, nil).Once()
+	mockDB.On("IsMuted", mock.Anything, 2, 1).Return(false, nil).Once()
+	mockDB.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n *entity.Notification) bool {
+		return n.Type == entity.NotificationFollow && n.RecipientID == 2 && n.TweetID == nil
+	})).Return(nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1}).Return(nil, errors.New("storage down")).Once()
+
+	err := service.NotifyFollow(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	if assert.Len(t, hub.sent, 1) {
+		assert.Equal(t, "follower", hub.sent[0].ActorName)
+		assert.Empty(t, hub.sent[0].ActorAvatar)
+	}
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_GetNotifications_FillsAvatarsInOneBatch(t *testing.T) {
+	mockDB := &mockStorage{}
+	mockMedia := &mockMediaService{}
+	service := notification.NewNotificationService(&mockHub{}, mockDB, mockMedia)
+
+	page := &entity.Page{Limit: 3}
+	next := &entity.Cursor{CreatedAt: time.Now(), ID: 42}
+	mockDB.On("GetNotificationsByRecipientID", mock.Anything, 1, page).Return([]entity.Notification{
+		{ID: "a", ActorID: 2},
+		{ID: "b", ActorID: 3},
+		{ID: "c", ActorID: 2},
+	}, next, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{2, 3}).Return(map[int]string{2: "/avatars/2.jpg"}, nil).Once()
+
+	notifications, cursor, err := service.GetNotifications(context.Background(), 1, page)
+
+	assert.NoError(t, err)
+	assert.Equal(t, next, cursor)
+	if assert.Len(t, notifications, 3) {
+		assert.Equal(t, "/avatars/2.jpg", notifications[0].ActorAvatar)
+		assert.Empty(t, notifications[1].ActorAvatar)
+		assert.Equal(t, "/avatars/2.jpg", notifications[2].ActorAvatar)
+	}
+	mockDB.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+}
+
+func TestService_GetNotifications_Empty(t *testing.T) {
+	mockDB := &mockStorage{}
+	mockMedia := &mockMediaService{}
+	service := notification.NewNotificationService(&mockHub{}, mockDB, mockMedia)
+
+	page := &entity.Page{Limit: 20}
+	mockDB.On("GetNotificationsByRecipientID", mock.Anything, 1, page).Return([]entity.Notification{}, nil, nil).Once()
+
+	notifications, cursor, err := service.GetNotifications(context.Background(), 1, page)
+
+	assert.NoError(t, err)
+	assert.Empty(t, notifications)
+	assert.Nil(t, cursor)
+	mockMedia.AssertNotCalled(t, "GetAvatarUrlsByUserIDs", mock.Anything, mock.Anything)
+}
+
+func TestService_MarkAsRead_NotFound(t *testing.T) {
+	mockDB := &mockStorage{}
+	service := notification.NewNotificationService(&mockHub{}, mockDB, &mockMediaService{})
+
+	mockDB.On("MarkNotificationAsRead", mock.Anything, 1, "a").Return(errs.ErrNotificationNotFound).Once()
+
+	err := service.MarkAsRead(context.Background(), 1, "a")
+
+	assert.ErrorIs(t, err, errs.ErrNotificationNotFound)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_MarkAllAsRead(t *testing.T) {
+	mockDB := &mockStorage{}
+	service := notification.NewNotificationService(&mockHub{}, mockDB, &mockMediaService{})
+
+	mockDB.On("MarkAllNotificationsAsRead", mock.Anything, 1).Return(nil).Once()
+
+	err := service.MarkAllAsRead(context.Background(), 1)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
@@ -0,0 +1,24 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type (
+	db interface {
+		BeginTx(ctx context.Context) (*sql.Tx, error)
+		TryLockOutboxRelayTx(ctx context.Context, tx *sql.Tx) (bool, error)
+		GetPendingOutboxEventsTx(ctx context.Context, tx *sql.Tx, limit int) ([]entity.OutboxEvent, error)
+		MarkOutboxEventSentTx(ctx context.Context, tx *sql.Tx, eventID int64) error
+		MarkOutboxEventFailedTx(ctx context.Context, tx *sql.Tx, eventID int64, reason string) error
+		DeleteSentOutboxEvents(ctx context.Context, sentBefore time.Time) (int64, error)
+	}
+
+	eventProducer interface {
+		Publish(ctx context.Context, topic string, event any) error
+	}
+)
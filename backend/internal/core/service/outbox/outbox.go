@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+const cleanupInterval = time.Hour
+
+type service struct {
+	db        db
+	producer  eventProducer
+	interval  time.Duration
+	batchSize int
+	retention time.Duration
+}
+
+func NewOutboxService(cfg *config.OutboxConfig, db db, producer eventProducer) *service {
+	return &service{
+		db:        db,
+		producer:  producer,
+		interval:  cfg.PollInterval,
+		batchSize: cfg.BatchSize,
+		retention: cfg.Retention,
+	}
+}
+
+// Run relays pending outbox events to Kafka until ctx is cancelled.
+// Events that fail to publish stay pending and are retried on the next tick,
+// so every event is delivered at least once. Only one replica relays at a time,
+// so events reach Kafka in the order they were written.
+func (s *service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cleanup.C:
+			s.cleanup(ctx)
+		case <-ticker.C:
+			for {
+				sent, err := s.relay(ctx)
+				if err != nil {
+					logrus.WithError(err).Warn("outbox relay failed")
+					break
+				}
+				if sent < s.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (s *service) relay(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	locked, err := s.db.TryLockOutboxRelayTx(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock outbox relay: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	pending, err := s.db.GetPendingOutboxEventsTx(ctx, tx, s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending outbox events: %w", err)
+	}
+
+	sent := 0
+	var publishErr error
+	for _, event := range pending {
+		if publishErr = s.producer.Publish(ctx, event.Topic, json.RawMessage(event.Payload)); publishErr != nil {
+			if err := s.db.MarkOutboxEventFailedTx(ctx, tx, event.ID, publishErr.Error()); err != nil {
+				return 0, fmt.Errorf("failed to mark outbox event as failed: %w", err)
+			}
+			// Stop on the first failure; the relay lock makes this keep events in order.
+			break
+		}
+		if err := s.db.MarkOutboxEventSentTx(ctx, tx, event.ID); err != nil {
+			return 0, fmt.Errorf("failed to mark outbox event as sent: %w", err)
+		}
+		sent++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	if publishErr != nil {
+		return sent, fmt.Errorf("failed to publish outbox event: %w", publishErr)
+	}
+	return sent, nil
+}
+
+func (s *service) cleanup(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	deleted, err := s.db.DeleteSentOutboxEvents(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		logrus.WithError(err).Warn("failed to delete sent outbox events")
+		return
+	}
+	if deleted > 0 {
+		logrus.WithField("deleted", deleted).Info("sent outbox events cleaned up")
+	}
+}
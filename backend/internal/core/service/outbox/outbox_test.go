@@ -0,0 +1,142 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/core/service/outbox"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/domain/events"
+	"github.com/kust1q/Zapp/backend/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockOutboxStorage struct {
+	mock.Mock
+}
+
+func (m *mockOutboxStorage) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	args := m.Called(ctx)
+	tx, _ := args.Get(0).(*sql.Tx)
+	return tx, args.Error(1)
+}
+
+func (m *mockOutboxStorage) TryLockOutboxRelayTx(ctx context.Context, tx *sql.Tx) (bool, error) {
+	args := m.Called(ctx, tx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockOutboxStorage) GetPendingOutboxEventsTx(ctx context.Context, tx *sql.Tx, limit int) ([]entity.OutboxEvent, error) {
+	args := m.Called(ctx, tx, limit)
+	return args.Get(0).([]entity.OutboxEvent), args.Error(1)
+}
+
+func (m *mockOutboxStorage) MarkOutboxEventSentTx(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	args := m.Called(ctx, tx, eventID)
+	return args.Error(0)
+}
+
+func (m *mockOutboxStorage) MarkOutboxEventFailedTx(ctx context.Context, tx *sql.Tx, eventID int64, reason string) error {
+	args := m.Called(ctx, tx, eventID, reason)
+	return args.Error(0)
+}
+
+func (m *mockOutboxStorage) DeleteSentOutboxEvents(ctx context.Context, sentBefore time.Time) (int64, error) {
+	args := m.Called(ctx, sentBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type mockEventProducer struct {
+	mock.Mock
+}
+
+func (m *mockEventProducer) Publish(ctx context.Context, topic string, event any) error {
+	args := m.Called(ctx, topic, event)
+	return args.Error(0)
+}
+
+func TestService_Run_PublishesPendingEvents(t *testing.T) {
+	mockDB := &mockOutboxStorage{}
+	mockProducer := &mockEventProducer{}
+
+	service := outbox.NewOutboxService(&config.OutboxConfig{PollInterval: 10 * time.Millisecond, BatchSize: 10, Retention: time.Hour}, mockDB, mockProducer)
+
+	tx := mocks.NewTestTx(t)
+	pending := []entity.OutboxEvent{
+		{ID: 1, Topic: events.TopicTweet, Payload: []byte(`{"event_type":"tweet.created","id":1}`)},
+		{ID: 2, Topic: events.TopicUser, Payload: []byte(`{"event_type":"user.created","id":2}`)},
+	}
+
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("TryLockOutboxRelayTx", mock.Anything, tx).Return(true, nil).Once()
+	mockDB.On("GetPendingOutboxEventsTx", mock.Anything, tx, 10).Return(pending, nil).Once()
+	mockProducer.On("Publish", mock.Anything, events.TopicTweet, json.RawMessage(pending[0].Payload)).Return(nil).Once()
+	mockProducer.On("Publish", mock.Anything, events.TopicUser, json.RawMessage(pending[1].Payload)).Return(nil).Once()
+	mockDB.On("MarkOutboxEventSentTx", mock.Anything, tx, int64(1)).Return(nil).Once()
+	mockDB.On("MarkOutboxEventSentTx", mock.Anything, tx, int64(2)).Return(nil).Once()
+
+	// Later ticks find nothing to relay.
+	mockDB.On("BeginTx", mock.Anything).Return(mocks.NewTestTx(t), nil)
+	mockDB.On("TryLockOutboxRelayTx", mock.Anything, mock.Anything).Return(true, nil)
+	mockDB.On("GetPendingOutboxEventsTx", mock.Anything, mock.Anything, 10).Return([]entity.OutboxEvent{}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	service.Run(ctx)
+
+	mockDB.AssertExpectations(t)
+	mockProducer.AssertExpectations(t)
+}
+
+func TestService_Run_StopsOnPublishError(t *testing.T) {
+	mockDB := &mockOutboxStorage{}
+	mockProducer := &mockEventProducer{}
+
+	service := outbox.NewOutboxService(&config.OutboxConfig{PollInterval: 10 * time.Millisecond, BatchSize: 10, Retention: time.Hour}, mockDB, mockProducer)
+
+	tx := mocks.NewTestTx(t)
+	pending := []entity.OutboxEvent{
+		{ID: 1, Topic: events.TopicTweet, Payload: []byte(`{"id":1}`)},
+		{ID: 2, Topic: events.TopicTweet, Payload: []byte(`{"id":2}`)},
+	}
+
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("TryLockOutboxRelayTx", mock.Anything, tx).Return(true, nil).Once()
+	mockDB.On("GetPendingOutboxEventsTx", mock.Anything, tx, 10).Return(pending, nil).Once()
+	mockProducer.On("Publish", mock.Anything, events.TopicTweet, json.RawMessage(pending[0].Payload)).Return(errors.New("kafka down")).Once()
+	mockDB.On("MarkOutboxEventFailedTx", mock.Anything, tx, int64(1), "kafka down").Return(nil).Once()
+
+	mockDB.On("BeginTx", mock.Anything).Return(nil, errors.New("stop")).Maybe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	service.Run(ctx)
+
+	mockDB.AssertExpectations(t)
+	mockProducer.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "MarkOutboxEventSentTx", mock.Anything, mock.Anything, mock.Anything)
+	assert.Len(t, mockProducer.Calls, 1)
+}
+
+func TestService_Run_SkipsWhenAnotherRelayHoldsLock(t *testing.T) {
+	mockDB := &mockOutboxStorage{}
+	mockProducer := &mockEventProducer{}
+
+	service := outbox.NewOutboxService(&config.OutboxConfig{PollInterval: 10 * time.Millisecond, BatchSize: 10, Retention: time.Hour}, mockDB, mockProducer)
+
+	mockDB.On("BeginTx", mock.Anything).Return(mocks.NewTestTx(t), nil)
+	mockDB.On("TryLockOutboxRelayTx", mock.Anything, mock.Anything).Return(false, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	service.Run(ctx)
+
+	mockDB.AssertNotCalled(t, "GetPendingOutboxEventsTx", mock.Anything, mock.Anything, mock.Anything)
+	mockProducer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,18 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type (
+	rateLimitStorage interface {
+		Hit(ctx context.Context, key string, limit int, window time.Duration) (*entity.RateLimit, error)
+		RegisterSignInFailure(ctx context.Context, email string, window time.Duration) (int, error)
+		LockSignIn(ctx context.Context, email string, lockout, window time.Duration) error
+		GetSignInLockout(ctx context.Context, email string) (time.Duration, error)
+		ResetSignInFailures(ctx context.Context, email string) error
+	}
+)
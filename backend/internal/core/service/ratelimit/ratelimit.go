@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type service struct {
+	store    rateLimitStorage
+	policies map[string]config.RateLimitPolicy
+	lockout  config.SignInLockoutConfig
+}
+
+func NewRateLimitService(cfg *config.RateLimitConfig, store rateLimitStorage) *service {
+	return &service{
+		store:    store,
+		policies: cfg.Policies,
+		lockout:  cfg.SignInLockout,
+	}
+}
+
+// Allow counts a request of subject, an IP or a user, against the named policy.
+// It returns nil when the policy is not configured.
+func (s *service) Allow(ctx context.Context, policy, subject string) (*entity.RateLimit, error) {
+	p, ok := s.policies[policy]
+	if !ok {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	limit, err := s.store.Hit(ctx, policy+":"+subject, p.Limit, p.Window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count request: %w", err)
+	}
+	return limit, nil
+}
+
+// SignInLockout returns how long sign-ins of email stay locked, or zero.
+func (s *service) SignInLockout(ctx context.Context, email string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	lockout, err := s.store.GetSignInLockout(ctx, normalizeEmail(email))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sign in lockout: %w", err)
+	}
+	return lockout, nil
+}
+
+// RegisterSignInFailure counts a failed sign-in of email and locks the email out once
+// too many failures piled up. It returns the lockout applied, or zero.
+func (s *service) RegisterSignInFailure(ctx context.Context, email string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	email = normalizeEmail(email)
+	failures, err := s.store.RegisterSignInFailure(ctx, email, s.lockout.Window)
+	if err != nil {
+		return 0, fmt.Errorf("failed to register sign in failure: %w", err)
+	}
+	if failures < s.lockout.MaxFailures {
+		return 0, nil
+	}
+
+	lockout := s.lockoutDuration(failures)
+	if err := s.store.LockSignIn(ctx, email, lockout, s.lockout.Window); err != nil {
+		return 0, fmt.Errorf("failed to lock sign in: %w", err)
+	}
+	return lockout, nil
+}
+
+// ResetSignInFailures forgets the failed sign-ins of email after a successful one.
+func (s *service) ResetSignInFailures(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	if err := s.store.ResetSignInFailures(ctx, normalizeEmail(email)); err != nil {
+		return fmt.Errorf("failed to reset sign in failures: %w", err)
+	}
+	return nil
+}
+
+// lockoutDuration doubles the base delay for every failure past the threshold.
+func (s *service) lockoutDuration(failures int) time.Duration {
+	lockout := s.lockout.BaseDelay
+	for i := s.lockout.MaxFailures; i < failures && lockout < s.lockout.MaxDelay; i++ {
+		lockout *= 2
+	}
+	return min(lockout, s.lockout.MaxDelay)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
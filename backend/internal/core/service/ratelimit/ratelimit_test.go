@@ -0,0 +1,123 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/core/service/ratelimit"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStorage struct {
+	mock.Mock
+}
+
+func (m *mockStorage) Hit(ctx context.Context, key string, limit int, window time.Duration) (*entity.RateLimit, error) {
+	args := m.Called(ctx, key, limit, window)
+	result, _ := args.Get(0).(*entity.RateLimit)
+	return result, args.Error(1)
+}
+
+func (m *mockStorage) RegisterSignInFailure(ctx context.Context, email string, window time.Duration) (int, error) {
+	args := m.Called(ctx, email, window)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockStorage) LockSignIn(ctx context.Context, email string, lockout, window time.Duration) error {
+	args := m.Called(ctx, email, lockout, window)
+	return args.Error(0)
+}
+
+func (m *mockStorage) GetSignInLockout(ctx context.Context, email string) (time.Duration, error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *mockStorage) ResetSignInFailures(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func testConfig() *config.RateLimitConfig {
+	return &config.RateLimitConfig{
+		Policies: map[string]config.RateLimitPolicy{
+			"sign_in": {Limit: 10, Window: time.Minute},
+		},
+		SignInLockout: config.SignInLockoutConfig{
+			MaxFailures: 3,
+			Window:      15 * time.Minute,
+			BaseDelay:   time.Minute,
+			MaxDelay:    5 * time.Minute,
+		},
+	}
+}
+
+func TestService_Allow_CountsPerPolicyAndSubject(t *testing.T) {
+	store := &mockStorage{}
+	service := ratelimit.NewRateLimitService(testConfig(), store)
+
+	expected := &entity.RateLimit{Allowed: true, Limit: 10, Remaining: 9}
+	store.On("Hit", mock.Anything, "sign_in:ip:10.0.0.1", 10, time.Minute).Return(expected, nil).Once()
+
+	limit, err := service.Allow(context.Background(), "sign_in", "ip:10.0.0.1")
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, limit)
+	store.AssertExpectations(t)
+}
+
+func TestService_Allow_UnknownPolicy(t *testing.T) {
+	store := &mockStorage{}
+	service := ratelimit.NewRateLimitService(testConfig(), store)
+
+	limit, err := service.Allow(context.Background(), "tweet_write", "user:1")
+
+	assert.NoError(t, err)
+	assert.Nil(t, limit)
+	store.AssertNotCalled(t, "Hit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_RegisterSignInFailure_BelowThreshold(t *testing.T) {
+	store := &mockStorage{}
+	service := ratelimit.NewRateLimitService(testConfig(), store)
+
+	store.On("RegisterSignInFailure", mock.Anything, "user@example.com", 15*time.Minute).Return(2, nil).Once()
+
+	lockout, err := service.RegisterSignInFailure(context.Background(), " User@Example.com ")
+
+	assert.NoError(t, err)
+	assert.Zero(t, lockout)
+	store.AssertNotCalled(t, "LockSignIn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_RegisterSignInFailure_ProgressiveLockout(t *testing.T) {
+	tests := []struct {
+		failures int
+		lockout  time.Duration
+	}{
+		{failures: 3, lockout: time.Minute},
+		{failures: 4, lockout: 2 * time.Minute},
+		{failures: 5, lockout: 4 * time.Minute},
+		{failures: 6, lockout: 5 * time.Minute},
+		{failures: 60, lockout: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		store := &mockStorage{}
+		service := ratelimit.NewRateLimitService(testConfig(), store)
+
+		store.On("RegisterSignInFailure", mock.Anything, "user@example.com", 15*time.Minute).Return(tt.failures, nil).Once()
+		store.On("LockSignIn", mock.Anything, "user@example.com", tt.lockout, 15*time.Minute).Return(nil).Once()
+
+		lockout, err := service.RegisterSignInFailure(context.Background(), "user@example.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.lockout, lockout, "failures: %d", tt.failures)
+		store.AssertExpectations(t)
+	}
+}
@@ -0,0 +1,28 @@
+package reports
+
+import (
+	"context"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type (
+	db interface {
+		GetTweetById(ctx context.Context, tweetID int) (*entity.Tweet, error)
+		GetUserByID(ctx context.Context, userID int) (*entity.User, error)
+
+		CreateReport(ctx context.Context, report *entity.Report) (*entity.Report, error)
+		GetReportByID(ctx context.Context, reportID int) (*entity.Report, error)
+		GetReportsByStatus(ctx context.Context, status entity.ReportStatus, page *entity.Page) ([]entity.Report, *entity.Cursor, error)
+		ClaimReport(ctx context.Context, reportID, adminID int) (*entity.Report, error)
+		ResolveReport(ctx context.Context, reportID, adminID int, resolution entity.ReportResolution, resolvedAt time.Time) (*entity.Report, error)
+
+		CreateAuditEntry(ctx context.Context, entry *entity.AuditEntry) error
+	}
+
+	adminService interface {
+		DeactivateUser(ctx context.Context, adminID, userID int) error
+		DeleteTweet(ctx context.Context, adminID, tweetID int) error
+	}
+)
@@ -0,0 +1,149 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+type service struct {
+	db           db
+	adminService adminService
+}
+
+func NewReportService(db db, adminService adminService) *service {
+	return &service{
+		db:           db,
+		adminService: adminService,
+	}
+}
+
+// CreateReport files a report about a tweet or an account. Reporting the same target again
+// while an earlier report is unresolved returns that report.
+func (s *service) CreateReport(ctx context.Context, req *entity.Report) (*entity.Report, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	reportedUserID, err := s.reportedUser(ctx, req.TargetType, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if reportedUserID == req.ReporterID {
+		return nil, errs.ErrInvalidInput
+	}
+
+	req.ReportedUserID = reportedUserID
+	req.Status = entity.ReportOpen
+	req.CreatedAt = time.Now()
+	report, err := s.db.CreateReport(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+	return report, nil
+}
+
+func (s *service) GetReports(ctx context.Context, status entity.ReportStatus, page *entity.Page) ([]entity.Report, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	reports, next, err := s.db.GetReportsByStatus(ctx, status, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get reports: %w", err)
+	}
+	return reports, next, nil
+}
+
+// ClaimReport takes an open report off the queue so other moderators skip it.
+func (s *service) ClaimReport(ctx context.Context, adminID, reportID int) (*entity.Report, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	report, err := s.db.ClaimReport(ctx, reportID, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim report: %w", err)
+	}
+	if err := s.audit(ctx, adminID, entity.AuditClaimReport, reportID); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ResolveReport applies the resolution to the reported content and closes the report.
+// Only the moderator who claimed the report can resolve it.
+func (s *service) ResolveReport(ctx context.Context, adminID, reportID int, resolution entity.ReportResolution) (*entity.Report, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	report, err := s.db.GetReportByID(ctx, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	if report.Status != entity.ReportClaimed || report.ClaimedBy != adminID {
+		return nil, errs.ErrReportNotClaimed
+	}
+
+	switch resolution {
+	case entity.ResolutionDismiss:
+	case entity.ResolutionDeleteContent:
+		if report.TargetType != entity.ReportTargetTweet {
+			return nil, errs.ErrInvalidInput
+		}
+		if err := s.adminService.DeleteTweet(ctx, adminID, report.TargetID); err != nil && !errors.Is(err, errs.ErrTweetNotFound) {
+			return nil, err
+		}
+	case entity.ResolutionSuspendUser:
+		if report.ReportedUserID == 0 {
+			return nil, errs.ErrUserNotFound
+		}
+		if err := s.adminService.DeactivateUser(ctx, adminID, report.ReportedUserID); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errs.ErrInvalidInput
+	}
+
+	report, err = s.db.ResolveReport(ctx, reportID, adminID, resolution, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve report: %w", err)
+	}
+	if err := s.audit(ctx, adminID, entity.AuditResolveReport, reportID); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// reportedUser returns the account behind a report target and fails when the target is missing.
+func (s *service) reportedUser(ctx context.Context, targetType entity.ReportTarget, targetID int) (int, error) {
+	switch targetType {
+	case entity.ReportTargetTweet:
+		tweet, err := s.db.GetTweetById(ctx, targetID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get tweet: %w", err)
+		}
+		return tweet.Author.ID, nil
+	case entity.ReportTargetUser:
+		user, err := s.db.GetUserByID(ctx, targetID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get user: %w", err)
+		}
+		return user.ID, nil
+	default:
+		return 0, errs.ErrInvalidInput
+	}
+}
+
+func (s *service) audit(ctx context.Context, adminID int, action entity.AuditAction, reportID int) error {
+	err := s.db.CreateAuditEntry(ctx, &entity.AuditEntry{
+		AdminID:    adminID,
+		Action:     action,
+		TargetType: entity.AuditTargetReport,
+		TargetID:   reportID,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package reports_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/core/service/reports"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDB struct {
+	mock.Mock
+}
+
+func (m *mockDB) GetTweetById(ctx context.Context, tweetID int) (*entity.Tweet, error) {
+	args := m.Called(ctx, tweetID)
+	tweet, _ := args.Get(0).(*entity.Tweet)
+	return tweet, args.Error(1)
+}
+
+func (m *mockDB) GetUserByID(ctx context.Context, userID int) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+	user, _ := args.Get(0).(*entity.User)
+	return user, args.Error(1)
+}
+
+func (m *mockDB) CreateReport(ctx context.Context, report *entity.Report) (*entity.Report, error) {
+	args := m.Called(ctx, report)
+	created, _ := args.Get(0).(*entity.Report)
+	return created, args.Error(1)
+}
+
+func (m *mockDB) GetReportByID(ctx context.Context, reportID int) (*entity.Report, error) {
+	args := m.Called(ctx, reportID)
+	report, _ := args.Get(0).(*entity.Report)
+	return report, args.Error(1)
+}
+
+func (m *mockDB) GetReportsByStatus(ctx context.Context, status entity.ReportStatus, page *entity.Page) ([]entity.Report, *entity.Cursor, error) {
+	args := m.Called(ctx, status, page)
+	list, _ := args.Get(0).([]entity.Report)
+	cursor, _ := args.Get(1).(*entity.Cursor)
+	return list, cursor, args.Error(2)
+}
+
+func (m *mockDB) ClaimReport(ctx context.Context, reportID, adminID int) (*entity.Report, error) {
+	args := m.Called(ctx, reportID, adminID)
+	report, _ := args.Get(0).(*entity.Report)
+	return report, args.Error(1)
+}
+
+func (m *mockDB) ResolveReport(ctx context.Context, reportID, adminID int, resolution entity.ReportResolution, resolvedAt time.Time) (*entity.Report, error) {
+	args := m.Called(ctx, reportID, adminID, resolution, resolvedAt)
+	report, _ := args.Get(0).(*entity.Report)
+	return report, args.Error(1)
+}
+
+func (m *mockDB) CreateAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+type mockAdminService struct {
+	mock.Mock
+}
+
+func (m *mockAdminService) DeactivateUser(ctx context.Context, adminID, userID int) error {
+	args := m.Called(ctx, adminID, userID)
+	return args.Error(0)
+}
+
+func (m *mockAdminService) DeleteTweet(ctx context.Context, adminID, tweetID int) error {
+	args := m.Called(ctx, adminID, tweetID)
+	return args.Error(0)
+}
+
+func TestService_CreateReport_Tweet(t *testing.T) {
+	mockDB := &mockDB{}
+	service := reports.NewReportService(mockDB, &mockAdminService{})
+
+	mockDB.On("GetTweetById", mock.Anything, 10).Return(&entity.Tweet{ID: 10, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("CreateReport", mock.Anything, mock.MatchedBy(func(r *entity.Report) bool {
+		return r.ReporterID == 1 && r.ReportedUserID == 2 && r.Status == entity.ReportOpen
+	})).Return(&entity.Report{ID: 5, ReporterID: 1, TargetType: entity.ReportTargetTweet, TargetID: 10, ReportedUserID: 2}, nil).Once()
+
+	report, err := service.CreateReport(context.Background(), &entity.Report{
+		ReporterID: 1,
+		TargetType: entity.ReportTargetTweet,
+		TargetID:   10,
+		Reason:     entity.ReportSpam,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.ID)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_CreateReport_OwnTweet(t *testing.T) {
+	mockDB := &mockDB{}
+	service := reports.NewReportService(mockDB, &mockAdminService{})
+
+	mockDB.On("GetTweetById", mock.Anything, 10).Return(&entity.Tweet{ID: 10, Author: &entity.SmallUser{ID: 1}}, nil).Once()
+
+	_, err := service.CreateReport(context.Background(), &entity.Report{
+		ReporterID: 1,
+		TargetType: entity.ReportTargetTweet,
+		TargetID:   10,
+		Reason:     entity.ReportSpam,
+	})
+
+	assert.ErrorIs(t, err, errs.ErrInvalidInput)
+	mockDB.AssertNotCalled(t, "CreateReport", mock.Anything, mock.Anything)
+}
+
+func TestService_CreateReport_UserNotFound(t *testing.T) {
+	mockDB := &mockDB{}
+	service := reports.NewReportService(mockDB, &mockAdminService{})
+
+	mockDB.On("GetUserByID", mock.Anything, 3).Return(nil, errs.ErrUserNotFound).Once()
+
+	_, err := service.CreateReport(context.Background(), &entity.Report{
+		ReporterID: 1,
+		TargetType: entity.ReportTargetUser,
+		TargetID:   3,
+		Reason:     entity.ReportHarassment,
+	})
+
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+}
+
+func TestService_ClaimReport_AlreadyClaimed(t *testing.T) {
+	mockDB := &mockDB{}
+	service := reports.NewReportService(mockDB, &mockAdminService{})
+
+	mockDB.On("ClaimReport", mock.Anything, 5, 9).Return(nil, errs.ErrReportNotClaimable).Once()
+
+	_, err := service.ClaimReport(context.Background(), 9, 5)
+
+	assert.ErrorIs(t, err, errs.ErrReportNotClaimable)
+	mockDB.AssertNotCalled(t, "CreateAuditEntry", mock.Anything, mock.Anything)
+}
+
+func TestService_ResolveReport_DeleteContent(t *testing.T) {
+	mockDB := &mockDB{}
+	mockAdmin := &mockAdminService{}
+	service := reports.NewReportService(mockDB, mockAdmin)
+
+	claimed := &entity.Report{ID: 5, TargetType: entity.ReportTargetTweet, TargetID: 10, ReportedUserID: 2, Status: entity.ReportClaimed, ClaimedBy: 9}
+	resolved := *claimed
+	resolved.Status = entity.ReportResolved
+	resolved.Resolution = entity.ResolutionDeleteContent
+
+	mockDB.On("GetReportByID", mock.Anything, 5).Return(claimed, nil).Once()
+	mockAdmin.On("DeleteTweet", mock.Anything, 9, 10).Return(nil).Once()
+	mockDB.On("ResolveReport", mock.Anything, 5, 9, entity.ResolutionDeleteContent, mock.Anything).Return(&resolved, nil).Once()
+	mockDB.On("CreateAuditEntry", mock.Anything, mock.MatchedBy(func(e *entity.AuditEntry) bool {
+		return e.Action == entity.AuditResolveReport && e.TargetType == entity.AuditTargetReport && e.TargetID == 5
+	})).Return(nil).Once()
+
+	report, err := service.ResolveReport(context.Background(), 9, 5, entity.ResolutionDeleteContent)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ReportResolved, report.Status)
+	mockDB.AssertExpectations(t)
+	mockAdmin.AssertExpectations(t)
+}
+
+func TestService_ResolveReport_SuspendUser(t *testing.T) {
+	mockDB := &mockDB{}
+	mockAdmin := &mockAdminService{}
+	service := reports.NewReportService(mockDB, mockAdmin)
+
+	claimed := &entity.Report{ID: 5, TargetType: entity.ReportTargetTweet, TargetID: 10, ReportedUserID: 2, Status: entity.ReportClaimed, ClaimedBy: 9}
+
+	mockDB.On("GetReportByID", mock.Anything, 5).Return(claimed, nil).Once()
+	mockAdmin.On("DeactivateUser", mock.Anything, 9, 2).Return(nil).Once()
+	mockDB.On("ResolveReport", mock.Anything, 5, 9, entity.ResolutionSuspendUser, mock.Anything).Return(claimed, nil).Once()
+	mockDB.On("CreateAuditEntry", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := service.ResolveReport(context.Background(), 9, 5, entity.ResolutionSuspendUser)
+
+	assert.NoError(t, err)
+	mockAdmin.AssertExpectations(t)
+}
+
+func TestService_ResolveReport_NotClaimedByCaller(t *testing.T) {
+	mockDB := &mockDB{}
+	mockAdmin := &mockAdminService{}
+	service := reports.NewReportService(mockDB, mockAdmin)
+
+	mockDB.On("GetReportByID", mock.Anything, 5).Return(&entity.Report{ID: 5, Status: entity.ReportClaimed, ClaimedBy: 7}, nil).Once()
+
+	_, err := service.ResolveReport(context.Background(), 9, 5, entity.ResolutionDismiss)
+
+	assert.ErrorIs(t, err, errs.ErrReportNotClaimed)
+	mockDB.AssertNotCalled(t, "ResolveReport", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_ResolveReport_DeleteContentOfUser(t *testing.T) {
+	mockDB := &mockDB{}
+	mockAdmin := &mockAdminService{}
+	service := reports.NewReportService(mockDB, mockAdmin)
+
+	mockDB.On("GetReportByID", mock.Anything, 5).Return(&entity.Report{ID: 5, TargetType: entity.ReportTargetUser, TargetID: 2, Status: entity.ReportClaimed, ClaimedBy: 9}, nil).Once()
+
+	_, err := service.ResolveReport(context.Background(), 9, 5, entity.ResolutionDeleteContent)
+
+	assert.ErrorIs(t, err, errs.ErrInvalidInput)
+	mockAdmin.AssertNotCalled(t, "DeleteTweet", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -8,21 +8,22 @@ import (
 
 type (
 	mediaService interface {
-		GetMediaUrlByTweetID(ctx context.Context, tweetID int) (string, error)
-		GetAvatarUrlByUserID(ctx context.Context, userID int) (string, error)
+		GetAvatarUrlsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error)
 	}
 
 	tweetService interface {
-		BuildEntityTweetToResponse(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error)
+		BuildEntityTweetsToResponse(ctx context.Context, tweets []entity.Tweet) ([]entity.Tweet, error)
 	}
 
 	searchStorage interface {
 		GetTweetsByIDs(ctx context.Context, ids []int) ([]entity.Tweet, error)
 		GetUsersByIDs(ctx context.Context, ids []int) ([]entity.User, error)
+		GetBlockedUserIDs(ctx context.Context, userID int) ([]int, error)
 	}
 
 	searchProvider interface {
 		SearchTweets(ctx context.Context, query string) ([]int, error)
 		SearchUsers(ctx context.Context, query string) ([]int, error)
+		GetTrends(ctx context.Context, limit int) ([]entity.Trend, error)
 	}
 )
@@ -37,13 +37,9 @@ func (s *service) SearchTweets(ctx context.Context, query string) ([]entity.Twee
 		return nil, fmt.Errorf("failed to get tweets by ids: %w", err)
 	}
 
-	res := make([]entity.Tweet, 0, len(tweets))
-	for _, t := range tweets {
-		tr, err := s.tweet.BuildEntityTweetToResponse(ctx, &t)
-		if err != nil {
-			return nil, fmt.Errorf("failed to change tweet entity to response: %w", err)
-		}
-		res = append(res, *tr)
+	res, err := s.tweet.BuildEntityTweetsToResponse(ctx, tweets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change tweet entity to response: %w", err)
 	}
 
 	return res, nil
@@ -62,13 +58,53 @@ func (s *service) SearchUsers(ctx context.Context, query string) ([]entity.User,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users by ids: %w", err)
 	}
+	users, err = s.hideBlocked(ctx, users)
+	if err != nil {
+		return nil, err
+	}
 
+	avatarUrls, err := s.media.GetAvatarUrlsByUserIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user avatars by ids: %w", err)
+	}
 	for i := range users {
-		users[i].AvatarUrl, err = s.media.GetAvatarUrlByUserID(ctx, users[i].ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user avatar by id: %w", err)
-		}
+		users[i].AvatarUrl = avatarUrls[users[i].ID]
 	}
 
 	return users, nil
 }
+
+// hideBlocked drops users on either side of a block with the viewer of ctx.
+func (s *service) hideBlocked(ctx context.Context, users []entity.User) ([]entity.User, error) {
+	viewerID, ok := entity.ViewerFromContext(ctx)
+	if !ok {
+		return users, nil
+	}
+	blockedIDs, err := s.db.GetBlockedUserIDs(ctx, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+	if len(blockedIDs) == 0 {
+		return users, nil
+	}
+
+	blocked := make(map[int]struct{}, len(blockedIDs))
+	for _, id := range blockedIDs {
+		blocked[id] = struct{}{}
+	}
+	res := make([]entity.User, 0, len(users))
+	for i := range users {
+		if _, ok := blocked[users[i].ID]; !ok {
+			res = append(res, users[i])
+		}
+	}
+	return res, nil
+}
+
+func (s *service) GetTrends(ctx context.Context, limit int) ([]entity.Trend, error) {
+	trends, err := s.search.GetTrends(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trends: %w", err)
+	}
+	return trends, nil
+}
@@ -0,0 +1,25 @@
+package timeline
+
+import (
+	"context"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type (
+	db interface {
+		GetTimelineEntries(ctx context.Context, actorIDs []int, page *entity.Page) ([]entity.TimelineEntry, *entity.Cursor, error)
+		GetFollowersCount(ctx context.Context, userID int) (int, error)
+		GetAllFollowersIds(ctx context.Context, userID int) ([]int, error)
+		GetTimelineAuthorsIds(ctx context.Context, userID, minFollowers int) ([]int, []int, error)
+	}
+
+	timelineStorage interface {
+		PushToTimelines(ctx context.Context, userIDs []int, entry *entity.TimelineEntry) error
+		PushToTimeline(ctx context.Context, userID int, entries []entity.TimelineEntry) error
+		ReplaceTimeline(ctx context.Context, userID int, entries []entity.TimelineEntry) error
+		GetTimeline(ctx context.Context, userID int, page *entity.Page) ([]entity.TimelineEntry, error)
+		RemoveFromTimelines(ctx context.Context, userIDs []int, entry *entity.TimelineEntry) error
+		RemoveActorFromTimeline(ctx context.Context, userID, actorID int) error
+	}
+)
@@ -0,0 +1,216 @@
+package timeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+// Home timelines are materialized in Redis on write. Tweets of accounts with at
+// least celebrityThreshold followers are not fanned out; they are read from
+// Postgres and merged in when the timeline is requested.
+type service struct {
+	db                 db
+	store              timelineStorage
+	maxLength          int
+	celebrityThreshold int
+	backfillSize       int
+}
+
+func NewTimelineService(cfg *config.TimelineConfig, db db, store timelineStorage) *service {
+	return &service{
+		db:                 db,
+		store:              store,
+		maxLength:          cfg.MaxLength,
+		celebrityThreshold: cfg.CelebrityThreshold,
+		backfillSize:       cfg.BackfillSize,
+	}
+}
+
+// FanOut places a new tweet or retweet on the timelines of the actor and its followers.
+func (s *service) FanOut(ctx context.Context, entry *entity.TimelineEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	recipients, err := s.recipients(ctx, entry.ActorID)
+	if err != nil {
+		return err
+	}
+	if err := s.store.PushToTimelines(ctx, recipients, entry); err != nil {
+		return fmt.Errorf("failed to push to timelines: %w", err)
+	}
+	return nil
+}
+
+// Retract removes a deleted retweet from the timelines it was fanned out to.
+func (s *service) Retract(ctx context.Context, entry *entity.TimelineEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	recipients, err := s.recipients(ctx, entry.ActorID)
+	if err != nil {
+		return err
+	}
+	if err := s.store.RemoveFromTimelines(ctx, recipients, entry); err != nil {
+		return fmt.Errorf("failed to remove from timelines: %w", err)
+	}
+	return nil
+}
+
+// Backfill copies recent tweets of a newly followed account into the follower's timeline.
+func (s *service) Backfill(ctx context.Context, followerID, followingID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	count, err := s.db.GetFollowersCount(ctx, followingID)
+	if err != nil {
+		return fmt.Errorf("failed to get followers count: %w", err)
+	}
+	if count >= s.celebrityThreshold {
+		return nil
+	}
+
+	entries, _, err := s.db.GetTimelineEntries(ctx, []int{followingID}, &entity.Page{Limit: s.backfillSize})
+	if err != nil {
+		return fmt.Errorf("failed to get timeline entries: %w", err)
+	}
+	if err := s.store.PushToTimeline(ctx, followerID, entries); err != nil {
+		return fmt.Errorf("failed to push to timeline: %w", err)
+	}
+	return nil
+}
+
+// Cleanup removes tweets of an unfollowed account from the follower's timeline.
+func (s *service) Cleanup(ctx context.Context, followerID, followingID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := s.store.RemoveActorFromTimeline(ctx, followerID, followingID); err != nil {
+		return fmt.Errorf("failed to remove actor from timeline: %w", err)
+	}
+	return nil
+}
+
+// GetHomeTimeline returns a page of the home timeline of userID. A timeline
+// missing from Redis is rebuilt from Postgres. Both sources are read from the
+// start of the page and the offset is applied once they are merged.
+func (s *service) GetHomeTimeline(ctx context.Context, userID int, page *entity.Page) ([]entity.TimelineEntry, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	regular, celebrities, err := s.db.GetTimelineAuthorsIds(ctx, userID, s.celebrityThreshold)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get timeline authors: %w", err)
+	}
+
+	offset := page.Offset
+	if page.After != nil {
+		offset = 0
+	}
+	window := &entity.Page{Limit: offset + page.Limit, After: page.After}
+
+	entries, err := s.store.GetTimeline(ctx, userID, window)
+	if errors.Is(err, errs.ErrCacheKeyNotFound) {
+		entries, err = s.rebuild(ctx, userID, append(regular, userID), window)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get timeline: %w", err)
+	}
+	more := len(entries) == window.Limit
+
+	if len(celebrities) > 0 {
+		celebrityEntries, celebrityNext, err := s.db.GetTimelineEntries(ctx, celebrities, window)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get celebrity timeline entries: %w", err)
+		}
+		entries = mergeEntries(entries, celebrityEntries)
+		more = more || celebrityNext != nil
+	}
+
+	if len(entries) > window.Limit {
+		entries = entries[:window.Limit]
+		more = true
+	}
+	entries = entries[min(offset, len(entries)):]
+	if !more || len(entries) == 0 {
+		return entries, nil, nil
+	}
+	last := entries[len(entries)-1]
+	return entries, &entity.Cursor{CreatedAt: last.CreatedAt, ID: last.TweetID}, nil
+}
+
+func (s *service) recipients(ctx context.Context, actorID int) ([]int, error) {
+	count, err := s.db.GetFollowersCount(ctx, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers count: %w", err)
+	}
+
+	recipients := []int{actorID}
+	if count >= s.celebrityThreshold {
+		return recipients, nil
+	}
+
+	followers, err := s.db.GetAllFollowersIds(ctx, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers: %w", err)
+	}
+	return append(recipients, followers...), nil
+}
+
+// rebuild materializes the timeline of userID from Postgres and returns the requested page of it.
+func (s *service) rebuild(ctx context.Context, userID int, actorIDs []int, page *entity.Page) ([]entity.TimelineEntry, error) {
+	entries, _, err := s.db.GetTimelineEntries(ctx, actorIDs, &entity.Page{Limit: s.maxLength})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timeline entries: %w", err)
+	}
+	if err := s.store.ReplaceTimeline(ctx, userID, entries); err != nil {
+		return nil, fmt.Errorf("failed to store timeline: %w", err)
+	}
+
+	start := page.Offset
+	if page.After != nil {
+		start = sort.Search(len(entries), func(i int) bool {
+			at, after := entries[i].CreatedAt, page.After.CreatedAt
+			return at.Before(after) || (at.Equal(after) && entries[i].TweetID < page.After.ID)
+		})
+	}
+	start = min(start, len(entries))
+	end := min(start+page.Limit, len(entries))
+	return entries[start:end], nil
+}
+
+// mergeEntries interleaves two pages ordered newest first and drops entries
+// present in both, e.g. tweets fanned out before their author became a celebrity.
+func mergeEntries(a, b []entity.TimelineEntry) []entity.TimelineEntry {
+	type key struct {
+		tweetID   int
+		createdAt int64
+	}
+
+	seen := make(map[key]struct{}, len(a)+len(b))
+	merged := make([]entity.TimelineEntry, 0, len(a)+len(b))
+	for _, page := range [][]entity.TimelineEntry{a, b} {
+		for _, e := range page {
+			k := key{tweetID: e.TweetID, createdAt: e.CreatedAt.UnixMicro()}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			merged = append(merged, e)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if !merged[i].CreatedAt.Equal(merged[j].CreatedAt) {
+			return merged[i].CreatedAt.After(merged[j].CreatedAt)
+		}
+		return merged[i].TweetID > merged[j].TweetID
+	})
+	return merged
+}
@@ -0,0 +1,187 @@
+package timeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/core/service/timeline"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDB struct {
+	mock.Mock
+}
+
+func (m *mockDB) GetTimelineEntries(ctx context.Context, actorIDs []int, page *entity.Page) ([]entity.TimelineEntry, *entity.Cursor, error) {
+	args := m.Called(ctx, actorIDs, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.TimelineEntry), next, args.Error(2)
+}
+
+func (m *mockDB) GetFollowersCount(ctx context.Context, userID int) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockDB) GetAllFollowersIds(ctx context.Context, userID int) ([]int, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *mockDB) GetTimelineAuthorsIds(ctx context.Context, userID, minFollowers int) ([]int, []int, error) {
+	args := m.Called(ctx, userID, minFollowers)
+	return args.Get(0).([]int), args.Get(1).([]int), args.Error(2)
+}
+
+type mockTimelineStorage struct {
+	mock.Mock
+}
+
+func (m *mockTimelineStorage) PushToTimelines(ctx context.Context, userIDs []int, entry *entity.TimelineEntry) error {
+	args := m.Called(ctx, userIDs, entry)
+	return args.Error(0)
+}
+
+func (m *mockTimelineStorage) PushToTimeline(ctx context.Context, userID int, entries []entity.TimelineEntry) error {
+	args := m.Called(ctx, userID, entries)
+	return args.Error(0)
+}
+
+func (m *mockTimelineStorage) ReplaceTimeline(ctx context.Context, userID int, entries []entity.TimelineEntry) error {
+	args := m.Called(ctx, userID, entries)
+	return args.Error(0)
+}
+
+func (m *mockTimelineStorage) GetTimeline(ctx context.Context, userID int, page *entity.Page) ([]entity.TimelineEntry, error) {
+	args := m.Called(ctx, userID, page)
+	entries, _ := args.Get(0).([]entity.TimelineEntry)
+	return entries, args.Error(1)
+}
+
+func (m *mockTimelineStorage) RemoveFromTimelines(ctx context.Context, userIDs []int, entry *entity.TimelineEntry) error {
+	args := m.Called(ctx, userIDs, entry)
+	return args.Error(0)
+}
+
+func (m *mockTimelineStorage) RemoveActorFromTimeline(ctx context.Context, userID, actorID int) error {
+	args := m.Called(ctx, userID, actorID)
+	return args.Error(0)
+}
+
+var (
+	testCfg = &config.TimelineConfig{MaxLength: 100, CelebrityThreshold: 1000, BackfillSize: 20}
+	base    = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func entry(tweetID, actorID, minute int) entity.TimelineEntry {
+	return entity.TimelineEntry{TweetID: tweetID, ActorID: actorID, CreatedAt: base.Add(time.Duration(minute) * time.Minute)}
+}
+
+func TestService_GetHomeTimeline_OffsetAppliedAfterMerge(t *testing.T) {
+	mockDB := &mockDB{}
+	mockStore := &mockTimelineStorage{}
+
+	service := timeline.NewTimelineService(testCfg, mockDB, mockStore)
+
+	window := &entity.Page{Limit: 4}
+	stored := []entity.TimelineEntry{entry(10, 2, 10), entry(8, 2, 8), entry(6, 2, 6), entry(4, 2, 4)}
+	celebrity := []entity.TimelineEntry{entry(9, 3, 9), entry(7, 3, 7)}
+
+	mockDB.On("GetTimelineAuthorsIds", mock.Anything, 1, 1000).Return([]int{2}, []int{3}, nil).Once()
+	mockStore.On("GetTimeline", mock.Anything, 1, window).Return(stored, nil).Once()
+	mockDB.On("GetTimelineEntries", mock.Anything, []int{3}, window).Return(celebrity, nil, nil).Once()
+
+	entries, next, err := service.GetHomeTimeline(context.Background(), 1, &entity.Page{Limit: 2, Offset: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.TimelineEntry{entry(8, 2, 8), entry(7, 3, 7)}, entries)
+	assert.Equal(t, &entity.Cursor{CreatedAt: base.Add(7 * time.Minute), ID: 7}, next)
+	mockDB.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
+}
+
+func TestService_GetHomeTimeline_CursorMergesSources(t *testing.T) {
+	mockDB := &mockDB{}
+	mockStore := &mockTimelineStorage{}
+
+	service := timeline.NewTimelineService(testCfg, mockDB, mockStore)
+
+	cursor := &entity.Cursor{CreatedAt: base.Add(10 * time.Minute), ID: 10}
+	page := &entity.Page{Limit: 2, Offset: 5, After: cursor}
+	window := &entity.Page{Limit: 2, After: cursor}
+	stored := []entity.TimelineEntry{entry(8, 2, 8)}
+	celebrity := []entity.TimelineEntry{entry(9, 3, 9)}
+
+	mockDB.On("GetTimelineAuthorsIds", mock.Anything, 1, 1000).Return([]int{2}, []int{3}, nil).Once()
+	mockStore.On("GetTimeline", mock.Anything, 1, window).Return(stored, nil).Once()
+	mockDB.On("GetTimelineEntries", mock.Anything, []int{3}, window).Return(celebrity, nil, nil).Once()
+
+	entries, next, err := service.GetHomeTimeline(context.Background(), 1, page)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.TimelineEntry{entry(9, 3, 9), entry(8, 2, 8)}, entries)
+	assert.Nil(t, next)
+	mockDB.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
+}
+
+func TestService_GetHomeTimeline_RebuildKeepsTiesAfterCursor(t *testing.T) {
+	mockDB := &mockDB{}
+	mockStore := &mockTimelineStorage{}
+
+	service := timeline.NewTimelineService(testCfg, mockDB, mockStore)
+
+	// Three tweets share a timestamp; the previous page ended in the middle of them.
+	all := []entity.TimelineEntry{entry(7, 2, 6), entry(5, 2, 5), entry(4, 2, 5), entry(3, 2, 5), entry(1, 2, 1)}
+	cursor := &entity.Cursor{CreatedAt: base.Add(5 * time.Minute), ID: 5}
+	window := &entity.Page{Limit: 2, After: cursor}
+
+	mockDB.On("GetTimelineAuthorsIds", mock.Anything, 1, 1000).Return([]int{2}, []int{}, nil).Once()
+	mockStore.On("GetTimeline", mock.Anything, 1, window).Return(nil, errs.ErrCacheKeyNotFound).Once()
+	mockDB.On("GetTimelineEntries", mock.Anything, []int{2, 1}, &entity.Page{Limit: 100}).Return(all, nil, nil).Once()
+	mockStore.On("ReplaceTimeline", mock.Anything, 1, all).Return(nil).Once()
+
+	entries, next, err := service.GetHomeTimeline(context.Background(), 1, &entity.Page{Limit: 2, After: cursor})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.TimelineEntry{entry(4, 2, 5), entry(3, 2, 5)}, entries)
+	assert.Equal(t, &entity.Cursor{CreatedAt: base.Add(5 * time.Minute), ID: 3}, next)
+	mockDB.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
+}
+
+func TestService_FanOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		followers  int
+		recipients []int
+	}{
+		{name: "regular account", followers: 2, recipients: []int{5, 6, 7}},
+		{name: "celebrity", followers: 1000, recipients: []int{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &mockDB{}
+			mockStore := &mockTimelineStorage{}
+
+			service := timeline.NewTimelineService(testCfg, mockDB, mockStore)
+
+			e := entry(1, 5, 0)
+			mockDB.On("GetFollowersCount", mock.Anything, 5).Return(tt.followers, nil).Once()
+			mockDB.On("GetAllFollowersIds", mock.Anything, 5).Return([]int{6, 7}, nil).Maybe()
+			mockStore.On("PushToTimelines", mock.Anything, tt.recipients, &e).Return(nil).Once()
+
+			err := service.FanOut(context.Background(), &e)
+
+			assert.NoError(t, err)
+			mockDB.AssertExpectations(t)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
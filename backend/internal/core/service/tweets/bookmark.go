@@ -0,0 +1,61 @@
+package tweets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+func (s *service) BookmarkTweet(ctx context.Context, userID, tweetID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tweet, err := s.db.GetTweetById(ctx, tweetID)
+	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get tweet: %w", err)
+	}
+	if err := s.checkNotPrivate(ctx, userID, tweet); err != nil {
+		return err
+	}
+
+	if err := s.db.BookmarkTweet(ctx, userID, tweetID, time.Now()); err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to bookmark tweet: %w", err)
+	}
+	return nil
+}
+
+func (s *service) UnbookmarkTweet(ctx context.Context, userID, tweetID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.db.UnbookmarkTweet(ctx, userID, tweetID); err != nil {
+		if errors.Is(err, errs.ErrBookmarkNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+	return nil
+}
+
+func (s *service) GetBookmarks(ctx context.Context, userID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tweets, next, err := s.db.GetBookmarkedTweets(ctx, userID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get bookmarks: %w", err)
+	}
+
+	tweets, err = s.BuildEntityTweetsToResponse(ctx, tweets)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tweets, next, nil
+}
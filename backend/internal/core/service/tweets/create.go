@@ -2,63 +2,121 @@ package tweets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/kust1q/Zapp/backend/internal/domain/events"
-	"github.com/sirupsen/logrus"
+	"github.com/kust1q/Zapp/backend/internal/errs"
 )
 
+// CreateTweet publishes a tweet. A tweet published from a draft consumes the draft in
+// the same transaction, so a draft is published at most once.
 func (s *service) CreateTweet(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	if tweet.ParentTweetID != nil {
+		parent, err := s.db.GetTweetById(ctx, *tweet.ParentTweetID)
+		if err != nil {
+			if errors.Is(err, errs.ErrTweetNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to get parent tweet: %w", err)
+		}
+		if err := s.checkNotBlocked(ctx, tweet.Author.ID, parent); err != nil {
+			return nil, err
+		}
+		if err := s.checkNotPrivate(ctx, tweet.Author.ID, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	if tweet.QuotedTweetID != nil {
+		quoted, err := s.db.GetTweetById(ctx, *tweet.QuotedTweetID)
+		if err != nil {
+			if errors.Is(err, errs.ErrTweetNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to get quoted tweet: %w", err)
+		}
+		if err := s.checkNotBlocked(ctx, tweet.Author.ID, quoted); err != nil {
+			return nil, err
+		}
+		if err := s.checkNotPrivate(ctx, tweet.Author.ID, quoted); err != nil {
+			return nil, err
+		}
+	}
+
 	tx, err := s.db.BeginTx(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if tweet.DraftID != 0 {
+		if err := s.db.DeleteDraftTx(ctx, tx, tweet.Author.ID, tweet.DraftID); err != nil {
+			if errors.Is(err, errs.ErrDraftNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to consume draft: %w", err)
+		}
+	}
+
 	createdTweet, err := s.db.CreateTweetTx(ctx, tx, tweet)
 	if err != nil {
 		return nil, fmt.Errorf("user creation failed: %w", err)
 	}
 
-	var mediaUrl string
-	if tweet.File != nil {
-		mediaUrl, err = s.media.UploadAndAttachTweetMediaTx(ctx, createdTweet.ID, tweet.File.File, tweet.File.Header.Filename, tx)
+	var media []entity.TweetMedia
+	if len(tweet.Attachments) > 0 {
+		media, err = s.media.UploadAndAttachTweetMediaTx(ctx, createdTweet.ID, tweet.Author.ID, tweet.Attachments, tx)
+		if err != nil {
+			return nil, err
+		}
+	} else if tweet.Uploaded != nil {
+		media, err = s.media.AttachTweetMediaTx(ctx, createdTweet.ID, tweet.Uploaded, tx)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	createdTweet.MediaUrl = mediaUrl
+	if err := s.saveTags(ctx, tx, createdTweet); err != nil {
+		return nil, err
+	}
 
 	response, err := s.BuildEntityTweetToResponse(ctx, createdTweet)
 	if err != nil {
 		return nil, err
 	}
+	response.Media = media
+	response.Hashtags = createdTweet.Hashtags
+	response.Mentions = createdTweet.Mentions
+
+	event := events.TweetEvent{
+		EventType: events.TweetCreateEvent,
+		ID:        response.ID,
+		Content:   response.Content,
+		UserID:    response.Author.ID,
+		Username:  response.Author.Username,
+		CreatedAt: response.CreatedAt,
+		Hashtags:  response.Hashtags,
+		Mentions:  mentionedUsernames(response.Mentions),
+	}
+	if err := s.db.CreateOutboxEventTx(ctx, tx, events.TopicTweet, event); err != nil {
+		return nil, fmt.Errorf("failed to save tweet.created event: %w", err)
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("commit transaction failed: %w", err)
 	}
 
-	go func() {
-		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		event := events.TweetEvent{
-			EventType: events.TweetCreateEvent,
-			ID:        createdTweet.ID,
-			Content:   createdTweet.Content,
-			UserID:    createdTweet.Author.ID,
-			Username:  createdTweet.Author.Username,
-		}
-
-		if err := s.producer.Publish(cntx, events.TopicTweet, event); err != nil {
-			logrus.WithError(err).Error("failed to publish tweet.created")
-		}
-	}()
+	s.fanOut(entity.TimelineEntry{
+		TweetID:   response.ID,
+		ActorID:   response.Author.ID,
+		CreatedAt: response.CreatedAt,
+	})
 
 	return response, nil
 }
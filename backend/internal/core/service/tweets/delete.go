@@ -2,39 +2,63 @@ package tweets
 
 import (
 	"context"
-	"errors"
+	"database/sql"
+	"fmt"
 	"time"
 
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/kust1q/Zapp/backend/internal/domain/events"
-	"github.com/kust1q/Zapp/backend/internal/errs"
-	"github.com/sirupsen/logrus"
 )
 
 func (s *service) DeleteTweet(ctx context.Context, userID, tweetID int) error {
+	return s.deleteTweet(ctx, tweetID, func(ctx context.Context, tx *sql.Tx) (int, error) {
+		return userID, s.db.DeleteTweetTx(ctx, tx, userID, tweetID)
+	})
+}
+
+// ForceDeleteTweet removes a tweet and its media regardless of the author, for moderation.
+func (s *service) ForceDeleteTweet(ctx context.Context, tweetID int) error {
+	return s.deleteTweet(ctx, tweetID, func(ctx context.Context, tx *sql.Tx) (int, error) {
+		return s.db.ForceDeleteTweetTx(ctx, tx, tweetID)
+	})
+}
+
+// deleteTweet removes a tweet with remove, which returns its author, in one transaction
+// with its media rows and the tweet.deleted event. Media objects are removed and the
+// tweet leaves timelines only once the transaction commits.
+func (s *service) deleteTweet(ctx context.Context, tweetID int, remove func(ctx context.Context, tx *sql.Tx) (int, error)) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	if err := s.media.DeleteTweetMedia(ctx, tweetID, userID); err != nil && !errors.Is(err, errs.ErrTweetMediaNotFound) {
-		logrus.WithFields(logrus.Fields{
-			"tweet_id": tweetID,
-			"err":      err,
-		}).Warnf("failed to delete tweet media")
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	if err := s.db.DeleteTweet(ctx, userID, tweetID); err != nil {
+	detached, err := s.media.DetachTweetMediaTx(ctx, tweetID, tx)
+	if err != nil {
+		return err
+	}
+	authorID, err := remove(ctx, tx)
+	if err != nil {
 		return err
 	}
 
-	go func() {
-		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		event := events.TweetDeleted{
-			EventType: events.TweetDeleteEvent,
-			ID:        tweetID,
-		}
-		if err := s.producer.Publish(cntx, events.TopicTweet, event); err != nil {
-			logrus.WithError(err).Error("failed to publish tweet.deleted")
-		}
-	}()
+	event := events.TweetDeleted{
+		EventType: events.TweetDeleteEvent,
+		ID:        tweetID,
+	}
+	if err := s.db.CreateOutboxEventTx(ctx, tx, events.TopicTweet, event); err != nil {
+		return fmt.Errorf("failed to save tweet.deleted event: %w", err)
+	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if len(detached) > 0 {
+		s.media.RemoveDetachedMedia(detached)
+	}
+	s.retract(entity.TimelineEntry{TweetID: tweetID, ActorID: authorID})
 	return nil
 }
@@ -22,60 +22,138 @@ func (s *service) GetTweetById(ctx context.Context, tweetID int) (*entity.Tweet,
 		}
 		return nil, fmt.Errorf("failed to get tweet by id: %w", err)
 	}
+	visible, err := s.hideBlocked(ctx, []entity.Tweet{*tweet})
+	if err != nil {
+		return nil, err
+	}
+	if len(visible) == 0 {
+		return nil, errs.ErrTweetNotFound
+	}
 	return s.BuildEntityTweetToResponse(ctx, tweet)
 }
 
-func (s *service) GetTweetsAndRetweetsByUsername(ctx context.Context, username string, limit, offset int) ([]entity.Tweet, error) {
+// GetTweetHistory returns the earlier versions of an edited tweet, most recent first.
+func (s *service) GetTweetHistory(ctx context.Context, tweetID int, page *entity.Page) ([]entity.TweetRevision, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.checkVisible(ctx, tweetID); err != nil {
+		return nil, nil, err
+	}
+
+	revisions, next, err := s.db.GetTweetRevisions(ctx, tweetID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tweet revisions: %w", err)
+	}
+	return revisions, next, nil
+}
+
+// GetTweetsByIds loads and hydrates tweets in the order of ids, skipping the ones that no longer exist.
+func (s *service) GetTweetsByIds(ctx context.Context, ids []int) ([]entity.Tweet, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tweets, err := s.db.GetTweetsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tweets by ids: %w", err)
+	}
+	return s.BuildEntityTweetsToResponse(ctx, tweets)
+}
+
+func (s *service) GetTweetsAndRetweetsByUsername(ctx context.Context, username string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	tweets, err := s.db.GetTweetsAndRetweetsByUsername(ctx, username, limit, offset)
+	owner, err := s.db.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user by username: %w", err)
+	}
+	visible, err := s.canSeeTweetsOf(ctx, owner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !visible {
+		return []entity.Tweet{}, nil, nil
+	}
+
+	tweets, next, err := s.db.GetTweetsAndRetweetsByUsername(ctx, username, page)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return []entity.Tweet{}, nil
+			return []entity.Tweet{}, nil, nil
 		}
-		return nil, fmt.Errorf("failed to get tweets by username: %w", err)
+		return nil, nil, fmt.Errorf("failed to get tweets by username: %w", err)
 	}
 
-	for i := range tweets {
-		if tweets[i].MediaUrl != "" {
-			mediaUrl, err := s.media.GetMediaUrlByTweetID(ctx, tweets[i].ID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get tweet media url: %w", err)
-			}
-			tweets[i].MediaUrl = mediaUrl
-		}
-		processedTweet, err := s.BuildEntityTweetToResponse(ctx, &tweets[i])
-		if err != nil {
-			return nil, err
-		}
-		tweets[i] = *processedTweet
+	tweets, err = s.BuildEntityTweetsToResponse(ctx, tweets)
+	if err != nil {
+		return nil, nil, err
 	}
-	return tweets, nil
+	return tweets, next, nil
 }
 
-func (s *service) GetRepliesToTweet(ctx context.Context, tweetID, limit, offset int) ([]entity.Tweet, error) {
+func (s *service) GetRepliesToTweet(ctx context.Context, tweetID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
+	if err := s.checkVisible(ctx, tweetID); err != nil {
+		return nil, nil, err
+	}
 
-	replies, err := s.db.GetRepliesToTweet(ctx, tweetID, limit, offset)
+	replies, next, err := s.db.GetRepliesToTweet(ctx, tweetID, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get replies: %w", err)
+		return nil, nil, fmt.Errorf("failed to get replies: %w", err)
 	}
 
-	for i := range replies {
-		if replies[i].MediaUrl != "" {
-			mediaUrl, err := s.media.GetMediaUrlByTweetID(ctx, replies[i].ID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get tweet media url: %w", err)
-			}
-			replies[i].MediaUrl = mediaUrl
-		}
+	replies, err = s.BuildEntityTweetsToResponse(ctx, replies)
+	if err != nil {
+		return nil, nil, err
+	}
+	return replies, next, nil
+}
 
-		processedTweet, err := s.BuildEntityTweetToResponse(ctx, &replies[i])
-		if err != nil {
-			return nil, err
+// checkVisible fails with errs.ErrTweetNotFound when tweetID does not exist or is
+// hidden from the viewer of ctx by a block or a private account.
+func (s *service) checkVisible(ctx context.Context, tweetID int) error {
+	tweet, err := s.db.GetTweetById(ctx, tweetID)
+	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
+			return err
 		}
-		replies[i] = *processedTweet
+		return fmt.Errorf("failed to get tweet by id: %w", err)
+	}
+	visible, err := s.hideBlocked(ctx, []entity.Tweet{*tweet})
+	if err != nil {
+		return err
+	}
+	if len(visible) == 0 {
+		return errs.ErrTweetNotFound
+	}
+	viewerID, _ := entity.ViewerFromContext(ctx)
+	return s.checkNotPrivate(ctx, viewerID, tweet)
+}
+
+// canSeeTweetsOf reports whether the viewer of ctx may list the tweets and retweets of
+// owner: nobody on either side of a block may, and only approved followers may list a
+// private account.
+func (s *service) canSeeTweetsOf(ctx context.Context, owner *entity.User) (bool, error) {
+	viewerID, ok := entity.ViewerFromContext(ctx)
+	if ok && viewerID == owner.ID {
+		return true, nil
+	}
+	if !ok {
+		return !owner.IsPrivate, nil
+	}
+
+	blocked, err := s.db.IsBlockedBetween(ctx, viewerID, owner.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+	if blocked {
+		return false, nil
+	}
+	if !owner.IsPrivate {
+		return true, nil
+	}
+
+	following, err := s.db.IsFollowing(ctx, viewerID, owner.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check follow: %w", err)
 	}
-	return replies, nil
+	return following, nil
 }
@@ -0,0 +1,56 @@
+package tweets
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+// saveTags parses hashtags and mentions out of the tweet content and stores them with the tweet.
+// Mentions of unknown usernames are dropped, so tweet.Mentions holds only existing users.
+func (s *service) saveTags(ctx context.Context, tx *sql.Tx, tweet *entity.Tweet) error {
+	hashtags := entity.ParseHashtags(tweet.Content)
+	mentioned, err := s.db.ReplaceTweetTagsTx(ctx, tx, tweet.ID, tweet.CreatedAt, hashtags, entity.ParseMentions(tweet.Content))
+	if err != nil {
+		return fmt.Errorf("failed to save tweet tags: %w", err)
+	}
+	tweet.Hashtags = hashtags
+	tweet.Mentions = mentioned
+	return nil
+}
+
+func (s *service) GetTweetsByHashtag(ctx context.Context, tag string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tag, ok := entity.NormalizeHashtag(tag)
+	if !ok {
+		return nil, nil, errs.ErrInvalidHashtag
+	}
+
+	tweets, next, err := s.db.GetTweetsByHashtag(ctx, tag, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tweets by hashtag: %w", err)
+	}
+
+	tweets, err = s.BuildEntityTweetsToResponse(ctx, tweets)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tweets, next, nil
+}
+
+func mentionedUsernames(users []entity.SmallUser) []string {
+	if len(users) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(users))
+	for _, user := range users {
+		res = append(res, user.Username)
+	}
+	return res
+}
@@ -3,7 +3,6 @@ package tweets
 import (
 	"context"
 	"database/sql"
-	"io"
 	"time"
 
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
@@ -15,29 +14,53 @@ type (
 		CreateTweetTx(ctx context.Context, tx *sql.Tx, tweet *entity.Tweet) (*entity.Tweet, error)
 		CreateTweet(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error)
 		GetTweetById(ctx context.Context, tweetID int) (*entity.Tweet, error)
-		UpdateTweet(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error)
-		DeleteTweet(ctx context.Context, userID, tweetID int) error
+		UpdateTweetTx(ctx context.Context, tx *sql.Tx, tweet *entity.Tweet, maxRevisions int) (*entity.Tweet, error)
+		GetTweetRevisions(ctx context.Context, tweetID int, page *entity.Page) ([]entity.TweetRevision, *entity.Cursor, error)
+		DeleteTweetTx(ctx context.Context, tx *sql.Tx, userID, tweetID int) error
+		ForceDeleteTweetTx(ctx context.Context, tx *sql.Tx, tweetID int) (int, error)
 		LikeTweet(ctx context.Context, userID, tweetID int) error
 		UnLikeTweet(ctx context.Context, userID, tweetID int) error
 		Retweet(ctx context.Context, userID, tweetID int, createdAt time.Time) error
 		DeleteRetweet(ctx context.Context, userID, retweetID int) error
-		GetRepliesToTweet(ctx context.Context, parentTweetID, limit, offset int) ([]entity.Tweet, error)
-		GetTweetsAndRetweetsByUsername(ctx context.Context, username string, limit, offset int) ([]entity.Tweet, error)
-		GetCounts(ctx context.Context, tweetID int) (*entity.Counters, error)
-		GetLikes(ctx context.Context, tweetID int, limit, offset int) ([]entity.SmallUser, error)
+		GetRepliesToTweet(ctx context.Context, parentTweetID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetTweetsAndRetweetsByUsername(ctx context.Context, username string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetThreadAncestors(ctx context.Context, tweetID int) ([]entity.Tweet, error)
+		GetThreadDescendants(ctx context.Context, tweetID, maxDepth, maxReplies int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		GetTweetsByIDs(ctx context.Context, ids []int) ([]entity.Tweet, error)
+		GetCountsByTweetIDs(ctx context.Context, tweetIDs []int) (map[int]*entity.Counters, error)
+		GetViewerStates(ctx context.Context, viewerID int, tweetIDs []int) (map[int]*entity.ViewerState, error)
+		GetLikes(ctx context.Context, tweetID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
+		BookmarkTweet(ctx context.Context, userID, tweetID int, createdAt time.Time) error
+		UnbookmarkTweet(ctx context.Context, userID, tweetID int) error
+		GetBookmarkedTweets(ctx context.Context, userID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
+		ReplaceTweetTagsTx(ctx context.Context, tx *sql.Tx, tweetID int, createdAt time.Time, hashtags, mentions []string) ([]entity.SmallUser, error)
+		GetTweetsByHashtag(ctx context.Context, tag string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
 
-		GetUserByID(ctx context.Context, userID int) (*entity.User, error)
+		GetUsersMapByIDs(ctx context.Context, ids []int) (map[int]*entity.User, error)
+		GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+		IsBlockedBetween(ctx context.Context, userID, otherID int) (bool, error)
+		GetBlockedUserIDs(ctx context.Context, userID int) ([]int, error)
+		IsFollowing(ctx context.Context, followerID, followingID int) (bool, error)
+		GetFollowedUserIDs(ctx context.Context, followerID int, userIDs []int) ([]int, error)
+
+		DeleteDraftTx(ctx context.Context, tx *sql.Tx, userID, draftID int) error
+
+		CreateOutboxEventTx(ctx context.Context, tx *sql.Tx, topic string, event any) error
 	}
 
 	mediaService interface {
-		UploadAndAttachTweetMediaTx(ctx context.Context, tweetID int, file io.Reader, filename string, tx *sql.Tx) (string, error)
-		GetMediaUrlByTweetID(ctx context.Context, tweetID int) (string, error)
-		GetAvatarUrlByUserID(ctx context.Context, userID int) (string, error)
-		DeleteTweetMedia(ctx context.Context, tweetID, userID int) error
+		ValidateTweetAttachments(ctx context.Context, userID int, attachments []entity.Attachment) error
+		UploadAndAttachTweetMediaTx(ctx context.Context, tweetID, userID int, attachments []entity.Attachment, tx *sql.Tx) ([]entity.TweetMedia, error)
+		AttachTweetMediaTx(ctx context.Context, tweetID int, media *entity.UploadedMedia, tx *sql.Tx) ([]entity.TweetMedia, error)
+		DetachTweetMediaTx(ctx context.Context, tweetID int, tx *sql.Tx) ([]string, error)
+		RemoveDetachedMedia(paths []string)
+		GetMediaByTweetIDs(ctx context.Context, tweetIDs []int) (map[int][]entity.TweetMedia, error)
+		GetAvatarUrlsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error)
 		GetPresignedURL(ctx context.Context, path string) (string, error)
 	}
 
-	eventProducer interface {
-		Publish(ctx context.Context, topic string, event any) error
+	timelineService interface {
+		FanOut(ctx context.Context, entry *entity.TimelineEntry) error
+		Retract(ctx context.Context, entry *entity.TimelineEntry) error
 	}
 )
@@ -14,7 +14,21 @@ import (
 func (s *service) LikeTweet(ctx context.Context, userID, tweetID int) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	err := s.db.LikeTweet(ctx, userID, tweetID)
+	tweet, err := s.db.GetTweetById(ctx, tweetID)
+	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get tweet: %w", err)
+	}
+	if err := s.checkNotBlocked(ctx, userID, tweet); err != nil {
+		return err
+	}
+	if err := s.checkNotPrivate(ctx, userID, tweet); err != nil {
+		return err
+	}
+
+	err = s.db.LikeTweet(ctx, userID, tweetID)
 	if err != nil && !errors.Is(err, errs.ErrTweetNotFound) {
 		return fmt.Errorf("failed to like tweet: %w", err)
 	} else if errors.Is(err, errs.ErrTweetNotFound) {
@@ -33,19 +47,28 @@ func (s *service) UnlikeTweet(ctx context.Context, userID, tweetID int) error {
 	return nil
 }
 
-func (s *service) GetLikes(ctx context.Context, tweetID, limit, offset int) ([]entity.SmallUser, error) {
+// GetLikes lists the users who liked tweetID; the list is hidden along with the tweet itself.
+func (s *service) GetLikes(ctx context.Context, tweetID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	users, err := s.db.GetLikes(ctx, tweetID, limit, offset)
+	if err := s.checkVisible(ctx, tweetID); err != nil {
+		return nil, nil, err
+	}
+	users, next, err := s.db.GetLikes(ctx, tweetID, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get likes: %w", err)
+		return nil, nil, fmt.Errorf("failed to get likes: %w", err)
 	}
 
+	ids := make([]int, 0, len(users))
 	for i := range users {
-		users[i].AvatarUrl, err = s.media.GetAvatarUrlByUserID(ctx, users[i].ID)
-		if err != nil {
-			logrus.WithError(err).Warn("failed to get avatar url")
-		}
+		ids = append(ids, users[i].ID)
+	}
+	avatarUrls, err := s.media.GetAvatarUrlsByUserIDs(ctx, ids)
+	if err != nil {
+		logrus.WithError(err).Warn("failed to get avatar urls")
+	}
+	for i := range users {
+		users[i].AvatarUrl = avatarUrls[users[i].ID]
 	}
-	return users, nil
+	return users, next, nil
 }
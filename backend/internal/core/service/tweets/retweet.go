@@ -2,17 +2,41 @@ package tweets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
 )
 
 func (s *service) CreateRetweet(ctx context.Context, userID, tweetID int) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	err := s.db.Retweet(ctx, userID, tweetID, time.Now())
+	tweet, err := s.db.GetTweetById(ctx, tweetID)
 	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get tweet: %w", err)
+	}
+	if err := s.checkNotBlocked(ctx, userID, tweet); err != nil {
+		return err
+	}
+	if err := s.checkRetweetable(ctx, userID, tweet); err != nil {
+		return err
+	}
+
+	createdAt := time.Now()
+	if err := s.db.Retweet(ctx, userID, tweetID, createdAt); err != nil {
 		return fmt.Errorf("failed to create retweet")
 	}
+
+	s.fanOut(entity.TimelineEntry{
+		TweetID:   tweetID,
+		ActorID:   userID,
+		CreatedAt: createdAt,
+	})
 	return nil
 }
 
@@ -23,5 +47,29 @@ func (s *service) DeleteRetweet(ctx context.Context, userID, retweetID int) erro
 	if err != nil {
 		return fmt.Errorf("failed to delete retweet")
 	}
+
+	s.retract(entity.TimelineEntry{TweetID: retweetID, ActorID: userID})
 	return nil
 }
+
+// checkRetweetable fails with errs.ErrPrivateRetweet for tweets of private accounts, which
+// would otherwise reach the retweeter's followers, and with errs.ErrTweetNotFound when
+// userID may not see the tweet at all.
+func (s *service) checkRetweetable(ctx context.Context, userID int, tweet *entity.Tweet) error {
+	authors, err := s.db.GetUsersMapByIDs(ctx, []int{tweet.Author.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get tweet author: %w", err)
+	}
+	author, ok := authors[tweet.Author.ID]
+	if !ok || !author.IsPrivate {
+		return nil
+	}
+	visible, err := s.hidePrivate(ctx, userID, []entity.Tweet{*tweet}, authors)
+	if err != nil {
+		return err
+	}
+	if len(visible) == 0 {
+		return errs.ErrTweetNotFound
+	}
+	return errs.ErrPrivateRetweet
+}
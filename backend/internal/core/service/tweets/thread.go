@@ -0,0 +1,117 @@
+package tweets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+const (
+	defaultThreadDepth = 3
+	maxThreadDepth     = 10
+	// maxNestedReplies caps the replies loaded under each reply, so that a busy
+	// subthread cannot blow up a page. The reply counters tell clients there is more.
+	maxNestedReplies = 5
+)
+
+// GetThread returns the conversation around tweetID. Ancestors, the focal tweet and
+// the page of replies are hydrated in a single batch. depth is clamped to [1, maxThreadDepth].
+func (s *service) GetThread(ctx context.Context, tweetID, depth int, page *entity.Page) (*entity.Thread, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if depth < 1 {
+		depth = defaultThreadDepth
+	}
+	if depth > maxThreadDepth {
+		depth = maxThreadDepth
+	}
+
+	focal, err := s.db.GetTweetById(ctx, tweetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	visible, err := s.hideBlocked(ctx, []entity.Tweet{*focal})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(visible) == 0 {
+		return nil, nil, errs.ErrTweetNotFound
+	}
+
+	ancestors, err := s.db.GetThreadAncestors(ctx, tweetID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get thread ancestors: %w", err)
+	}
+	ancestors, err = s.hideBlocked(ctx, ancestors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A hidden reply takes its whole subtree with it: buildReplyTree only
+	// nests replies under parents that are present.
+	descendants, next, err := s.db.GetThreadDescendants(ctx, tweetID, depth, maxNestedReplies, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get thread descendants: %w", err)
+	}
+	descendants, err = s.hideBlocked(ctx, descendants)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	all := make([]entity.Tweet, 0, len(ancestors)+1+len(descendants))
+	all = append(all, ancestors...)
+	all = append(all, *focal)
+	all = append(all, descendants...)
+
+	// Tweets of private accounts the viewer does not follow are dropped while
+	// building, so the focal tweet is located again in what is left.
+	built, err := s.buildTweets(ctx, all, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	focalIdx := -1
+	for i := range built {
+		if built[i].ID == tweetID {
+			focalIdx = i
+			break
+		}
+	}
+	if focalIdx < 0 {
+		return nil, nil, errs.ErrTweetNotFound
+	}
+
+	return &entity.Thread{
+		Ancestors: built[:focalIdx],
+		Tweet:     &built[focalIdx],
+		Replies:   buildReplyTree(tweetID, built[focalIdx+1:]),
+	}, next, nil
+}
+
+// buildReplyTree nests replies under their parents, keeping the order they came in.
+func buildReplyTree(rootID int, replies []entity.Tweet) []entity.ThreadReply {
+	children := make(map[int][]entity.Tweet)
+	for i := range replies {
+		if replies[i].ParentTweetID == nil {
+			continue
+		}
+		parentID := *replies[i].ParentTweetID
+		children[parentID] = append(children[parentID], replies[i])
+	}
+
+	var nest func(parentID int) []entity.ThreadReply
+	nest = func(parentID int) []entity.ThreadReply {
+		res := make([]entity.ThreadReply, 0, len(children[parentID]))
+		for _, reply := range children[parentID] {
+			res = append(res, entity.ThreadReply{
+				Tweet:   reply,
+				Replies: nest(reply.ID),
+			})
+		}
+		return res
+	}
+	return nest(rootID)
+}
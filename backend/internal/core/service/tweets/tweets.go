@@ -3,58 +3,329 @@ package tweets
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/kust1q/Zapp/backend/internal/config"
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
 )
 
 type service struct {
-	db       tweetStorage
-	media    mediaService
-	producer eventProducer
+	db         tweetStorage
+	media      mediaService
+	timeline   timelineService
+	editWindow time.Duration
+	maxEdits   int
 }
 
-func NewTweetService(db tweetStorage, media mediaService, eventProducer eventProducer) *service {
+func NewTweetService(cfg *config.TweetsConfig, db tweetStorage, media mediaService, timeline timelineService) *service {
 	return &service{
-		db:       db,
-		media:    media,
-		producer: eventProducer,
+		db:         db,
+		media:      media,
+		timeline:   timeline,
+		editWindow: cfg.EditWindow,
+		maxEdits:   cfg.MaxEdits,
 	}
 }
 
+// fanOut places a new tweet or retweet on home timelines without delaying the response.
+func (s *service) fanOut(entry entity.TimelineEntry) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := s.timeline.FanOut(ctx, &entry); err != nil {
+			logrus.WithError(err).WithField("tweet_id", entry.TweetID).Warn("fan out to timelines failed")
+		}
+	}()
+}
+
 func (s *service) BuildEntityTweetToResponse(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error) {
-	author, err := s.db.GetUserByID(ctx, tweet.Author.ID)
+	built, err := s.buildTweets(ctx, []entity.Tweet{*tweet}, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(built) == 0 {
+		return nil, errs.ErrTweetNotFound
+	}
+	return &built[0], nil
+}
+
+// BuildEntityTweetsToResponse hydrates authors, avatars, media and counters of a page of tweets
+// with one batched lookup per kind instead of one per tweet. When ctx carries a viewer, the
+// viewer's own interactions with each tweet are resolved as well, and tweets of users on either
+// side of a block with the viewer are dropped. Tweets of private accounts are dropped unless the
+// viewer owns or follows them. Quoted tweets are embedded one level deep.
+func (s *service) BuildEntityTweetsToResponse(ctx context.Context, tweets []entity.Tweet) ([]entity.Tweet, error) {
+	visible, err := s.hideBlocked(ctx, tweets)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildTweets(ctx, visible, true)
+}
+
+// hideBlocked drops tweets whose authors or retweeters blocked the viewer of ctx or were
+// blocked by them. Anonymous requests see everything.
+func (s *service) hideBlocked(ctx context.Context, tweets []entity.Tweet) ([]entity.Tweet, error) {
+	viewerID, ok := entity.ViewerFromContext(ctx)
+	if !ok || len(tweets) == 0 {
+		return tweets, nil
+	}
+
+	blockedIDs, err := s.db.GetBlockedUserIDs(ctx, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+	if len(blockedIDs) == 0 {
+		return tweets, nil
+	}
+
+	blocked := make(map[int]struct{}, len(blockedIDs))
+	for _, id := range blockedIDs {
+		blocked[id] = struct{}{}
+	}
+	res := make([]entity.Tweet, 0, len(tweets))
+	for i := range tweets {
+		if _, ok := blocked[tweets[i].Author.ID]; ok {
+			continue
+		}
+		if tweets[i].RetweetedBy != nil {
+			if _, ok := blocked[tweets[i].RetweetedBy.ID]; ok {
+				continue
+			}
+		}
+		res = append(res, tweets[i])
+	}
+	return res, nil
+}
+
+// checkNotBlocked fails with errs.ErrBlocked when userID and the author of tweet are on
+// either side of a block.
+func (s *service) checkNotBlocked(ctx context.Context, userID int, tweet *entity.Tweet) error {
+	blocked, err := s.db.IsBlockedBetween(ctx, userID, tweet.Author.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check block: %w", err)
+	}
+	if blocked {
+		return errs.ErrBlocked
+	}
+	return nil
+}
+
+// checkNotPrivate fails with errs.ErrTweetNotFound when tweet belongs to a private account
+// that userID neither owns nor follows. A zero userID stands for an anonymous viewer.
+func (s *service) checkNotPrivate(ctx context.Context, userID int, tweet *entity.Tweet) error {
+	authors, err := s.db.GetUsersMapByIDs(ctx, []int{tweet.Author.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get tweet author: %w", err)
+	}
+	visible, err := s.hidePrivate(ctx, userID, []entity.Tweet{*tweet}, authors)
+	if err != nil {
+		return err
+	}
+	if len(visible) == 0 {
+		return errs.ErrTweetNotFound
+	}
+	return nil
+}
+
+// hidePrivate drops tweets of private authors that viewerID neither is nor follows.
+func (s *service) hidePrivate(ctx context.Context, viewerID int, tweets []entity.Tweet, authors map[int]*entity.User) ([]entity.Tweet, error) {
+	privateIDs := make([]int, 0)
+	seen := make(map[int]struct{})
+	for i := range tweets {
+		authorID := tweets[i].Author.ID
+		author, ok := authors[authorID]
+		if !ok || !author.IsPrivate || authorID == viewerID {
+			continue
+		}
+		if _, ok := seen[authorID]; !ok {
+			seen[authorID] = struct{}{}
+			privateIDs = append(privateIDs, authorID)
+		}
+	}
+	if len(privateIDs) == 0 {
+		return tweets, nil
+	}
+
+	if viewerID != 0 {
+		followedIDs, err := s.db.GetFollowedUserIDs(ctx, viewerID, privateIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get followed users: %w", err)
+		}
+		for _, id := range followedIDs {
+			delete(seen, id)
+		}
+	}
+
+	res := make([]entity.Tweet, 0, len(tweets))
+	for i := range tweets {
+		if _, hidden := seen[tweets[i].Author.ID]; !hidden {
+			res = append(res, tweets[i])
+		}
+	}
+	return res, nil
+}
+
+// buildTweets hydrates tweets in the order given, dropping the ones the viewer of ctx
+// may not see because their authors are private.
+func (s *service) buildTweets(ctx context.Context, tweets []entity.Tweet, embedQuotes bool) ([]entity.Tweet, error) {
+	if len(tweets) == 0 {
+		return []entity.Tweet{}, nil
+	}
+
+	authorIDs := make([]int, 0, len(tweets))
+	seenAuthors := make(map[int]struct{}, len(tweets))
+	addAuthor := func(id int) {
+		if _, ok := seenAuthors[id]; !ok {
+			seenAuthors[id] = struct{}{}
+			authorIDs = append(authorIDs, id)
+		}
+	}
+	for i := range tweets {
+		addAuthor(tweets[i].Author.ID)
+		if tweets[i].RetweetedBy != nil {
+			addAuthor(tweets[i].RetweetedBy.ID)
+		}
+	}
+
+	authors, err := s.db.GetUsersMapByIDs(ctx, authorIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tweet author: %w", err)
+		return nil, fmt.Errorf("failed to get tweet authors: %w", err)
 	}
 
-	avatarUrl, err := s.media.GetAvatarUrlByUserID(ctx, tweet.Author.ID)
+	viewerID, _ := entity.ViewerFromContext(ctx)
+	tweets, err = s.hidePrivate(ctx, viewerID, tweets, authors)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user avatar: %w", err)
+		return nil, err
+	}
+	if len(tweets) == 0 {
+		return []entity.Tweet{}, nil
 	}
 
-	mediaUrl, err := s.media.GetMediaUrlByTweetID(ctx, tweet.ID)
+	tweetIDs := make([]int, 0, len(tweets))
+	for i := range tweets {
+		tweetIDs = append(tweetIDs, tweets[i].ID)
+	}
+
+	avatarUrls, err := s.media.GetAvatarUrlsByUserIDs(ctx, authorIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tweet media url: %w", err)
+		return nil, fmt.Errorf("failed to get user avatars: %w", err)
 	}
-	tweet.MediaUrl = mediaUrl
 
-	counts, err := s.db.GetCounts(ctx, tweet.ID)
+	media, err := s.media.GetMediaByTweetIDs(ctx, tweetIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tweet media: %w", err)
+	}
+
+	counts, err := s.db.GetCountsByTweetIDs(ctx, tweetIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get counters: %w", err)
 	}
 
-	return &entity.Tweet{
-		ID:            tweet.ID,
-		ParentTweetID: tweet.ParentTweetID,
-		Content:       tweet.Content,
-		CreatedAt:     tweet.CreatedAt,
-		UpdatedAt:     tweet.UpdatedAt,
-		MediaUrl:      tweet.MediaUrl,
-		Author: &entity.SmallUser{
-			ID:        author.ID,
-			Username:  author.Username,
-			AvatarUrl: avatarUrl,
-		},
-		Counters: counts,
-	}, nil
+	var viewerStates map[int]*entity.ViewerState
+	if viewerID, ok := entity.ViewerFromContext(ctx); ok {
+		viewerStates, err = s.db.GetViewerStates(ctx, viewerID, tweetIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get viewer states: %w", err)
+		}
+	}
+
+	var quoted map[int]*entity.Tweet
+	if embedQuotes {
+		quoted, err = s.buildQuotedTweets(ctx, tweets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]entity.Tweet, 0, len(tweets))
+	for i := range tweets {
+		author, ok := authors[tweets[i].Author.ID]
+		if !ok {
+			return nil, fmt.Errorf("failed to get tweet author: %w", errs.ErrUserNotFound)
+		}
+		counters, ok := counts[tweets[i].ID]
+		if !ok {
+			counters = &entity.Counters{}
+		}
+		res = append(res, entity.Tweet{
+			ID:            tweets[i].ID,
+			ParentTweetID: tweets[i].ParentTweetID,
+			QuotedTweetID: tweets[i].QuotedTweetID,
+			Content:       tweets[i].Content,
+			CreatedAt:     tweets[i].CreatedAt,
+			UpdatedAt:     tweets[i].UpdatedAt,
+			RevisionCount: tweets[i].RevisionCount,
+			Media:         media[tweets[i].ID],
+			Author: &entity.SmallUser{
+				ID:        author.ID,
+				Username:  author.Username,
+				AvatarUrl: avatarUrls[author.ID],
+			},
+			Counters: counters,
+			Viewer:   viewerStates[tweets[i].ID],
+		})
+		if tweets[i].RetweetedBy != nil {
+			if retweeter, ok := authors[tweets[i].RetweetedBy.ID]; ok {
+				res[i].RetweetedBy = &entity.SmallUser{
+					ID:        retweeter.ID,
+					Username:  retweeter.Username,
+					AvatarUrl: avatarUrls[retweeter.ID],
+				}
+			}
+		}
+		if tweets[i].QuotedTweetID != nil {
+			res[i].QuotedTweet = quoted[*tweets[i].QuotedTweetID]
+		}
+	}
+	return res, nil
+}
+
+// buildQuotedTweets loads and hydrates every tweet quoted on the page in one batch.
+func (s *service) buildQuotedTweets(ctx context.Context, tweets []entity.Tweet) (map[int]*entity.Tweet, error) {
+	ids := make([]int, 0)
+	seen := make(map[int]struct{})
+	for i := range tweets {
+		if tweets[i].QuotedTweetID == nil {
+			continue
+		}
+		if _, ok := seen[*tweets[i].QuotedTweetID]; !ok {
+			seen[*tweets[i].QuotedTweetID] = struct{}{}
+			ids = append(ids, *tweets[i].QuotedTweetID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	quotedTweets, err := s.db.GetTweetsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quoted tweets: %w", err)
+	}
+	quotedTweets, err = s.hideBlocked(ctx, quotedTweets)
+	if err != nil {
+		return nil, err
+	}
+	built, err := s.buildTweets(ctx, quotedTweets, false)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int]*entity.Tweet, len(built))
+	for i := range built {
+		res[built[i].ID] = &built[i]
+	}
+	return res, nil
+}
+
+// retract removes a deleted tweet or retweet from home timelines in the background.
+func (s *service) retract(entry entity.TimelineEntry) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := s.timeline.Retract(ctx, &entry); err != nil {
+			logrus.WithError(err).WithField("tweet_id", entry.TweetID).Warn("retract from timelines failed")
+		}
+	}()
 }
@@ -4,14 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"io"
 	"testing"
 	"time"
 
+	"github.com/kust1q/Zapp/backend/internal/config"
 	"github.com/kust1q/Zapp/backend/internal/core/service/tweets"
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/kust1q/Zapp/backend/internal/domain/events"
 	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/kust1q/Zapp/backend/internal/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -45,16 +46,28 @@ func (m *mockTweetStorage) GetTweetById(ctx context.Context, tweetID int) (*enti
 	return tweet.(*entity.Tweet), args.Error(1)
 }
 
-func (m *mockTweetStorage) UpdateTweet(ctx context.Context, tweet *entity.Tweet) (*entity.Tweet, error) {
-	args := m.Called(ctx, tweet)
-	return args.Get(0).(*entity.Tweet), args.Error(1)
+func (m *mockTweetStorage) UpdateTweetTx(ctx context.Context, tx *sql.Tx, tweet *entity.Tweet, maxRevisions int) (*entity.Tweet, error) {
+	args := m.Called(ctx, tx, tweet, maxRevisions)
+	updated, _ := args.Get(0).(*entity.Tweet)
+	return updated, args.Error(1)
 }
 
-func (m *mockTweetStorage) DeleteTweet(ctx context.Context, userID, tweetID int) error {
-	args := m.Called(ctx, userID, tweetID)
+func (m *mockTweetStorage) GetTweetRevisions(ctx context.Context, tweetID int, page *entity.Page) ([]entity.TweetRevision, *entity.Cursor, error) {
+	args := m.Called(ctx, tweetID, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.TweetRevision), next, args.Error(2)
+}
+
+func (m *mockTweetStorage) DeleteTweetTx(ctx context.Context, tx *sql.Tx, userID, tweetID int) error {
+	args := m.Called(ctx, tx, userID, tweetID)
 	return args.Error(0)
 }
 
+func (m *mockTweetStorage) ForceDeleteTweetTx(ctx context.Context, tx *sql.Tx, tweetID int) (int, error) {
+	args := m.Called(ctx, tx, tweetID)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *mockTweetStorage) LikeTweet(ctx context.Context, userID, tweetID int) error {
 	args := m.Called(ctx, userID, tweetID)
 	return args.Error(0)
@@ -75,61 +88,169 @@ func (m *mockTweetStorage) DeleteRetweet(ctx context.Context, userID, retweetID
 	return args.Error(0)
 }
 
-func (m *mockTweetStorage) GetRepliesToTweet(ctx context.Context, parentTweetID, limit, offset int) ([]entity.Tweet, error) {
-	args := m.Called(ctx, parentTweetID, limit, offset)
-	return args.Get(0).([]entity.Tweet), args.Error(1)
+func (m *mockTweetStorage) GetRepliesToTweet(ctx context.Context, parentTweetID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	args := m.Called(ctx, parentTweetID, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.Tweet), next, args.Error(2)
 }
 
-func (m *mockTweetStorage) GetTweetsAndRetweetsByUsername(ctx context.Context, username string, limit, offset int) ([]entity.Tweet, error) {
-	args := m.Called(ctx, username, limit, offset)
-	return args.Get(0).([]entity.Tweet), args.Error(1)
+func (m *mockTweetStorage) GetTweetsAndRetweetsByUsername(ctx context.Context, username string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	args := m.Called(ctx, username, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.Tweet), next, args.Error(2)
 }
 
-func (m *mockTweetStorage) GetCounts(ctx context.Context, tweetID int) (*entity.Counters, error) {
+func (m *mockTweetStorage) GetThreadAncestors(ctx context.Context, tweetID int) ([]entity.Tweet, error) {
 	args := m.Called(ctx, tweetID)
-	counters := args.Get(0)
-	if counters == nil {
-		return nil, args.Error(1)
-	}
-	return counters.(*entity.Counters), args.Error(1)
+	tweets, _ := args.Get(0).([]entity.Tweet)
+	return tweets, args.Error(1)
+}
+
+func (m *mockTweetStorage) GetThreadDescendants(ctx context.Context, tweetID, maxDepth, maxReplies int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	args := m.Called(ctx, tweetID, maxDepth, maxReplies, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.Tweet), next, args.Error(2)
+}
+
+func (m *mockTweetStorage) GetTweetsByIDs(ctx context.Context, ids []int) ([]entity.Tweet, error) {
+	args := m.Called(ctx, ids)
+	tweets, _ := args.Get(0).([]entity.Tweet)
+	return tweets, args.Error(1)
+}
+
+func (m *mockTweetStorage) GetCountsByTweetIDs(ctx context.Context, tweetIDs []int) (map[int]*entity.Counters, error) {
+	args := m.Called(ctx, tweetIDs)
+	counters, _ := args.Get(0).(map[int]*entity.Counters)
+	return counters, args.Error(1)
+}
+
+func (m *mockTweetStorage) GetViewerStates(ctx context.Context, viewerID int, tweetIDs []int) (map[int]*entity.ViewerState, error) {
+	args := m.Called(ctx, viewerID, tweetIDs)
+	states, _ := args.Get(0).(map[int]*entity.ViewerState)
+	return states, args.Error(1)
+}
+
+func (m *mockTweetStorage) GetLikes(ctx context.Context, tweetID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error) {
+	args := m.Called(ctx, tweetID, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.SmallUser), next, args.Error(2)
+}
+
+func (m *mockTweetStorage) GetUsersMapByIDs(ctx context.Context, ids []int) (map[int]*entity.User, error) {
+	args := m.Called(ctx, ids)
+	users, _ := args.Get(0).(map[int]*entity.User)
+	return users, args.Error(1)
+}
+
+func (m *mockTweetStorage) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	args := m.Called(ctx, username)
+	user, _ := args.Get(0).(*entity.User)
+	return user, args.Error(1)
 }
 
-func (m *mockTweetStorage) GetLikes(ctx context.Context, tweetID int, limit, offset int) ([]entity.SmallUser, error) {
-	args := m.Called(ctx, tweetID, limit, offset)
-	return args.Get(0).([]entity.SmallUser), args.Error(1)
+func (m *mockTweetStorage) IsBlockedBetween(ctx context.Context, userID, otherID int) (bool, error) {
+	args := m.Called(ctx, userID, otherID)
+	return args.Bool(0), args.Error(1)
 }
 
-func (m *mockTweetStorage) GetUserByID(ctx context.Context, userID int) (*entity.User, error) {
+func (m *mockTweetStorage) IsFollowing(ctx context.Context, followerID, followingID int) (bool, error) {
+	args := m.Called(ctx, followerID, followingID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockTweetStorage) GetFollowedUserIDs(ctx context.Context, followerID int, userIDs []int) ([]int, error) {
+	args := m.Called(ctx, followerID, userIDs)
+	ids, _ := args.Get(0).([]int)
+	return ids, args.Error(1)
+}
+
+func (m *mockTweetStorage) GetBlockedUserIDs(ctx context.Context, userID int) ([]int, error) {
 	args := m.Called(ctx, userID)
-	user := args.Get(0)
-	if user == nil {
-		return nil, args.Error(1)
-	}
-	return user.(*entity.User), args.Error(1)
+	ids, _ := args.Get(0).([]int)
+	return ids, args.Error(1)
+}
+
+func (m *mockTweetStorage) BookmarkTweet(ctx context.Context, userID, tweetID int, createdAt time.Time) error {
+	args := m.Called(ctx, userID, tweetID, createdAt)
+	return args.Error(0)
+}
+
+func (m *mockTweetStorage) UnbookmarkTweet(ctx context.Context, userID, tweetID int) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *mockTweetStorage) GetBookmarkedTweets(ctx context.Context, userID int, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	args := m.Called(ctx, userID, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	tweets, _ := args.Get(0).([]entity.Tweet)
+	return tweets, next, args.Error(2)
+}
+
+func (m *mockTweetStorage) ReplaceTweetTagsTx(ctx context.Context, tx *sql.Tx, tweetID int, createdAt time.Time, hashtags, mentions []string) ([]entity.SmallUser, error) {
+	args := m.Called(ctx, tx, tweetID, createdAt, hashtags, mentions)
+	users, _ := args.Get(0).([]entity.SmallUser)
+	return users, args.Error(1)
+}
+
+func (m *mockTweetStorage) GetTweetsByHashtag(ctx context.Context, tag string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	args := m.Called(ctx, tag, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	tweets, _ := args.Get(0).([]entity.Tweet)
+	return tweets, next, args.Error(2)
+}
+
+func (m *mockTweetStorage) DeleteDraftTx(ctx context.Context, tx *sql.Tx, userID, draftID int) error {
+	args := m.Called(ctx, tx, userID, draftID)
+	return args.Error(0)
+}
+
+func (m *mockTweetStorage) CreateOutboxEventTx(ctx context.Context, tx *sql.Tx, topic string, event any) error {
+	args := m.Called(ctx, tx, topic, event)
+	return args.Error(0)
 }
 
 type mockMediaService struct {
 	mock.Mock
 }
 
-func (m *mockMediaService) UploadAndAttachTweetMediaTx(ctx context.Context, tweetID int, file io.Reader, filename string, tx *sql.Tx) (string, error) {
-	args := m.Called(ctx, tweetID, file, filename, tx)
-	return args.String(0), args.Error(1)
+func (m *mockMediaService) ValidateTweetAttachments(ctx context.Context, userID int, attachments []entity.Attachment) error {
+	args := m.Called(userID, attachments)
+	return args.Error(0)
 }
 
-func (m *mockMediaService) GetMediaUrlByTweetID(ctx context.Context, tweetID int) (string, error) {
-	args := m.Called(ctx, tweetID)
-	return args.String(0), args.Error(1)
+func (m *mockMediaService) UploadAndAttachTweetMediaTx(ctx context.Context, tweetID, userID int, attachments []entity.Attachment, tx *sql.Tx) ([]entity.TweetMedia, error) {
+	args := m.Called(ctx, tweetID, userID, attachments, tx)
+	media, _ := args.Get(0).([]entity.TweetMedia)
+	return media, args.Error(1)
 }
 
-func (m *mockMediaService) GetAvatarUrlByUserID(ctx context.Context, userID int) (string, error) {
-	args := m.Called(ctx, userID)
-	return args.String(0), args.Error(1)
+func (m *mockMediaService) AttachTweetMediaTx(ctx context.Context, tweetID int, media *entity.UploadedMedia, tx *sql.Tx) ([]entity.TweetMedia, error) {
+	args := m.Called(ctx, tweetID, media, tx)
+	attached, _ := args.Get(0).([]entity.TweetMedia)
+	return attached, args.Error(1)
 }
 
-func (m *mockMediaService) DeleteTweetMedia(ctx context.Context, tweetID, userID int) error {
-	args := m.Called(ctx, tweetID, userID)
-	return args.Error(0)
+func (m *mockMediaService) GetMediaByTweetIDs(ctx context.Context, tweetIDs []int) (map[int][]entity.TweetMedia, error) {
+	args := m.Called(ctx, tweetIDs)
+	media, _ := args.Get(0).(map[int][]entity.TweetMedia)
+	return media, args.Error(1)
+}
+
+func (m *mockMediaService) GetAvatarUrlsByUserIDs(ctx context.Context, userIDs []int) (map[int]string, error) {
+	args := m.Called(ctx, userIDs)
+	urls, _ := args.Get(0).(map[int]string)
+	return urls, args.Error(1)
+}
+
+func (m *mockMediaService) DetachTweetMediaTx(ctx context.Context, tweetID int, tx *sql.Tx) ([]string, error) {
+	args := m.Called(ctx, tweetID, tx)
+	paths, _ := args.Get(0).([]string)
+	return paths, args.Error(1)
+}
+
+func (m *mockMediaService) RemoveDetachedMedia(paths []string) {
+	m.Called(paths)
 }
 
 func (m *mockMediaService) GetPresignedURL(ctx context.Context, path string) (string, error) {
@@ -137,21 +258,32 @@ func (m *mockMediaService) GetPresignedURL(ctx context.Context, path string) (st
 	return args.String(0), args.Error(1)
 }
 
-type mockEventProducer struct {
+type mockTimelineService struct {
 	mock.Mock
 }
 
-func (m *mockEventProducer) Publish(ctx context.Context, topic string, event any) error {
-	args := m.Called(ctx, topic, event)
+func newMockTimelineService() *mockTimelineService {
+	m := &mockTimelineService{}
+	m.On("FanOut", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("Retract", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *mockTimelineService) FanOut(ctx context.Context, entry *entity.TimelineEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *mockTimelineService) Retract(ctx context.Context, entry *entity.TimelineEntry) error {
+	args := m.Called(ctx, entry)
 	return args.Error(0)
 }
 
 func TestService_GetTweetById_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -175,10 +307,10 @@ func TestService_GetTweetById_Success(t *testing.T) {
 	}
 
 	mockDB.On("GetTweetById", mock.Anything, 1).Return(tweet, nil).Once()
-	mockDB.On("GetUserByID", mock.Anything, 1).Return(author, nil).Once()
-	mockDB.On("GetCounts", mock.Anything, 1).Return(counters, nil).Once()
-	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("/avatars/1.jpg", nil).Once()
-	mockMedia.On("GetMediaUrlByTweetID", mock.Anything, 1).Return("", nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{1}).Return(map[int]*entity.User{1: author}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{1}).Return(map[int]*entity.Counters{1: counters}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1}).Return(map[int]string{1: "/avatars/1.jpg"}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{1}).Return(map[int][]entity.TweetMedia{}, nil).Once()
 
 	result, err := service.GetTweetById(ctx, 1)
 
@@ -194,9 +326,8 @@ func TestService_GetTweetById_Success(t *testing.T) {
 func TestService_GetTweetById_NotFound(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -214,108 +345,106 @@ func TestService_GetTweetById_NotFound(t *testing.T) {
 func TestService_DeleteTweet_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
+	tx := mocks.NewTestTx(t)
 
-	mockMedia.On("DeleteTweetMedia", mock.Anything, 1, 1).Return(nil).Once()
-	mockDB.On("DeleteTweet", mock.Anything, 1, 1).Return(nil).Once()
-	mockProducer.On("Publish", mock.Anything, events.TopicTweet, mock.AnythingOfType("events.TweetDeleted")).Return(nil).Once()
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockMedia.On("DetachTweetMediaTx", mock.Anything, 1, tx).Return([]string{"images/a.png"}, nil).Once()
+	mockDB.On("DeleteTweetTx", mock.Anything, tx, 1, 1).Return(nil).Once()
+	mockDB.On("CreateOutboxEventTx", mock.Anything, tx, events.TopicTweet, events.TweetDeleted{EventType: events.TweetDeleteEvent, ID: 1}).Return(nil).Once()
+	mockMedia.On("RemoveDetachedMedia", []string{"images/a.png"}).Once()
 
 	err := service.DeleteTweet(ctx, 1, 1)
 
 	assert.NoError(t, err)
 
-	time.Sleep(100 * time.Millisecond)
-
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
-	mockProducer.AssertExpectations(t)
 }
 
-func TestService_DeleteTweet_MediaNotFound(t *testing.T) {
+func TestService_ForceDeleteTweet_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
+	tx := mocks.NewTestTx(t)
 
-	mockMedia.On("DeleteTweetMedia", mock.Anything, 1, 1).Return(errs.ErrTweetMediaNotFound).Once()
-	mockDB.On("DeleteTweet", mock.Anything, 1, 1).Return(nil).Once()
-	mockProducer.On("Publish", mock.Anything, events.TopicTweet, mock.AnythingOfType("events.TweetDeleted")).Return(nil).Once()
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockMedia.On("DetachTweetMediaTx", mock.Anything, 1, tx).Return([]string{}, nil).Once()
+	mockDB.On("ForceDeleteTweetTx", mock.Anything, tx, 1).Return(2, nil).Once()
+	mockDB.On("CreateOutboxEventTx", mock.Anything, tx, events.TopicTweet, events.TweetDeleted{EventType: events.TweetDeleteEvent, ID: 1}).Return(nil).Once()
 
-	err := service.DeleteTweet(ctx, 1, 1)
+	err := service.ForceDeleteTweet(ctx, 1)
 
 	assert.NoError(t, err)
 
-	time.Sleep(100 * time.Millisecond)
-
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
-	mockProducer.AssertExpectations(t)
+	mockMedia.AssertNotCalled(t, "RemoveDetachedMedia", mock.Anything)
 }
 
-func TestService_DeleteTweet_OtherMediaError(t *testing.T) {
+func TestService_DeleteTweet_MediaError(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
+	tx := mocks.NewTestTx(t)
 
-	mockMedia.On("DeleteTweetMedia", mock.Anything, 1, 1).Return(errors.New("other media error")).Once()
-	mockDB.On("DeleteTweet", mock.Anything, 1, 1).Return(nil).Once()
-	mockProducer.On("Publish", mock.Anything, events.TopicTweet, mock.AnythingOfType("events.TweetDeleted")).Return(nil).Once()
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockMedia.On("DetachTweetMediaTx", mock.Anything, 1, tx).Return(nil, errors.New("media error")).Once()
 
 	err := service.DeleteTweet(ctx, 1, 1)
 
-	assert.NoError(t, err)
-
-	time.Sleep(100 * time.Millisecond)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "media error")
 
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
-	mockProducer.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "DeleteTweetTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockDB.AssertNotCalled(t, "CreateOutboxEventTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestService_DeleteTweet_DBError(t *testing.T) {
+func TestService_DeleteTweet_NotFoundKeepsMedia(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
+	tx := mocks.NewTestTx(t)
 
-	mockMedia.On("DeleteTweetMedia", mock.Anything, 1, 1).Return(nil).Once()
-	mockDB.On("DeleteTweet", mock.Anything, 1, 1).Return(errors.New("database error")).Once()
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockMedia.On("DetachTweetMediaTx", mock.Anything, 1, tx).Return([]string{"images/a.png"}, nil).Once()
+	mockDB.On("DeleteTweetTx", mock.Anything, tx, 2, 1).Return(errs.ErrTweetNotFound).Once()
 
-	err := service.DeleteTweet(ctx, 1, 1)
+	err := service.DeleteTweet(ctx, 2, 1)
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "database error")
-
-	time.Sleep(100 * time.Millisecond)
+	assert.ErrorIs(t, err, errs.ErrTweetNotFound)
 
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
-	mockProducer.AssertExpectations(t)
+	mockMedia.AssertNotCalled(t, "RemoveDetachedMedia", mock.Anything)
+	mockDB.AssertNotCalled(t, "CreateOutboxEventTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestService_LikeTweet_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(false, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{2}).Return(map[int]*entity.User{2: {ID: 2}}, nil).Once()
 	mockDB.On("LikeTweet", mock.Anything, 1, 1).Return(nil).Once()
 
 	err := service.LikeTweet(ctx, 1, 1)
@@ -328,13 +457,12 @@ func TestService_LikeTweet_Success(t *testing.T) {
 func TestService_LikeTweet_TweetNotFound(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
-	mockDB.On("LikeTweet", mock.Anything, 1, 1).Return(errs.ErrTweetNotFound).Once()
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(nil, errs.ErrTweetNotFound).Once()
 
 	err := service.LikeTweet(ctx, 1, 1)
 
@@ -344,12 +472,201 @@ func TestService_LikeTweet_TweetNotFound(t *testing.T) {
 	mockDB.AssertExpectations(t)
 }
 
+func TestService_LikeTweet_Blocked(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(true, nil).Once()
+
+	err := service.LikeTweet(context.Background(), 1, 1)
+
+	assert.ErrorIs(t, err, errs.ErrBlocked)
+	mockDB.AssertNotCalled(t, "LikeTweet", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_CreateTweet_ReplyBlocked(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	parentID := 5
+	mockDB.On("GetTweetById", mock.Anything, parentID).Return(&entity.Tweet{ID: parentID, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(true, nil).Once()
+
+	_, err := service.CreateTweet(context.Background(), &entity.Tweet{
+		ParentTweetID: &parentID,
+		Content:       "reply",
+		Author:        &entity.SmallUser{ID: 1},
+	})
+
+	assert.ErrorIs(t, err, errs.ErrBlocked)
+	mockDB.AssertNotCalled(t, "BeginTx", mock.Anything)
+}
+
+func TestService_CreateTweet_QuoteBlocked(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	quotedID := 5
+	mockDB.On("GetTweetById", mock.Anything, quotedID).Return(&entity.Tweet{ID: quotedID, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(true, nil).Once()
+
+	_, err := service.CreateTweet(context.Background(), &entity.Tweet{
+		QuotedTweetID: &quotedID,
+		Content:       "quote",
+		Author:        &entity.SmallUser{ID: 1},
+	})
+
+	assert.ErrorIs(t, err, errs.ErrBlocked)
+	mockDB.AssertNotCalled(t, "GetUsersMapByIDs", mock.Anything, mock.Anything)
+	mockDB.AssertNotCalled(t, "BeginTx", mock.Anything)
+}
+
+func TestService_CreateTweet_DraftAlreadyPublished(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	tx := mocks.NewTestTx(t)
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("DeleteDraftTx", mock.Anything, tx, 1, 7).Return(errs.ErrDraftNotFound).Once()
+
+	_, err := service.CreateTweet(context.Background(), &entity.Tweet{
+		Content: "scheduled",
+		DraftID: 7,
+		Author:  &entity.SmallUser{ID: 1},
+	})
+
+	assert.ErrorIs(t, err, errs.ErrDraftNotFound)
+	mockDB.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "CreateTweetTx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_CreateTweet_InvalidAttachments(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	attachments := []entity.Attachment{{AltText: "a"}, {AltText: "b"}}
+	tx := mocks.NewTestTx(t)
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("CreateTweetTx", mock.Anything, tx, mock.Anything).Return(&entity.Tweet{ID: 3, Author: &entity.SmallUser{ID: 1}}, nil).Once()
+	mockMedia.On("UploadAndAttachTweetMediaTx", mock.Anything, 3, 1, attachments, tx).Return(nil, errs.ErrMixedAttachments).Once()
+
+	_, err := service.CreateTweet(context.Background(), &entity.Tweet{
+		Content:     "two files",
+		Attachments: attachments,
+		Author:      &entity.SmallUser{ID: 1},
+	})
+
+	assert.ErrorIs(t, err, errs.ErrMixedAttachments)
+	mockMedia.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "CreateOutboxEventTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_BuildEntityTweetsToResponse_HidesBlockedAuthors(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	ctx := entity.WithViewer(context.Background(), 1)
+	page := []entity.Tweet{
+		{ID: 10, Author: &entity.SmallUser{ID: 2}},
+		{ID: 11, Author: &entity.SmallUser{ID: 3}},
+	}
+
+	mockDB.On("GetBlockedUserIDs", mock.Anything, 1).Return([]int{2}, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{3}).Return(map[int]*entity.User{3: {ID: 3, Username: "visible"}}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{3}).Return(map[int]string{}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{11}).Return(map[int][]entity.TweetMedia{}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{11}).Return(map[int]*entity.Counters{}, nil).Once()
+	mockDB.On("GetViewerStates", mock.Anything, 1, []int{11}).Return(map[int]*entity.ViewerState{}, nil).Once()
+
+	built, err := service.BuildEntityTweetsToResponse(ctx, page)
+
+	assert.NoError(t, err)
+	if assert.Len(t, built, 1) {
+		assert.Equal(t, 11, built[0].ID)
+	}
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_BuildEntityTweetsToResponse_HidesPrivateAuthorsFromNonFollowers(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	ctx := entity.WithViewer(context.Background(), 1)
+	page := []entity.Tweet{
+		{ID: 10, Author: &entity.SmallUser{ID: 2}},
+		{ID: 11, Author: &entity.SmallUser{ID: 3}},
+		{ID: 12, Author: &entity.SmallUser{ID: 4}},
+	}
+
+	mockDB.On("GetBlockedUserIDs", mock.Anything, 1).Return([]int{}, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{2, 3, 4}).Return(map[int]*entity.User{
+		2: {ID: 2, Username: "followed", IsPrivate: true},
+		3: {ID: 3, Username: "stranger", IsPrivate: true},
+		4: {ID: 4, Username: "public"},
+	}, nil).Once()
+	mockDB.On("GetFollowedUserIDs", mock.Anything, 1, []int{2, 3}).Return([]int{2}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{2, 3, 4}).Return(map[int]string{}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{10, 12}).Return(map[int][]entity.TweetMedia{}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{10, 12}).Return(map[int]*entity.Counters{}, nil).Once()
+	mockDB.On("GetViewerStates", mock.Anything, 1, []int{10, 12}).Return(map[int]*entity.ViewerState{}, nil).Once()
+
+	built, err := service.BuildEntityTweetsToResponse(ctx, page)
+
+	assert.NoError(t, err)
+	if assert.Len(t, built, 2) {
+		assert.Equal(t, 10, built[0].ID)
+		assert.Equal(t, 12, built[1].ID)
+	}
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_GetTweetById_PrivateHiddenFromAnonymous(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{2}).Return(map[int]*entity.User{2: {ID: 2, IsPrivate: true}}, nil).Once()
+
+	result, err := service.GetTweetById(context.Background(), 1)
+
+	assert.ErrorIs(t, err, errs.ErrTweetNotFound)
+	assert.Nil(t, result)
+	mockDB.AssertNotCalled(t, "GetFollowedUserIDs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_LikeTweet_PrivateNotFollowed(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 3, 2).Return(false, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{2}).Return(map[int]*entity.User{2: {ID: 2, IsPrivate: true}}, nil).Once()
+	mockDB.On("GetFollowedUserIDs", mock.Anything, 3, []int{2}).Return([]int{}, nil).Once()
+
+	err := service.LikeTweet(context.Background(), 3, 1)
+
+	assert.ErrorIs(t, err, errs.ErrTweetNotFound)
+	mockDB.AssertNotCalled(t, "LikeTweet", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestService_UnlikeTweet_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -365,9 +682,8 @@ func TestService_UnlikeTweet_Success(t *testing.T) {
 func TestService_GetTweetsAndRetweetsByUsername_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -399,19 +715,24 @@ func TestService_GetTweetsAndRetweetsByUsername_Success(t *testing.T) {
 		LikeCount:    0,
 	}
 
-	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", 10, 0).Return(tweets, nil).Once()
-	mockDB.On("GetUserByID", mock.Anything, 1).Return(author, nil).Times(2)
-	mockDB.On("GetCounts", mock.Anything, mock.AnythingOfType("int")).Return(counters, nil).Times(2)
-	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("/avatars/1.jpg", nil).Times(2)
-	mockMedia.On("GetMediaUrlByTweetID", mock.Anything, mock.AnythingOfType("int")).Return("", nil).Times(2)
+	mockDB.On("GetUserByUsername", mock.Anything, "testuser").Return(author, nil).Once()
+	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return(tweets, nil, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{1}).Return(map[int]*entity.User{1: author}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{1, 2}).Return(map[int]*entity.Counters{1: counters, 2: counters}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1}).Return(map[int]string{1: "/avatars/1.jpg"}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{1, 2}).Return(map[int][]entity.TweetMedia{2: {{ID: 5, TweetID: 2, Path: "/media/2.jpg"}}}, nil).Once()
 
-	result, err := service.GetTweetsAndRetweetsByUsername(ctx, "testuser", 10, 0)
+	result, _, err := service.GetTweetsAndRetweetsByUsername(ctx, "testuser", &entity.Page{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, result, 2)
 	assert.Equal(t, "Tweet 1", result[0].Content)
 	assert.Equal(t, "Tweet 2", result[1].Content)
+	assert.Equal(t, "/avatars/1.jpg", result[1].Author.AvatarUrl)
+	assert.Empty(t, result[0].Media)
+	assert.Len(t, result[1].Media, 1)
+	assert.Equal(t, "/media/2.jpg", result[1].Media[0].Path)
 
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
@@ -420,15 +741,15 @@ func TestService_GetTweetsAndRetweetsByUsername_Success(t *testing.T) {
 func TestService_GetTweetsAndRetweetsByUsername_NoRows(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
-	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", 10, 0).Return([]entity.Tweet{}, sql.ErrNoRows).Once()
+	mockDB.On("GetUserByUsername", mock.Anything, "testuser").Return(&entity.User{ID: 1, Username: "testuser"}, nil).Once()
+	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return([]entity.Tweet{}, nil, sql.ErrNoRows).Once()
 
-	result, err := service.GetTweetsAndRetweetsByUsername(ctx, "testuser", 10, 0)
+	result, _, err := service.GetTweetsAndRetweetsByUsername(ctx, "testuser", &entity.Page{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -440,9 +761,8 @@ func TestService_GetTweetsAndRetweetsByUsername_NoRows(t *testing.T) {
 func TestService_GetRepliesToTweet_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -467,13 +787,15 @@ func TestService_GetRepliesToTweet_Success(t *testing.T) {
 		LikeCount:    0,
 	}
 
-	mockDB.On("GetRepliesToTweet", mock.Anything, 1, 10, 0).Return(replies, nil).Once()
-	mockDB.On("GetUserByID", mock.Anything, 2).Return(author, nil).Once()
-	mockDB.On("GetCounts", mock.Anything, 2).Return(counters, nil).Once()
-	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 2).Return("/avatars/2.jpg", nil).Once()
-	mockMedia.On("GetMediaUrlByTweetID", mock.Anything, 2).Return("", nil).Once()
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 1}}, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{1}).Return(map[int]*entity.User{1: {ID: 1}}, nil).Once()
+	mockDB.On("GetRepliesToTweet", mock.Anything, 1, &entity.Page{Limit: 10}).Return(replies, nil, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{2}).Return(map[int]*entity.User{2: author}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{2}).Return(map[int]*entity.Counters{2: counters}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{2}).Return(map[int]string{2: "/avatars/2.jpg"}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{2}).Return(map[int][]entity.TweetMedia{}, nil).Once()
 
-	result, err := service.GetRepliesToTweet(ctx, 1, 10, 0)
+	result, _, err := service.GetRepliesToTweet(ctx, 1, &entity.Page{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -484,12 +806,52 @@ func TestService_GetRepliesToTweet_Success(t *testing.T) {
 	mockMedia.AssertExpectations(t)
 }
 
+func TestService_GetThread_Success(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	ctx := context.Background()
+	page := &entity.Page{Limit: 10}
+
+	rootID, focalID, replyID := 1, 2, 3
+	focal := &entity.Tweet{ID: 2, ParentTweetID: &rootID, Content: "Focal", Author: &entity.SmallUser{ID: 1}}
+	ancestors := []entity.Tweet{{ID: 1, Content: "Root", Author: &entity.SmallUser{ID: 1}}}
+	descendants := []entity.Tweet{
+		{ID: 3, ParentTweetID: &focalID, Content: "Reply", Author: &entity.SmallUser{ID: 1}},
+		{ID: 4, ParentTweetID: &replyID, Content: "Nested", Author: &entity.SmallUser{ID: 1}},
+	}
+
+	mockDB.On("GetTweetById", mock.Anything, 2).Return(focal, nil).Once()
+	mockDB.On("GetThreadAncestors", mock.Anything, 2).Return(ancestors, nil).Once()
+	mockDB.On("GetThreadDescendants", mock.Anything, 2, 3, 5, page).Return(descendants, nil, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{1}).Return(map[int]*entity.User{1: {ID: 1, Username: "testuser"}}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1}).Return(map[int]string{}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{1, 2, 3, 4}).Return(map[int][]entity.TweetMedia{}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{1, 2, 3, 4}).Return(map[int]*entity.Counters{}, nil).Once()
+
+	thread, next, err := service.GetThread(ctx, 2, 0, page)
+
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Len(t, thread.Ancestors, 1)
+	assert.Equal(t, "Root", thread.Ancestors[0].Content)
+	assert.Equal(t, 2, thread.Tweet.ID)
+	assert.Len(t, thread.Replies, 1)
+	assert.Equal(t, 3, thread.Replies[0].Tweet.ID)
+	assert.Len(t, thread.Replies[0].Replies, 1)
+	assert.Equal(t, 4, thread.Replies[0].Replies[0].Tweet.ID)
+
+	mockDB.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+}
+
 func TestService_GetLikes_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -504,11 +866,12 @@ func TestService_GetLikes_Success(t *testing.T) {
 		},
 	}
 
-	mockDB.On("GetLikes", mock.Anything, 1, 10, 0).Return(users, nil).Once()
-	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("/avatars/1.jpg", nil).Once()
-	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 2).Return("/avatars/2.jpg", nil).Once()
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 3}}, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{3}).Return(map[int]*entity.User{3: {ID: 3}}, nil).Once()
+	mockDB.On("GetLikes", mock.Anything, 1, &entity.Page{Limit: 10}).Return(users, nil, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1, 2}).Return(map[int]string{1: "/avatars/1.jpg", 2: "/avatars/2.jpg"}, nil).Once()
 
-	result, err := service.GetLikes(ctx, 1, 10, 0)
+	result, _, err := service.GetLikes(ctx, 1, &entity.Page{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -525,12 +888,14 @@ func TestService_GetLikes_Success(t *testing.T) {
 func TestService_CreateRetweet_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(false, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{2}).Return(map[int]*entity.User{2: {ID: 2}}, nil).Once()
 	mockDB.On("Retweet", mock.Anything, 1, 1, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 	err := service.CreateRetweet(ctx, 1, 1)
@@ -540,12 +905,90 @@ func TestService_CreateRetweet_Success(t *testing.T) {
 	mockDB.AssertExpectations(t)
 }
 
+func TestService_CreateRetweet_PrivateTweetOfFollowedAccount(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 3}}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 3).Return(false, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{3}).Return(map[int]*entity.User{3: {ID: 3, IsPrivate: true}}, nil).Once()
+	mockDB.On("GetFollowedUserIDs", mock.Anything, 1, []int{3}).Return([]int{3}, nil).Once()
+
+	err := service.CreateRetweet(context.Background(), 1, 1)
+
+	assert.ErrorIs(t, err, errs.ErrPrivateRetweet)
+
+	mockDB.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "Retweet", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_CreateRetweet_Blocked(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 2}}, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(true, nil).Once()
+
+	err := service.CreateRetweet(context.Background(), 1, 1)
+
+	assert.ErrorIs(t, err, errs.ErrBlocked)
+
+	mockDB.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "Retweet", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_GetTweetsAndRetweetsByUsername_HidesPrivateRetweet(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	// User 1 follows the private user 3 and retweeted one of their tweets; viewer 4 follows neither.
+	ctx := entity.WithViewer(context.Background(), 4)
+	follower := &entity.User{ID: 1, Username: "follower"}
+	rows := []entity.Tweet{
+		{ID: 10, Content: "own", Author: &entity.SmallUser{ID: 1}},
+		{ID: 11, Content: "private", Author: &entity.SmallUser{ID: 3}, RetweetedBy: &entity.SmallUser{ID: 1}},
+		{ID: 12, Content: "public", Author: &entity.SmallUser{ID: 2}, RetweetedBy: &entity.SmallUser{ID: 1}},
+	}
+	users := map[int]*entity.User{
+		1: follower,
+		2: {ID: 2, Username: "public"},
+		3: {ID: 3, Username: "private", IsPrivate: true},
+	}
+
+	mockDB.On("GetUserByUsername", mock.Anything, "follower").Return(follower, nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 4, 1).Return(false, nil).Once()
+	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "follower", &entity.Page{Limit: 10}).Return(rows, nil, nil).Once()
+	mockDB.On("GetBlockedUserIDs", mock.Anything, 4).Return([]int{}, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{1, 3, 2}).Return(users, nil).Once()
+	mockDB.On("GetFollowedUserIDs", mock.Anything, 4, []int{3}).Return([]int{}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{10, 12}).Return(map[int]*entity.Counters{}, nil).Once()
+	mockDB.On("GetViewerStates", mock.Anything, 4, []int{10, 12}).Return(map[int]*entity.ViewerState{}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1, 3, 2}).Return(map[int]string{1: "/avatars/1.jpg"}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{10, 12}).Return(map[int][]entity.TweetMedia{}, nil).Once()
+
+	result, _, err := service.GetTweetsAndRetweetsByUsername(ctx, "follower", &entity.Page{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, 10, result[0].ID)
+	assert.Nil(t, result[0].RetweetedBy)
+	assert.Equal(t, 12, result[1].ID)
+	assert.Equal(t, "public", result[1].Author.Username)
+	assert.Equal(t, &entity.SmallUser{ID: 1, Username: "follower", AvatarUrl: "/avatars/1.jpg"}, result[1].RetweetedBy)
+
+	mockDB.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+}
+
 func TestService_DeleteRetweet_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -561,9 +1004,8 @@ func TestService_DeleteRetweet_Success(t *testing.T) {
 func TestService_UpdateTweet_NotFound(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -589,9 +1031,8 @@ func TestService_UpdateTweet_NotFound(t *testing.T) {
 func TestService_UpdateTweet_Unauthorized(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -622,12 +1063,116 @@ func TestService_UpdateTweet_Unauthorized(t *testing.T) {
 	mockDB.AssertExpectations(t)
 }
 
+func TestService_UpdateTweet_EditWindowClosed(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{EditWindow: time.Hour}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	existingTweet := &entity.Tweet{
+		ID:        1,
+		Content:   "Old content",
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+		Author:    &entity.SmallUser{ID: 1},
+	}
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(existingTweet, nil).Once()
+
+	result, err := service.UpdateTweet(context.Background(), &entity.Tweet{ID: 1, Content: "Updated content", Author: &entity.SmallUser{ID: 1}})
+
+	assert.ErrorIs(t, err, errs.ErrEditWindowClosed)
+	assert.Nil(t, result)
+	mockDB.AssertNotCalled(t, "BeginTx", mock.Anything)
+}
+
+func TestService_UpdateTweet_EditLimitReached(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{MaxEdits: 2}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	existingTweet := &entity.Tweet{
+		ID:        1,
+		Content:   "Old content",
+		CreatedAt: time.Now(),
+		Author:    &entity.SmallUser{ID: 1},
+	}
+	tx := mocks.NewTestTx(t)
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(existingTweet, nil).Once()
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	// The cached tweet is stale; the locked row already has two revisions.
+	mockDB.On("UpdateTweetTx", mock.Anything, tx, existingTweet, 2).Return(nil, errs.ErrEditLimitReached).Once()
+
+	result, err := service.UpdateTweet(context.Background(), &entity.Tweet{ID: 1, Content: "Updated content", Author: &entity.SmallUser{ID: 1}})
+
+	assert.ErrorIs(t, err, errs.ErrEditLimitReached)
+	assert.Nil(t, result)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_UpdateTweet_InvalidAttachmentsKeepMedia(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	attachments := []entity.Attachment{{}, {}, {}, {}, {}}
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, CreatedAt: time.Now(), Author: &entity.SmallUser{ID: 1}}, nil).Once()
+	mockMedia.On("ValidateTweetAttachments", 1, attachments).Return(errs.ErrTooManyAttachments).Once()
+
+	result, err := service.UpdateTweet(context.Background(), &entity.Tweet{ID: 1, Content: "new files", Attachments: attachments, Author: &entity.SmallUser{ID: 1}})
+
+	assert.ErrorIs(t, err, errs.ErrTooManyAttachments)
+	assert.Nil(t, result)
+	mockMedia.AssertNotCalled(t, "DetachTweetMediaTx", mock.Anything, mock.Anything, mock.Anything)
+	mockDB.AssertNotCalled(t, "BeginTx", mock.Anything)
+}
+
+func TestService_UpdateTweet_FailedUploadKeepsOldMedia(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	attachments := []entity.Attachment{{UploadID: 5}}
+	existingTweet := &entity.Tweet{ID: 1, CreatedAt: time.Now(), Author: &entity.SmallUser{ID: 1}}
+	tx := mocks.NewTestTx(t)
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(existingTweet, nil).Once()
+	mockMedia.On("ValidateTweetAttachments", 1, attachments).Return(nil).Once()
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("UpdateTweetTx", mock.Anything, tx, existingTweet, 0).Return(existingTweet, nil).Once()
+	mockMedia.On("DetachTweetMediaTx", mock.Anything, 1, tx).Return([]string{"image/old.png"}, nil).Once()
+	mockMedia.On("UploadAndAttachTweetMediaTx", mock.Anything, 1, 1, attachments, tx).Return(nil, errs.ErrUploadNotFound).Once()
+
+	result, err := service.UpdateTweet(context.Background(), &entity.Tweet{ID: 1, Content: "new file", Attachments: attachments, Author: &entity.SmallUser{ID: 1}})
+
+	assert.ErrorIs(t, err, errs.ErrUploadNotFound)
+	assert.Nil(t, result)
+	mockMedia.AssertExpectations(t)
+	mockMedia.AssertNotCalled(t, "RemoveDetachedMedia", mock.Anything)
+}
+
+func TestService_GetTweetHistory_Success(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, &mockMediaService{}, newMockTimelineService())
+
+	page := &entity.Page{Limit: 20}
+	revisions := []entity.TweetRevision{{ID: 2, TweetID: 1, Content: "second"}, {ID: 1, TweetID: 1, Content: "first"}}
+	mockDB.On("GetTweetById", mock.Anything, 1).Return(&entity.Tweet{ID: 1, Author: &entity.SmallUser{ID: 3}}, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{3}).Return(map[int]*entity.User{3: {ID: 3}}, nil).Maybe()
+	mockDB.On("GetTweetRevisions", mock.Anything, 1, page).Return(revisions, nil, nil).Once()
+
+	result, next, err := service.GetTweetHistory(context.Background(), 1, page)
+
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, revisions, result)
+	mockDB.AssertExpectations(t)
+}
+
 func TestService_BuildEntityTweetToResponse_Success(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -650,10 +1195,10 @@ func TestService_BuildEntityTweetToResponse_Success(t *testing.T) {
 		LikeCount:    0,
 	}
 
-	mockDB.On("GetUserByID", mock.Anything, 1).Return(author, nil).Once()
-	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("/avatars/1.jpg", nil).Once()
-	mockMedia.On("GetMediaUrlByTweetID", mock.Anything, 1).Return("/media/test.jpg", nil).Once()
-	mockDB.On("GetCounts", mock.Anything, 1).Return(counters, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{1}).Return(map[int]*entity.User{1: author}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1}).Return(map[int]string{1: "/avatars/1.jpg"}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{1}).Return(map[int][]entity.TweetMedia{1: {{ID: 3, TweetID: 1, Path: "/media/test.jpg", AltText: "a cat"}}}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{1}).Return(map[int]*entity.Counters{1: counters}, nil).Once()
 
 	result, err := service.BuildEntityTweetToResponse(ctx, tweet)
 
@@ -661,8 +1206,87 @@ func TestService_BuildEntityTweetToResponse_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, "testuser", result.Author.Username)
 	assert.Equal(t, "/avatars/1.jpg", result.Author.AvatarUrl)
-	assert.Equal(t, "/media/test.jpg", result.MediaUrl)
+	assert.Len(t, result.Media, 1)
+	assert.Equal(t, "/media/test.jpg", result.Media[0].Path)
+	assert.Equal(t, "a cat", result.Media[0].AltText)
 	assert.Equal(t, 0, result.Counters.LikeCount)
+	assert.Nil(t, result.Viewer)
+
+	mockDB.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+}
+
+func TestService_BuildEntityTweetsToResponse_WithViewer(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	ctx := entity.WithViewer(context.Background(), 7)
+
+	list := []entity.Tweet{
+		{ID: 1, Content: "Tweet 1", Author: &entity.SmallUser{ID: 1}},
+		{ID: 2, Content: "Tweet 2", Author: &entity.SmallUser{ID: 1}},
+	}
+
+	mockDB.On("GetBlockedUserIDs", mock.Anything, 7).Return([]int{}, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{1}).Return(map[int]*entity.User{1: {ID: 1, Username: "testuser"}}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1}).Return(map[int]string{}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{1, 2}).Return(map[int][]entity.TweetMedia{}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{1, 2}).Return(map[int]*entity.Counters{}, nil).Once()
+	mockDB.On("GetViewerStates", mock.Anything, 7, []int{1, 2}).Return(map[int]*entity.ViewerState{
+		1: {Liked: true},
+		2: {Retweeted: true},
+	}, nil).Once()
+
+	result, err := service.BuildEntityTweetsToResponse(ctx, list)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.True(t, result[0].Viewer.Liked)
+	assert.False(t, result[0].Viewer.Retweeted)
+	assert.True(t, result[1].Viewer.Retweeted)
+	assert.NotNil(t, result[1].Counters)
+
+	mockDB.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+}
+
+func TestService_BuildEntityTweetsToResponse_EmbedsQuotedTweet(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	ctx := context.Background()
+
+	quotedID := 5
+	list := []entity.Tweet{
+		{ID: 1, Content: "Quote", QuotedTweetID: &quotedID, Author: &entity.SmallUser{ID: 1}},
+	}
+
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{1}).Return(map[int]*entity.User{1: {ID: 1, Username: "quoter"}}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{1}).Return(map[int]string{}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{1}).Return(map[int][]entity.TweetMedia{}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{1}).Return(map[int]*entity.Counters{}, nil).Once()
+
+	mockDB.On("GetTweetsByIDs", mock.Anything, []int{5}).Return([]entity.Tweet{
+		{ID: 5, Content: "Original", Author: &entity.SmallUser{ID: 2}},
+	}, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{2}).Return(map[int]*entity.User{2: {ID: 2, Username: "author"}}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{2}).Return(map[int]string{}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{5}).Return(map[int][]entity.TweetMedia{}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{5}).Return(map[int]*entity.Counters{5: {QuoteCount: 1}}, nil).Once()
+
+	result, err := service.BuildEntityTweetsToResponse(ctx, list)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, &quotedID, result[0].QuotedTweetID)
+	assert.NotNil(t, result[0].QuotedTweet)
+	assert.Equal(t, "Original", result[0].QuotedTweet.Content)
+	assert.Equal(t, "author", result[0].QuotedTweet.Author.Username)
+	assert.Equal(t, 1, result[0].QuotedTweet.Counters.QuoteCount)
 
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
@@ -671,9 +1295,8 @@ func TestService_BuildEntityTweetToResponse_Success(t *testing.T) {
 func TestService_BuildEntityTweetToResponse_UserNotFound(t *testing.T) {
 	mockDB := &mockTweetStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := tweets.NewTweetService(mockDB, mockMedia, mockProducer)
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
 
 	ctx := context.Background()
 
@@ -685,7 +1308,7 @@ func TestService_BuildEntityTweetToResponse_UserNotFound(t *testing.T) {
 		},
 	}
 
-	mockDB.On("GetUserByID", mock.Anything, 1).Return(nil, errors.New("user not found")).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{1}).Return(nil, errors.New("user not found")).Once()
 
 	result, err := service.BuildEntityTweetToResponse(ctx, tweet)
 
@@ -695,3 +1318,111 @@ func TestService_BuildEntityTweetToResponse_UserNotFound(t *testing.T) {
 
 	mockDB.AssertExpectations(t)
 }
+
+func TestService_BookmarkTweet_TweetNotFound(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	ctx := context.Background()
+
+	mockDB.On("GetTweetById", mock.Anything, 2).Return(nil, errs.ErrTweetNotFound).Once()
+
+	err := service.BookmarkTweet(ctx, 1, 2)
+
+	assert.ErrorIs(t, err, errs.ErrTweetNotFound)
+
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_UnbookmarkTweet_NotFound(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	ctx := context.Background()
+
+	mockDB.On("UnbookmarkTweet", mock.Anything, 1, 2).Return(errs.ErrBookmarkNotFound).Once()
+
+	err := service.UnbookmarkTweet(ctx, 1, 2)
+
+	assert.ErrorIs(t, err, errs.ErrBookmarkNotFound)
+
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_GetBookmarks_Success(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	ctx := context.Background()
+
+	bookmarked := []entity.Tweet{
+		{ID: 5, Content: "Saved", Author: &entity.SmallUser{ID: 3}},
+	}
+	next := &entity.Cursor{CreatedAt: time.Now(), ID: 5}
+
+	mockDB.On("GetBookmarkedTweets", mock.Anything, 1, &entity.Page{Limit: 1}).Return(bookmarked, next, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{3}).Return(map[int]*entity.User{3: {ID: 3, Username: "author"}}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{5}).Return(map[int]*entity.Counters{5: {BookmarkCount: 1}}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{3}).Return(map[int]string{}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{5}).Return(map[int][]entity.TweetMedia{}, nil).Once()
+
+	result, cursor, err := service.GetBookmarks(ctx, 1, &entity.Page{Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "author", result[0].Author.Username)
+	assert.Equal(t, 1, result[0].Counters.BookmarkCount)
+	assert.Equal(t, next, cursor)
+
+	mockDB.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+}
+
+func TestService_GetTweetsByHashtag_NormalizesTag(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	ctx := context.Background()
+
+	tagged := []entity.Tweet{
+		{ID: 7, Content: "Learning #Go today", Author: &entity.SmallUser{ID: 2}},
+	}
+
+	mockDB.On("GetTweetsByHashtag", mock.Anything, "go", &entity.Page{Limit: 10}).Return(tagged, nil, nil).Once()
+	mockDB.On("GetUsersMapByIDs", mock.Anything, []int{2}).Return(map[int]*entity.User{2: {ID: 2, Username: "gopher"}}, nil).Once()
+	mockDB.On("GetCountsByTweetIDs", mock.Anything, []int{7}).Return(map[int]*entity.Counters{}, nil).Once()
+	mockMedia.On("GetAvatarUrlsByUserIDs", mock.Anything, []int{2}).Return(map[int]string{}, nil).Once()
+	mockMedia.On("GetMediaByTweetIDs", mock.Anything, []int{7}).Return(map[int][]entity.TweetMedia{}, nil).Once()
+
+	result, next, err := service.GetTweetsByHashtag(ctx, "#Go", &entity.Page{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "gopher", result[0].Author.Username)
+
+	mockDB.AssertExpectations(t)
+	mockMedia.AssertExpectations(t)
+}
+
+func TestService_GetTweetsByHashtag_Invalid(t *testing.T) {
+	mockDB := &mockTweetStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := tweets.NewTweetService(&config.TweetsConfig{}, mockDB, mockMedia, newMockTimelineService())
+
+	result, _, err := service.GetTweetsByHashtag(context.Background(), "no spaces", &entity.Page{Limit: 10})
+
+	assert.ErrorIs(t, err, errs.ErrInvalidHashtag)
+	assert.Nil(t, result)
+
+	mockDB.AssertExpectations(t)
+}
@@ -10,9 +10,10 @@ import (
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/kust1q/Zapp/backend/internal/domain/events"
 	"github.com/kust1q/Zapp/backend/internal/errs"
-	"github.com/sirupsen/logrus"
 )
 
+// UpdateTweet edits a tweet of its author. The replaced version is kept in the tweet's
+// history, and edits past the configured window or count are rejected.
 func (s *service) UpdateTweet(ctx context.Context, req *entity.Tweet) (*entity.Tweet, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -28,6 +29,18 @@ func (s *service) UpdateTweet(ctx context.Context, req *entity.Tweet) (*entity.T
 	if exTweet.Author.ID != req.Author.ID {
 		return nil, errs.ErrUnauthorizedUpdate
 	}
+	if s.editWindow > 0 && time.Since(exTweet.CreatedAt) > s.editWindow {
+		return nil, errs.ErrEditWindowClosed
+	}
+	if s.maxEdits > 0 && exTweet.RevisionCount >= s.maxEdits {
+		return nil, errs.ErrEditLimitReached
+	}
+	// Attachments replace the current ones; they are validated before anything is uploaded.
+	if len(req.Attachments) > 0 {
+		if err := s.media.ValidateTweetAttachments(ctx, req.Author.ID, req.Attachments); err != nil {
+			return nil, err
+		}
+	}
 
 	tx, err := s.db.BeginTx(ctx)
 	if err != nil {
@@ -38,45 +51,57 @@ func (s *service) UpdateTweet(ctx context.Context, req *entity.Tweet) (*entity.T
 	exTweet.Content = req.Content
 	exTweet.UpdatedAt = time.Now()
 
-	updatedTweet, err := s.db.UpdateTweet(ctx, exTweet)
+	updatedTweet, err := s.db.UpdateTweetTx(ctx, tx, exTweet, s.maxEdits)
 	if err != nil {
+		if errors.Is(err, errs.ErrTweetNotFound) || errors.Is(err, errs.ErrEditLimitReached) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to update tweet: %w", err)
 	}
 
-	var mediaUrl string
-	if req.File != nil {
-		if err := s.media.DeleteTweetMedia(ctx, req.ID, req.Author.ID); err != nil {
-			logrus.WithFields(logrus.Fields{
-				"tweet_id": req.ID,
-				"error":    err,
-			}).Warn("failed to delete old media record")
-		}
-		mediaUrl, err = s.media.UploadAndAttachTweetMediaTx(ctx, updatedTweet.ID, req.File.File, req.File.Header.Filename, tx)
+	// The old attachments leave with the transaction; their objects are removed only
+	// once it commits.
+	var detached []string
+	if len(req.Attachments) > 0 {
+		detached, err = s.media.DetachTweetMediaTx(ctx, updatedTweet.ID, tx)
 		if err != nil {
+			return nil, err
+		}
+		if _, err := s.media.UploadAndAttachTweetMediaTx(ctx, updatedTweet.ID, req.Author.ID, req.Attachments, tx); err != nil {
 			return nil, fmt.Errorf("failed to upload new media: %w", err)
 		}
 	}
 
-	updatedTweet.MediaUrl = mediaUrl
+	if err := s.saveTags(ctx, tx, updatedTweet); err != nil {
+		return nil, err
+	}
+
+	event := events.TweetEvent{
+		EventType: events.TweetUpdateEvent,
+		ID:        updatedTweet.ID,
+		Content:   updatedTweet.Content,
+		UserID:    updatedTweet.Author.ID,
+		Username:  updatedTweet.Author.Username,
+		CreatedAt: updatedTweet.CreatedAt,
+		Hashtags:  updatedTweet.Hashtags,
+		Mentions:  mentionedUsernames(updatedTweet.Mentions),
+	}
+	if err := s.db.CreateOutboxEventTx(ctx, tx, events.TopicTweet, event); err != nil {
+		return nil, fmt.Errorf("failed to save tweet.updated event: %w", err)
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	if len(detached) > 0 {
+		s.media.RemoveDetachedMedia(detached)
+	}
 
-	go func() {
-		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		event := events.TweetEvent{
-			EventType: events.TweetUpdateEvent,
-			ID:        updatedTweet.ID,
-			Content:   updatedTweet.Content,
-			UserID:    updatedTweet.Author.ID,
-			Username:  updatedTweet.Author.Username,
-		}
-		if err := s.producer.Publish(cntx, events.TopicTweet, event); err != nil {
-			logrus.WithError(err).Error("failed to publish tweet.updated")
-		}
-	}()
-
-	return s.BuildEntityTweetToResponse(ctx, updatedTweet)
+	response, err := s.BuildEntityTweetToResponse(ctx, updatedTweet)
+	if err != nil {
+		return nil, err
+	}
+	response.Hashtags = updatedTweet.Hashtags
+	response.Mentions = updatedTweet.Mentions
+	return response, nil
 }
@@ -0,0 +1,118 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
+)
+
+// BlockUser blocks blockedID for blockerID. Follows between them are removed in
+// both directions, so their tweets also leave each other's home timelines.
+func (s *service) BlockUser(ctx context.Context, blockerID, blockedID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if blockerID == blockedID {
+		return errs.ErrInvalidInput
+	}
+	if err := s.db.BlockUser(ctx, blockerID, blockedID, time.Now()); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.timeline.Cleanup(ctx, blockerID, blockedID); err != nil {
+			logrus.WithError(err).WithField("follower_id", blockerID).Warn("timeline cleanup failed")
+		}
+		if err := s.timeline.Cleanup(ctx, blockedID, blockerID); err != nil {
+			logrus.WithError(err).WithField("follower_id", blockedID).Warn("timeline cleanup failed")
+		}
+	}()
+	return nil
+}
+
+func (s *service) UnblockUser(ctx context.Context, blockerID, blockedID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.db.UnblockUser(ctx, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+	return nil
+}
+
+// MuteUser hides mutedID from the feed and notifications of muterID only; the
+// muted user is not told and can still follow, reply and like.
+func (s *service) MuteUser(ctx context.Context, muterID, mutedID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if muterID == mutedID {
+		return errs.ErrInvalidInput
+	}
+	if err := s.db.MuteUser(ctx, muterID, mutedID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mute user: %w", err)
+	}
+	return nil
+}
+
+func (s *service) UnmuteUser(ctx context.Context, muterID, mutedID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.db.UnmuteUser(ctx, muterID, mutedID); err != nil {
+		return fmt.Errorf("failed to unmute user: %w", err)
+	}
+	return nil
+}
+
+func (s *service) GetBlockedUsers(ctx context.Context, userID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	ids, next, err := s.db.GetBlocksIds(ctx, userID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get blocked users ids: %w", err)
+	}
+	users, err := s.smallUsers(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return users, next, nil
+}
+
+func (s *service) GetMutedUsers(ctx context.Context, userID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	ids, next, err := s.db.GetMutesIds(ctx, userID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get muted users ids: %w", err)
+	}
+	users, err := s.smallUsers(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return users, next, nil
+}
+
+func (s *service) smallUsers(ctx context.Context, ids []int) ([]entity.SmallUser, error) {
+	users := make([]entity.SmallUser, 0, len(ids))
+	for _, id := range ids {
+		user, err := s.db.GetUserByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user by id: %w", err)
+		}
+
+		avatarURL, err := s.media.GetAvatarUrlByUserID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get avatar: %w", err)
+		}
+
+		users = append(users, entity.SmallUser{
+			ID:        user.ID,
+			Username:  user.Username,
+			AvatarUrl: avatarURL,
+		})
+	}
+	return users, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/kust1q/Zapp/backend/internal/domain/events"
@@ -28,23 +29,36 @@ func (s *service) DeleteUser(ctx context.Context, userID int) error {
 		}).Warnf("failed to delete all user tweet medias")
 	}
 
-	if err := s.db.DeleteUser(ctx, userID); err != nil {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.db.DeleteUserTx(ctx, tx, userID); err != nil {
 		if errors.Is(err, errs.ErrUserNotFound) {
 			return err
 		}
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
-	go func() {
-		cntx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		event := events.UserDeleted{
-			EventType: events.TweetDeleteEvent,
-			ID:        userID,
-		}
-		if err := s.producer.Publish(cntx, events.TopicTweet, event); err != nil {
-			logrus.WithError(err).Error("failed to publish user.deleted")
-		}
-	}()
 
+	event := events.UserDeleted{
+		EventType: events.UserDeleteEvent,
+		ID:        userID,
+	}
+	if err := s.db.CreateOutboxEventTx(ctx, tx, events.TopicUser, event); err != nil {
+		return fmt.Errorf("failed to save user.deleted event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	if err := s.tokens.CloseAllSessions(ctx, strconv.Itoa(userID)); err != nil {
+		return fmt.Errorf("failed to close sessions: %w", err)
+	}
+	if err := s.tokens.DenyUserAccess(ctx, strconv.Itoa(userID), time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
 	return nil
 }
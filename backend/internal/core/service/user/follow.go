@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/sirupsen/logrus"
 )
 
 func (s *service) FollowToUser(ctx context.Context, followerID, followingID int) (*entity.Follow, error) {
@@ -14,33 +16,86 @@ func (s *service) FollowToUser(ctx context.Context, followerID, followingID int)
 	if followerID == followingID {
 		return nil, fmt.Errorf("impossible to subscribe to yourself")
 	}
-	return s.db.FollowToUser(ctx, followerID, followingID, time.Now())
+	blocked, err := s.db.IsBlockedBetween(ctx, followerID, followingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check block: %w", err)
+	}
+	if blocked {
+		return nil, errs.ErrBlocked
+	}
+
+	following, err := s.db.GetUserByID(ctx, followingID)
+	if err != nil {
+		return nil, err
+	}
+	if following.IsPrivate {
+		already, err := s.db.IsFollowing(ctx, followerID, followingID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check follow: %w", err)
+		}
+		if !already {
+			return s.requestFollow(ctx, followerID, followingID)
+		}
+	}
+
+	follow, err := s.db.FollowToUser(ctx, followerID, followingID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	s.backfill(followerID, followingID)
+	return follow, nil
 }
 
+// requestFollow leaves a pending follow request for the owner of a private account to approve.
+func (s *service) requestFollow(ctx context.Context, followerID, followingID int) (*entity.Follow, error) {
+	createdAt := time.Now()
+	if err := s.db.CreateFollowRequest(ctx, followerID, followingID, createdAt); err != nil {
+		return nil, fmt.Errorf("failed to create follow request: %w", err)
+	}
+	return &entity.Follow{
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		Pending:     true,
+		CreatedAt:   createdAt,
+	}, nil
+}
+
+// UnfollowUser removes a follow or withdraws a pending follow request.
 func (s *service) UnfollowUser(ctx context.Context, followerID, followingID int) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	return s.db.UnfollowUser(ctx, followerID, followingID)
+	if err := s.db.UnfollowUser(ctx, followerID, followingID); err != nil {
+		return err
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.timeline.Cleanup(ctx, followerID, followingID); err != nil {
+			logrus.WithError(err).WithField("follower_id", followerID).Warn("timeline cleanup failed")
+		}
+	}()
+	return nil
 }
 
-func (s *service) GetFollowers(ctx context.Context, username string, limit, offset int) ([]entity.SmallUser, error) {
+func (s *service) GetFollowers(ctx context.Context, username string, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	followersIDs, err := s.db.GetFollowersIds(ctx, username, limit, offset)
+	followersIDs, next, err := s.db.GetFollowersIds(ctx, username, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get followers ids: %w", err)
+		return nil, nil, fmt.Errorf("failed to get followers ids: %w", err)
 	}
 
 	users := make([]entity.SmallUser, 0, len(followersIDs))
 	for _, id := range followersIDs {
 		user, err := s.db.GetUserByID(ctx, id)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get user by id: %w", err)
+			return nil, nil, fmt.Errorf("failed to get user by id: %w", err)
 		}
 
 		avatarURL, err := s.media.GetAvatarUrlByUserID(ctx, id)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get avatar")
+			return nil, nil, fmt.Errorf("failed to get avatar")
 		}
 
 		users = append(users, entity.SmallUser{
@@ -50,28 +105,28 @@ func (s *service) GetFollowers(ctx context.Context, username string, limit, offs
 		})
 	}
 
-	return users, nil
+	return users, next, nil
 }
 
-func (s *service) GetFollowings(ctx context.Context, username string, limit, offset int) ([]entity.SmallUser, error) {
+func (s *service) GetFollowings(ctx context.Context, username string, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	followingsIDs, err := s.db.GetFollowingsIds(ctx, username, limit, offset)
+	followingsIDs, next, err := s.db.GetFollowingsIds(ctx, username, page)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get followers ids: %w", err)
+		return nil, nil, fmt.Errorf("failed to get followers ids: %w", err)
 	}
 
 	users := make([]entity.SmallUser, 0, len(followingsIDs))
 	for _, id := range followingsIDs {
 		user, err := s.db.GetUserByID(ctx, id)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get user by id: %w", err)
+			return nil, nil, fmt.Errorf("failed to get user by id: %w", err)
 		}
 
 		avatarURL, err := s.media.GetAvatarUrlByUserID(ctx, id)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get avatar")
+			return nil, nil, fmt.Errorf("failed to get avatar")
 		}
 
 		users = append(users, entity.SmallUser{
@@ -81,5 +136,5 @@ func (s *service) GetFollowings(ctx context.Context, username string, limit, off
 		})
 	}
 
-	return users, nil
+	return users, next, nil
 }
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
@@ -9,30 +10,55 @@ import (
 
 type (
 	db interface {
+		BeginTx(ctx context.Context) (*sql.Tx, error)
+		CreateOutboxEventTx(ctx context.Context, tx *sql.Tx, topic string, event any) error
 		//user
 		GetUserByID(ctx context.Context, userID int) (*entity.User, error)
 		GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
 		UpdateUserBio(ctx context.Context, userID int, bio string) error
-		DeleteUser(ctx context.Context, userID int) error
+		DeleteUserTx(ctx context.Context, tx *sql.Tx, userID int) error
 		FollowToUser(ctx context.Context, followerID, followingID int, createdAt time.Time) (*entity.Follow, error)
 		UnfollowUser(ctx context.Context, followerID, followingID int) error
-		GetFollowersIds(ctx context.Context, username string, limit, offset int) ([]int, error)
-		GetFollowingsIds(ctx context.Context, username string, limit, offset int) ([]int, error)
+		GetFollowersIds(ctx context.Context, username string, page *entity.Page) ([]int, *entity.Cursor, error)
+		GetFollowingsIds(ctx context.Context, username string, page *entity.Page) ([]int, *entity.Cursor, error)
+		//privacy
+		UpdateUserPrivacy(ctx context.Context, userID int, isPrivate bool) error
+		IsFollowing(ctx context.Context, followerID, followingID int) (bool, error)
+		CreateFollowRequest(ctx context.Context, requesterID, targetID int, createdAt time.Time) error
+		ApproveFollowRequest(ctx context.Context, requesterID, targetID int, createdAt time.Time) (*entity.Follow, error)
+		ApproveAllFollowRequests(ctx context.Context, targetID int, createdAt time.Time) ([]int, error)
+		DeleteFollowRequest(ctx context.Context, requesterID, targetID int) error
+		GetFollowRequestsIds(ctx context.Context, targetID int, page *entity.Page) ([]int, *entity.Cursor, error)
+		//blocks
+		BlockUser(ctx context.Context, blockerID, blockedID int, createdAt time.Time) error
+		UnblockUser(ctx context.Context, blockerID, blockedID int) error
+		MuteUser(ctx context.Context, muterID, mutedID int, createdAt time.Time) error
+		UnmuteUser(ctx context.Context, muterID, mutedID int) error
+		IsBlockedBetween(ctx context.Context, userID, otherID int) (bool, error)
+		GetBlocksIds(ctx context.Context, userID int, page *entity.Page) ([]int, *entity.Cursor, error)
+		GetMutesIds(ctx context.Context, userID int, page *entity.Page) ([]int, *entity.Cursor, error)
 		//tweets
-		GetTweetsAndRetweetsByUsername(ctx context.Context, username string, limit, offset int) ([]entity.Tweet, error)
-		GetCounts(ctx context.Context, tweetID int) (*entity.Counters, error)
+		GetTweetsAndRetweetsByUsername(ctx context.Context, username string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
 	}
 
 	mediaService interface {
 		GetAvatarUrlByUserID(ctx context.Context, userID int) (string, error)
 		DeleteAvatar(ctx context.Context, userID int) error
 
-		GetMediaUrlByTweetID(ctx context.Context, tweetID int) (string, error)
-
 		DeleteMediasByUserID(ctx context.Context, userID int) error
 	}
 
-	eventProducer interface {
-		Publish(ctx context.Context, topic string, event any) error
+	tweetService interface {
+		BuildEntityTweetsToResponse(ctx context.Context, tweets []entity.Tweet) ([]entity.Tweet, error)
+	}
+
+	tokenStorage interface {
+		CloseAllSessions(ctx context.Context, userID string) error
+		DenyUserAccess(ctx context.Context, userID string, issuedBefore time.Time) error
+	}
+
+	timelineService interface {
+		Backfill(ctx context.Context, followerID, followingID int) error
+		Cleanup(ctx context.Context, followerID, followingID int) error
 	}
 )
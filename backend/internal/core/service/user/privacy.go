@@ -0,0 +1,107 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/sirupsen/logrus"
+)
+
+// UpdatePrivacy switches the account between public and private. Making the account
+// public approves every pending follow request.
+func (s *service) UpdatePrivacy(ctx context.Context, req *entity.UpdatePrivacy) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.db.UpdateUserPrivacy(ctx, req.UserID, req.IsPrivate); err != nil {
+		return fmt.Errorf("failed to update privacy: %w", err)
+	}
+	if req.IsPrivate {
+		return nil
+	}
+
+	followerIDs, err := s.db.ApproveAllFollowRequests(ctx, req.UserID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to approve follow requests: %w", err)
+	}
+	for _, followerID := range followerIDs {
+		s.backfill(followerID, req.UserID)
+	}
+	return nil
+}
+
+func (s *service) GetFollowRequests(ctx context.Context, userID int, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	ids, next, err := s.db.GetFollowRequestsIds(ctx, userID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get follow requests ids: %w", err)
+	}
+	users, err := s.smallUsers(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return users, next, nil
+}
+
+func (s *service) ApproveFollowRequest(ctx context.Context, userID, requesterID int) (*entity.Follow, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	follow, err := s.db.ApproveFollowRequest(ctx, requesterID, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve follow request: %w", err)
+	}
+	s.backfill(requesterID, userID)
+	return follow, nil
+}
+
+func (s *service) DenyFollowRequest(ctx context.Context, userID, requesterID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := s.db.DeleteFollowRequest(ctx, requesterID, userID); err != nil {
+		return fmt.Errorf("failed to deny follow request: %w", err)
+	}
+	return nil
+}
+
+// canSeeTweets reports whether the viewer of ctx may read the tweets of owner: nobody
+// on either side of a block may, and only approved followers may read a private account.
+func (s *service) canSeeTweets(ctx context.Context, owner *entity.User) (bool, error) {
+	viewerID, ok := entity.ViewerFromContext(ctx)
+	if ok && viewerID == owner.ID {
+		return true, nil
+	}
+	if !ok {
+		return !owner.IsPrivate, nil
+	}
+
+	blocked, err := s.db.IsBlockedBetween(ctx, viewerID, owner.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+	if blocked {
+		return false, nil
+	}
+	if !owner.IsPrivate {
+		return true, nil
+	}
+
+	following, err := s.db.IsFollowing(ctx, viewerID, owner.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check follow: %w", err)
+	}
+	return following, nil
+}
+
+// backfill copies recent tweets of followingID into the home timeline of a new follower
+// without delaying the response.
+func (s *service) backfill(followerID, followingID int) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.timeline.Backfill(ctx, followerID, followingID); err != nil {
+			logrus.WithError(err).WithField("follower_id", followerID).Warn("timeline backfill failed")
+		}
+	}()
+}
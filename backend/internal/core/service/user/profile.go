@@ -10,17 +10,17 @@ import (
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 )
 
-func (s *service) GetMe(ctx context.Context, userID, limit, offset int) (*entity.UserProfile, error) {
+func (s *service) GetMe(ctx context.Context, userID int, page *entity.Page) (*entity.UserProfile, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	user, err := s.db.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by id: %w", err)
 	}
-	return s.GetUserProfile(ctx, user.Username, limit, offset)
+	return s.GetUserProfile(ctx, user.Username, page)
 }
 
-func (s *service) GetUserProfile(ctx context.Context, username string, limit, offset int) (*entity.UserProfile, error) {
+func (s *service) GetUserProfile(ctx context.Context, username string, page *entity.Page) (*entity.UserProfile, error) {
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
@@ -36,45 +36,32 @@ func (s *service) GetUserProfile(ctx context.Context, username string, limit, of
 
 	user.AvatarUrl = avatarURL
 
-	tweets, err := s.db.GetTweetsAndRetweetsByUsername(ctx, user.Username, limit, offset)
+	visible, err := s.canSeeTweets(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return &entity.UserProfile{
+			User:   user,
+			Tweets: []entity.Tweet{},
+		}, nil
+	}
+
+	tweets, next, err := s.db.GetTweetsAndRetweetsByUsername(ctx, user.Username, page)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("failed to get tweets by username: %w", err)
 		}
 	}
 
-	for i := range tweets {
-		author, err := s.db.GetUserByID(ctx, tweets[i].Author.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get tweet author: %w", err)
-		}
-
-		avatarUrl, err := s.media.GetAvatarUrlByUserID(ctx, tweets[i].Author.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user avatar: %w", err)
-		}
-
-		tweets[i].Author = &entity.SmallUser{
-			ID:        author.ID,
-			Username:  author.Username,
-			AvatarUrl: avatarUrl,
-		}
-
-		mediaUrl, err := s.media.GetMediaUrlByTweetID(ctx, tweets[i].ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get tweet media url")
-		}
-		tweets[i].MediaUrl = mediaUrl
-
-		counts, err := s.db.GetCounts(ctx, tweets[i].ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get counters: %w", err)
-		}
-		tweets[i].Counters = counts
+	tweets, err = s.tweets.BuildEntityTweetsToResponse(ctx, tweets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tweets: %w", err)
 	}
 
 	return &entity.UserProfile{
-		User:   user,
-		Tweets: tweets,
+		User:       user,
+		Tweets:     tweets,
+		NextCursor: next,
 	}, nil
 }
@@ -3,13 +3,17 @@ package user
 type service struct {
 	db       db
 	media    mediaService
-	producer eventProducer
+	timeline timelineService
+	tweets   tweetService
+	tokens   tokenStorage
 }
 
-func NewUserService(db db, media mediaService, producer eventProducer) *service {
+func NewUserService(db db, media mediaService, timeline timelineService, tweets tweetService, tokens tokenStorage) *service {
 	return &service{
 		db:       db,
 		media:    media,
-		producer: producer,
+		timeline: timeline,
+		tweets:   tweets,
+		tokens:   tokens,
 	}
 }
@@ -2,6 +2,7 @@ package user_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 	"github.com/kust1q/Zapp/backend/internal/domain/events"
 	"github.com/kust1q/Zapp/backend/internal/errs"
+	"github.com/kust1q/Zapp/backend/internal/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -41,8 +43,19 @@ func (m *mockUserStorage) UpdateUserBio(ctx context.Context, userID int, bio str
 	return args.Error(0)
 }
 
-func (m *mockUserStorage) DeleteUser(ctx context.Context, userID int) error {
-	args := m.Called(ctx, userID)
+func (m *mockUserStorage) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	args := m.Called(ctx)
+	tx, _ := args.Get(0).(*sql.Tx)
+	return tx, args.Error(1)
+}
+
+func (m *mockUserStorage) CreateOutboxEventTx(ctx context.Context, tx *sql.Tx, topic string, event any) error {
+	args := m.Called(ctx, tx, topic, event)
+	return args.Error(0)
+}
+
+func (m *mockUserStorage) DeleteUserTx(ctx context.Context, tx *sql.Tx, userID int) error {
+	args := m.Called(ctx, tx, userID)
 	return args.Error(0)
 }
 
@@ -60,28 +73,22 @@ func (m *mockUserStorage) UnfollowUser(ctx context.Context, followerID, followin
 	return args.Error(0)
 }
 
-func (m *mockUserStorage) GetFollowersIds(ctx context.Context, username string, limit, offset int) ([]int, error) {
-	args := m.Called(ctx, username, limit, offset)
-	return args.Get(0).([]int), args.Error(1)
+func (m *mockUserStorage) GetFollowersIds(ctx context.Context, username string, page *entity.Page) ([]int, *entity.Cursor, error) {
+	args := m.Called(ctx, username, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]int), next, args.Error(2)
 }
 
-func (m *mockUserStorage) GetFollowingsIds(ctx context.Context, username string, limit, offset int) ([]int, error) {
-	args := m.Called(ctx, username, limit, offset)
-	return args.Get(0).([]int), args.Error(1)
+func (m *mockUserStorage) GetFollowingsIds(ctx context.Context, username string, page *entity.Page) ([]int, *entity.Cursor, error) {
+	args := m.Called(ctx, username, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]int), next, args.Error(2)
 }
 
-func (m *mockUserStorage) GetTweetsAndRetweetsByUsername(ctx context.Context, username string, limit, offset int) ([]entity.Tweet, error) {
-	args := m.Called(ctx, username, limit, offset)
-	return args.Get(0).([]entity.Tweet), args.Error(1)
-}
-
-func (m *mockUserStorage) GetCounts(ctx context.Context, tweetID int) (*entity.Counters, error) {
-	args := m.Called(ctx, tweetID)
-	counters := args.Get(0)
-	if counters == nil {
-		return nil, args.Error(1)
-	}
-	return counters.(*entity.Counters), args.Error(1)
+func (m *mockUserStorage) GetTweetsAndRetweetsByUsername(ctx context.Context, username string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error) {
+	args := m.Called(ctx, username, page)
+	next, _ := args.Get(1).(*entity.Cursor)
+	return args.Get(0).([]entity.Tweet), next, args.Error(2)
 }
 
 type mockMediaService struct {
@@ -98,87 +105,203 @@ func (m *mockMediaService) DeleteAvatar(ctx context.Context, userID int) error {
 	return args.Error(0)
 }
 
-func (m *mockMediaService) GetMediaUrlByTweetID(ctx context.Context, tweetID int) (string, error) {
-	args := m.Called(ctx, tweetID)
-	return args.String(0), args.Error(1)
-}
-
 func (m *mockMediaService) DeleteMediasByUserID(ctx context.Context, userID int) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
 
-type mockEventProducer struct {
+type mockTweetService struct {
+	mock.Mock
+}
+
+func (m *mockTweetService) BuildEntityTweetsToResponse(ctx context.Context, tweets []entity.Tweet) ([]entity.Tweet, error) {
+	args := m.Called(ctx, tweets)
+	built, _ := args.Get(0).([]entity.Tweet)
+	return built, args.Error(1)
+}
+
+type mockTimelineService struct {
+	mock.Mock
+}
+
+func newMockTimelineService() *mockTimelineService {
+	m := &mockTimelineService{}
+	m.On("Backfill", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("Cleanup", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *mockTimelineService) Backfill(ctx context.Context, followerID, followingID int) error {
+	args := m.Called(ctx, followerID, followingID)
+	return args.Error(0)
+}
+
+func (m *mockTimelineService) Cleanup(ctx context.Context, followerID, followingID int) error {
+	args := m.Called(ctx, followerID, followingID)
+	return args.Error(0)
+}
+
+type mockTokenStorage struct {
 	mock.Mock
 }
 
-func (m *mockEventProducer) Publish(ctx context.Context, topic string, event any) error {
-	args := m.Called(ctx, topic, event)
+func (m *mockTokenStorage) CloseAllSessions(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockTokenStorage) DenyUserAccess(ctx context.Context, userID string, issuedBefore time.Time) error {
+	args := m.Called(ctx, userID, issuedBefore)
+	return args.Error(0)
+}
+
+func (m *mockUserStorage) BlockUser(ctx context.Context, blockerID, blockedID int, createdAt time.Time) error {
+	args := m.Called(ctx, blockerID, blockedID, createdAt)
+	return args.Error(0)
+}
+
+func (m *mockUserStorage) UnblockUser(ctx context.Context, blockerID, blockedID int) error {
+	args := m.Called(ctx, blockerID, blockedID)
+	return args.Error(0)
+}
+
+func (m *mockUserStorage) MuteUser(ctx context.Context, muterID, mutedID int, createdAt time.Time) error {
+	args := m.Called(ctx, muterID, mutedID, createdAt)
 	return args.Error(0)
 }
 
+func (m *mockUserStorage) UnmuteUser(ctx context.Context, muterID, mutedID int) error {
+	args := m.Called(ctx, muterID, mutedID)
+	return args.Error(0)
+}
+
+func (m *mockUserStorage) IsBlockedBetween(ctx context.Context, userID, otherID int) (bool, error) {
+	args := m.Called(ctx, userID, otherID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockUserStorage) GetBlocksIds(ctx context.Context, userID int, page *entity.Page) ([]int, *entity.Cursor, error) {
+	args := m.Called(ctx, userID, page)
+	ids, _ := args.Get(0).([]int)
+	cursor, _ := args.Get(1).(*entity.Cursor)
+	return ids, cursor, args.Error(2)
+}
+
+func (m *mockUserStorage) GetMutesIds(ctx context.Context, userID int, page *entity.Page) ([]int, *entity.Cursor, error) {
+	args := m.Called(ctx, userID, page)
+	ids, _ := args.Get(0).([]int)
+	cursor, _ := args.Get(1).(*entity.Cursor)
+	return ids, cursor, args.Error(2)
+}
+
+func (m *mockUserStorage) UpdateUserPrivacy(ctx context.Context, userID int, isPrivate bool) error {
+	args := m.Called(ctx, userID, isPrivate)
+	return args.Error(0)
+}
+
+func (m *mockUserStorage) IsFollowing(ctx context.Context, followerID, followingID int) (bool, error) {
+	args := m.Called(ctx, followerID, followingID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockUserStorage) CreateFollowRequest(ctx context.Context, requesterID, targetID int, createdAt time.Time) error {
+	args := m.Called(ctx, requesterID, targetID, createdAt)
+	return args.Error(0)
+}
+
+func (m *mockUserStorage) ApproveFollowRequest(ctx context.Context, requesterID, targetID int, createdAt time.Time) (*entity.Follow, error) {
+	args := m.Called(ctx, requesterID, targetID, createdAt)
+	follow, _ := args.Get(0).(*entity.Follow)
+	return follow, args.Error(1)
+}
+
+func (m *mockUserStorage) ApproveAllFollowRequests(ctx context.Context, targetID int, createdAt time.Time) ([]int, error) {
+	args := m.Called(ctx, targetID, createdAt)
+	ids, _ := args.Get(0).([]int)
+	return ids, args.Error(1)
+}
+
+func (m *mockUserStorage) DeleteFollowRequest(ctx context.Context, requesterID, targetID int) error {
+	args := m.Called(ctx, requesterID, targetID)
+	return args.Error(0)
+}
+
+func (m *mockUserStorage) GetFollowRequestsIds(ctx context.Context, targetID int, page *entity.Page) ([]int, *entity.Cursor, error) {
+	args := m.Called(ctx, targetID, page)
+	ids, _ := args.Get(0).([]int)
+	cursor, _ := args.Get(1).(*entity.Cursor)
+	return ids, cursor, args.Error(2)
+}
+
 func TestService_DeleteUser_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
+	mockTokens := &mockTokenStorage{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, mockTokens)
 
 	ctx := context.Background()
 
 	mockMedia.On("DeleteAvatar", mock.Anything, 1).Return(nil).Once()
 	mockMedia.On("DeleteMediasByUserID", mock.Anything, 1).Return(nil).Once()
-	mockDB.On("DeleteUser", mock.Anything, 1).Return(nil).Once()
-	mockProducer.On("Publish", mock.Anything, events.TopicTweet, mock.AnythingOfType("events.UserDeleted")).Return(nil).Once()
+	tx := mocks.NewTestTx(t)
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("DeleteUserTx", mock.Anything, tx, 1).Return(nil).Once()
+	mockDB.On("CreateOutboxEventTx", mock.Anything, tx, events.TopicUser, events.UserDeleted{EventType: events.UserDeleteEvent, ID: 1}).Return(nil).Once()
+
+	mockTokens.On("CloseAllSessions", mock.Anything, "1").Return(nil).Once()
+	mockTokens.On("DenyUserAccess", mock.Anything, "1", mock.Anything).Return(nil).Once()
 
 	err := service.DeleteUser(ctx, 1)
 
 	assert.NoError(t, err)
 
-	time.Sleep(100 * time.Millisecond)
-
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
-	mockProducer.AssertExpectations(t)
+	mockTokens.AssertExpectations(t)
 }
 
 func TestService_DeleteUser_MediaErrors(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
+	mockTokens := &mockTokenStorage{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, mockTokens)
 
 	ctx := context.Background()
 
 	mockMedia.On("DeleteAvatar", mock.Anything, 1).Return(errors.New("avatar error")).Once()
 	mockMedia.On("DeleteMediasByUserID", mock.Anything, 1).Return(errors.New("medias error")).Once()
-	mockDB.On("DeleteUser", mock.Anything, 1).Return(nil).Once()
-	mockProducer.On("Publish", mock.Anything, events.TopicTweet, mock.AnythingOfType("events.UserDeleted")).Return(nil).Once()
+	tx := mocks.NewTestTx(t)
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("DeleteUserTx", mock.Anything, tx, 1).Return(nil).Once()
+	mockDB.On("CreateOutboxEventTx", mock.Anything, tx, events.TopicUser, events.UserDeleted{EventType: events.UserDeleteEvent, ID: 1}).Return(nil).Once()
+
+	mockTokens.On("CloseAllSessions", mock.Anything, "1").Return(nil).Once()
+	mockTokens.On("DenyUserAccess", mock.Anything, "1", mock.Anything).Return(nil).Once()
 
 	err := service.DeleteUser(ctx, 1)
 
 	assert.NoError(t, err)
 
-	time.Sleep(100 * time.Millisecond)
-
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
-	mockProducer.AssertExpectations(t)
+	mockTokens.AssertExpectations(t)
 }
 
 func TestService_DeleteUser_UserNotFound(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
 	mockMedia.On("DeleteAvatar", mock.Anything, 1).Return(nil).Once()
 	mockMedia.On("DeleteMediasByUserID", mock.Anything, 1).Return(nil).Once()
-	mockDB.On("DeleteUser", mock.Anything, 1).Return(errs.ErrUserNotFound).Once()
+	tx := mocks.NewTestTx(t)
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("DeleteUserTx", mock.Anything, tx, 1).Return(errs.ErrUserNotFound).Once()
 
 	err := service.DeleteUser(ctx, 1)
 
@@ -187,21 +310,21 @@ func TestService_DeleteUser_UserNotFound(t *testing.T) {
 
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
-	mockProducer.AssertExpectations(t)
 }
 
 func TestService_DeleteUser_DBError(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
 	mockMedia.On("DeleteAvatar", mock.Anything, 1).Return(nil).Once()
 	mockMedia.On("DeleteMediasByUserID", mock.Anything, 1).Return(nil).Once()
-	mockDB.On("DeleteUser", mock.Anything, 1).Return(errors.New("db error")).Once()
+	tx := mocks.NewTestTx(t)
+	mockDB.On("BeginTx", mock.Anything).Return(tx, nil).Once()
+	mockDB.On("DeleteUserTx", mock.Anything, tx, 1).Return(errors.New("db error")).Once()
 
 	err := service.DeleteUser(ctx, 1)
 
@@ -210,15 +333,15 @@ func TestService_DeleteUser_DBError(t *testing.T) {
 
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
-	mockProducer.AssertExpectations(t)
 }
 
 func TestService_FollowToUser_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	mockTimeline := newMockTimelineService()
+
+	service := user.NewUserService(mockDB, mockMedia, mockTimeline, &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -228,6 +351,8 @@ func TestService_FollowToUser_Success(t *testing.T) {
 		CreatedAt:   time.Now(),
 	}
 
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(false, nil).Once()
+	mockDB.On("GetUserByID", mock.Anything, 2).Return(&entity.User{ID: 2}, nil).Once()
 	mockDB.On("FollowToUser", mock.Anything, 1, 2, mock.AnythingOfType("time.Time")).Return(follow, nil).Once()
 
 	result, err := service.FollowToUser(ctx, 1, 2)
@@ -236,6 +361,9 @@ func TestService_FollowToUser_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, 1, result.FollowerID)
 	assert.Equal(t, 2, result.FollowingID)
+	assert.Eventually(t, func() bool {
+		return mockTimeline.AssertCalled(&testing.T{}, "Backfill", mock.Anything, 1, 2)
+	}, time.Second, 10*time.Millisecond)
 
 	mockDB.AssertExpectations(t)
 }
@@ -243,9 +371,8 @@ func TestService_FollowToUser_Success(t *testing.T) {
 func TestService_FollowToUser_SelfFollow(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -261,9 +388,8 @@ func TestService_FollowToUser_SelfFollow(t *testing.T) {
 func TestService_UnfollowUser_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -279,9 +405,8 @@ func TestService_UnfollowUser_Success(t *testing.T) {
 func TestService_GetFollowers_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -289,13 +414,13 @@ func TestService_GetFollowers_Success(t *testing.T) {
 	user2 := &entity.User{ID: 2, Username: "user2"}
 	user3 := &entity.User{ID: 3, Username: "user3"}
 
-	mockDB.On("GetFollowersIds", mock.Anything, "testuser", 10, 0).Return(followerIDs, nil).Once()
+	mockDB.On("GetFollowersIds", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return(followerIDs, nil, nil).Once()
 	mockDB.On("GetUserByID", mock.Anything, 2).Return(user2, nil).Once()
 	mockDB.On("GetUserByID", mock.Anything, 3).Return(user3, nil).Once()
 	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 2).Return("/avatars/2.jpg", nil).Once()
 	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 3).Return("/avatars/3.jpg", nil).Once()
 
-	result, err := service.GetFollowers(ctx, "testuser", 10, 0)
+	result, _, err := service.GetFollowers(ctx, "testuser", &entity.Page{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -312,15 +437,14 @@ func TestService_GetFollowers_Success(t *testing.T) {
 func TestService_GetFollowers_GetIDsError(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
-	mockDB.On("GetFollowersIds", mock.Anything, "testuser", 10, 0).Return([]int{}, errors.New("db error")).Once()
+	mockDB.On("GetFollowersIds", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return([]int{}, nil, errors.New("db error")).Once()
 
-	result, err := service.GetFollowers(ctx, "testuser", 10, 0)
+	result, _, err := service.GetFollowers(ctx, "testuser", &entity.Page{Limit: 10})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -332,17 +456,16 @@ func TestService_GetFollowers_GetIDsError(t *testing.T) {
 func TestService_GetFollowers_GetUserError(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
 	followerIDs := []int{2}
-	mockDB.On("GetFollowersIds", mock.Anything, "testuser", 10, 0).Return(followerIDs, nil).Once()
+	mockDB.On("GetFollowersIds", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return(followerIDs, nil, nil).Once()
 	mockDB.On("GetUserByID", mock.Anything, 2).Return(nil, errors.New("user not found")).Once()
 
-	result, err := service.GetFollowers(ctx, "testuser", 10, 0)
+	result, _, err := service.GetFollowers(ctx, "testuser", &entity.Page{Limit: 10})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -354,20 +477,19 @@ func TestService_GetFollowers_GetUserError(t *testing.T) {
 func TestService_GetFollowers_GetAvatarError(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
 	followerIDs := []int{2}
 	user2 := &entity.User{ID: 2, Username: "user2"}
 
-	mockDB.On("GetFollowersIds", mock.Anything, "testuser", 10, 0).Return(followerIDs, nil).Once()
+	mockDB.On("GetFollowersIds", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return(followerIDs, nil, nil).Once()
 	mockDB.On("GetUserByID", mock.Anything, 2).Return(user2, nil).Once()
 	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 2).Return("", errors.New("avatar error")).Once()
 
-	result, err := service.GetFollowers(ctx, "testuser", 10, 0)
+	result, _, err := service.GetFollowers(ctx, "testuser", &entity.Page{Limit: 10})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -380,9 +502,8 @@ func TestService_GetFollowers_GetAvatarError(t *testing.T) {
 func TestService_GetFollowings_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -390,13 +511,13 @@ func TestService_GetFollowings_Success(t *testing.T) {
 	user4 := &entity.User{ID: 4, Username: "user4"}
 	user5 := &entity.User{ID: 5, Username: "user5"}
 
-	mockDB.On("GetFollowingsIds", mock.Anything, "testuser", 10, 0).Return(followingIDs, nil).Once()
+	mockDB.On("GetFollowingsIds", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return(followingIDs, nil, nil).Once()
 	mockDB.On("GetUserByID", mock.Anything, 4).Return(user4, nil).Once()
 	mockDB.On("GetUserByID", mock.Anything, 5).Return(user5, nil).Once()
 	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 4).Return("/avatars/4.jpg", nil).Once()
 	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 5).Return("/avatars/5.jpg", nil).Once()
 
-	result, err := service.GetFollowings(ctx, "testuser", 10, 0)
+	result, _, err := service.GetFollowings(ctx, "testuser", &entity.Page{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -413,9 +534,8 @@ func TestService_GetFollowings_Success(t *testing.T) {
 func TestService_GetUserByID_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -443,9 +563,8 @@ func TestService_GetUserByID_Success(t *testing.T) {
 func TestService_GetUserByID_NotFound(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -463,9 +582,8 @@ func TestService_GetUserByID_NotFound(t *testing.T) {
 func TestService_GetUserByID_AvatarError(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -490,9 +608,8 @@ func TestService_GetUserByID_AvatarError(t *testing.T) {
 func TestService_GetUserByUsername_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -520,9 +637,9 @@ func TestService_GetUserByUsername_Success(t *testing.T) {
 func TestService_GetMe_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
+	mockTweets := &mockTweetService{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), mockTweets, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -541,27 +658,26 @@ func TestService_GetMe_Success(t *testing.T) {
 		},
 	}
 
-	author := &entity.User{
-		ID:       1,
-		Username: "testuser",
-	}
-
-	counters := &entity.Counters{
-		ReplyCount:   0,
-		RetweetCount: 0,
-		LikeCount:    0,
+	built := []entity.Tweet{
+		{
+			ID:      1,
+			Content: "Tweet 1",
+			Author: &entity.SmallUser{
+				ID:        1,
+				Username:  "testuser",
+				AvatarUrl: "/avatars/1.jpg",
+			},
+			Counters: &entity.Counters{},
+		},
 	}
 
 	mockDB.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
 	mockDB.On("GetUserByUsername", mock.Anything, "testuser").Return(user, nil).Once()
 	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("/avatars/1.jpg", nil).Once()
-	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", 10, 0).Return(tweets, nil).Once()
-	mockDB.On("GetUserByID", mock.Anything, 1).Return(author, nil).Once()
-	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("/avatars/1.jpg", nil).Once()
-	mockMedia.On("GetMediaUrlByTweetID", mock.Anything, 1).Return("", nil).Once()
-	mockDB.On("GetCounts", mock.Anything, 1).Return(counters, nil).Once()
+	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return(tweets, nil, nil).Once()
+	mockTweets.On("BuildEntityTweetsToResponse", mock.Anything, tweets).Return(built, nil).Once()
 
-	result, err := service.GetMe(ctx, 1, 10, 0)
+	result, err := service.GetMe(ctx, 1, &entity.Page{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -571,14 +687,15 @@ func TestService_GetMe_Success(t *testing.T) {
 
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
+	mockTweets.AssertExpectations(t)
 }
 
 func TestService_GetUserProfile_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
+	mockTweets := &mockTweetService{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), mockTweets, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -597,43 +714,44 @@ func TestService_GetUserProfile_Success(t *testing.T) {
 		},
 	}
 
-	author := &entity.User{
-		ID:       1,
-		Username: "testuser",
-	}
-
-	counters := &entity.Counters{
-		ReplyCount:   0,
-		RetweetCount: 0,
-		LikeCount:    0,
+	built := []entity.Tweet{
+		{
+			ID:      1,
+			Content: "Tweet 1",
+			Author: &entity.SmallUser{
+				ID:        1,
+				Username:  "testuser",
+				AvatarUrl: "/avatars/1.jpg",
+			},
+			Counters: &entity.Counters{},
+		},
 	}
 
 	mockDB.On("GetUserByUsername", mock.Anything, "testuser").Return(user, nil).Once()
 	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("/avatars/1.jpg", nil).Once()
-	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", 10, 0).Return(tweets, nil).Once()
-	mockDB.On("GetUserByID", mock.Anything, 1).Return(author, nil).Once()
-	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("/avatars/1.jpg", nil).Once()
-	mockMedia.On("GetMediaUrlByTweetID", mock.Anything, 1).Return("", nil).Once()
-	mockDB.On("GetCounts", mock.Anything, 1).Return(counters, nil).Once()
+	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return(tweets, nil, nil).Once()
+	mockTweets.On("BuildEntityTweetsToResponse", mock.Anything, tweets).Return(built, nil).Once()
 
-	result, err := service.GetUserProfile(ctx, "testuser", 10, 0)
+	result, err := service.GetUserProfile(ctx, "testuser", &entity.Page{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "testuser", result.User.Username)
 	assert.Len(t, result.Tweets, 1)
 	assert.Equal(t, "Tweet 1", result.Tweets[0].Content)
+	assert.Equal(t, "testuser", result.Tweets[0].Author.Username)
 
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
+	mockTweets.AssertExpectations(t)
 }
 
 func TestService_GetUserProfile_NoTweets(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
+	mockTweets := &mockTweetService{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), mockTweets, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -644,9 +762,10 @@ func TestService_GetUserProfile_NoTweets(t *testing.T) {
 
 	mockDB.On("GetUserByUsername", mock.Anything, "testuser").Return(user, nil).Once()
 	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("/avatars/1.jpg", nil).Once()
-	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", 10, 0).Return([]entity.Tweet{}, nil).Once()
+	mockDB.On("GetTweetsAndRetweetsByUsername", mock.Anything, "testuser", &entity.Page{Limit: 10}).Return([]entity.Tweet{}, nil, nil).Once()
+	mockTweets.On("BuildEntityTweetsToResponse", mock.Anything, []entity.Tweet{}).Return([]entity.Tweet{}, nil).Once()
 
-	result, err := service.GetUserProfile(ctx, "testuser", 10, 0)
+	result, err := service.GetUserProfile(ctx, "testuser", &entity.Page{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -655,14 +774,14 @@ func TestService_GetUserProfile_NoTweets(t *testing.T) {
 
 	mockDB.AssertExpectations(t)
 	mockMedia.AssertExpectations(t)
+	mockTweets.AssertExpectations(t)
 }
 
 func TestService_Update_Success(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -683,9 +802,8 @@ func TestService_Update_Success(t *testing.T) {
 func TestService_Update_Error(t *testing.T) {
 	mockDB := &mockUserStorage{}
 	mockMedia := &mockMediaService{}
-	mockProducer := &mockEventProducer{}
 
-	service := user.NewUserService(mockDB, mockMedia, mockProducer)
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
 
 	ctx := context.Background()
 
@@ -703,3 +821,155 @@ func TestService_Update_Error(t *testing.T) {
 
 	mockDB.AssertExpectations(t)
 }
+
+func TestService_FollowToUser_Blocked(t *testing.T) {
+	mockDB := &mockUserStorage{}
+
+	service := user.NewUserService(mockDB, &mockMediaService{}, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
+
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(true, nil).Once()
+
+	result, err := service.FollowToUser(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, errs.ErrBlocked)
+	assert.Nil(t, result)
+	mockDB.AssertNotCalled(t, "FollowToUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_BlockUser_CleansUpBothTimelines(t *testing.T) {
+	mockDB := &mockUserStorage{}
+	mockTimeline := newMockTimelineService()
+
+	service := user.NewUserService(mockDB, &mockMediaService{}, mockTimeline, &mockTweetService{}, &mockTokenStorage{})
+
+	mockDB.On("BlockUser", mock.Anything, 1, 2, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	err := service.BlockUser(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return mockTimeline.AssertCalled(&testing.T{}, "Cleanup", mock.Anything, 1, 2) &&
+			mockTimeline.AssertCalled(&testing.T{}, "Cleanup", mock.Anything, 2, 1)
+	}, time.Second, 10*time.Millisecond)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_BlockUser_Self(t *testing.T) {
+	service := user.NewUserService(&mockUserStorage{}, &mockMediaService{}, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
+
+	err := service.BlockUser(context.Background(), 1, 1)
+
+	assert.ErrorIs(t, err, errs.ErrInvalidInput)
+}
+
+func TestService_GetUserProfile_BlockedViewer(t *testing.T) {
+	mockDB := &mockUserStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
+
+	ctx := entity.WithViewer(context.Background(), 2)
+
+	mockDB.On("GetUserByUsername", mock.Anything, "testuser").Return(&entity.User{ID: 1, Username: "testuser"}, nil).Once()
+	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("", nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 2, 1).Return(true, nil).Once()
+
+	profile, err := service.GetUserProfile(ctx, "testuser", &entity.Page{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, profile.User.ID)
+	assert.Empty(t, profile.Tweets)
+	mockDB.AssertNotCalled(t, "GetTweetsAndRetweetsByUsername", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_FollowToUser_PrivateCreatesRequest(t *testing.T) {
+	mockDB := &mockUserStorage{}
+	mockTimeline := newMockTimelineService()
+
+	service := user.NewUserService(mockDB, &mockMediaService{}, mockTimeline, &mockTweetService{}, &mockTokenStorage{})
+
+	mockDB.On("IsBlockedBetween", mock.Anything, 1, 2).Return(false, nil).Once()
+	mockDB.On("GetUserByID", mock.Anything, 2).Return(&entity.User{ID: 2, IsPrivate: true}, nil).Once()
+	mockDB.On("IsFollowing", mock.Anything, 1, 2).Return(false, nil).Once()
+	mockDB.On("CreateFollowRequest", mock.Anything, 1, 2, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	result, err := service.FollowToUser(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Pending)
+	mockDB.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "FollowToUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockTimeline.AssertNotCalled(t, "Backfill", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_ApproveFollowRequest_BackfillsTimeline(t *testing.T) {
+	mockDB := &mockUserStorage{}
+	mockTimeline := newMockTimelineService()
+
+	service := user.NewUserService(mockDB, &mockMediaService{}, mockTimeline, &mockTweetService{}, &mockTokenStorage{})
+
+	mockDB.On("ApproveFollowRequest", mock.Anything, 1, 2, mock.AnythingOfType("time.Time")).
+		Return(&entity.Follow{FollowerID: 1, FollowingID: 2}, nil).Once()
+
+	follow, err := service.ApproveFollowRequest(context.Background(), 2, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, follow.FollowerID)
+	assert.Eventually(t, func() bool {
+		return mockTimeline.AssertCalled(&testing.T{}, "Backfill", mock.Anything, 1, 2)
+	}, time.Second, 10*time.Millisecond)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_DenyFollowRequest_NotFound(t *testing.T) {
+	mockDB := &mockUserStorage{}
+
+	service := user.NewUserService(mockDB, &mockMediaService{}, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
+
+	mockDB.On("DeleteFollowRequest", mock.Anything, 1, 2).Return(errs.ErrFollowRequestNotFound).Once()
+
+	err := service.DenyFollowRequest(context.Background(), 2, 1)
+
+	assert.ErrorIs(t, err, errs.ErrFollowRequestNotFound)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_UpdatePrivacy_PublicApprovesPendingRequests(t *testing.T) {
+	mockDB := &mockUserStorage{}
+	mockTimeline := newMockTimelineService()
+
+	service := user.NewUserService(mockDB, &mockMediaService{}, mockTimeline, &mockTweetService{}, &mockTokenStorage{})
+
+	mockDB.On("UpdateUserPrivacy", mock.Anything, 2, false).Return(nil).Once()
+	mockDB.On("ApproveAllFollowRequests", mock.Anything, 2, mock.AnythingOfType("time.Time")).Return([]int{3, 4}, nil).Once()
+
+	err := service.UpdatePrivacy(context.Background(), &entity.UpdatePrivacy{UserID: 2, IsPrivate: false})
+
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return mockTimeline.AssertCalled(&testing.T{}, "Backfill", mock.Anything, 3, 2) &&
+			mockTimeline.AssertCalled(&testing.T{}, "Backfill", mock.Anything, 4, 2)
+	}, time.Second, 10*time.Millisecond)
+	mockDB.AssertExpectations(t)
+}
+
+func TestService_GetUserProfile_PrivateHiddenFromNonFollower(t *testing.T) {
+	mockDB := &mockUserStorage{}
+	mockMedia := &mockMediaService{}
+
+	service := user.NewUserService(mockDB, mockMedia, newMockTimelineService(), &mockTweetService{}, &mockTokenStorage{})
+
+	ctx := entity.WithViewer(context.Background(), 2)
+
+	mockDB.On("GetUserByUsername", mock.Anything, "private").Return(&entity.User{ID: 1, Username: "private", IsPrivate: true}, nil).Once()
+	mockMedia.On("GetAvatarUrlByUserID", mock.Anything, 1).Return("", nil).Once()
+	mockDB.On("IsBlockedBetween", mock.Anything, 2, 1).Return(false, nil).Once()
+	mockDB.On("IsFollowing", mock.Anything, 2, 1).Return(false, nil).Once()
+
+	profile, err := service.GetUserProfile(ctx, "private", &entity.Page{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.True(t, profile.User.IsPrivate)
+	assert.Empty(t, profile.Tweets)
+	mockDB.AssertNotCalled(t, "GetTweetsAndRetweetsByUsername", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+type AuditAction string
+
+const (
+	AuditDeactivateUser   AuditAction = "deactivate_user"
+	AuditReactivateUser   AuditAction = "reactivate_user"
+	AuditDeleteTweet      AuditAction = "delete_tweet"
+	AuditDeleteTweetMedia AuditAction = "delete_tweet_media"
+	AuditClaimReport      AuditAction = "claim_report"
+	AuditResolveReport    AuditAction = "resolve_report"
+)
+
+type AuditTarget string
+
+const (
+	AuditTargetUser   AuditTarget = "user"
+	AuditTargetTweet  AuditTarget = "tweet"
+	AuditTargetReport AuditTarget = "report"
+)
+
+type (
+	// Claims is the identity carried by a verified access token.
+	Claims struct {
+		UserID    int
+		Role      string
+		SessionID string
+		TokenID   string
+		IssuedAt  time.Time
+		ExpiresAt time.Time
+	}
+
+	// AuditEntry records a single moderation action taken by an admin.
+	AuditEntry struct {
+		ID         int
+		AdminID    int
+		Action     AuditAction
+		TargetType AuditTarget
+		TargetID   int
+		CreatedAt  time.Time
+	}
+)
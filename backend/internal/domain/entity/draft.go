@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+type (
+	// Draft is a tweet composed but not published yet. A draft with ScheduledAt set is
+	// published by the scheduler once that time passes; LastError tells why the last
+	// attempt failed.
+	Draft struct {
+		ID            int
+		UserID        int
+		Content       string
+		ParentTweetID *int
+		QuotedTweetID *int
+		Media         *UploadedMedia
+		MediaUrl      string
+		File          *File
+		ScheduledAt   *time.Time
+		Attempts      int
+		LastError     string
+		CreatedAt     time.Time
+		UpdatedAt     time.Time
+	}
+
+	// UploadedMedia is a file already stored in object storage but not attached to a tweet yet.
+	UploadedMedia struct {
+		Path      string
+		MimeType  string
+		SizeBytes int64
+	}
+)
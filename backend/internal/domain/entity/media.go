@@ -17,12 +17,23 @@ type (
 		ForceMimeType string
 	}
 
+	// TweetMedia is one attachment of a tweet; Position orders the attachments of a tweet.
 	TweetMedia struct {
 		ID        int
 		TweetID   int
+		Position  int
 		Path      string
 		MimeType  string
 		SizeBytes int64
+		AltText   string
+	}
+
+	// Attachment is a file to attach to a tweet together with its alt text. Instead of
+	// a File it may refer to a completed resumable upload by UploadID.
+	Attachment struct {
+		File     *File
+		UploadID int
+		AltText  string
 	}
 
 	Avatar struct {
@@ -0,0 +1,77 @@
+package entity
+
+import "time"
+
+const (
+	// MaxConversationParticipants bounds a group conversation, its creator included.
+	MaxConversationParticipants = 10
+	MaxMessageLength            = 1000
+)
+
+type FrameType string
+
+// Frame types never collide with a NotificationType, so clients can tell
+// chat frames from notifications on the same WebSocket.
+const (
+	FrameMessage     FrameType = "message"
+	FrameReadReceipt FrameType = "read_receipt"
+)
+
+type (
+	Conversation struct {
+		ID            int
+		CreatorID     int
+		IsGroup       bool
+		Participants  []Participant
+		LastMessage   *DirectMessage
+		UnreadCount   int
+		CreatedAt     time.Time
+		LastMessageAt time.Time
+	}
+
+	// Participant is a member of a conversation together with its read receipt.
+	Participant struct {
+		User              SmallUser
+		LastReadMessageID *int
+		LastReadAt        *time.Time
+	}
+
+	DirectMessage struct {
+		ID             int
+		ConversationID int
+		Sender         *SmallUser
+		Content        string
+		MediaUrl       string
+		File           *File
+		CreatedAt      time.Time
+	}
+
+	MessageMedia struct {
+		ID        int
+		MessageID int
+		Path      string
+		MimeType  string
+		SizeBytes int64
+	}
+
+	ReadReceipt struct {
+		ConversationID int
+		UserID         int
+		MessageID      int
+		ReadAt         time.Time
+	}
+
+	// ChatFrame is pushed over the WebSocket alongside notifications.
+	ChatFrame struct {
+		Type           FrameType `json:"type"`
+		RecipientID    int       `json:"recipient_id"`
+		ConversationID int       `json:"conversation_id"`
+		MessageID      int       `json:"message_id"`
+		SenderID       int       `json:"sender_id,omitempty"`
+		SenderName     string    `json:"sender_name,omitempty"`
+		Content        string    `json:"content,omitempty"`
+		MediaUrl       string    `json:"media_url,omitempty"`
+		ReaderID       int       `json:"reader_id,omitempty"`
+		Timestamp      time.Time `json:"timestamp"`
+	}
+)
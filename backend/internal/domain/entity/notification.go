@@ -9,6 +9,13 @@ const (
 	NotificationRetweet NotificationType = "retweet"
 	NotificationReply   NotificationType = "reply"
 	NotificationFollow  NotificationType = "follow"
+	NotificationQuote   NotificationType = "quote"
+	NotificationMention NotificationType = "mention"
+
+	NotificationFollowRequest NotificationType = "follow_request"
+	NotificationFollowAccept  NotificationType = "follow_accept"
+
+	NotificationReportResolved NotificationType = "report_resolved"
 )
 
 type Notification struct {
@@ -20,6 +27,8 @@ type Notification struct {
 	ActorAvatar string           `json:"actor_avatar,omitempty"`
 	TweetID     *int             `json:"tweet_id,omitempty"`
 	TweetText   *string          `json:"tweet_text,omitempty"`
+	ReportID    *int             `json:"report_id,omitempty"`
+	Resolution  *string          `json:"resolution,omitempty"`
 	Timestamp   time.Time        `json:"timestamp"`
 	Read        bool             `json:"read"`
 }
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type OutboxEvent struct {
+	ID        int64
+	Topic     string
+	Payload   []byte
+	Attempts  int
+	CreatedAt time.Time
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/errs"
+)
+
+type (
+	// Cursor points at the last item of a page ordered by (created_at, id) descending.
+	Cursor struct {
+		CreatedAt time.Time
+		ID        int
+	}
+
+	// Page selects a part of a list. When After is set the page starts right
+	// after it and Offset is ignored.
+	Page struct {
+		Limit  int
+		Offset int
+		After  *Cursor
+	}
+)
+
+// Encode returns the opaque string form of the cursor handed out to clients.
+func (c *Cursor) Encode() string {
+	if c == nil {
+		return ""
+	}
+	raw := fmt.Sprintf("%d:%d", c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by Encode. An empty string yields a nil cursor.
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errs.ErrInvalidCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, errs.ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, errs.ErrInvalidCursor
+	}
+	cursorID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.ErrInvalidCursor
+	}
+	return &Cursor{
+		CreatedAt: time.Unix(0, nanos).UTC(),
+		ID:        cursorID,
+	}, nil
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// RateLimit is the state of one rate limit counter right after a request was counted
+// against it. RetryAfter is set only when the request was rejected.
+type RateLimit struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAt    time.Time
+	RetryAfter time.Duration
+}
@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+type ReportTarget string
+
+const (
+	ReportTargetTweet ReportTarget = "tweet"
+	ReportTargetUser  ReportTarget = "user"
+)
+
+type ReportReason string
+
+const (
+	ReportSpam           ReportReason = "spam"
+	ReportHarassment     ReportReason = "harassment"
+	ReportHate           ReportReason = "hate"
+	ReportViolence       ReportReason = "violence"
+	ReportAdultContent   ReportReason = "adult_content"
+	ReportMisinformation ReportReason = "misinformation"
+	ReportOther          ReportReason = "other"
+)
+
+type ReportStatus string
+
+const (
+	ReportOpen     ReportStatus = "open"
+	ReportClaimed  ReportStatus = "claimed"
+	ReportResolved ReportStatus = "resolved"
+)
+
+type ReportResolution string
+
+const (
+	ResolutionDismiss       ReportResolution = "dismiss"
+	ResolutionDeleteContent ReportResolution = "delete_content"
+	ResolutionSuspendUser   ReportResolution = "suspend_user"
+)
+
+// Report is a user complaint about a tweet or an account. ReportedUserID is the account
+// behind the target: the user itself or the author of the tweet.
+type Report struct {
+	ID             int
+	ReporterID     int
+	TargetType     ReportTarget
+	TargetID       int
+	ReportedUserID int
+	Reason         ReportReason
+	Details        string
+	Status         ReportStatus
+	ClaimedBy      int
+	Resolution     ReportResolution
+	CreatedAt      time.Time
+	ResolvedAt     *time.Time
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type (
+	// Device describes the client a session was opened from.
+	Device struct {
+		UserAgent string
+		IP        string
+	}
+
+	// Session is one signed-in device. Its refresh token rotates on every refresh
+	// while the session ID stays the same for the whole rotation chain.
+	Session struct {
+		ID         string
+		UserID     int
+		Device     Device
+		CreatedAt  time.Time
+		LastUsedAt time.Time
+		Current    bool
+	}
+)
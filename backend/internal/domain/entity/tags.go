@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A tag starts a word: it is either at the beginning of the text or follows a
+// character that cannot be part of a word, so "a#b" and "mail@host" are not tags.
+var (
+	hashtagPattern = regexp.MustCompile(`(^|[^\p{L}\p{N}_#@])#([\p{L}\p{N}_]{1,100})`)
+	mentionPattern = regexp.MustCompile(`(^|[^\p{L}\p{N}_#@])@([A-Za-z0-9]{1,50})`)
+	hashtagText    = regexp.MustCompile(`^[\p{L}\p{N}_]{1,100}$`)
+)
+
+// ParseHashtags returns the distinct hashtags of content, lowercased and without
+// the leading '#', in order of first appearance.
+func ParseHashtags(content string) []string {
+	return parseTags(hashtagPattern, content, strings.ToLower)
+}
+
+// ParseMentions returns the distinct usernames mentioned in content, without the
+// leading '@', in order of first appearance.
+func ParseMentions(content string) []string {
+	return parseTags(mentionPattern, content, func(s string) string { return s })
+}
+
+// NormalizeHashtag turns user input like "#Go" into the stored form "go".
+func NormalizeHashtag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	if !hashtagText.MatchString(tag) {
+		return "", false
+	}
+	return tag, true
+}
+
+// StripTags removes hashtags and mentions from content, leaving the plain text.
+func StripTags(content string) string {
+	content = hashtagPattern.ReplaceAllString(content, "$1")
+	content = mentionPattern.ReplaceAllString(content, "$1")
+	return strings.TrimSpace(content)
+}
+
+func parseTags(pattern *regexp.Regexp, content string, normalize func(string) string) []string {
+	matches := pattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+	for _, m := range matches {
+		tag := normalize(m[2])
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		res = append(res, tag)
+	}
+	return res
+}
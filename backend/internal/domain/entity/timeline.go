@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// TimelineEntry is a tweet placed on a home timeline. ActorID is the followed
+// account that brought it there: the author, or the user who retweeted it.
+type TimelineEntry struct {
+	TweetID   int
+	ActorID   int
+	CreatedAt time.Time
+}
@@ -0,0 +1,28 @@
+package entity
+
+type TrendKind string
+
+const (
+	TrendHashtag TrendKind = "hashtag"
+	TrendTopic   TrendKind = "topic"
+)
+
+type (
+	// TrendCandidate is a term used in recent tweets together with how often it
+	// was used in the recent window and in the longer baseline window before it.
+	TrendCandidate struct {
+		Term     string
+		Kind     TrendKind
+		Recent   int
+		Baseline int
+	}
+
+	// Trend is a term that is gaining use. Velocity compares its recent rate of use
+	// with its baseline rate: 1 means steady, higher means it is picking up.
+	Trend struct {
+		Term     string
+		Kind     TrendKind
+		Count    int
+		Velocity float64
+	}
+)
@@ -8,19 +8,58 @@ type (
 	Tweet struct {
 		ID            int
 		ParentTweetID *int
+		QuotedTweetID *int
 		Content       string
 		CreatedAt     time.Time
 		UpdatedAt     time.Time
-		MediaUrl      string
-		Author        *SmallUser
-		File          *File
-		Counters      *Counters
+		// RevisionCount is the number of times the tweet was edited.
+		RevisionCount int
+		// Media lists the attachments of the tweet in order, with presigned urls as paths.
+		Media  []TweetMedia
+		Author *SmallUser
+		// RetweetedBy is the user whose retweet put the tweet on a profile, nil for own tweets.
+		RetweetedBy *SmallUser
+		Attachments []Attachment
+		// Uploaded is attached instead of Attachments when it was uploaded beforehand, and
+		// DraftID names the draft the tweet is published from, which is consumed with it.
+		Uploaded    *UploadedMedia
+		DraftID     int
+		Counters    *Counters
+		Viewer      *ViewerState
+		QuotedTweet *Tweet
+		Hashtags    []string
+		Mentions    []SmallUser
 	}
 
 	Counters struct {
-		ReplyCount   int
-		RetweetCount int
-		LikeCount    int
+		ReplyCount    int
+		RetweetCount  int
+		LikeCount     int
+		BookmarkCount int
+		QuoteCount    int
+	}
+
+	// Thread is the conversation around a focal tweet: its ancestors from the root
+	// down and a depth-limited tree of replies.
+	Thread struct {
+		Ancestors []Tweet
+		Tweet     *Tweet
+		Replies   []ThreadReply
+	}
+
+	ThreadReply struct {
+		Tweet   Tweet
+		Replies []ThreadReply
+	}
+
+	// TweetRevision is a superseded version of an edited tweet. CreatedAt is when the
+	// version was published and ReplacedAt when an edit replaced it.
+	TweetRevision struct {
+		ID         int
+		TweetID    int
+		Content    string
+		CreatedAt  time.Time
+		ReplacedAt time.Time
 	}
 
 	Retweet struct {
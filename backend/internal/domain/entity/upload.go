@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// Upload is a resumable upload of a large file. Chunks are written at Offset until it
+// reaches Size; a completed upload can then be attached to a tweet once until ExpiresAt.
+// Path and StorageUploadID are set once the first chunk arrives. ChunkSize is the size
+// every chunk but the last one must have.
+type Upload struct {
+	ID              int
+	UserID          int
+	Filename        string
+	MediaType       MediaType
+	Size            int64
+	Offset          int64
+	ChunkSize       int64
+	Path            string
+	MimeType        string
+	StorageUploadID string
+	CompletedAt     *time.Time
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+// Completed reports whether every chunk of the upload was stored and assembled.
+func (u *Upload) Completed() bool {
+	return u.CompletedAt != nil
+}
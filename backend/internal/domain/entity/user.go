@@ -18,10 +18,6 @@ type (
 		Access string
 	}
 
-	Recovery struct {
-		Recovery string
-	}
-
 	Tokens struct {
 		Access  *Access
 		Refresh *Refresh
@@ -42,6 +38,10 @@ type (
 		NewPassword   string
 	}
 
+	VerifyEmail struct {
+		VerificationToken string
+	}
+
 	User struct {
 		ID          int
 		Username    string
@@ -50,18 +50,24 @@ type (
 		CreatedAt   time.Time
 		IsSuperuser bool
 		IsActive    bool
-		AvatarUrl   string
-		Credential  *Credential
+		IsPrivate   bool
+		// EmailVerified stays false until the link sent on sign-up is followed.
+		EmailVerified bool
+		AvatarUrl     string
+		Credential    *Credential
 	}
 
 	UserProfile struct {
-		User   *User
-		Tweets []Tweet
+		User       *User
+		Tweets     []Tweet
+		NextCursor *Cursor
 	}
 
+	// Follow is Pending while a follow request to a private account awaits approval.
 	Follow struct {
 		FollowerID  int
 		FollowingID int
+		Pending     bool
 		CreatedAt   time.Time
 	}
 
@@ -88,4 +94,9 @@ type (
 		UserID int
 		Bio    string
 	}
+
+	UpdatePrivacy struct {
+		UserID    int
+		IsPrivate bool
+	}
 )
@@ -0,0 +1,25 @@
+package entity
+
+import "context"
+
+type (
+	// ViewerState describes how the signed-in user has interacted with a tweet.
+	ViewerState struct {
+		Liked      bool
+		Retweeted  bool
+		Bookmarked bool
+	}
+
+	viewerCtxKey struct{}
+)
+
+// WithViewer marks ctx as belonging to a request made by the given user.
+func WithViewer(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, viewerCtxKey{}, userID)
+}
+
+// ViewerFromContext returns the signed-in user of the request, if any.
+func ViewerFromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(viewerCtxKey{}).(int)
+	return userID, ok && userID != 0
+}
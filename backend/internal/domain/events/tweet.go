@@ -1,5 +1,7 @@
 package events
 
+import "time"
+
 var (
 	TweetCreateEvent EventType = "tweet.created"
 	TweetUpdateEvent EventType = "tweet.updated"
@@ -13,6 +15,9 @@ type (
 		Content   string    `json:"content"`
 		UserID    int       `json:"user_id"`
 		Username  string    `json:"username"`
+		CreatedAt time.Time `json:"created_at"`
+		Hashtags  []string  `json:"hashtags,omitempty"`
+		Mentions  []string  `json:"mentions,omitempty"`
 	}
 
 	TweetDeleted struct {
@@ -3,19 +3,59 @@ package errs
 import "errors"
 
 var (
-	ErrUsernameAlreadyUsed = errors.New("username already used")
-	ErrEmailAlreadyUsed    = errors.New("email already used")
-	ErrInvalidInput        = errors.New("invalid input data")
-	ErrInvalidCredentials  = errors.New("invalid credential")
-	ErrTokenNotFound       = errors.New("refresh token not found")
-	ErrInvalidRefreshToken = errors.New("invalid refresh token")
-	ErrUserNotFound        = errors.New("user not found")
-	ErrTweetNotFound       = errors.New("tweet not found")
-	ErrTweetMediaNotFound  = errors.New("tweet media not found")
-	ErrUnauthorizedUpdate  = errors.New("user is not authorized to update this tweet")
-
-	ErrFileTooLarge     = errors.New("file too large")
-	ErrInvalidmediaType = errors.New("invalid media type")
+	ErrUsernameAlreadyUsed      = errors.New("username already used")
+	ErrEmailAlreadyUsed         = errors.New("email already used")
+	ErrInvalidInput             = errors.New("invalid input data")
+	ErrInvalidCursor            = errors.New("invalid cursor")
+	ErrInvalidHashtag           = errors.New("invalid hashtag")
+	ErrInvalidCredentials       = errors.New("invalid credential")
+	ErrTokenNotFound            = errors.New("refresh token not found")
+	ErrInvalidRefreshToken      = errors.New("invalid refresh token")
+	ErrRefreshTokenReused       = errors.New("refresh token reused")
+	ErrSessionNotFound          = errors.New("session not found")
+	ErrAccessRevoked            = errors.New("access token revoked")
+	ErrInvalidRecoveryToken     = errors.New("invalid recovery token")
+	ErrInvalidVerificationToken = errors.New("invalid verification token")
+	ErrEmailAlreadyVerified     = errors.New("email already verified")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrUserInactive             = errors.New("user is deactivated")
+	ErrTweetNotFound            = errors.New("tweet not found")
+	ErrTweetMediaNotFound       = errors.New("tweet media not found")
+	ErrUnauthorizedUpdate       = errors.New("user is not authorized to update this tweet")
+	ErrEditWindowClosed         = errors.New("tweet can no longer be edited")
+	ErrEditLimitReached         = errors.New("tweet edit limit reached")
+	ErrPrivateRetweet           = errors.New("tweets of private accounts cannot be retweeted")
+
+	ErrNotificationNotFound  = errors.New("notification not found")
+	ErrBookmarkNotFound      = errors.New("bookmark not found")
+	ErrBlocked               = errors.New("user is blocked")
+	ErrFollowRequestNotFound = errors.New("follow request not found")
+
+	ErrReportNotFound     = errors.New("report not found")
+	ErrReportNotClaimable = errors.New("report is claimed by another moderator or already resolved")
+	ErrReportNotClaimed   = errors.New("report must be claimed before it is resolved")
+
+	ErrDraftNotFound   = errors.New("draft not found")
+	ErrEmptyDraft      = errors.New("draft is empty")
+	ErrInvalidSchedule = errors.New("scheduled time must be in the future")
+
+	ErrConversationNotFound = errors.New("conversation not found")
+	ErrMessageNotFound      = errors.New("message not found")
+	ErrNotMutualFollow      = errors.New("participants must follow each other")
+	ErrTooManyParticipants  = errors.New("too many participants")
+	ErrEmptyMessage         = errors.New("message is empty")
+
+	ErrFileTooLarge       = errors.New("file too large")
+	ErrInvalidmediaType   = errors.New("invalid media type")
+	ErrTooManyAttachments = errors.New("too many attachments")
+	ErrMixedAttachments   = errors.New("attachments must share one media type")
+	ErrAltTextTooLong     = errors.New("alt text too long")
+
+	ErrUploadNotFound       = errors.New("upload not found")
+	ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+	ErrInvalidChunk         = errors.New("invalid upload chunk")
+	ErrUploadIncomplete     = errors.New("upload is incomplete")
+	ErrUploadCompleted      = errors.New("upload is already completed")
 
 	ErrCacheKeyNotFound = errors.New("key not found")
 )
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// noopDriver lets tests obtain a real *sql.Tx without a database.
+type noopDriver struct{}
+
+func (noopDriver) Open(name string) (driver.Conn, error) { return noopConn{}, nil }
+
+type noopConn struct{}
+
+func (noopConn) Prepare(query string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (noopConn) Close() error                              { return nil }
+func (noopConn) Begin() (driver.Tx, error)                 { return noopTx{}, nil }
+
+type noopTx struct{}
+
+func (noopTx) Commit() error   { return nil }
+func (noopTx) Rollback() error { return nil }
+
+var registerNoopDriver sync.Once
+
+// NewTestTx returns a transaction that commits and rolls back without a database, for
+// services that only pass the transaction on to mocked storage.
+func NewTestTx(t testing.TB) *sql.Tx {
+	t.Helper()
+	registerNoopDriver.Do(func() {
+		sql.Register("noop", noopDriver{})
+	})
+	db, err := sql.Open("noop", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
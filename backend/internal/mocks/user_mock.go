@@ -12,12 +12,12 @@ type MockUserRepository interface {
 	UpdateBio(ctx context.Context, userID int, bio string) error
 	FollowUser(ctx context.Context, followerID, followingID int) error
 	UnfollowUser(ctx context.Context, followerID, followingID int) error
-	GetFollowers(ctx context.Context, username string, limit, offset int) ([]entity.SmallUser, error)
-	GetFollowings(ctx context.Context, username string, limit, offset int) ([]entity.SmallUser, error)
+	GetFollowers(ctx context.Context, username string, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
+	GetFollowings(ctx context.Context, username string, page *entity.Page) ([]entity.SmallUser, *entity.Cursor, error)
 	DeleteUser(ctx context.Context, userID int) error
-	GetUserProfile(ctx context.Context, username string, limit, offset int) (*entity.UserProfile, error)
+	GetUserProfile(ctx context.Context, username string, page *entity.Page) (*entity.UserProfile, error)
 }
 
 type MockTweetService interface {
-	GetTweetsAndRetweetsByUsername(ctx context.Context, username string, limit, offset int) ([]entity.Tweet, error)
+	GetTweetsAndRetweetsByUsername(ctx context.Context, username string, page *entity.Page) ([]entity.Tweet, *entity.Cursor, error)
 }
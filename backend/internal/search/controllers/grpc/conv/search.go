@@ -1,6 +1,9 @@
 package conv
 
-import searchproto "github.com/kust1q/Zapp/backend/pkg/gen/proto/search"
+import (
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	searchproto "github.com/kust1q/Zapp/backend/pkg/gen/proto/search"
+)
 
 func ToSearchUserProtoResponse(ids []int) *searchproto.SearchUsersResponse {
 	res := make([]int64, 0, len(ids))
@@ -21,3 +24,18 @@ func ToSearchTweetProtoResponse(ids []int) *searchproto.SearchTweetsResponse {
 		TweetIds: res,
 	}
 }
+
+func ToTrendsProtoResponse(trends []entity.Trend) *searchproto.GetTrendsResponse {
+	res := make([]*searchproto.Trend, 0, len(trends))
+	for _, trend := range trends {
+		res = append(res, &searchproto.Trend{
+			Term:     trend.Term,
+			Kind:     string(trend.Kind),
+			Count:    int64(trend.Count),
+			Velocity: trend.Velocity,
+		})
+	}
+	return &searchproto.GetTrendsResponse{
+		Trends: res,
+	}
+}
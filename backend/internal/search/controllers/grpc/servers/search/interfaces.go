@@ -2,11 +2,14 @@ package searchgrpc
 
 import (
 	"context"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 )
 
 type (
 	searchService interface {
 		SearchTweets(ctx context.Context, query string) ([]int, error)
 		SearchUsers(ctx context.Context, query string) ([]int, error)
+		GetTrends(ctx context.Context, limit int) ([]entity.Trend, error)
 	}
 )
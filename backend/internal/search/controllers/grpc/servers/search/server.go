@@ -44,3 +44,15 @@ func (s *searchServiceAPI) SearchTweets(ctx context.Context, req *searchproto.Se
 	}
 	return conv.ToSearchTweetProtoResponse(tweets), nil
 }
+
+func (s *searchServiceAPI) GetTrends(ctx context.Context, req *searchproto.GetTrendsRequest) (*searchproto.GetTrendsResponse, error) {
+	trends, err := s.searchService.GetTrends(ctx, int(req.Limit))
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"limit":   req.Limit,
+			"service": "search",
+		}).Error("failed to get trends")
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	return conv.ToTrendsProtoResponse(trends), nil
+}
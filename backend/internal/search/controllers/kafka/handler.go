@@ -49,6 +49,11 @@ func (h *eventSearchHandler) handleTweet(ctx context.Context, data []byte) error
 				ID:       ev.UserID,
 				Username: ev.Username,
 			},
+			CreatedAt: ev.CreatedAt,
+			Hashtags:  ev.Hashtags,
+		}
+		for _, username := range ev.Mentions {
+			tweet.Mentions = append(tweet.Mentions, entity.SmallUser{Username: username})
 		}
 		return h.searchService.IndexTweet(ctx, &tweet)
 
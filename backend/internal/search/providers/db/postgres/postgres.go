@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/lib/pq"
+)
+
+const (
+	UserTable          = "users"
+	TweetsTable        = "tweets"
+	HashtagsTable      = "hashtags"
+	TweetHashtagsTable = "tweet_hashtags"
+	TweetMentionsTable = "tweet_mentions"
+	OutboxTable        = "outbox"
+)
+
+// sourceDB reads tweets and users in id order for rebuilding search indices, and the
+// outbox events written while a rebuild was running.
+type sourceDB struct {
+	db *sqlx.DB
+}
+
+func NewSourceDB(db *sqlx.DB) *sourceDB {
+	return &sourceDB{db: db}
+}
+
+type (
+	tweetRow struct {
+		ID        int            `db:"id"`
+		Content   string         `db:"content"`
+		UserID    int            `db:"user_id"`
+		Username  string         `db:"username"`
+		CreatedAt time.Time      `db:"created_at"`
+		Hashtags  pq.StringArray `db:"hashtags"`
+		Mentions  pq.StringArray `db:"mentions"`
+	}
+
+	userRow struct {
+		ID       int    `db:"id"`
+		Username string `db:"username"`
+		Bio      string `db:"bio"`
+	}
+
+	outboxRow struct {
+		ID        int64     `db:"id"`
+		Topic     string    `db:"topic"`
+		Payload   []byte    `db:"payload"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+)
+
+// GetTweetsAfterID returns up to limit tweets with id > afterID changed at or after since.
+func (s *sourceDB) GetTweetsAfterID(ctx context.Context, afterID int, since time.Time, limit int) ([]entity.Tweet, error) {
+	query := fmt.Sprintf(`
+		SELECT t.id, t.content, t.user_id, u.username, t.created_at,
+			ARRAY(SELECT h.tag FROM %[3]s th JOIN %[4]s h ON h.id = th.hashtag_id WHERE th.tweet_id = t.id) AS hashtags,
+			ARRAY(SELECT mu.username FROM %[5]s tm JOIN %[2]s mu ON mu.id = tm.user_id WHERE tm.tweet_id = t.id) AS mentions
+		FROM %[1]s t
+		JOIN %[2]s u ON u.id = t.user_id
+		WHERE t.id > $1 AND t.updated_at >= $2
+		ORDER BY t.id
+		LIMIT $3`,
+		TweetsTable, UserTable, TweetHashtagsTable, HashtagsTable, TweetMentionsTable)
+
+	var rows []tweetRow
+	if err := s.db.SelectContext(ctx, &rows, query, afterID, since, limit); err != nil {
+		return nil, err
+	}
+
+	tweets := make([]entity.Tweet, 0, len(rows))
+	for _, row := range rows {
+		tweet := entity.Tweet{
+			ID:      row.ID,
+			Content: row.Content,
+			Author: &entity.SmallUser{
+				ID:       row.UserID,
+				Username: row.Username,
+			},
+			CreatedAt: row.CreatedAt,
+			Hashtags:  row.Hashtags,
+		}
+		for _, username := range row.Mentions {
+			tweet.Mentions = append(tweet.Mentions, entity.SmallUser{Username: username})
+		}
+		tweets = append(tweets, tweet)
+	}
+	return tweets, nil
+}
+
+// GetUsersAfterID returns up to limit users with id > afterID changed at or after since.
+func (s *sourceDB) GetUsersAfterID(ctx context.Context, afterID int, since time.Time, limit int) ([]entity.User, error) {
+	query := fmt.Sprintf(`
+		SELECT id, username, COALESCE(bio, '') AS bio
+		FROM %s
+		WHERE id > $1 AND updated_at >= $2
+		ORDER BY id
+		LIMIT $3`,
+		UserTable)
+
+	var rows []userRow
+	if err := s.db.SelectContext(ctx, &rows, query, afterID, since, limit); err != nil {
+		return nil, err
+	}
+
+	users := make([]entity.User, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, entity.User{
+			ID:       row.ID,
+			Username: row.Username,
+			Bio:      row.Bio,
+		})
+	}
+	return users, nil
+}
+
+// GetOutboxPosition returns the id of the latest outbox event, or 0 if there is none.
+func (s *sourceDB) GetOutboxPosition(ctx context.Context) (int64, error) {
+	query := fmt.Sprintf("SELECT COALESCE(MAX(id), 0) FROM %s", OutboxTable)
+
+	var position int64
+	if err := s.db.GetContext(ctx, &position, query); err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+// GetOutboxEventsAfterID returns up to limit outbox events of topics with id > afterID in id order.
+func (s *sourceDB) GetOutboxEventsAfterID(ctx context.Context, afterID int64, topics []string, limit int) ([]entity.OutboxEvent, error) {
+	query := fmt.Sprintf(`
+		SELECT id, topic, payload, created_at
+		FROM %s
+		WHERE id > $1 AND topic = ANY($2)
+		ORDER BY id
+		LIMIT $3`,
+		OutboxTable)
+
+	var rows []outboxRow
+	if err := s.db.SelectContext(ctx, &rows, query, afterID, pq.Array(topics), limit); err != nil {
+		return nil, err
+	}
+
+	outboxEvents := make([]entity.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		outboxEvents = append(outboxEvents, entity.OutboxEvent{
+			ID:        row.ID,
+			Topic:     row.Topic,
+			Payload:   row.Payload,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return outboxEvents, nil
+}
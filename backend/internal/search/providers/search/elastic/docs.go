@@ -1,10 +1,20 @@
 package elastic
 
+import (
+	"strings"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
 type (
 	tweetDoc struct {
-		Content  string `json:"content"`
-		Username string `json:"username"`
-		UserID   int    `json:"user_id"`
+		Content   string     `json:"content"`
+		Username  string     `json:"username"`
+		UserID    int        `json:"user_id"`
+		Hashtags  []string   `json:"hashtags"`
+		Mentions  []string   `json:"mentions"`
+		CreatedAt *time.Time `json:"created_at,omitempty"`
 	}
 
 	userDoc struct {
@@ -12,3 +22,22 @@ type (
 		Bio      string `json:"bio"`
 	}
 )
+
+func newTweetDoc(tweet *entity.Tweet) tweetDoc {
+	doc := tweetDoc{
+		Content:  tweet.Content,
+		Hashtags: tweet.Hashtags,
+		Mentions: make([]string, 0, len(tweet.Mentions)),
+	}
+	if !tweet.CreatedAt.IsZero() {
+		doc.CreatedAt = &tweet.CreatedAt
+	}
+	if tweet.Author != nil {
+		doc.Username = tweet.Author.Username
+		doc.UserID = tweet.Author.ID
+	}
+	for _, mentioned := range tweet.Mentions {
+		doc.Mentions = append(doc.Mentions, strings.ToLower(mentioned.Username))
+	}
+	return doc
+}
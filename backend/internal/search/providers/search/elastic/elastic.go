@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
@@ -27,12 +28,7 @@ func NewElasticRepository(client *elasticsearch.Client) *elasticRepository {
 }
 
 func (r *elasticRepository) IndexTweet(ctx context.Context, tweet *entity.Tweet) error {
-	doc := tweetDoc{
-		Content:  tweet.Content,
-		Username: tweet.Author.Username,
-		UserID:   tweet.Author.ID,
-	}
-	return r.indexDocument(ctx, IndexTweets, tweet.ID, doc)
+	return r.indexDocument(ctx, IndexTweets, tweet.ID, newTweetDoc(tweet))
 }
 
 func (r *elasticRepository) IndexUser(ctx context.Context, user *entity.User) error {
@@ -148,14 +144,36 @@ func (r *elasticRepository) indexDocument(ctx context.Context, index string, id
 	return nil
 }
 
+// SearchTweets matches query text against content and author. Hashtags and
+// mentions in the query narrow the results to tweets carrying all of them.
 func (r *elasticRepository) SearchTweets(ctx context.Context, query string) ([]int, error) {
-	queryMap := map[string]any{
-		"query": map[string]any{
+	var filters []map[string]any
+	for _, tag := range entity.ParseHashtags(query) {
+		filters = append(filters, map[string]any{"term": map[string]any{"hashtags": tag}})
+	}
+	for _, username := range entity.ParseMentions(query) {
+		filters = append(filters, map[string]any{"term": map[string]any{"mentions": strings.ToLower(username)}})
+	}
+
+	var must map[string]any
+	if text := entity.StripTags(query); text != "" || len(filters) == 0 {
+		must = map[string]any{
 			"multi_match": map[string]any{
-				"query":     query,
+				"query":     text,
 				"fields":    []string{"content", "username"},
 				"fuzziness": "AUTO",
 			},
+		}
+	} else {
+		must = map[string]any{"match_all": map[string]any{}}
+	}
+
+	queryMap := map[string]any{
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must":   must,
+				"filter": filters,
+			},
 		},
 		"_source": false,
 	}
@@ -224,111 +242,3 @@ func (r *elasticRepository) performSearch(ctx context.Context, index string, que
 
 	return ids, nil
 }
-
-// func (r *elasticRepository) InitIndices(ctx context.Context) error {
-// 	settings := `
-//     {
-//         "settings": {
-//             "index": {
-//                 "max_ngram_diff": 10
-//             },
-//             "analysis": {
-//                 "analyzer": {
-//                     "ngram_analyzer": {
-//                         "tokenizer": "ngram_tokenizer",
-//                         "filter": ["lowercase"]
-//                     }
-//                 },
-//                 "tokenizer": {
-//                     "ngram_tokenizer": {
-//                         "type": "ngram",
-//                         "min_gram": 3,
-//                         "max_gram": 10,
-//                         "token_chars": ["letter", "digit"]
-//                     }
-//                 }
-//             }
-//         },`
-
-// 	usersBody := settings + `
-//         "mappings": {
-//             "properties": {
-//                 "username": {
-//                     "type": "text",
-//                     "analyzer": "ngram_analyzer",
-//                     "search_analyzer": "standard"
-//                 },
-//                 "bio": {
-//                     "type": "text",
-//                     "analyzer": "ngram_analyzer",
-//                     "search_analyzer": "standard"
-//                 },
-//                 "user_id": { "type": "integer" }
-//             }
-//         }
-//     }`
-
-// 	tweetsBody := settings + `
-//         "mappings": {
-//             "properties": {
-//                 "content": {
-//                     "type": "text",
-//                     "analyzer": "ngram_analyzer",
-//                     "search_analyzer": "standard"
-//                 },
-//                 "username": {
-//                     "type": "text",
-//                     "analyzer": "ngram_analyzer",
-//                     "search_analyzer": "standard"
-//                 },
-//                 "user_id": { "type": "integer" }
-//             }
-//         }
-//     }`
-
-// 	if err := r.createIndexIfNotExists(ctx, IndexUsers, usersBody); err != nil {
-// 		return err
-// 	}
-// 	if err := r.createIndexIfNotExists(ctx, IndexTweets, tweetsBody); err != nil {
-// 		return err
-// 	}
-// 	return nil
-// }
-
-// func (r *elasticRepository) createIndexIfNotExists(ctx context.Context, indexName, mapping string) error {
-// 	exists, err := r.client.Indices.Exists(
-// 		[]string{indexName},
-// 		r.client.Indices.Exists.WithContext(ctx),
-// 	)
-// 	if err != nil {
-// 		return fmt.Errorf("check index %s exists error: %w", indexName, err)
-// 	}
-// 	defer exists.Body.Close()
-
-// 	if exists.StatusCode == 200 {
-// 		return nil
-// 	}
-
-// 	if exists.StatusCode == 404 {
-// 		logrus.Infof("Creating index: %s", indexName)
-
-// 		res, err := r.client.Indices.Create(
-// 			indexName,
-// 			r.client.Indices.Create.WithBody(strings.NewReader(mapping)),
-// 			r.client.Indices.Create.WithContext(ctx),
-// 		)
-// 		if err != nil {
-// 			return fmt.Errorf("create index %s error: %w", indexName, err)
-// 		}
-// 		defer res.Body.Close()
-
-// 		if res.IsError() {
-// 			return fmt.Errorf("create index %s response error: %s", indexName, res.String())
-// 		}
-
-// 		logrus.Infof("Index %s created successfully", indexName)
-// 		return nil
-// 	}
-
-// 	return fmt.Errorf("unexpected status checking index %s: %s", indexName, exists.Status())
-// }
@@ -0,0 +1,336 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/sirupsen/logrus"
+)
+
+// IndexTweets and IndexUsers are aliases. Each one points to a single
+// versioned index (e.g. tweets_20250101120000) so a rebuild can fill a fresh
+// index and swap the alias without leaving search empty.
+
+const indexSettings = `
+	"settings": {
+		"index": {
+			"max_ngram_diff": 10,
+			"refresh_interval": "-1"
+		},
+		"analysis": {
+			"analyzer": {
+				"ngram_analyzer": {
+					"tokenizer": "ngram_tokenizer",
+					"filter": ["lowercase"]
+				},
+				"words_analyzer": {
+					"tokenizer": "standard",
+					"filter": ["lowercase", "english_stop", "russian_stop"]
+				}
+			},
+			"filter": {
+				"english_stop": { "type": "stop", "stopwords": "_english_" },
+				"russian_stop": { "type": "stop", "stopwords": "_russian_" }
+			},
+			"tokenizer": {
+				"ngram_tokenizer": {
+					"type": "ngram",
+					"min_gram": 3,
+					"max_gram": 10,
+					"token_chars": ["letter", "digit"]
+				}
+			}
+		}
+	}`
+
+var indexBodies = map[string]string{
+	IndexUsers: `{` + indexSettings + `,
+	"mappings": {
+		"properties": {
+			"username": {
+				"type": "text",
+				"analyzer": "ngram_analyzer",
+				"search_analyzer": "standard",
+				"fields": { "keyword": { "type": "keyword" } }
+			},
+			"bio": {
+				"type": "text",
+				"analyzer": "ngram_analyzer",
+				"search_analyzer": "standard"
+			}
+		}
+	}
+}`,
+	IndexTweets: `{` + indexSettings + `,
+	"mappings": {
+		"properties": {
+			"content": {
+				"type": "text",
+				"analyzer": "ngram_analyzer",
+				"search_analyzer": "standard",
+				"fields": { "words": { "type": "text", "analyzer": "words_analyzer" } }
+			},
+			"username": {
+				"type": "text",
+				"analyzer": "ngram_analyzer",
+				"search_analyzer": "standard",
+				"fields": { "keyword": { "type": "keyword" } }
+			},
+			"user_id": { "type": "integer" },
+			"hashtags": { "type": "keyword" },
+			"mentions": { "type": "keyword" },
+			"created_at": { "type": "date" }
+		}
+	}
+}`,
+}
+
+// InitIndices creates the tweets and users indices behind their aliases if neither exists yet.
+func (r *elasticRepository) InitIndices(ctx context.Context) error {
+	for _, alias := range []string{IndexUsers, IndexTweets} {
+		current, err := r.resolveAlias(ctx, alias)
+		if err != nil {
+			return err
+		}
+		if len(current) > 0 {
+			continue
+		}
+
+		index, err := r.CreateIndex(ctx, alias)
+		if err != nil {
+			return err
+		}
+		if err := r.FinishIndex(ctx, index); err != nil {
+			return err
+		}
+		if err := r.SwapAlias(ctx, alias, index); err != nil {
+			return err
+		}
+		logrus.Infof("index %s created for alias %s", index, alias)
+	}
+	return nil
+}
+
+// CreateIndex creates a new versioned index for alias and returns its name.
+// Refresh is disabled until FinishIndex is called.
+func (r *elasticRepository) CreateIndex(ctx context.Context, alias string) (string, error) {
+	body, ok := indexBodies[alias]
+	if !ok {
+		return "", fmt.Errorf("unknown index %s", alias)
+	}
+	index := fmt.Sprintf("%s_%s", alias, time.Now().UTC().Format("20060102150405"))
+
+	res, err := r.client.Indices.Create(
+		index,
+		r.client.Indices.Create.WithBody(strings.NewReader(body)),
+		r.client.Indices.Create.WithContext(ctx),
+	)
+	if err != nil {
+		return "", fmt.Errorf("create index %s error: %w", index, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("create index %s response error: %s", index, res.String())
+	}
+	return index, nil
+}
+
+// FinishIndex turns refresh back on and makes all loaded documents searchable.
+func (r *elasticRepository) FinishIndex(ctx context.Context, index string) error {
+	res, err := r.client.Indices.PutSettings(
+		strings.NewReader(`{"index": {"refresh_interval": "1s"}}`),
+		r.client.Indices.PutSettings.WithIndex(index),
+		r.client.Indices.PutSettings.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("put settings %s error: %w", index, err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("put settings %s response error: %s", index, res.String())
+	}
+
+	refresh, err := r.client.Indices.Refresh(
+		r.client.Indices.Refresh.WithIndex(index),
+		r.client.Indices.Refresh.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("refresh %s error: %w", index, err)
+	}
+	defer refresh.Body.Close()
+	if refresh.IsError() {
+		return fmt.Errorf("refresh %s response error: %s", index, refresh.String())
+	}
+	return nil
+}
+
+func (r *elasticRepository) DeleteIndex(ctx context.Context, index string) error {
+	res, err := r.client.Indices.Delete([]string{index}, r.client.Indices.Delete.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("delete index %s error: %w", index, err)
+	}
+	defer res.Body.Close()
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("delete index %s response error: %s", index, res.String())
+	}
+	return nil
+}
+
+// SwapAlias atomically points alias to index and drops the indices it pointed to before.
+// A legacy concrete index named like the alias is removed in the same request.
+func (r *elasticRepository) SwapAlias(ctx context.Context, alias, index string) error {
+	current, err := r.resolveAlias(ctx, alias)
+	if err != nil {
+		return err
+	}
+
+	actions := make([]map[string]any, 0, len(current)+1)
+	var stale []string
+	for _, old := range current {
+		if old == index {
+			continue
+		}
+		if old == alias {
+			actions = append(actions, map[string]any{"remove_index": map[string]any{"index": old}})
+			continue
+		}
+		actions = append(actions, map[string]any{"remove": map[string]any{"index": old, "alias": alias}})
+		stale = append(stale, old)
+	}
+	actions = append(actions, map[string]any{"add": map[string]any{"index": index, "alias": alias}})
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]any{"actions": actions}); err != nil {
+		return err
+	}
+
+	res, err := r.client.Indices.UpdateAliases(&buf, r.client.Indices.UpdateAliases.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("update aliases error: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("update aliases response error: %s", res.String())
+	}
+
+	for _, old := range stale {
+		if err := r.DeleteIndex(ctx, old); err != nil {
+			logrus.WithError(err).WithField("index", old).Warn("failed to delete stale index")
+		}
+	}
+	return nil
+}
+
+// resolveAlias returns the concrete indices behind name, or nil if nothing exists.
+func (r *elasticRepository) resolveAlias(ctx context.Context, name string) ([]string, error) {
+	res, err := r.client.Indices.Get([]string{name}, r.client.Indices.Get.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("get index %s error: %w", name, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("get index %s response error: %s", name, res.String())
+	}
+
+	var indices map[string]json.RawMessage
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(indices))
+	for index := range indices {
+		names = append(names, index)
+	}
+	return names, nil
+}
+
+func (r *elasticRepository) BulkIndexTweets(ctx context.Context, index string, tweets []entity.Tweet) error {
+	docs := make(map[int]any, len(tweets))
+	for i := range tweets {
+		docs[tweets[i].ID] = newTweetDoc(&tweets[i])
+	}
+	return r.bulkIndex(ctx, index, docs)
+}
+
+func (r *elasticRepository) BulkIndexUsers(ctx context.Context, index string, users []entity.User) error {
+	docs := make(map[int]any, len(users))
+	for _, user := range users {
+		docs[user.ID] = userDoc{
+			Username: user.Username,
+			Bio:      user.Bio,
+		}
+	}
+	return r.bulkIndex(ctx, index, docs)
+}
+
+func (r *elasticRepository) bulkIndex(ctx context.Context, index string, docs map[int]any) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for id, doc := range docs {
+		meta := map[string]any{"index": map[string]any{"_index": index, "_id": strconv.Itoa(id)}}
+		if err := enc.Encode(meta); err != nil {
+			return err
+		}
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+
+	req := esapi.BulkRequest{
+		Index: index,
+		Body:  &buf,
+	}
+	res, err := req.Do(ctx, r.client)
+	if err != nil {
+		return fmt.Errorf("bulk request error: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("bulk error: %s", res.String())
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.Errors {
+		return nil
+	}
+
+	for _, item := range result.Items {
+		for _, op := range item {
+			if op.Error != nil {
+				return fmt.Errorf("bulk item %s failed: %s: %s", op.ID, op.Error.Type, op.Error.Reason)
+			}
+		}
+	}
+	return fmt.Errorf("bulk request reported errors")
+}
@@ -0,0 +1,117 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// GetTrendCandidates counts hashtags and significant content terms of tweets created since
+// recentFrom and compares them with the baseline window [baselineFrom, recentFrom).
+// Hashtags are picked by recent volume; content terms by how unusual they are for the baseline.
+// Up to size of each are returned, so callers ranking by velocity should ask for more than they keep.
+func (r *elasticRepository) GetTrendCandidates(ctx context.Context, recentFrom, baselineFrom time.Time, size int) ([]entity.TrendCandidate, error) {
+	recent := map[string]any{"range": map[string]any{"created_at": map[string]any{"gte": recentFrom}}}
+	baseline := map[string]any{"range": map[string]any{"created_at": map[string]any{"gte": baselineFrom, "lt": recentFrom}}}
+
+	queryMap := map[string]any{
+		"size":  0,
+		"query": map[string]any{"range": map[string]any{"created_at": map[string]any{"gte": baselineFrom}}},
+		"aggs": map[string]any{
+			"hashtags": map[string]any{
+				"terms": map[string]any{
+					"field": "hashtags",
+					"size":  size,
+					"order": map[string]any{"recent": "desc"},
+				},
+				"aggs": map[string]any{
+					"recent": map[string]any{"filter": recent},
+				},
+			},
+			"recent": map[string]any{
+				"filter": recent,
+				"aggs": map[string]any{
+					"topics": map[string]any{
+						"significant_text": map[string]any{
+							"field":                 "content.words",
+							"source_fields":         []string{"content"},
+							"size":                  size,
+							"filter_duplicate_text": true,
+							"background_filter":     baseline,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(queryMap); err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithContext(ctx),
+		r.client.Search.WithIndex(IndexTweets),
+		r.client.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("trends aggregation error: %s", res.String())
+	}
+
+	var result struct {
+		Aggregations struct {
+			Hashtags struct {
+				Buckets []struct {
+					Key      string `json:"key"`
+					DocCount int    `json:"doc_count"`
+					Recent   struct {
+						DocCount int `json:"doc_count"`
+					} `json:"recent"`
+				} `json:"buckets"`
+			} `json:"hashtags"`
+			Recent struct {
+				Topics struct {
+					Buckets []struct {
+						Key      string `json:"key"`
+						DocCount int    `json:"doc_count"`
+						BgCount  int    `json:"bg_count"`
+					} `json:"buckets"`
+				} `json:"topics"`
+			} `json:"recent"`
+		} `json:"aggregations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	hashtags := result.Aggregations.Hashtags.Buckets
+	topics := result.Aggregations.Recent.Topics.Buckets
+	candidates := make([]entity.TrendCandidate, 0, len(hashtags)+len(topics))
+	for _, b := range hashtags {
+		candidates = append(candidates, entity.TrendCandidate{
+			Term:     b.Key,
+			Kind:     entity.TrendHashtag,
+			Recent:   b.Recent.DocCount,
+			Baseline: b.DocCount - b.Recent.DocCount,
+		})
+	}
+	for _, b := range topics {
+		candidates = append(candidates, entity.TrendCandidate{
+			Term:     b.Key,
+			Kind:     entity.TrendTopic,
+			Recent:   b.DocCount,
+			Baseline: b.BgCount,
+		})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,30 @@
+package reindex
+
+import (
+	"context"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+type (
+	source interface {
+		GetTweetsAfterID(ctx context.Context, afterID int, since time.Time, limit int) ([]entity.Tweet, error)
+		GetUsersAfterID(ctx context.Context, afterID int, since time.Time, limit int) ([]entity.User, error)
+		GetOutboxPosition(ctx context.Context) (int64, error)
+		GetOutboxEventsAfterID(ctx context.Context, afterID int64, topics []string, limit int) ([]entity.OutboxEvent, error)
+	}
+
+	indexRepository interface {
+		CreateIndex(ctx context.Context, alias string) (string, error)
+		FinishIndex(ctx context.Context, index string) error
+		DeleteIndex(ctx context.Context, index string) error
+		SwapAlias(ctx context.Context, alias, index string) error
+		BulkIndexTweets(ctx context.Context, index string, tweets []entity.Tweet) error
+		BulkIndexUsers(ctx context.Context, index string, users []entity.User) error
+	}
+
+	eventHandler interface {
+		Handle(ctx context.Context, topic string, data []byte) error
+	}
+)
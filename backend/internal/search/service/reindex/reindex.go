@@ -0,0 +1,166 @@
+package reindex
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/events"
+	"github.com/kust1q/Zapp/backend/internal/search/providers/search/elastic"
+	"github.com/sirupsen/logrus"
+)
+
+type reindexService struct {
+	source    source
+	repo      indexRepository
+	handler   eventHandler
+	batchSize int
+}
+
+// NewReindexService builds a service that rebuilds indices from source. The handler
+// applies the search events written during a rebuild, the way the live consumer does.
+func NewReindexService(source source, repo indexRepository, handler eventHandler, batchSize int) *reindexService {
+	return &reindexService{
+		source:    source,
+		repo:      repo,
+		handler:   handler,
+		batchSize: batchSize,
+	}
+}
+
+// ReindexTweets rebuilds the tweets index. User events are replayed as well,
+// since deleting a user removes their tweets.
+func (s *reindexService) ReindexTweets(ctx context.Context) error {
+	return s.rebuild(ctx, elastic.IndexTweets, []string{events.TopicTweet, events.TopicUser}, s.loadTweets)
+}
+
+func (s *reindexService) ReindexUsers(ctx context.Context) error {
+	return s.rebuild(ctx, elastic.IndexUsers, []string{events.TopicUser}, s.loadUsers)
+}
+
+// rebuild fills a fresh index and swaps the alias to it once it is complete.
+// Rows changed while the full load was running are picked up by a second,
+// incremental pass right before the swap. Writes the live consumer made after
+// that pass went to the old index, which the swap drops, so the outbox events of
+// topics written since the rebuild started are replayed through the alias at the end.
+// Replayed events are applied again, which is harmless as indexing and deleting
+// are idempotent. Sent events are kept for the outbox retention, which bounds how
+// long a rebuild may take.
+func (s *reindexService) rebuild(ctx context.Context, alias string, topics []string, load func(ctx context.Context, index string, since time.Time) (int, error)) error {
+	startedAt := time.Now()
+
+	position, err := s.source.GetOutboxPosition(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get outbox position: %w", err)
+	}
+
+	index, err := s.repo.CreateIndex(ctx, alias)
+	if err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+	logger := logrus.WithFields(logrus.Fields{
+		"alias": alias,
+		"index": index,
+	})
+	logger.Info("reindex started")
+
+	if err := s.fill(ctx, index, startedAt, load); err != nil {
+		if err := s.repo.DeleteIndex(context.Background(), index); err != nil {
+			logger.WithError(err).Warn("failed to delete unfinished index")
+		}
+		return err
+	}
+
+	if err := s.repo.SwapAlias(ctx, alias, index); err != nil {
+		return fmt.Errorf("failed to swap alias: %w", err)
+	}
+
+	replayed, err := s.replay(ctx, position, topics)
+	if err != nil {
+		return fmt.Errorf("failed to replay events: %w", err)
+	}
+	logger.WithFields(logrus.Fields{
+		"replayed": replayed,
+		"took":     time.Since(startedAt).String(),
+	}).Info("reindex finished")
+	return nil
+}
+
+// replay applies the outbox events of topics with id > position.
+func (s *reindexService) replay(ctx context.Context, position int64, topics []string) (int, error) {
+	total := 0
+	for {
+		outboxEvents, err := s.source.GetOutboxEventsAfterID(ctx, position, topics, s.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to read outbox events: %w", err)
+		}
+		if len(outboxEvents) == 0 {
+			return total, nil
+		}
+		for _, event := range outboxEvents {
+			if err := s.handler.Handle(ctx, event.Topic, event.Payload); err != nil {
+				return total, fmt.Errorf("failed to apply event %d: %w", event.ID, err)
+			}
+		}
+		total += len(outboxEvents)
+		position = outboxEvents[len(outboxEvents)-1].ID
+	}
+}
+
+func (s *reindexService) fill(ctx context.Context, index string, startedAt time.Time, load func(ctx context.Context, index string, since time.Time) (int, error)) error {
+	total, err := load(ctx, index, time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to load documents: %w", err)
+	}
+
+	changed, err := load(ctx, index, startedAt)
+	if err != nil {
+		return fmt.Errorf("failed to load changed documents: %w", err)
+	}
+
+	if err := s.repo.FinishIndex(ctx, index); err != nil {
+		return fmt.Errorf("failed to finish index: %w", err)
+	}
+	logrus.WithFields(logrus.Fields{
+		"index":   index,
+		"total":   total,
+		"changed": changed,
+	}).Info("documents loaded")
+	return nil
+}
+
+func (s *reindexService) loadTweets(ctx context.Context, index string, since time.Time) (int, error) {
+	total, afterID := 0, 0
+	for {
+		tweets, err := s.source.GetTweetsAfterID(ctx, afterID, since, s.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to read tweets: %w", err)
+		}
+		if len(tweets) == 0 {
+			return total, nil
+		}
+		if err := s.repo.BulkIndexTweets(ctx, index, tweets); err != nil {
+			return total, fmt.Errorf("failed to bulk index tweets: %w", err)
+		}
+		total += len(tweets)
+		afterID = tweets[len(tweets)-1].ID
+	}
+}
+
+func (s *reindexService) loadUsers(ctx context.Context, index string, since time.Time) (int, error) {
+	total, afterID := 0, 0
+	for {
+		users, err := s.source.GetUsersAfterID(ctx, afterID, since, s.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to read users: %w", err)
+		}
+		if len(users) == 0 {
+			return total, nil
+		}
+		if err := s.repo.BulkIndexUsers(ctx, index, users); err != nil {
+			return total, fmt.Errorf("failed to bulk index users: %w", err)
+		}
+		total += len(users)
+		afterID = users[len(users)-1].ID
+	}
+}
@@ -0,0 +1,212 @@
+package reindex_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/kust1q/Zapp/backend/internal/domain/events"
+	"github.com/kust1q/Zapp/backend/internal/search/providers/search/elastic"
+	"github.com/kust1q/Zapp/backend/internal/search/service/reindex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockSource struct {
+	mock.Mock
+}
+
+func (m *mockSource) GetTweetsAfterID(ctx context.Context, afterID int, since time.Time, limit int) ([]entity.Tweet, error) {
+	args := m.Called(ctx, afterID, since, limit)
+	return args.Get(0).([]entity.Tweet), args.Error(1)
+}
+
+func (m *mockSource) GetUsersAfterID(ctx context.Context, afterID int, since time.Time, limit int) ([]entity.User, error) {
+	args := m.Called(ctx, afterID, since, limit)
+	return args.Get(0).([]entity.User), args.Error(1)
+}
+
+func (m *mockSource) GetOutboxPosition(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockSource) GetOutboxEventsAfterID(ctx context.Context, afterID int64, topics []string, limit int) ([]entity.OutboxEvent, error) {
+	args := m.Called(ctx, afterID, topics, limit)
+	return args.Get(0).([]entity.OutboxEvent), args.Error(1)
+}
+
+type mockIndexRepository struct {
+	mock.Mock
+}
+
+func (m *mockIndexRepository) CreateIndex(ctx context.Context, alias string) (string, error) {
+	args := m.Called(ctx, alias)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockIndexRepository) FinishIndex(ctx context.Context, index string) error {
+	args := m.Called(ctx, index)
+	return args.Error(0)
+}
+
+func (m *mockIndexRepository) DeleteIndex(ctx context.Context, index string) error {
+	args := m.Called(ctx, index)
+	return args.Error(0)
+}
+
+func (m *mockIndexRepository) SwapAlias(ctx context.Context, alias, index string) error {
+	args := m.Called(ctx, alias, index)
+	return args.Error(0)
+}
+
+func (m *mockIndexRepository) BulkIndexTweets(ctx context.Context, index string, tweets []entity.Tweet) error {
+	args := m.Called(ctx, index, tweets)
+	return args.Error(0)
+}
+
+func (m *mockIndexRepository) BulkIndexUsers(ctx context.Context, index string, users []entity.User) error {
+	args := m.Called(ctx, index, users)
+	return args.Error(0)
+}
+
+type mockEventHandler struct {
+	mock.Mock
+}
+
+func (m *mockEventHandler) Handle(ctx context.Context, topic string, data []byte) error {
+	args := m.Called(ctx, topic, data)
+	return args.Error(0)
+}
+
+var (
+	fullLoad = mock.MatchedBy(func(since time.Time) bool { return since.IsZero() })
+	catchUp  = mock.MatchedBy(func(since time.Time) bool { return !since.IsZero() })
+)
+
+func TestReindexService_ReindexTweets_Success(t *testing.T) {
+	mockSource := &mockSource{}
+	mockRepo := &mockIndexRepository{}
+	mockHandler := &mockEventHandler{}
+
+	service := reindex.NewReindexService(mockSource, mockRepo, mockHandler, 2)
+
+	topics := []string{events.TopicTweet, events.TopicUser}
+	firstBatch := []entity.Tweet{{ID: 1}, {ID: 2}}
+	secondBatch := []entity.Tweet{{ID: 5}}
+	changed := []entity.Tweet{{ID: 2, Content: "edited"}}
+	replayed := []entity.OutboxEvent{
+		{ID: 41, Topic: events.TopicTweet, Payload: []byte(`{"event_type":"tweet.deleted","id":5}`)},
+		{ID: 42, Topic: events.TopicUser, Payload: []byte(`{"event_type":"user.deleted","id":7}`)},
+	}
+
+	mockSource.On("GetOutboxPosition", mock.Anything).Return(int64(40), nil).Once()
+	mockRepo.On("CreateIndex", mock.Anything, elastic.IndexTweets).Return("tweets_1", nil).Once()
+
+	mockSource.On("GetTweetsAfterID", mock.Anything, 0, fullLoad, 2).Return(firstBatch, nil).Once()
+	mockRepo.On("BulkIndexTweets", mock.Anything, "tweets_1", firstBatch).Return(nil).Once()
+	mockSource.On("GetTweetsAfterID", mock.Anything, 2, fullLoad, 2).Return(secondBatch, nil).Once()
+	mockRepo.On("BulkIndexTweets", mock.Anything, "tweets_1", secondBatch).Return(nil).Once()
+	mockSource.On("GetTweetsAfterID", mock.Anything, 5, fullLoad, 2).Return([]entity.Tweet{}, nil).Once()
+
+	mockSource.On("GetTweetsAfterID", mock.Anything, 0, catchUp, 2).Return(changed, nil).Once()
+	mockRepo.On("BulkIndexTweets", mock.Anything, "tweets_1", changed).Return(nil).Once()
+	mockSource.On("GetTweetsAfterID", mock.Anything, 2, catchUp, 2).Return([]entity.Tweet{}, nil).Once()
+
+	mockRepo.On("FinishIndex", mock.Anything, "tweets_1").Return(nil).Once()
+	mockRepo.On("SwapAlias", mock.Anything, elastic.IndexTweets, "tweets_1").Return(nil).Once()
+
+	mockSource.On("GetOutboxEventsAfterID", mock.Anything, int64(40), topics, 2).Return(replayed, nil).Once()
+	mockHandler.On("Handle", mock.Anything, events.TopicTweet, replayed[0].Payload).Return(nil).Once()
+	mockHandler.On("Handle", mock.Anything, events.TopicUser, replayed[1].Payload).Return(nil).Once()
+	mockSource.On("GetOutboxEventsAfterID", mock.Anything, int64(42), topics, 2).Return([]entity.OutboxEvent{}, nil).Once()
+
+	err := service.ReindexTweets(context.Background())
+
+	assert.NoError(t, err)
+	mockSource.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestReindexService_ReindexUsers_ReplaysUserEventsOnly(t *testing.T) {
+	mockSource := &mockSource{}
+	mockRepo := &mockIndexRepository{}
+	mockHandler := &mockEventHandler{}
+
+	service := reindex.NewReindexService(mockSource, mockRepo, mockHandler, 10)
+
+	users := []entity.User{{ID: 1, Username: "alice"}}
+	replayed := []entity.OutboxEvent{{ID: 8, Topic: events.TopicUser, Payload: []byte(`{"event_type":"user.created","id":2}`)}}
+
+	mockSource.On("GetOutboxPosition", mock.Anything).Return(int64(7), nil).Once()
+	mockRepo.On("CreateIndex", mock.Anything, elastic.IndexUsers).Return("users_1", nil).Once()
+	mockSource.On("GetUsersAfterID", mock.Anything, 0, fullLoad, 10).Return(users, nil).Once()
+	mockRepo.On("BulkIndexUsers", mock.Anything, "users_1", users).Return(nil).Once()
+	mockSource.On("GetUsersAfterID", mock.Anything, 1, fullLoad, 10).Return([]entity.User{}, nil).Once()
+	mockSource.On("GetUsersAfterID", mock.Anything, 0, catchUp, 10).Return([]entity.User{}, nil).Once()
+	mockRepo.On("FinishIndex", mock.Anything, "users_1").Return(nil).Once()
+	mockRepo.On("SwapAlias", mock.Anything, elastic.IndexUsers, "users_1").Return(nil).Once()
+	mockSource.On("GetOutboxEventsAfterID", mock.Anything, int64(7), []string{events.TopicUser}, 10).Return(replayed, nil).Once()
+	mockHandler.On("Handle", mock.Anything, events.TopicUser, replayed[0].Payload).Return(nil).Once()
+	mockSource.On("GetOutboxEventsAfterID", mock.Anything, int64(8), []string{events.TopicUser}, 10).Return([]entity.OutboxEvent{}, nil).Once()
+
+	err := service.ReindexUsers(context.Background())
+
+	assert.NoError(t, err)
+	mockSource.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestReindexService_ReindexUsers_LoadErrorDeletesIndex(t *testing.T) {
+	mockSource := &mockSource{}
+	mockRepo := &mockIndexRepository{}
+	mockHandler := &mockEventHandler{}
+
+	service := reindex.NewReindexService(mockSource, mockRepo, mockHandler, 10)
+
+	loadErr := errors.New("connection reset")
+
+	mockSource.On("GetOutboxPosition", mock.Anything).Return(int64(0), nil).Once()
+	mockRepo.On("CreateIndex", mock.Anything, elastic.IndexUsers).Return("users_1", nil).Once()
+	mockSource.On("GetUsersAfterID", mock.Anything, 0, fullLoad, 10).Return([]entity.User(nil), loadErr).Once()
+	mockRepo.On("DeleteIndex", mock.Anything, "users_1").Return(nil).Once()
+
+	err := service.ReindexUsers(context.Background())
+
+	assert.ErrorIs(t, err, loadErr)
+	mockSource.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SwapAlias", mock.Anything, mock.Anything, mock.Anything)
+	mockHandler.AssertNotCalled(t, "Handle", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReindexService_ReindexTweets_ReplayError(t *testing.T) {
+	mockSource := &mockSource{}
+	mockRepo := &mockIndexRepository{}
+	mockHandler := &mockEventHandler{}
+
+	service := reindex.NewReindexService(mockSource, mockRepo, mockHandler, 10)
+
+	topics := []string{events.TopicTweet, events.TopicUser}
+	replayed := []entity.OutboxEvent{{ID: 3, Topic: events.TopicTweet, Payload: []byte(`{"event_type":"tweet.updated","id":1}`)}}
+	handleErr := errors.New("elastic unavailable")
+
+	mockSource.On("GetOutboxPosition", mock.Anything).Return(int64(2), nil).Once()
+	mockRepo.On("CreateIndex", mock.Anything, elastic.IndexTweets).Return("tweets_1", nil).Once()
+	mockSource.On("GetTweetsAfterID", mock.Anything, 0, mock.Anything, 10).Return([]entity.Tweet{}, nil).Twice()
+	mockRepo.On("FinishIndex", mock.Anything, "tweets_1").Return(nil).Once()
+	mockRepo.On("SwapAlias", mock.Anything, elastic.IndexTweets, "tweets_1").Return(nil).Once()
+	mockSource.On("GetOutboxEventsAfterID", mock.Anything, int64(2), topics, 10).Return(replayed, nil).Once()
+	mockHandler.On("Handle", mock.Anything, events.TopicTweet, replayed[0].Payload).Return(handleErr).Once()
+
+	err := service.ReindexTweets(context.Background())
+
+	assert.ErrorIs(t, err, handleErr)
+	mockSource.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	mockHandler.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package search
 
 import (
 	"context"
+	"time"
 
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 )
@@ -15,5 +16,6 @@ type (
 		DeleteTweet(ctx context.Context, tweetID int) error
 		DeleteUser(ctx context.Context, userID int) error
 		DeleteTweetsByUserID(ctx context.Context, userID int) error
+		GetTrendCandidates(ctx context.Context, recentFrom, baselineFrom time.Time, size int) ([]entity.TrendCandidate, error)
 	}
 )
@@ -3,18 +3,26 @@ package search
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/kust1q/Zapp/backend/internal/config"
 	"github.com/kust1q/Zapp/backend/internal/domain/entity"
 )
 
 type searchService struct {
 	searchRepo searchRepository
+	trendsCfg  *config.TrendsConfig
+
+	trendsMu      sync.Mutex
+	trends        []entity.Trend
+	trendsExpires time.Time
 }
 
-func NewSearchService(searchRepo searchRepository) *searchService {
+func NewSearchService(searchRepo searchRepository, trendsCfg *config.TrendsConfig) *searchService {
 	return &searchService{
 		searchRepo: searchRepo,
+		trendsCfg:  trendsCfg,
 	}
 }
 
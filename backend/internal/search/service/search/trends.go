@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+)
+
+// candidatesPerTrend is how many candidates are fetched per trend kept. Candidates are
+// picked by recent volume, so a small but fast rising term is only ranked if it gets in.
+const candidatesPerTrend = 10
+
+// GetTrends returns up to limit trending hashtags and topics, fastest growing first.
+// The ranking is recomputed at most once per cache TTL.
+func (s *searchService) GetTrends(ctx context.Context, limit int) ([]entity.Trend, error) {
+	if limit <= 0 || limit > s.trendsCfg.MaxSize {
+		limit = s.trendsCfg.MaxSize
+	}
+
+	s.trendsMu.Lock()
+	defer s.trendsMu.Unlock()
+
+	if time.Now().After(s.trendsExpires) {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		now := time.Now()
+		recentFrom := now.Add(-s.trendsCfg.Window)
+		candidates, err := s.searchRepo.GetTrendCandidates(ctx, recentFrom, recentFrom.Add(-s.trendsCfg.Baseline), s.trendsCfg.MaxSize*candidatesPerTrend)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trend candidates: %w", err)
+		}
+		trends := rankTrends(candidates, s.trendsCfg.Window, s.trendsCfg.Baseline, s.trendsCfg.MinCount)
+		if len(trends) > s.trendsCfg.MaxSize {
+			trends = trends[:s.trendsCfg.MaxSize]
+		}
+		s.trends = trends
+		s.trendsExpires = now.Add(s.trendsCfg.CacheTTL)
+	}
+
+	if len(s.trends) < limit {
+		limit = len(s.trends)
+	}
+	return s.trends[:limit], nil
+}
+
+// rankTrends orders candidates by velocity: the recent count divided by the count the
+// baseline rate predicts for a window of the same length. Both sides are smoothed by one
+// so that terms without history do not get infinite velocity. Topics that repeat a
+// hashtag are dropped, and terms used fewer than minCount times recently are ignored.
+func rankTrends(candidates []entity.TrendCandidate, window, baseline time.Duration, minCount int) []entity.Trend {
+	hashtags := make(map[string]struct{})
+	for _, c := range candidates {
+		if c.Kind == entity.TrendHashtag {
+			hashtags[c.Term] = struct{}{}
+		}
+	}
+
+	scale := float64(window) / float64(baseline)
+	trends := make([]entity.Trend, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Recent < minCount {
+			continue
+		}
+		if _, ok := hashtags[c.Term]; ok && c.Kind == entity.TrendTopic {
+			continue
+		}
+		expected := float64(c.Baseline) * scale
+		trends = append(trends, entity.Trend{
+			Term:     c.Term,
+			Kind:     c.Kind,
+			Count:    c.Recent,
+			Velocity: (float64(c.Recent) + 1) / (expected + 1),
+		})
+	}
+
+	sort.SliceStable(trends, func(i, j int) bool {
+		if trends[i].Velocity != trends[j].Velocity {
+			return trends[i].Velocity > trends[j].Velocity
+		}
+		return trends[i].Count > trends[j].Count
+	})
+	return trends
+}
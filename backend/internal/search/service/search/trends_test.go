@@ -0,0 +1,159 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kust1q/Zapp/backend/internal/config"
+	"github.com/kust1q/Zapp/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *mockSearchRepository) SearchTweets(ctx context.Context, query string) ([]int, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *mockSearchRepository) SearchUsers(ctx context.Context, query string) ([]int, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *mockSearchRepository) IndexTweet(ctx context.Context, tweet *entity.Tweet) error {
+	args := m.Called(ctx, tweet)
+	return args.Error(0)
+}
+
+func (m *mockSearchRepository) IndexUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *mockSearchRepository) DeleteTweet(ctx context.Context, tweetID int) error {
+	args := m.Called(ctx, tweetID)
+	return args.Error(0)
+}
+
+func (m *mockSearchRepository) DeleteUser(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockSearchRepository) DeleteTweetsByUserID(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockSearchRepository) GetTrendCandidates(ctx context.Context, recentFrom, baselineFrom time.Time, size int) ([]entity.TrendCandidate, error) {
+	args := m.Called(ctx, recentFrom, baselineFrom, size)
+	return args.Get(0).([]entity.TrendCandidate), args.Error(1)
+}
+
+func TestRankTrends(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []entity.TrendCandidate
+		minCount   int
+		expected   []entity.Trend
+	}{
+		{
+			name: "smoothing keeps new terms finite",
+			candidates: []entity.TrendCandidate{
+				{Term: "new", Kind: entity.TrendHashtag, Recent: 5, Baseline: 0},
+				{Term: "steady", Kind: entity.TrendHashtag, Recent: 5, Baseline: 24},
+			},
+			expected: []entity.Trend{
+				{Term: "new", Kind: entity.TrendHashtag, Count: 5, Velocity: 6},
+				{Term: "steady", Kind: entity.TrendHashtag, Count: 5, Velocity: 3},
+			},
+		},
+		{
+			name: "small fast rising term beats a big steady one",
+			candidates: []entity.TrendCandidate{
+				{Term: "big", Kind: entity.TrendHashtag, Recent: 100, Baseline: 2400},
+				{Term: "small", Kind: entity.TrendHashtag, Recent: 9, Baseline: 0},
+			},
+			expected: []entity.Trend{
+				{Term: "small", Kind: entity.TrendHashtag, Count: 9, Velocity: 10},
+				{Term: "big", Kind: entity.TrendHashtag, Count: 100, Velocity: 1},
+			},
+		},
+		{
+			name: "terms below minCount are dropped",
+			candidates: []entity.TrendCandidate{
+				{Term: "rare", Kind: entity.TrendTopic, Recent: 2, Baseline: 0},
+				{Term: "common", Kind: entity.TrendTopic, Recent: 3, Baseline: 0},
+			},
+			minCount: 3,
+			expected: []entity.Trend{
+				{Term: "common", Kind: entity.TrendTopic, Count: 3, Velocity: 4},
+			},
+		},
+		{
+			name: "topics repeating a hashtag are dropped",
+			candidates: []entity.TrendCandidate{
+				{Term: "golang", Kind: entity.TrendHashtag, Recent: 4, Baseline: 0},
+				{Term: "golang", Kind: entity.TrendTopic, Recent: 9, Baseline: 0},
+				{Term: "rust", Kind: entity.TrendTopic, Recent: 4, Baseline: 0},
+			},
+			expected: []entity.Trend{
+				{Term: "golang", Kind: entity.TrendHashtag, Count: 4, Velocity: 5},
+				{Term: "rust", Kind: entity.TrendTopic, Count: 4, Velocity: 5},
+			},
+		},
+		{
+			name: "equal velocity is ordered by count",
+			candidates: []entity.TrendCandidate{
+				{Term: "less", Kind: entity.TrendTopic, Recent: 1, Baseline: 0},
+				{Term: "more", Kind: entity.TrendTopic, Recent: 3, Baseline: 24},
+			},
+			expected: []entity.Trend{
+				{Term: "more", Kind: entity.TrendTopic, Count: 3, Velocity: 2},
+				{Term: "less", Kind: entity.TrendTopic, Count: 1, Velocity: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trends := rankTrends(tt.candidates, time.Hour, 24*time.Hour, tt.minCount)
+			assert.Equal(t, tt.expected, trends)
+		})
+	}
+}
+
+func TestSearchService_GetTrends_OverfetchesCandidates(t *testing.T) {
+	mockRepo := &mockSearchRepository{}
+
+	service := NewSearchService(mockRepo, &config.TrendsConfig{
+		Window:   time.Hour,
+		Baseline: 24 * time.Hour,
+		MinCount: 1,
+		MaxSize:  2,
+		CacheTTL: time.Minute,
+	})
+
+	// The rising tag comes last by volume but leads once ranked by velocity.
+	candidates := []entity.TrendCandidate{
+		{Term: "a", Kind: entity.TrendHashtag, Recent: 50, Baseline: 1200},
+		{Term: "b", Kind: entity.TrendHashtag, Recent: 40, Baseline: 936},
+		{Term: "c", Kind: entity.TrendHashtag, Recent: 30, Baseline: 696},
+		{Term: "rising", Kind: entity.TrendHashtag, Recent: 5, Baseline: 0},
+	}
+	mockRepo.On("GetTrendCandidates", mock.Anything, mock.Anything, mock.Anything, 2*candidatesPerTrend).Return(candidates, nil).Once()
+
+	trends, err := service.GetTrends(context.Background(), 10)
+
+	assert.NoError(t, err)
+	if assert.Len(t, trends, 2) {
+		assert.Equal(t, "rising", trends[0].Term)
+		assert.Equal(t, "c", trends[1].Term)
+	}
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY,
+    type VARCHAR(20) NOT NULL,
+    recipient_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tweet_id INT DEFAULT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    is_read BOOLEAN DEFAULT FALSE NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient_created_at ON notifications(recipient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient_unread ON notifications(recipient_id) WHERE is_read = FALSE;
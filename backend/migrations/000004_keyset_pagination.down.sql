@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_follows_follower_created_at;
+DROP INDEX IF EXISTS idx_follows_following_created_at;
+DROP INDEX IF EXISTS idx_likes_tweet_created_at;
+DROP INDEX IF EXISTS idx_tweets_created_at_id;
+
+ALTER TABLE likes DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,6 @@
+ALTER TABLE likes ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_tweets_created_at_id ON tweets(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_likes_tweet_created_at ON likes(tweet_id, created_at DESC, user_id DESC);
+CREATE INDEX IF NOT EXISTS idx_follows_following_created_at ON follows(following_id, created_at DESC, follower_id DESC);
+CREATE INDEX IF NOT EXISTS idx_follows_follower_created_at ON follows(follower_id, created_at DESC, following_id DESC);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS bookmarks (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tweet_id INT NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (user_id, tweet_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bookmarks_user_created_at ON bookmarks(user_id, created_at DESC, tweet_id DESC);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_tweet ON bookmarks(tweet_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tweets_quoted_tweet_id;
+
+ALTER TABLE tweets DROP COLUMN IF EXISTS quoted_tweet_id;
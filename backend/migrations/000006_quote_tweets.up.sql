@@ -0,0 +1,3 @@
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS quoted_tweet_id INT DEFAULT NULL REFERENCES tweets(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tweets_quoted_tweet_id ON tweets(quoted_tweet_id) WHERE quoted_tweet_id IS NOT NULL;
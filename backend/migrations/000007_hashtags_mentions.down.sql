@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tweet_mentions;
+DROP TABLE IF EXISTS tweet_hashtags;
+DROP TABLE IF EXISTS hashtags;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS hashtags (
+    id SERIAL PRIMARY KEY,
+    tag VARCHAR(100) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tweet_hashtags (
+    tweet_id INT NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    hashtag_id INT NOT NULL REFERENCES hashtags(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tweet_id, hashtag_id)
+);
+
+CREATE TABLE IF NOT EXISTS tweet_mentions (
+    tweet_id INT NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (tweet_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tweet_hashtags_hashtag_created_at ON tweet_hashtags(hashtag_id, created_at DESC, tweet_id DESC);
+CREATE INDEX IF NOT EXISTS idx_tweet_mentions_user ON tweet_mentions(user_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS message_media;
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS conversation_participants;
+DROP TABLE IF EXISTS conversations;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS blocks (
+    blocker_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id)
+);
+
+CREATE TABLE IF NOT EXISTS mutes (
+    muter_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (muter_id, muted_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocks_blocked ON blocks(blocked_id);
+CREATE INDEX IF NOT EXISTS idx_blocks_blocker_created_at ON blocks(blocker_id, created_at DESC, blocked_id DESC);
+CREATE INDEX IF NOT EXISTS idx_mutes_muter_created_at ON mutes(muter_id, created_at DESC, muted_id DESC);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS reports (
+    id SERIAL PRIMARY KEY,
+    reporter_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(16) NOT NULL,
+    target_id INT NOT NULL,
+    reported_user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    reason VARCHAR(32) NOT NULL,
+    details VARCHAR(500) DEFAULT '' NOT NULL,
+    status VARCHAR(16) DEFAULT 'open' NOT NULL,
+    claimed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    resolution VARCHAR(32),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_unresolved_unique ON reports(reporter_id, target_type, target_id) WHERE status <> 'resolved';
+CREATE INDEX IF NOT EXISTS idx_reports_status_created_at ON reports(status, created_at, id);
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS report_id INT DEFAULT NULL REFERENCES reports(id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+-- Accounts created before verification existed are treated as verified.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN DEFAULT TRUE NOT NULL;
+ALTER TABLE users ALTER COLUMN email_verified SET DEFAULT FALSE;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS drafts (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content VARCHAR(280) DEFAULT '' NOT NULL,
+    parent_tweet_id INT DEFAULT NULL,
+    quoted_tweet_id INT DEFAULT NULL,
+    media_path TEXT DEFAULT NULL,
+    media_mime_type VARCHAR(15) DEFAULT NULL,
+    media_size_bytes BIGINT DEFAULT NULL,
+    scheduled_at TIMESTAMPTZ DEFAULT NULL,
+    locked_until TIMESTAMPTZ DEFAULT NULL,
+    publish_attempts INT DEFAULT 0 NOT NULL,
+    last_error TEXT DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_drafts_user_created_at ON drafts(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_drafts_scheduled_at ON drafts(scheduled_at) WHERE scheduled_at IS NOT NULL;
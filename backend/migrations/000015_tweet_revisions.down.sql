@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tweet_revisions;
+
+ALTER TABLE tweets DROP COLUMN IF EXISTS revision_count;
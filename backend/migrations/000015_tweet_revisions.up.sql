@@ -0,0 +1,11 @@
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS revision_count INT DEFAULT 0 NOT NULL;
+
+CREATE TABLE IF NOT EXISTS tweet_revisions (
+    id SERIAL PRIMARY KEY,
+    tweet_id INT NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    content VARCHAR(280) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    replaced_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tweet_revisions_tweet_created_at ON tweet_revisions(tweet_id, created_at DESC, id DESC);
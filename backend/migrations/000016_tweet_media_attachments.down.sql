@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS uidx_tweet_media_tweet_position;
+
+DELETE FROM tweet_media WHERE position > 0;
+
+ALTER TABLE tweet_media DROP COLUMN IF EXISTS alt_text;
+ALTER TABLE tweet_media DROP COLUMN IF EXISTS position;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_media_tweet ON tweet_media(tweet_id);
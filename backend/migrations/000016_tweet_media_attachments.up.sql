@@ -0,0 +1,7 @@
+ALTER TABLE tweet_media DROP CONSTRAINT IF EXISTS tweet_media_tweet_id_key;
+DROP INDEX IF EXISTS uidx_media_tweet;
+
+ALTER TABLE tweet_media ADD COLUMN IF NOT EXISTS position SMALLINT DEFAULT 0 NOT NULL;
+ALTER TABLE tweet_media ADD COLUMN IF NOT EXISTS alt_text VARCHAR(1000) DEFAULT '' NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_tweet_media_tweet_position ON tweet_media(tweet_id, position);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS media_uploads (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    media_type VARCHAR(15) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    upload_offset BIGINT DEFAULT 0 NOT NULL,
+    path TEXT DEFAULT NULL,
+    mime_type VARCHAR(50) DEFAULT NULL,
+    storage_upload_id TEXT DEFAULT NULL,
+    locked_until TIMESTAMPTZ DEFAULT NULL,
+    completed_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_uploads_user_id ON media_uploads(user_id);
+CREATE INDEX IF NOT EXISTS idx_media_uploads_expires_at ON media_uploads(expires_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_notifications_recipient_created_at_seq;
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient_created_at ON notifications(recipient_id, created_at DESC);
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS seq;
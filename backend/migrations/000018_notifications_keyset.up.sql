@@ -0,0 +1,4 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+
+DROP INDEX IF EXISTS idx_notifications_recipient_created_at;
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient_created_at_seq ON notifications(recipient_id, created_at DESC, seq DESC);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS follows_followers_count ON follows;
+DROP FUNCTION IF EXISTS update_followers_count();
+
+ALTER TABLE users DROP COLUMN IF EXISTS followers_count;
@@ -0,0 +1,21 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS followers_count INT NOT NULL DEFAULT 0;
+
+UPDATE users u SET followers_count = c.cnt
+FROM (SELECT following_id, COUNT(*) AS cnt FROM follows GROUP BY following_id) c
+WHERE u.id = c.following_id;
+
+CREATE OR REPLACE FUNCTION update_followers_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET followers_count = followers_count + 1 WHERE id = NEW.following_id;
+    ELSE
+        UPDATE users SET followers_count = followers_count - 1 WHERE id = OLD.following_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS follows_followers_count ON follows;
+CREATE TRIGGER follows_followers_count
+AFTER INSERT OR DELETE ON follows
+FOR EACH ROW EXECUTE FUNCTION update_followers_count();
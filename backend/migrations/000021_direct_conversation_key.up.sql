@@ -0,0 +1,17 @@
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS direct_key TEXT;
+
+-- Pairs that already have several direct conversations keep the oldest one as theirs.
+UPDATE conversations c SET direct_key = k.direct_key
+FROM (
+    SELECT DISTINCT ON (direct_key) conversation_id, direct_key
+    FROM (
+        SELECT p.conversation_id, MIN(p.user_id) || ':' || MAX(p.user_id) AS direct_key
+        FROM conversation_participants p
+        JOIN conversations dc ON dc.id = p.conversation_id AND NOT dc.is_group
+        GROUP BY p.conversation_id
+    ) pairs
+    ORDER BY direct_key, conversation_id
+) k
+WHERE c.id = k.conversation_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_conversations_direct_key ON conversations(direct_key) WHERE NOT is_group;
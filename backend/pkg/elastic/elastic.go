@@ -2,7 +2,6 @@ package elastic
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
 )
@@ -27,24 +26,5 @@ func NewElasticClient(addresses []string) (*elasticsearch.Client, error) {
 		return nil, fmt.Errorf("error: %s", res.String())
 	}
 
-	_, _ = es.Indices.Create("users", es.Indices.Create.WithBody(strings.NewReader(`{
-		"mappings": {
-		"properties": {
-			"username": { "type": "text", "fields": { "keyword": { "type": "keyword" } } },
-			"bio": { "type": "text", "analyzer": "russian" }
-		}
-		}
-	}`)))
-
-	_, _ = es.Indices.Create("tweets", es.Indices.Create.WithBody(strings.NewReader(`{
-		"mappings": {
-		"properties": {
-			"content": { "type": "text", "analyzer": "russian" },
-			"username": { "type": "text", "fields": { "keyword": { "type": "keyword" } } },
-			"user_id": { "type": "integer" }
-		}
-		}
-	}`)))
-
 	return es, nil
 }
@@ -245,6 +245,162 @@ func (x *SearchUsersResponse) GetTotalCount() int64 {
 	return 0
 }
 
+type GetTrendsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendsRequest) Reset() {
+	*x = GetTrendsRequest{}
+	mi := &file_proto_search_search_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendsRequest) ProtoMessage() {}
+
+func (x *GetTrendsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_search_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendsRequest.ProtoReflect.Descriptor instead.
+func (*GetTrendsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_search_search_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTrendsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Trend struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          string                 `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Velocity      float64                `protobuf:"fixed64,4,opt,name=velocity,proto3" json:"velocity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trend) Reset() {
+	*x = Trend{}
+	mi := &file_proto_search_search_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trend) ProtoMessage() {}
+
+func (x *Trend) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_search_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trend.ProtoReflect.Descriptor instead.
+func (*Trend) Descriptor() ([]byte, []int) {
+	return file_proto_search_search_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Trend) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *Trend) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Trend) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Trend) GetVelocity() float64 {
+	if x != nil {
+		return x.Velocity
+	}
+	return 0
+}
+
+type GetTrendsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trends        []*Trend               `protobuf:"bytes,1,rep,name=trends,proto3" json:"trends,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendsResponse) Reset() {
+	*x = GetTrendsResponse{}
+	mi := &file_proto_search_search_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendsResponse) ProtoMessage() {}
+
+func (x *GetTrendsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_search_search_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendsResponse.ProtoReflect.Descriptor instead.
+func (*GetTrendsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_search_search_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTrendsResponse) GetTrends() []*Trend {
+	if x != nil {
+		return x.Trends
+	}
+	return nil
+}
+
 var File_proto_search_search_proto protoreflect.FileDescriptor
 
 const file_proto_search_search_proto_rawDesc = "" +
@@ -265,10 +421,20 @@ const file_proto_search_search_proto_rawDesc = "" +
 	"\x13SearchUsersResponse\x12\x19\n" +
 	"\buser_ids\x18\x01 \x03(\x03R\auserIds\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x03R\n" +
-	"totalCount2\xa2\x01\n" +
+	"totalCount\"(\n" +
+	"\x10GetTrendsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"a\n" +
+	"\x05Trend\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\tR\x04term\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\x12\x1a\n" +
+	"\bvelocity\x18\x04 \x01(\x01R\bvelocity\":\n" +
+	"\x11GetTrendsResponse\x12%\n" +
+	"\x06trends\x18\x01 \x03(\v2\r.search.TrendR\x06trends2\xe4\x01\n" +
 	"\rSearchService\x12I\n" +
 	"\fSearchTweets\x12\x1b.search.SearchTweetsRequest\x1a\x1c.search.SearchTweetsResponse\x12F\n" +
-	"\vSearchUsers\x12\x1a.search.SearchUsersRequest\x1a\x1b.search.SearchUsersResponseBAZ?github.com/kust1q/Zapp/backend/pkg/gen/proto/search;searchprotob\x06proto3"
+	"\vSearchUsers\x12\x1a.search.SearchUsersRequest\x1a\x1b.search.SearchUsersResponse\x12@\n" +
+	"\tGetTrends\x12\x18.search.GetTrendsRequest\x1a\x19.search.GetTrendsResponseBAZ?github.com/kust1q/Zapp/backend/pkg/gen/proto/search;searchprotob\x06proto3"
 
 var (
 	file_proto_search_search_proto_rawDescOnce sync.Once
@@ -282,23 +448,29 @@ func file_proto_search_search_proto_rawDescGZIP() []byte {
 	return file_proto_search_search_proto_rawDescData
 }
 
-var file_proto_search_search_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_search_search_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_proto_search_search_proto_goTypes = []any{
 	(*SearchTweetsRequest)(nil),  // 0: search.SearchTweetsRequest
 	(*SearchTweetsResponse)(nil), // 1: search.SearchTweetsResponse
 	(*SearchUsersRequest)(nil),   // 2: search.SearchUsersRequest
 	(*SearchUsersResponse)(nil),  // 3: search.SearchUsersResponse
+	(*GetTrendsRequest)(nil),     // 4: search.GetTrendsRequest
+	(*Trend)(nil),                // 5: search.Trend
+	(*GetTrendsResponse)(nil),    // 6: search.GetTrendsResponse
 }
 var file_proto_search_search_proto_depIdxs = []int32{
-	0, // 0: search.SearchService.SearchTweets:input_type -> search.SearchTweetsRequest
-	2, // 1: search.SearchService.SearchUsers:input_type -> search.SearchUsersRequest
-	1, // 2: search.SearchService.SearchTweets:output_type -> search.SearchTweetsResponse
-	3, // 3: search.SearchService.SearchUsers:output_type -> search.SearchUsersResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	5, // 0: search.GetTrendsResponse.trends:type_name -> search.Trend
+	0, // 1: search.SearchService.SearchTweets:input_type -> search.SearchTweetsRequest
+	2, // 2: search.SearchService.SearchUsers:input_type -> search.SearchUsersRequest
+	4, // 3: search.SearchService.GetTrends:input_type -> search.GetTrendsRequest
+	1, // 4: search.SearchService.SearchTweets:output_type -> search.SearchTweetsResponse
+	3, // 5: search.SearchService.SearchUsers:output_type -> search.SearchUsersResponse
+	6, // 6: search.SearchService.GetTrends:output_type -> search.GetTrendsResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_proto_search_search_proto_init() }
@@ -312,7 +484,7 @@ func file_proto_search_search_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_search_search_proto_rawDesc), len(file_proto_search_search_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	SearchService_SearchTweets_FullMethodName = "/search.SearchService/SearchTweets"
 	SearchService_SearchUsers_FullMethodName  = "/search.SearchService/SearchUsers"
+	SearchService_GetTrends_FullMethodName    = "/search.SearchService/GetTrends"
 )
 
 // SearchServiceClient is the client API for SearchService service.
@@ -33,6 +34,8 @@ type SearchServiceClient interface {
 	SearchTweets(ctx context.Context, in *SearchTweetsRequest, opts ...grpc.CallOption) (*SearchTweetsResponse, error)
 	// Search users by query (returns users ids)
 	SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error)
+	// Get trending hashtags and topics ranked by velocity
+	GetTrends(ctx context.Context, in *GetTrendsRequest, opts ...grpc.CallOption) (*GetTrendsResponse, error)
 }
 
 type searchServiceClient struct {
@@ -63,6 +66,16 @@ func (c *searchServiceClient) SearchUsers(ctx context.Context, in *SearchUsersRe
 	return out, nil
 }
 
+func (c *searchServiceClient) GetTrends(ctx context.Context, in *GetTrendsRequest, opts ...grpc.CallOption) (*GetTrendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrendsResponse)
+	err := c.cc.Invoke(ctx, SearchService_GetTrends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SearchServiceServer is the server API for SearchService service.
 // All implementations must embed UnimplementedSearchServiceServer
 // for forward compatibility.
@@ -73,6 +86,8 @@ type SearchServiceServer interface {
 	SearchTweets(context.Context, *SearchTweetsRequest) (*SearchTweetsResponse, error)
 	// Search users by query (returns users ids)
 	SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error)
+	// Get trending hashtags and topics ranked by velocity
+	GetTrends(context.Context, *GetTrendsRequest) (*GetTrendsResponse, error)
 	mustEmbedUnimplementedSearchServiceServer()
 }
 
@@ -89,6 +104,9 @@ func (UnimplementedSearchServiceServer) SearchTweets(context.Context, *SearchTwe
 func (UnimplementedSearchServiceServer) SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SearchUsers not implemented")
 }
+func (UnimplementedSearchServiceServer) GetTrends(context.Context, *GetTrendsRequest) (*GetTrendsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTrends not implemented")
+}
 func (UnimplementedSearchServiceServer) mustEmbedUnimplementedSearchServiceServer() {}
 func (UnimplementedSearchServiceServer) testEmbeddedByValue()                       {}
 
@@ -146,6 +164,24 @@ func _SearchService_SearchUsers_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SearchService_GetTrends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).GetTrends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_GetTrends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).GetTrends(ctx, req.(*GetTrendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SearchService_ServiceDesc is the grpc.ServiceDesc for SearchService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +197,10 @@ var SearchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchUsers",
 			Handler:    _SearchService_SearchUsers_Handler,
 		},
+		{
+			MethodName: "GetTrends",
+			Handler:    _SearchService_GetTrends_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/search/search.proto",
@@ -22,8 +22,10 @@ const (
 )
 
 type GetTweetByIdRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TweetId       int64                  `protobuf:"varint,1,opt,name=tweet_id,json=tweetId,proto3" json:"tweet_id,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TweetId int64                  `protobuf:"varint,1,opt,name=tweet_id,json=tweetId,proto3" json:"tweet_id,omitempty"`
+	// Signed-in user the data is read for, 0 reads anonymously; private accounts are visible only to their approved followers
+	ViewerId      int64 `protobuf:"varint,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -65,11 +67,22 @@ func (x *GetTweetByIdRequest) GetTweetId() int64 {
 	return 0
 }
 
+func (x *GetTweetByIdRequest) GetViewerId() int64 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
 type GetRepliesToTweetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TweetId       int64                  `protobuf:"varint,1,opt,name=tweet_id,json=tweetId,proto3" json:"tweet_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TweetId int64                  `protobuf:"varint,1,opt,name=tweet_id,json=tweetId,proto3" json:"tweet_id,omitempty"`
+	Limit   int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset  int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor from next_cursor of the previous page, takes precedence over offset
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Signed-in user the data is read for, 0 reads anonymously; private accounts are visible only to their approved followers
+	ViewerId      int64 `protobuf:"varint,5,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -125,18 +138,123 @@ func (x *GetRepliesToTweetRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetRepliesToTweetRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetRepliesToTweetRequest) GetViewerId() int64 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+type GetThreadRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TweetId int64                  `protobuf:"varint,1,opt,name=tweet_id,json=tweetId,proto3" json:"tweet_id,omitempty"`
+	// Levels of nested replies to load, defaults to 3
+	Depth  int32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	Limit  int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor from next_cursor of the previous page, paginates direct replies only
+	Cursor string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Signed-in user the data is read for, 0 reads anonymously; private accounts are visible only to their approved followers
+	ViewerId      int64 `protobuf:"varint,6,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadRequest) Reset() {
+	*x = GetThreadRequest{}
+	mi := &file_proto_tweet_tweet_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadRequest) ProtoMessage() {}
+
+func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tweet_tweet_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadRequest.ProtoReflect.Descriptor instead.
+func (*GetThreadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetThreadRequest) GetTweetId() int64 {
+	if x != nil {
+		return x.TweetId
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetThreadRequest) GetViewerId() int64 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
 type GetTweetsAndRetweetsByUsernameRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Limit    int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset   int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor from next_cursor of the previous page, takes precedence over offset
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Signed-in user the data is read for, 0 reads anonymously; private accounts are visible only to their approved followers
+	ViewerId      int64 `protobuf:"varint,5,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTweetsAndRetweetsByUsernameRequest) Reset() {
 	*x = GetTweetsAndRetweetsByUsernameRequest{}
-	mi := &file_proto_tweet_tweet_proto_msgTypes[2]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -148,7 +266,7 @@ func (x *GetTweetsAndRetweetsByUsernameRequest) String() string {
 func (*GetTweetsAndRetweetsByUsernameRequest) ProtoMessage() {}
 
 func (x *GetTweetsAndRetweetsByUsernameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tweet_tweet_proto_msgTypes[2]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,7 +279,7 @@ func (x *GetTweetsAndRetweetsByUsernameRequest) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use GetTweetsAndRetweetsByUsernameRequest.ProtoReflect.Descriptor instead.
 func (*GetTweetsAndRetweetsByUsernameRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{2}
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetTweetsAndRetweetsByUsernameRequest) GetUsername() string {
@@ -185,18 +303,36 @@ func (x *GetTweetsAndRetweetsByUsernameRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetTweetsAndRetweetsByUsernameRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetTweetsAndRetweetsByUsernameRequest) GetViewerId() int64 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
 type GetTweetLikesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TweetId       int64                  `protobuf:"varint,1,opt,name=tweet_id,json=tweetId,proto3" json:"tweet_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TweetId int64                  `protobuf:"varint,1,opt,name=tweet_id,json=tweetId,proto3" json:"tweet_id,omitempty"`
+	Limit   int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset  int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor from next_cursor of the previous page, takes precedence over offset
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Signed-in user the data is read for, 0 reads anonymously; private accounts are visible only to their approved followers
+	ViewerId      int64 `protobuf:"varint,5,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTweetLikesRequest) Reset() {
 	*x = GetTweetLikesRequest{}
-	mi := &file_proto_tweet_tweet_proto_msgTypes[3]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -208,7 +344,7 @@ func (x *GetTweetLikesRequest) String() string {
 func (*GetTweetLikesRequest) ProtoMessage() {}
 
 func (x *GetTweetLikesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tweet_tweet_proto_msgTypes[3]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -221,7 +357,7 @@ func (x *GetTweetLikesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTweetLikesRequest.ProtoReflect.Descriptor instead.
 func (*GetTweetLikesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{3}
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetTweetLikesRequest) GetTweetId() int64 {
@@ -245,6 +381,20 @@ func (x *GetTweetLikesRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetTweetLikesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetTweetLikesRequest) GetViewerId() int64 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
 type TweetAuthor struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -256,7 +406,7 @@ type TweetAuthor struct {
 
 func (x *TweetAuthor) Reset() {
 	*x = TweetAuthor{}
-	mi := &file_proto_tweet_tweet_proto_msgTypes[4]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -268,7 +418,7 @@ func (x *TweetAuthor) String() string {
 func (*TweetAuthor) ProtoMessage() {}
 
 func (x *TweetAuthor) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tweet_tweet_proto_msgTypes[4]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,7 +431,7 @@ func (x *TweetAuthor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TweetAuthor.ProtoReflect.Descriptor instead.
 func (*TweetAuthor) Descriptor() ([]byte, []int) {
-	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{4}
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TweetAuthor) GetId() int64 {
@@ -310,13 +460,15 @@ type TweetCounters struct {
 	ReplyCount    int64                  `protobuf:"varint,1,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
 	RetweetCount  int64                  `protobuf:"varint,2,opt,name=retweet_count,json=retweetCount,proto3" json:"retweet_count,omitempty"`
 	LikeCount     int64                  `protobuf:"varint,3,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	BookmarkCount int64                  `protobuf:"varint,4,opt,name=bookmark_count,json=bookmarkCount,proto3" json:"bookmark_count,omitempty"`
+	QuoteCount    int64                  `protobuf:"varint,5,opt,name=quote_count,json=quoteCount,proto3" json:"quote_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TweetCounters) Reset() {
 	*x = TweetCounters{}
-	mi := &file_proto_tweet_tweet_proto_msgTypes[5]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -328,7 +480,7 @@ func (x *TweetCounters) String() string {
 func (*TweetCounters) ProtoMessage() {}
 
 func (x *TweetCounters) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tweet_tweet_proto_msgTypes[5]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -341,7 +493,7 @@ func (x *TweetCounters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TweetCounters.ProtoReflect.Descriptor instead.
 func (*TweetCounters) Descriptor() ([]byte, []int) {
-	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{5}
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TweetCounters) GetReplyCount() int64 {
@@ -365,6 +517,20 @@ func (x *TweetCounters) GetLikeCount() int64 {
 	return 0
 }
 
+func (x *TweetCounters) GetBookmarkCount() int64 {
+	if x != nil {
+		return x.BookmarkCount
+	}
+	return 0
+}
+
+func (x *TweetCounters) GetQuoteCount() int64 {
+	if x != nil {
+		return x.QuoteCount
+	}
+	return 0
+}
+
 type Tweet struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -372,16 +538,23 @@ type Tweet struct {
 	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	ParentTweetId int64                  `protobuf:"varint,5,opt,name=parent_tweet_id,json=parentTweetId,proto3" json:"parent_tweet_id,omitempty"`
-	MediaUrl      string                 `protobuf:"bytes,6,opt,name=media_url,json=mediaUrl,proto3" json:"media_url,omitempty"`
 	Author        *TweetAuthor           `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
 	Counters      *TweetCounters         `protobuf:"bytes,8,opt,name=counters,proto3" json:"counters,omitempty"`
+	QuotedTweetId int64                  `protobuf:"varint,9,opt,name=quoted_tweet_id,json=quotedTweetId,proto3" json:"quoted_tweet_id,omitempty"`
+	QuotedTweet   *Tweet                 `protobuf:"bytes,10,opt,name=quoted_tweet,json=quotedTweet,proto3" json:"quoted_tweet,omitempty"`
+	// Whether the tweet was edited after it was published
+	Edited bool `protobuf:"varint,11,opt,name=edited,proto3" json:"edited,omitempty"`
+	// Number of earlier versions kept in the edit history
+	RevisionCount int32 `protobuf:"varint,12,opt,name=revision_count,json=revisionCount,proto3" json:"revision_count,omitempty"`
+	// Attachments of the tweet in order
+	Media         []*TweetMedia `protobuf:"bytes,13,rep,name=media,proto3" json:"media,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Tweet) Reset() {
 	*x = Tweet{}
-	mi := &file_proto_tweet_tweet_proto_msgTypes[6]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -393,7 +566,7 @@ func (x *Tweet) String() string {
 func (*Tweet) ProtoMessage() {}
 
 func (x *Tweet) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tweet_tweet_proto_msgTypes[6]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,7 +579,7 @@ func (x *Tweet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tweet.ProtoReflect.Descriptor instead.
 func (*Tweet) Descriptor() ([]byte, []int) {
-	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{6}
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Tweet) GetId() int64 {
@@ -444,13 +617,6 @@ func (x *Tweet) GetParentTweetId() int64 {
 	return 0
 }
 
-func (x *Tweet) GetMediaUrl() string {
-	if x != nil {
-		return x.MediaUrl
-	}
-	return ""
-}
-
 func (x *Tweet) GetAuthor() *TweetAuthor {
 	if x != nil {
 		return x.Author
@@ -465,16 +631,172 @@ func (x *Tweet) GetCounters() *TweetCounters {
 	return nil
 }
 
+func (x *Tweet) GetQuotedTweetId() int64 {
+	if x != nil {
+		return x.QuotedTweetId
+	}
+	return 0
+}
+
+func (x *Tweet) GetQuotedTweet() *Tweet {
+	if x != nil {
+		return x.QuotedTweet
+	}
+	return nil
+}
+
+func (x *Tweet) GetEdited() bool {
+	if x != nil {
+		return x.Edited
+	}
+	return false
+}
+
+func (x *Tweet) GetRevisionCount() int32 {
+	if x != nil {
+		return x.RevisionCount
+	}
+	return 0
+}
+
+func (x *Tweet) GetMedia() []*TweetMedia {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type ThreadReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tweet         *Tweet                 `protobuf:"bytes,1,opt,name=tweet,proto3" json:"tweet,omitempty"`
+	Replies       []*ThreadReply         `protobuf:"bytes,2,rep,name=replies,proto3" json:"replies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThreadReply) Reset() {
+	*x = ThreadReply{}
+	mi := &file_proto_tweet_tweet_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThreadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreadReply) ProtoMessage() {}
+
+func (x *ThreadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tweet_tweet_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreadReply.ProtoReflect.Descriptor instead.
+func (*ThreadReply) Descriptor() ([]byte, []int) {
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ThreadReply) GetTweet() *Tweet {
+	if x != nil {
+		return x.Tweet
+	}
+	return nil
+}
+
+func (x *ThreadReply) GetReplies() []*ThreadReply {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+type Thread struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ancestors     []*Tweet               `protobuf:"bytes,1,rep,name=ancestors,proto3" json:"ancestors,omitempty"`
+	Tweet         *Tweet                 `protobuf:"bytes,2,opt,name=tweet,proto3" json:"tweet,omitempty"`
+	Replies       []*ThreadReply         `protobuf:"bytes,3,rep,name=replies,proto3" json:"replies,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Thread) Reset() {
+	*x = Thread{}
+	mi := &file_proto_tweet_tweet_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Thread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Thread) ProtoMessage() {}
+
+func (x *Thread) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tweet_tweet_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Thread.ProtoReflect.Descriptor instead.
+func (*Thread) Descriptor() ([]byte, []int) {
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Thread) GetAncestors() []*Tweet {
+	if x != nil {
+		return x.Ancestors
+	}
+	return nil
+}
+
+func (x *Thread) GetTweet() *Tweet {
+	if x != nil {
+		return x.Tweet
+	}
+	return nil
+}
+
+func (x *Thread) GetReplies() []*ThreadReply {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+func (x *Thread) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type TweetList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tweets        []*Tweet               `protobuf:"bytes,1,rep,name=tweets,proto3" json:"tweets,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TweetList) Reset() {
 	*x = TweetList{}
-	mi := &file_proto_tweet_tweet_proto_msgTypes[7]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -486,7 +808,7 @@ func (x *TweetList) String() string {
 func (*TweetList) ProtoMessage() {}
 
 func (x *TweetList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tweet_tweet_proto_msgTypes[7]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +821,7 @@ func (x *TweetList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TweetList.ProtoReflect.Descriptor instead.
 func (*TweetList) Descriptor() ([]byte, []int) {
-	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{7}
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *TweetList) GetTweets() []*Tweet {
@@ -509,6 +831,13 @@ func (x *TweetList) GetTweets() []*Tweet {
 	return nil
 }
 
+func (x *TweetList) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type Liker struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -520,7 +849,7 @@ type Liker struct {
 
 func (x *Liker) Reset() {
 	*x = Liker{}
-	mi := &file_proto_tweet_tweet_proto_msgTypes[8]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -532,7 +861,7 @@ func (x *Liker) String() string {
 func (*Liker) ProtoMessage() {}
 
 func (x *Liker) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tweet_tweet_proto_msgTypes[8]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,7 +874,7 @@ func (x *Liker) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Liker.ProtoReflect.Descriptor instead.
 func (*Liker) Descriptor() ([]byte, []int) {
-	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{8}
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Liker) GetId() int64 {
@@ -572,13 +901,14 @@ func (x *Liker) GetAvatarUrl() string {
 type LikersList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Users         []*Liker               `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LikersList) Reset() {
 	*x = LikersList{}
-	mi := &file_proto_tweet_tweet_proto_msgTypes[9]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -590,7 +920,7 @@ func (x *LikersList) String() string {
 func (*LikersList) ProtoMessage() {}
 
 func (x *LikersList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tweet_tweet_proto_msgTypes[9]
+	mi := &file_proto_tweet_tweet_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -603,7 +933,7 @@ func (x *LikersList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LikersList.ProtoReflect.Descriptor instead.
 func (*LikersList) Descriptor() ([]byte, []int) {
-	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{9}
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *LikersList) GetUsers() []*Liker {
@@ -613,36 +943,136 @@ func (x *LikersList) GetUsers() []*Liker {
 	return nil
 }
 
+func (x *LikersList) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type TweetMedia struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	AltText       string                 `protobuf:"bytes,4,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	Position      int32                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TweetMedia) Reset() {
+	*x = TweetMedia{}
+	mi := &file_proto_tweet_tweet_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TweetMedia) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TweetMedia) ProtoMessage() {}
+
+func (x *TweetMedia) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tweet_tweet_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TweetMedia.ProtoReflect.Descriptor instead.
+func (*TweetMedia) Descriptor() ([]byte, []int) {
+	return file_proto_tweet_tweet_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TweetMedia) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TweetMedia) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *TweetMedia) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *TweetMedia) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *TweetMedia) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
 var File_proto_tweet_tweet_proto protoreflect.FileDescriptor
 
 const file_proto_tweet_tweet_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/tweet/tweet.proto\x12\x05tweet\"0\n" +
+	"\x17proto/tweet/tweet.proto\x12\x05tweet\"M\n" +
 	"\x13GetTweetByIdRequest\x12\x19\n" +
-	"\btweet_id\x18\x01 \x01(\x03R\atweetId\"c\n" +
+	"\btweet_id\x18\x01 \x01(\x03R\atweetId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\x03R\bviewerId\"\x98\x01\n" +
 	"\x18GetRepliesToTweetRequest\x12\x19\n" +
 	"\btweet_id\x18\x01 \x01(\x03R\atweetId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"q\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tviewer_id\x18\x05 \x01(\x03R\bviewerId\"\xa6\x01\n" +
+	"\x10GetThreadRequest\x12\x19\n" +
+	"\btweet_id\x18\x01 \x01(\x03R\atweetId\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tviewer_id\x18\x06 \x01(\x03R\bviewerId\"\xa6\x01\n" +
 	"%GetTweetsAndRetweetsByUsernameRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"_\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tviewer_id\x18\x05 \x01(\x03R\bviewerId\"\x94\x01\n" +
 	"\x14GetTweetLikesRequest\x12\x19\n" +
 	"\btweet_id\x18\x01 \x01(\x03R\atweetId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"X\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tviewer_id\x18\x05 \x01(\x03R\bviewerId\"X\n" +
 	"\vTweetAuthor\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
 	"\n" +
-	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\"t\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\"\xbc\x01\n" +
 	"\rTweetCounters\x12\x1f\n" +
 	"\vreply_count\x18\x01 \x01(\x03R\n" +
 	"replyCount\x12#\n" +
 	"\rretweet_count\x18\x02 \x01(\x03R\fretweetCount\x12\x1d\n" +
 	"\n" +
-	"like_count\x18\x03 \x01(\x03R\tlikeCount\"\x92\x02\n" +
+	"like_count\x18\x03 \x01(\x03R\tlikeCount\x12%\n" +
+	"\x0ebookmark_count\x18\x04 \x01(\x03R\rbookmarkCount\x12\x1f\n" +
+	"\vquote_count\x18\x05 \x01(\x03R\n" +
+	"quoteCount\"\xc7\x03\n" +
 	"\x05Tweet\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1d\n" +
@@ -650,23 +1080,49 @@ const file_proto_tweet_tweet_proto_rawDesc = "" +
 	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\x04 \x01(\tR\tupdatedAt\x12&\n" +
-	"\x0fparent_tweet_id\x18\x05 \x01(\x03R\rparentTweetId\x12\x1b\n" +
-	"\tmedia_url\x18\x06 \x01(\tR\bmediaUrl\x12*\n" +
+	"\x0fparent_tweet_id\x18\x05 \x01(\x03R\rparentTweetId\x12*\n" +
 	"\x06author\x18\a \x01(\v2\x12.tweet.TweetAuthorR\x06author\x120\n" +
-	"\bcounters\x18\b \x01(\v2\x14.tweet.TweetCountersR\bcounters\"1\n" +
+	"\bcounters\x18\b \x01(\v2\x14.tweet.TweetCountersR\bcounters\x12&\n" +
+	"\x0fquoted_tweet_id\x18\t \x01(\x03R\rquotedTweetId\x12/\n" +
+	"\fquoted_tweet\x18\n" +
+	" \x01(\v2\f.tweet.TweetR\vquotedTweet\x12\x16\n" +
+	"\x06edited\x18\v \x01(\bR\x06edited\x12%\n" +
+	"\x0erevision_count\x18\f \x01(\x05R\rrevisionCount\x12'\n" +
+	"\x05media\x18\r \x03(\v2\x11.tweet.TweetMediaR\x05mediaJ\x04\b\x06\x10\aR\tmedia_url\"_\n" +
+	"\vThreadReply\x12\"\n" +
+	"\x05tweet\x18\x01 \x01(\v2\f.tweet.TweetR\x05tweet\x12,\n" +
+	"\areplies\x18\x02 \x03(\v2\x12.tweet.ThreadReplyR\areplies\"\xa7\x01\n" +
+	"\x06Thread\x12*\n" +
+	"\tancestors\x18\x01 \x03(\v2\f.tweet.TweetR\tancestors\x12\"\n" +
+	"\x05tweet\x18\x02 \x01(\v2\f.tweet.TweetR\x05tweet\x12,\n" +
+	"\areplies\x18\x03 \x03(\v2\x12.tweet.ThreadReplyR\areplies\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\"R\n" +
 	"\tTweetList\x12$\n" +
-	"\x06tweets\x18\x01 \x03(\v2\f.tweet.TweetR\x06tweets\"R\n" +
+	"\x06tweets\x18\x01 \x03(\v2\f.tweet.TweetR\x06tweets\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"R\n" +
 	"\x05Liker\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
 	"\n" +
-	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\"0\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\"Q\n" +
 	"\n" +
 	"LikersList\x12\"\n" +
-	"\x05users\x18\x01 \x03(\v2\f.tweet.LikerR\x05users2\xb3\x02\n" +
+	"\x05users\x18\x01 \x03(\v2\f.tweet.LikerR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\x82\x01\n" +
+	"\n" +
+	"TweetMedia\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x19\n" +
+	"\balt_text\x18\x04 \x01(\tR\aaltText\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition2\xe8\x02\n" +
 	"\fTweetService\x128\n" +
 	"\fGetTweetById\x12\x1a.tweet.GetTweetByIdRequest\x1a\f.tweet.Tweet\x12F\n" +
-	"\x11GetRepliesToTweet\x12\x1f.tweet.GetRepliesToTweetRequest\x1a\x10.tweet.TweetList\x12`\n" +
+	"\x11GetRepliesToTweet\x12\x1f.tweet.GetRepliesToTweetRequest\x1a\x10.tweet.TweetList\x123\n" +
+	"\tGetThread\x12\x17.tweet.GetThreadRequest\x1a\r.tweet.Thread\x12`\n" +
 	"\x1eGetTweetsAndRetweetsByUsername\x12,.tweet.GetTweetsAndRetweetsByUsernameRequest\x1a\x10.tweet.TweetList\x12?\n" +
 	"\rGetTweetLikes\x12\x1b.tweet.GetTweetLikesRequest\x1a\x11.tweet.LikersListB7Z5github.com/kust1q/Zapp/backend/proto/tweet;tweetprotob\x06proto3"
 
@@ -682,37 +1138,50 @@ func file_proto_tweet_tweet_proto_rawDescGZIP() []byte {
 	return file_proto_tweet_tweet_proto_rawDescData
 }
 
-var file_proto_tweet_tweet_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_tweet_tweet_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_tweet_tweet_proto_goTypes = []any{
 	(*GetTweetByIdRequest)(nil),                   // 0: tweet.GetTweetByIdRequest
 	(*GetRepliesToTweetRequest)(nil),              // 1: tweet.GetRepliesToTweetRequest
-	(*GetTweetsAndRetweetsByUsernameRequest)(nil), // 2: tweet.GetTweetsAndRetweetsByUsernameRequest
-	(*GetTweetLikesRequest)(nil),                  // 3: tweet.GetTweetLikesRequest
-	(*TweetAuthor)(nil),                           // 4: tweet.TweetAuthor
-	(*TweetCounters)(nil),                         // 5: tweet.TweetCounters
-	(*Tweet)(nil),                                 // 6: tweet.Tweet
-	(*TweetList)(nil),                             // 7: tweet.TweetList
-	(*Liker)(nil),                                 // 8: tweet.Liker
-	(*LikersList)(nil),                            // 9: tweet.LikersList
+	(*GetThreadRequest)(nil),                      // 2: tweet.GetThreadRequest
+	(*GetTweetsAndRetweetsByUsernameRequest)(nil), // 3: tweet.GetTweetsAndRetweetsByUsernameRequest
+	(*GetTweetLikesRequest)(nil),                  // 4: tweet.GetTweetLikesRequest
+	(*TweetAuthor)(nil),                           // 5: tweet.TweetAuthor
+	(*TweetCounters)(nil),                         // 6: tweet.TweetCounters
+	(*Tweet)(nil),                                 // 7: tweet.Tweet
+	(*ThreadReply)(nil),                           // 8: tweet.ThreadReply
+	(*Thread)(nil),                                // 9: tweet.Thread
+	(*TweetList)(nil),                             // 10: tweet.TweetList
+	(*Liker)(nil),                                 // 11: tweet.Liker
+	(*LikersList)(nil),                            // 12: tweet.LikersList
+	(*TweetMedia)(nil),                            // 13: tweet.TweetMedia
 }
 var file_proto_tweet_tweet_proto_depIdxs = []int32{
-	4, // 0: tweet.Tweet.author:type_name -> tweet.TweetAuthor
-	5, // 1: tweet.Tweet.counters:type_name -> tweet.TweetCounters
-	6, // 2: tweet.TweetList.tweets:type_name -> tweet.Tweet
-	8, // 3: tweet.LikersList.users:type_name -> tweet.Liker
-	0, // 4: tweet.TweetService.GetTweetById:input_type -> tweet.GetTweetByIdRequest
-	1, // 5: tweet.TweetService.GetRepliesToTweet:input_type -> tweet.GetRepliesToTweetRequest
-	2, // 6: tweet.TweetService.GetTweetsAndRetweetsByUsername:input_type -> tweet.GetTweetsAndRetweetsByUsernameRequest
-	3, // 7: tweet.TweetService.GetTweetLikes:input_type -> tweet.GetTweetLikesRequest
-	6, // 8: tweet.TweetService.GetTweetById:output_type -> tweet.Tweet
-	7, // 9: tweet.TweetService.GetRepliesToTweet:output_type -> tweet.TweetList
-	7, // 10: tweet.TweetService.GetTweetsAndRetweetsByUsername:output_type -> tweet.TweetList
-	9, // 11: tweet.TweetService.GetTweetLikes:output_type -> tweet.LikersList
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5,  // 0: tweet.Tweet.author:type_name -> tweet.TweetAuthor
+	6,  // 1: tweet.Tweet.counters:type_name -> tweet.TweetCounters
+	7,  // 2: tweet.Tweet.quoted_tweet:type_name -> tweet.Tweet
+	13, // 3: tweet.Tweet.media:type_name -> tweet.TweetMedia
+	7,  // 4: tweet.ThreadReply.tweet:type_name -> tweet.Tweet
+	8,  // 5: tweet.ThreadReply.replies:type_name -> tweet.ThreadReply
+	7,  // 6: tweet.Thread.ancestors:type_name -> tweet.Tweet
+	7,  // 7: tweet.Thread.tweet:type_name -> tweet.Tweet
+	8,  // 8: tweet.Thread.replies:type_name -> tweet.ThreadReply
+	7,  // 9: tweet.TweetList.tweets:type_name -> tweet.Tweet
+	11, // 10: tweet.LikersList.users:type_name -> tweet.Liker
+	0,  // 11: tweet.TweetService.GetTweetById:input_type -> tweet.GetTweetByIdRequest
+	1,  // 12: tweet.TweetService.GetRepliesToTweet:input_type -> tweet.GetRepliesToTweetRequest
+	2,  // 13: tweet.TweetService.GetThread:input_type -> tweet.GetThreadRequest
+	3,  // 14: tweet.TweetService.GetTweetsAndRetweetsByUsername:input_type -> tweet.GetTweetsAndRetweetsByUsernameRequest
+	4,  // 15: tweet.TweetService.GetTweetLikes:input_type -> tweet.GetTweetLikesRequest
+	7,  // 16: tweet.TweetService.GetTweetById:output_type -> tweet.Tweet
+	10, // 17: tweet.TweetService.GetRepliesToTweet:output_type -> tweet.TweetList
+	9,  // 18: tweet.TweetService.GetThread:output_type -> tweet.Thread
+	10, // 19: tweet.TweetService.GetTweetsAndRetweetsByUsername:output_type -> tweet.TweetList
+	12, // 20: tweet.TweetService.GetTweetLikes:output_type -> tweet.LikersList
+	16, // [16:21] is the sub-list for method output_type
+	11, // [11:16] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_proto_tweet_tweet_proto_init() }
@@ -726,7 +1195,7 @@ func file_proto_tweet_tweet_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tweet_tweet_proto_rawDesc), len(file_proto_tweet_tweet_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	TweetService_GetTweetById_FullMethodName                   = "/tweet.TweetService/GetTweetById"
 	TweetService_GetRepliesToTweet_FullMethodName              = "/tweet.TweetService/GetRepliesToTweet"
+	TweetService_GetThread_FullMethodName                      = "/tweet.TweetService/GetThread"
 	TweetService_GetTweetsAndRetweetsByUsername_FullMethodName = "/tweet.TweetService/GetTweetsAndRetweetsByUsername"
 	TweetService_GetTweetLikes_FullMethodName                  = "/tweet.TweetService/GetTweetLikes"
 )
@@ -35,6 +36,8 @@ type TweetServiceClient interface {
 	GetTweetById(ctx context.Context, in *GetTweetByIdRequest, opts ...grpc.CallOption) (*Tweet, error)
 	// Get replies to a specific tweet
 	GetRepliesToTweet(ctx context.Context, in *GetRepliesToTweetRequest, opts ...grpc.CallOption) (*TweetList, error)
+	// Get conversation around a tweet: ancestors, the tweet itself and a tree of replies
+	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*Thread, error)
 	// Get all tweets and retweets by a specific username
 	GetTweetsAndRetweetsByUsername(ctx context.Context, in *GetTweetsAndRetweetsByUsernameRequest, opts ...grpc.CallOption) (*TweetList, error)
 	// Get users who liked a specific tweet
@@ -69,6 +72,16 @@ func (c *tweetServiceClient) GetRepliesToTweet(ctx context.Context, in *GetRepli
 	return out, nil
 }
 
+func (c *tweetServiceClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*Thread, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Thread)
+	err := c.cc.Invoke(ctx, TweetService_GetThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tweetServiceClient) GetTweetsAndRetweetsByUsername(ctx context.Context, in *GetTweetsAndRetweetsByUsernameRequest, opts ...grpc.CallOption) (*TweetList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TweetList)
@@ -99,6 +112,8 @@ type TweetServiceServer interface {
 	GetTweetById(context.Context, *GetTweetByIdRequest) (*Tweet, error)
 	// Get replies to a specific tweet
 	GetRepliesToTweet(context.Context, *GetRepliesToTweetRequest) (*TweetList, error)
+	// Get conversation around a tweet: ancestors, the tweet itself and a tree of replies
+	GetThread(context.Context, *GetThreadRequest) (*Thread, error)
 	// Get all tweets and retweets by a specific username
 	GetTweetsAndRetweetsByUsername(context.Context, *GetTweetsAndRetweetsByUsernameRequest) (*TweetList, error)
 	// Get users who liked a specific tweet
@@ -119,6 +134,9 @@ func (UnimplementedTweetServiceServer) GetTweetById(context.Context, *GetTweetBy
 func (UnimplementedTweetServiceServer) GetRepliesToTweet(context.Context, *GetRepliesToTweetRequest) (*TweetList, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetRepliesToTweet not implemented")
 }
+func (UnimplementedTweetServiceServer) GetThread(context.Context, *GetThreadRequest) (*Thread, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetThread not implemented")
+}
 func (UnimplementedTweetServiceServer) GetTweetsAndRetweetsByUsername(context.Context, *GetTweetsAndRetweetsByUsernameRequest) (*TweetList, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTweetsAndRetweetsByUsername not implemented")
 }
@@ -182,6 +200,24 @@ func _TweetService_GetRepliesToTweet_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TweetService_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TweetServiceServer).GetThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TweetService_GetThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TweetServiceServer).GetThread(ctx, req.(*GetThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TweetService_GetTweetsAndRetweetsByUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTweetsAndRetweetsByUsernameRequest)
 	if err := dec(in); err != nil {
@@ -233,6 +269,10 @@ var TweetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRepliesToTweet",
 			Handler:    _TweetService_GetRepliesToTweet_Handler,
 		},
+		{
+			MethodName: "GetThread",
+			Handler:    _TweetService_GetThread_Handler,
+		},
 		{
 			MethodName: "GetTweetsAndRetweetsByUsername",
 			Handler:    _TweetService_GetTweetsAndRetweetsByUsername_Handler,
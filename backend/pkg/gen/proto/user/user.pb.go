@@ -110,10 +110,14 @@ func (x *GetUserByUsernameRequest) GetUsername() string {
 }
 
 type GetUserProfileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Limit    int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset   int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor from next_cursor of the previous page, takes precedence over offset
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Signed-in user the data is read for, 0 reads anonymously; private accounts are visible only to their approved followers
+	ViewerId      int64 `protobuf:"varint,5,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -169,11 +173,27 @@ func (x *GetUserProfileRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetUserProfileRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetUserProfileRequest) GetViewerId() int64 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
 type GetFollowersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Limit    int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset   int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor from next_cursor of the previous page, takes precedence over offset
+	Cursor        string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -229,11 +249,20 @@ func (x *GetFollowersRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetFollowersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type GetFollowingsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Limit    int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset   int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor from next_cursor of the previous page, takes precedence over offset
+	Cursor        string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -289,6 +318,13 @@ func (x *GetFollowingsRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetFollowingsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -300,6 +336,7 @@ type User struct {
 	AvatarUrl     string                 `protobuf:"bytes,7,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
 	IsSuperuser   bool                   `protobuf:"varint,8,opt,name=is_superuser,json=isSuperuser,proto3" json:"is_superuser,omitempty"`
 	IsActive      bool                   `protobuf:"varint,9,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	IsPrivate     bool                   `protobuf:"varint,10,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -397,11 +434,20 @@ func (x *User) GetIsActive() bool {
 	return false
 }
 
+func (x *User) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
 type TweetCounters struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReplyCount    int64                  `protobuf:"varint,1,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
 	RetweetCount  int64                  `protobuf:"varint,2,opt,name=retweet_count,json=retweetCount,proto3" json:"retweet_count,omitempty"`
 	LikeCount     int64                  `protobuf:"varint,3,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	BookmarkCount int64                  `protobuf:"varint,4,opt,name=bookmark_count,json=bookmarkCount,proto3" json:"bookmark_count,omitempty"`
+	QuoteCount    int64                  `protobuf:"varint,5,opt,name=quote_count,json=quoteCount,proto3" json:"quote_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -457,6 +503,20 @@ func (x *TweetCounters) GetLikeCount() int64 {
 	return 0
 }
 
+func (x *TweetCounters) GetBookmarkCount() int64 {
+	if x != nil {
+		return x.BookmarkCount
+	}
+	return 0
+}
+
+func (x *TweetCounters) GetQuoteCount() int64 {
+	if x != nil {
+		return x.QuoteCount
+	}
+	return 0
+}
+
 type TweetAuthor struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -524,9 +584,16 @@ type Tweet struct {
 	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	ParentTweetId int64                  `protobuf:"varint,5,opt,name=parent_tweet_id,json=parentTweetId,proto3" json:"parent_tweet_id,omitempty"`
-	MediaUrl      string                 `protobuf:"bytes,6,opt,name=media_url,json=mediaUrl,proto3" json:"media_url,omitempty"`
 	Author        *TweetAuthor           `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
 	Counters      *TweetCounters         `protobuf:"bytes,8,opt,name=counters,proto3" json:"counters,omitempty"`
+	QuotedTweetId int64                  `protobuf:"varint,9,opt,name=quoted_tweet_id,json=quotedTweetId,proto3" json:"quoted_tweet_id,omitempty"`
+	QuotedTweet   *Tweet                 `protobuf:"bytes,10,opt,name=quoted_tweet,json=quotedTweet,proto3" json:"quoted_tweet,omitempty"`
+	// Whether the tweet was edited after it was published
+	Edited bool `protobuf:"varint,11,opt,name=edited,proto3" json:"edited,omitempty"`
+	// Number of earlier versions kept in the edit history
+	RevisionCount int32 `protobuf:"varint,12,opt,name=revision_count,json=revisionCount,proto3" json:"revision_count,omitempty"`
+	// Attachments of the tweet in order
+	Media         []*TweetMedia `protobuf:"bytes,13,rep,name=media,proto3" json:"media,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -596,13 +663,6 @@ func (x *Tweet) GetParentTweetId() int64 {
 	return 0
 }
 
-func (x *Tweet) GetMediaUrl() string {
-	if x != nil {
-		return x.MediaUrl
-	}
-	return ""
-}
-
 func (x *Tweet) GetAuthor() *TweetAuthor {
 	if x != nil {
 		return x.Author
@@ -617,10 +677,46 @@ func (x *Tweet) GetCounters() *TweetCounters {
 	return nil
 }
 
+func (x *Tweet) GetQuotedTweetId() int64 {
+	if x != nil {
+		return x.QuotedTweetId
+	}
+	return 0
+}
+
+func (x *Tweet) GetQuotedTweet() *Tweet {
+	if x != nil {
+		return x.QuotedTweet
+	}
+	return nil
+}
+
+func (x *Tweet) GetEdited() bool {
+	if x != nil {
+		return x.Edited
+	}
+	return false
+}
+
+func (x *Tweet) GetRevisionCount() int32 {
+	if x != nil {
+		return x.RevisionCount
+	}
+	return 0
+}
+
+func (x *Tweet) GetMedia() []*TweetMedia {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
 type UserProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	Tweets        []*Tweet               `protobuf:"bytes,2,rep,name=tweets,proto3" json:"tweets,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -669,6 +765,13 @@ func (x *UserProfile) GetTweets() []*Tweet {
 	return nil
 }
 
+func (x *UserProfile) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type SmallUser struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -732,6 +835,7 @@ func (x *SmallUser) GetAvatarUrl() string {
 type SmallUserList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Users         []*SmallUser           `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -773,6 +877,89 @@ func (x *SmallUserList) GetUsers() []*SmallUser {
 	return nil
 }
 
+func (x *SmallUserList) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type TweetMedia struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	AltText       string                 `protobuf:"bytes,4,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	Position      int32                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TweetMedia) Reset() {
+	*x = TweetMedia{}
+	mi := &file_proto_user_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TweetMedia) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TweetMedia) ProtoMessage() {}
+
+func (x *TweetMedia) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TweetMedia.ProtoReflect.Descriptor instead.
+func (*TweetMedia) Descriptor() ([]byte, []int) {
+	return file_proto_user_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TweetMedia) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TweetMedia) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *TweetMedia) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *TweetMedia) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *TweetMedia) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
 var File_proto_user_user_proto protoreflect.FileDescriptor
 
 const file_proto_user_user_proto_rawDesc = "" +
@@ -781,19 +968,23 @@ const file_proto_user_user_proto_rawDesc = "" +
 	"\x12GetUserByIDRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\"6\n" +
 	"\x18GetUserByUsernameRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\x96\x01\n" +
 	"\x15GetUserProfileRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"_\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tviewer_id\x18\x05 \x01(\x03R\bviewerId\"w\n" +
 	"\x13GetFollowersRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"`\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"x\n" +
 	"\x14GetFollowingsRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xea\x01\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\x89\x02\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
@@ -805,18 +996,24 @@ const file_proto_user_user_proto_rawDesc = "" +
 	"\n" +
 	"avatar_url\x18\a \x01(\tR\tavatarUrl\x12!\n" +
 	"\fis_superuser\x18\b \x01(\bR\visSuperuser\x12\x1b\n" +
-	"\tis_active\x18\t \x01(\bR\bisActive\"t\n" +
+	"\tis_active\x18\t \x01(\bR\bisActive\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\n" +
+	" \x01(\bR\tisPrivate\"\xbc\x01\n" +
 	"\rTweetCounters\x12\x1f\n" +
 	"\vreply_count\x18\x01 \x01(\x03R\n" +
 	"replyCount\x12#\n" +
 	"\rretweet_count\x18\x02 \x01(\x03R\fretweetCount\x12\x1d\n" +
 	"\n" +
-	"like_count\x18\x03 \x01(\x03R\tlikeCount\"X\n" +
+	"like_count\x18\x03 \x01(\x03R\tlikeCount\x12%\n" +
+	"\x0ebookmark_count\x18\x04 \x01(\x03R\rbookmarkCount\x12\x1f\n" +
+	"\vquote_count\x18\x05 \x01(\x03R\n" +
+	"quoteCount\"X\n" +
 	"\vTweetAuthor\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
 	"\n" +
-	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\"\x90\x02\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\"\xc3\x03\n" +
 	"\x05Tweet\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1d\n" +
@@ -824,21 +1021,37 @@ const file_proto_user_user_proto_rawDesc = "" +
 	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\x04 \x01(\tR\tupdatedAt\x12&\n" +
-	"\x0fparent_tweet_id\x18\x05 \x01(\x03R\rparentTweetId\x12\x1b\n" +
-	"\tmedia_url\x18\x06 \x01(\tR\bmediaUrl\x12)\n" +
+	"\x0fparent_tweet_id\x18\x05 \x01(\x03R\rparentTweetId\x12)\n" +
 	"\x06author\x18\a \x01(\v2\x11.user.TweetAuthorR\x06author\x12/\n" +
-	"\bcounters\x18\b \x01(\v2\x13.user.TweetCountersR\bcounters\"R\n" +
+	"\bcounters\x18\b \x01(\v2\x13.user.TweetCountersR\bcounters\x12&\n" +
+	"\x0fquoted_tweet_id\x18\t \x01(\x03R\rquotedTweetId\x12.\n" +
+	"\fquoted_tweet\x18\n" +
+	" \x01(\v2\v.user.TweetR\vquotedTweet\x12\x16\n" +
+	"\x06edited\x18\v \x01(\bR\x06edited\x12%\n" +
+	"\x0erevision_count\x18\f \x01(\x05R\rrevisionCount\x12&\n" +
+	"\x05media\x18\r \x03(\v2\x10.user.TweetMediaR\x05mediaJ\x04\b\x06\x10\aR\tmedia_url\"s\n" +
 	"\vUserProfile\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
 	".user.UserR\x04user\x12#\n" +
-	"\x06tweets\x18\x02 \x03(\v2\v.user.TweetR\x06tweets\"V\n" +
+	"\x06tweets\x18\x02 \x03(\v2\v.user.TweetR\x06tweets\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"V\n" +
 	"\tSmallUser\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
 	"\n" +
-	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\"6\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\"W\n" +
 	"\rSmallUserList\x12%\n" +
-	"\x05users\x18\x01 \x03(\v2\x0f.user.SmallUserR\x05users2\xc7\x02\n" +
+	"\x05users\x18\x01 \x03(\v2\x0f.user.SmallUserR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\x82\x01\n" +
+	"\n" +
+	"TweetMedia\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x19\n" +
+	"\balt_text\x18\x04 \x01(\tR\aaltText\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition2\xc7\x02\n" +
 	"\vUserService\x123\n" +
 	"\vGetUserByID\x12\x18.user.GetUserByIDRequest\x1a\n" +
 	".user.User\x12?\n" +
@@ -860,7 +1073,7 @@ func file_proto_user_user_proto_rawDescGZIP() []byte {
 	return file_proto_user_user_proto_rawDescData
 }
 
-var file_proto_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_proto_user_user_proto_goTypes = []any{
 	(*GetUserByIDRequest)(nil),       // 0: user.GetUserByIDRequest
 	(*GetUserByUsernameRequest)(nil), // 1: user.GetUserByUsernameRequest
@@ -874,28 +1087,31 @@ var file_proto_user_user_proto_goTypes = []any{
 	(*UserProfile)(nil),              // 9: user.UserProfile
 	(*SmallUser)(nil),                // 10: user.SmallUser
 	(*SmallUserList)(nil),            // 11: user.SmallUserList
+	(*TweetMedia)(nil),               // 12: user.TweetMedia
 }
 var file_proto_user_user_proto_depIdxs = []int32{
 	7,  // 0: user.Tweet.author:type_name -> user.TweetAuthor
 	6,  // 1: user.Tweet.counters:type_name -> user.TweetCounters
-	5,  // 2: user.UserProfile.user:type_name -> user.User
-	8,  // 3: user.UserProfile.tweets:type_name -> user.Tweet
-	10, // 4: user.SmallUserList.users:type_name -> user.SmallUser
-	0,  // 5: user.UserService.GetUserByID:input_type -> user.GetUserByIDRequest
-	1,  // 6: user.UserService.GetUserByUsername:input_type -> user.GetUserByUsernameRequest
-	2,  // 7: user.UserService.GetUserProfile:input_type -> user.GetUserProfileRequest
-	3,  // 8: user.UserService.GetFollowers:input_type -> user.GetFollowersRequest
-	4,  // 9: user.UserService.GetFollowings:input_type -> user.GetFollowingsRequest
-	5,  // 10: user.UserService.GetUserByID:output_type -> user.User
-	5,  // 11: user.UserService.GetUserByUsername:output_type -> user.User
-	9,  // 12: user.UserService.GetUserProfile:output_type -> user.UserProfile
-	11, // 13: user.UserService.GetFollowers:output_type -> user.SmallUserList
-	11, // 14: user.UserService.GetFollowings:output_type -> user.SmallUserList
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	8,  // 2: user.Tweet.quoted_tweet:type_name -> user.Tweet
+	12, // 3: user.Tweet.media:type_name -> user.TweetMedia
+	5,  // 4: user.UserProfile.user:type_name -> user.User
+	8,  // 5: user.UserProfile.tweets:type_name -> user.Tweet
+	10, // 6: user.SmallUserList.users:type_name -> user.SmallUser
+	0,  // 7: user.UserService.GetUserByID:input_type -> user.GetUserByIDRequest
+	1,  // 8: user.UserService.GetUserByUsername:input_type -> user.GetUserByUsernameRequest
+	2,  // 9: user.UserService.GetUserProfile:input_type -> user.GetUserProfileRequest
+	3,  // 10: user.UserService.GetFollowers:input_type -> user.GetFollowersRequest
+	4,  // 11: user.UserService.GetFollowings:input_type -> user.GetFollowingsRequest
+	5,  // 12: user.UserService.GetUserByID:output_type -> user.User
+	5,  // 13: user.UserService.GetUserByUsername:output_type -> user.User
+	9,  // 14: user.UserService.GetUserProfile:output_type -> user.UserProfile
+	11, // 15: user.UserService.GetFollowers:output_type -> user.SmallUserList
+	11, // 16: user.UserService.GetFollowings:output_type -> user.SmallUserList
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_proto_user_user_proto_init() }
@@ -909,7 +1125,7 @@ func file_proto_user_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_user_proto_rawDesc), len(file_proto_user_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},